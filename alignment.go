@@ -0,0 +1,94 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Sightline is a direction from an observing site to a feature on the
+// horizon, such as a notch between hills or a standing stone.
+type Sightline struct {
+	Name      string
+	Azimuth   float64 // degrees clockwise from true north
+	Elevation float64 // apparent altitude of the horizon feature in degrees
+}
+
+// Alignment lists the days on which the Sun rises or sets along a sightline.
+type Alignment struct {
+	Sightline Sightline
+	// Rising is true for a sightline in the eastern half of the sky, where the
+	// Sun rises, and false for one in the west, where it sets.
+	Rising bool
+	// Declination is the declination in degrees the Sun must have to appear on
+	// the sightline: about +23.4 and -23.4 at the solstices and 0 at the
+	// equinoxes. If it is beyond the range the Sun reaches, Times is empty.
+	Declination float64
+	// Times holds the sunrise or sunset on each aligned day.
+	Times []time.Time
+}
+
+// Align returns, for each sightline seen from the given site, the days of the
+// year on which the Sun rises or sets along it. Dates and times are in loc.
+//
+// The Sun is taken to be aligned when its upper limb first appears, or last
+// disappears, at the elevation of the horizon feature, allowing for standard
+// refraction. An aligned day is the one on which the azimuth of that event is
+// closest to the sightline, so a sightline crossed by the Sun's yearly swing
+// usually gives two days, one before and one after the solstice. A sightline
+// at the limit of the swing gives the solstice itself, provided the Sun comes
+// within alignmentTolerance of it.
+func Align(year int, loc *time.Location, latitude float64, longitude float64, lines []Sightline) []Alignment {
+	as := make([]Alignment, len(lines))
+	for i, l := range lines {
+		h := l.Elevation - Refraction(l.Elevation) - sunSemiDiameter
+		rising := between(0, 360, l.Azimuth) < 180
+		as[i] = Alignment{
+			Sightline:   l,
+			Rising:      rising,
+			Declination: angleAsin(angleSin(latitude)*angleSin(h) + angleCos(latitude)*angleCos(h)*angleCos(l.Azimuth)),
+			Times:       alignedTimes(year, loc, latitude, longitude, h, l.Azimuth, rising),
+		}
+	}
+	return as
+}
+
+// alignmentTolerance is how close in azimuth, in degrees, the Sun must come
+// to a sightline it does not cross for the day of its nearest approach to
+// count as aligned. It is about the diameter of the Sun.
+const alignmentTolerance = 0.5
+
+// alignedTimes returns the times in the given year that the Sun passes through
+// altitude h, rising or setting, closest to azimuth az, taking the better of
+// the two days either side of each change of sign of the azimuth difference,
+// or the day of a turning point within alignmentTolerance
+func alignedTimes(year int, loc *time.Location, latitude float64, longitude float64, h float64, az float64, rising bool) []time.Time {
+	var times []time.Time
+	var prev time.Time
+	var prevDiff, prevStep float64
+	have := false
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		at, ok := crossing(d, latitude, longitude, h, rising, defaultTolerance)
+		if !ok {
+			have = false
+			continue
+		}
+		diff := between(-180, 180, Azimuth(at, latitude, longitude)-az)
+		if have && (diff == 0 || (diff < 0) != (prevDiff < 0)) {
+			if math.Abs(diff) < math.Abs(prevDiff) {
+				times = append(times, at)
+			} else if len(times) == 0 || !times[len(times)-1].Equal(prev) {
+				times = append(times, prev)
+			}
+		} else if have && (diff-prevDiff)*prevStep < 0 && math.Abs(prevDiff) < alignmentTolerance &&
+			math.Abs(prevDiff) <= math.Abs(diff) {
+			times = append(times, prev)
+		}
+		if have {
+			prevStep = diff - prevDiff
+		} else {
+			prevStep = 0
+		}
+		prev, prevDiff, have = at, diff, true
+	}
+	return times
+}
@@ -0,0 +1,82 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Bennett's formula as given by Meeus, Astronomical Algorithms, chapter 16:
+// 34.5 arcminutes on the horizon, 5.3 at 10 degrees and 1 at 45, with the
+// small negative value at the zenith that Meeus corrects for left alone.
+func TestRefraction(t *testing.T) {
+	for _, tt := range []struct {
+		apparent, arcmin, tol float64
+	}{
+		{0, 34.5, 0.05},
+		{10, 5.3, 0.1},
+		{45, 1.0, 0.01},
+		{90, 0, 0.002},
+		{-1, 49.8, 0.1},
+		{-5, 49.8, 0.1}, // clamped to -1
+	} {
+		if got := Refraction(tt.apparent) * 60; math.Abs(got-tt.arcmin) > tt.tol {
+			t.Errorf("Refraction(%v) = %.3f', want %v'", tt.apparent, got, tt.arcmin)
+		}
+	}
+}
+
+// A sightline's declination is asin(sin φ sin h + cos φ cos h cos A) with h
+// the geometric altitude of the Sun's centre; due east on a flat horizon at
+// latitude 51.5 that is -0.658 degrees. The Sun's declination changes by
+// 0.395 degrees a day at the equinoxes, which fell at 03:06 UT on 20 March and
+// 12:44 UT on 22 September 2024, so it is -0.658 on 18 March and 24
+// September. On the level at sunrise the Sun moves 0.63 degrees of azimuth a
+// day, so the nearest day is within 0.32 degrees of the sightline.
+func TestAlign(t *testing.T) {
+	const lat, lon = 51.5, 0
+	for _, tt := range []struct {
+		line        Sightline
+		rising      bool
+		declination float64
+		dates       [][2]int
+	}{
+		{Sightline{"east", 90, 0}, true, -0.658, [][2]int{{3, 18}, {9, 24}}},
+		{Sightline{"west", 270, 0}, false, -0.658, [][2]int{{3, 18}, {9, 24}}},
+		{Sightline{"hill", 90, 2}, true, 1.119, [][2]int{{3, 23}, {9, 20}}},
+		// the solstice sunrise is at 48.89 degrees, so a sightline just
+		// south of it is crossed a few days either side
+		{Sightline{"midsummer", 48.95, 0}, true, 23.408, [][2]int{{6, 18}, {6, 24}}},
+		{Sightline{"north", 30, 0}, true, 31.841, nil},
+		{Sightline{"winter set", 229.5, 0}, false, -24.566, nil},
+	} {
+		a := Align(2024, time.UTC, lat, lon, []Sightline{tt.line})[0]
+		if a.Sightline != tt.line || a.Rising != tt.rising || math.Abs(a.Declination-tt.declination) > 0.001 {
+			t.Errorf("%s: rising %v, declination %.4f, want %v, %v", tt.line.Name, a.Rising, a.Declination, tt.rising, tt.declination)
+		}
+		if len(a.Times) != len(tt.dates) {
+			t.Errorf("%s: aligned on %v, want %v", tt.line.Name, a.Times, tt.dates)
+			continue
+		}
+		for i, at := range a.Times {
+			if want := date(time.UTC, 2024, time.Month(tt.dates[i][0]), tt.dates[i][1]); !within(at, want.Add(12*time.Hour), 36*time.Hour) {
+				t.Errorf("%s: aligned on %v, want %v", tt.line.Name, at.Format("Jan 2"), want.Format("Jan 2"))
+			}
+			if d := between(-180, 180, Azimuth(at, lat, lon)-tt.line.Azimuth); math.Abs(d) > 0.32 {
+				t.Errorf("%s: Sun at azimuth %.2f on %v", tt.line.Name, Azimuth(at, lat, lon), at.Format("Jan 2"))
+			}
+			if want := tt.line.Elevation - Refraction(tt.line.Elevation) - sunSemiDiameter; math.Abs(Altitude(at, lat, lon)-want) > 0.001 {
+				t.Errorf("%s: Sun at altitude %.4f, want %.4f", tt.line.Name, Altitude(at, lat, lon), want)
+			}
+		}
+	}
+}
+
+func TestAlignSolstice(t *testing.T) {
+	// a sightline just north of the solstice sunrise at 48.89 degrees is
+	// never crossed, but the Sun comes within alignmentTolerance of it then
+	a := Align(2024, time.UTC, 51.5, 0, []Sightline{{"midsummer", 48.6, 0}})[0]
+	if len(a.Times) != 1 || !within(a.Times[0], time.Date(2024, time.June, 20, 12, 0, 0, 0, time.UTC), 36*time.Hour) {
+		t.Errorf("aligned on %v, want once at the solstice", a.Times)
+	}
+}
@@ -0,0 +1,39 @@
+package sun
+
+import "time"
+
+// DayArc is the path of the Sun across one day, sampled for drawing the sun arc
+// seen in weather apps.
+type DayArc struct {
+	Start  time.Time // astronomical dawn, or the lower culmination before noon if there is none
+	End    time.Time // astronomical dusk, or the lower culmination after noon if there is none
+	Points []Sample  // evenly spaced from Start to End inclusive
+	Now    Sample    // the position at the time asked for
+	// NowFraction is how far Now is from Start to End, from 0 to 1 when Now
+	// falls within the arc.
+	NowFraction float64
+}
+
+// SunArc returns n points, at least 2, along the path of the Sun from
+// astronomical dawn to dusk on the date of now, with the position at now as
+// the marker. Times are in the time zone of now.
+func SunArc(now time.Time, latitude float64, longitude float64, n int) DayArc {
+	if n < 2 {
+		n = 2
+	}
+	noon := Culminate(now, latitude, longitude).Time
+	start, ok1 := crossing(now, latitude, longitude, AstronomicalTwilightAltitude, true, defaultTolerance)
+	end, ok2 := crossing(now, latitude, longitude, AstronomicalTwilightAltitude, false, defaultTolerance)
+	if !ok1 || !ok2 {
+		start, end = noon.Add(-12*time.Hour), noon.Add(12*time.Hour)
+	}
+	arc := DayArc{Start: start, End: end, Points: make([]Sample, n)}
+	span := end.Sub(start)
+	for i := range arc.Points {
+		t := start.Add(time.Duration(float64(span) * float64(i) / float64(n-1)))
+		arc.Points[i] = Sample{t, Altitude(t, latitude, longitude), Azimuth(t, latitude, longitude)}
+	}
+	arc.Now = Sample{now, Altitude(now, latitude, longitude), Azimuth(now, latitude, longitude)}
+	arc.NowFraction = float64(now.Sub(start)) / float64(span)
+	return arc
+}
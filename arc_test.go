@@ -0,0 +1,65 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSunArc(t *testing.T) {
+	loc := location(t, "Europe/London")
+	now := time.Date(2024, 12, 21, 10, 0, 0, 0, loc)
+	arc := SunArc(now, 51.5074, -0.1278, 25)
+	if len(arc.Points) != 25 {
+		t.Fatalf("%d points, want 25", len(arc.Points))
+	}
+	// the hour angle of -18 degrees at midwinter in London is 89.77 degrees,
+	// so astronomical dawn and dusk are 5h59m before and after noon at 11:59
+	if want := time.Date(2024, 12, 21, 5, 59, 40, 0, loc); !within(arc.Start, want, time.Minute) {
+		t.Errorf("Start = %v, want %v", arc.Start, want)
+	}
+	if want := time.Date(2024, 12, 21, 17, 57, 55, 0, loc); !within(arc.End, want, time.Minute) {
+		t.Errorf("End = %v, want %v", arc.End, want)
+	}
+	first, last := arc.Points[0], arc.Points[len(arc.Points)-1]
+	if !first.Time.Equal(arc.Start) || !last.Time.Equal(arc.End) {
+		t.Errorf("points run from %v to %v, want %v to %v", first.Time, last.Time, arc.Start, arc.End)
+	}
+	for _, p := range []Sample{first, last} {
+		if math.Abs(p.Altitude-AstronomicalTwilightAltitude) > 0.001 {
+			t.Errorf("altitude at %v = %v, want %v", p.Time, p.Altitude, AstronomicalTwilightAltitude)
+		}
+	}
+	step := arc.Points[1].Time.Sub(arc.Points[0].Time)
+	for i, p := range arc.Points[1:] {
+		if d := p.Time.Sub(arc.Points[i].Time) - step; d < -time.Microsecond || d > time.Microsecond {
+			t.Errorf("point %d is %v from the one before, want %v", i+1, p.Time.Sub(arc.Points[i].Time), step)
+		}
+		if p.Altitude != Altitude(p.Time, 51.5074, -0.1278) || p.Azimuth != Azimuth(p.Time, 51.5074, -0.1278) {
+			t.Errorf("point %d is not the Sun's position", i+1)
+		}
+	}
+	if !arc.Now.Time.Equal(now) || arc.Now.Altitude != Altitude(now, 51.5074, -0.1278) {
+		t.Errorf("Now = %+v, want the position at %v", arc.Now, now)
+	}
+	if want := float64(now.Sub(arc.Start)) / float64(arc.End.Sub(arc.Start)); arc.NowFraction != want {
+		t.Errorf("NowFraction = %v, want %v", arc.NowFraction, want)
+	}
+}
+
+func TestSunArcWithoutNight(t *testing.T) {
+	// in London at midsummer the Sun gets no lower than 15 degrees, so the
+	// arc runs between the lower culminations
+	now := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	arc := SunArc(now, 51.5074, -0.1278, 1)
+	if len(arc.Points) != 2 {
+		t.Errorf("%d points, want the minimum of 2", len(arc.Points))
+	}
+	noon := Culminate(now, 51.5074, -0.1278).Time
+	if !arc.Start.Equal(noon.Add(-12*time.Hour)) || !arc.End.Equal(noon.Add(12*time.Hour)) {
+		t.Errorf("arc from %v to %v, want 12 hours either side of %v", arc.Start, arc.End, noon)
+	}
+	if math.Abs(arc.NowFraction-0.5) > 0.01 {
+		t.Errorf("NowFraction at noon = %v, want about 0.5", arc.NowFraction)
+	}
+}
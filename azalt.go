@@ -0,0 +1,40 @@
+package sun
+
+import "time"
+
+// AzimuthAtAltitude returns the position of the Sun each time it passes the
+// given altitude on the date of t, in time order: usually once rising in the
+// east and once setting in the west, as when lining up an instrument on
+// where the Sun will clear a ridge of known height. It is empty if the Sun
+// does not reach the altitude that day, or stays above it.
+func AzimuthAtAltitude(t time.Time, latitude float64, longitude float64, altitude float64) []Sample {
+	start, end := dateSpan(t)
+	return crossingSamples(AltitudeCrossings(start, end, latitude, longitude, altitude), latitude, longitude)
+}
+
+// AltitudeAtAzimuth returns the position of the Sun each time it passes the
+// given azimuth on the date of t, in time order, as for AzimuthAtAltitude:
+// for example how high the Sun stands when it comes round to due east and
+// first shines square on to an east face. Below the horizon the altitude is
+// negative. Where the Sun never reaches the azimuth it is empty, and close to
+// the zenith it may pass it more than once.
+func AltitudeAtAzimuth(t time.Time, latitude float64, longitude float64, azimuth float64) []Sample {
+	start, end := dateSpan(t)
+	return crossingSamples(AzimuthCrossings(start, end, latitude, longitude, azimuth), latitude, longitude)
+}
+
+// dateSpan returns midnight at the start and end of the date of t
+func dateSpan(t time.Time) (time.Time, time.Time) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// crossingSamples returns the position of the Sun at each crossing
+func crossingSamples(cs []Crossing, latitude float64, longitude float64) []Sample {
+	ss := make([]Sample, len(cs))
+	for i, c := range cs {
+		ss[i] = Sample{c.Time, Altitude(c.Time, latitude, longitude), Azimuth(c.Time, latitude, longitude)}
+	}
+	return ss
+}
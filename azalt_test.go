@@ -0,0 +1,73 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// With sin δ = sin φ sin h + cos φ cos h cos A, at London on the June solstice
+// the Sun is 30 degrees up at cos A = (0.3978 - 0.3915) / 0.5388, azimuths
+// 89.32 and 270.68, and due east at sin h = 0.3978 / 0.7830, 30.54 degrees up.
+// On the equator at the equinox it rises and sets due east and west.
+func TestAzimuthAtAltitude(t *testing.T) {
+	june := date(time.UTC, 2024, time.June, 20)
+	december := date(time.UTC, 2024, time.December, 21)
+	for _, tt := range []struct {
+		d                  time.Time
+		lat, lon, altitude float64
+		azimuths           []float64
+		tol                float64
+	}{
+		{june, 51.5074, -0.1278, 30, []float64{89.32, 270.68}, 0.01},
+		{date(time.UTC, 2024, time.March, 20), 0, 0, 0, []float64{90, 270}, 0.3},
+		// in December the Sun gets to 15 degrees, and in June it is never
+		// more than 15 below
+		{december, 51.5074, -0.1278, 30, nil, 0},
+		{june, 51.5074, -0.1278, -30, nil, 0},
+	} {
+		got := AzimuthAtAltitude(tt.d, tt.lat, tt.lon, tt.altitude)
+		if len(got) != len(tt.azimuths) {
+			t.Errorf("%v at %v: %d crossings, want %d", tt.d.Format("2 Jan"), tt.lat, len(got), len(tt.azimuths))
+			continue
+		}
+		for i, s := range got {
+			if math.Abs(s.Azimuth-tt.azimuths[i]) > tt.tol || math.Abs(s.Altitude-tt.altitude) > 1e-6 {
+				t.Errorf("%v at %v: crossing %d at %v, %v, want azimuth %v", tt.d.Format("2 Jan"), tt.lat, i, s.Altitude, s.Azimuth, tt.azimuths[i])
+			}
+		}
+	}
+	// the same times as the 30 degree window in TestVitaminDWindow
+	if got := AzimuthAtAltitude(june, 51.5074, -0.1278, 30); !within(got[0].Time, clock(june, 7, 19), time.Minute) || !within(got[1].Time, clock(june, 16, 45), time.Minute) {
+		t.Errorf("30 degrees at %v and %v, want 07:19 and 16:45", got[0].Time, got[1].Time)
+	}
+}
+
+// At 20 N in June the Sun passes north of the zenith, 90 - 20 - 23.44 = 86.56
+// degrees up due north at noon and 20 + 23.44 - 90 = -46.56 at midnight, and
+// never comes round to due east.
+func TestAltitudeAtAzimuth(t *testing.T) {
+	june := date(time.UTC, 2024, time.June, 20)
+	for _, tt := range []struct {
+		d                 time.Time
+		lat, lon, azimuth float64
+		altitudes         []float64
+	}{
+		{june, 51.5074, -0.1278, 90, []float64{30.54}},
+		{june, 51.5074, -0.1278, 180, []float64{61.93}},
+		{date(time.UTC, 2024, time.December, 21), 51.5074, -0.1278, 90, []float64{-30.54}},
+		{june, 20, 0, 0, []float64{-46.56, 86.56}},
+		{june, 20, 0, 90, nil},
+	} {
+		got := AltitudeAtAzimuth(tt.d, tt.lat, tt.lon, tt.azimuth)
+		if len(got) != len(tt.altitudes) {
+			t.Errorf("%v at %v, azimuth %v: %d crossings, want %d", tt.d.Format("2 Jan"), tt.lat, tt.azimuth, len(got), len(tt.altitudes))
+			continue
+		}
+		for i, s := range got {
+			if math.Abs(s.Altitude-tt.altitudes[i]) > 0.01 || math.Abs(between(-180, 180, s.Azimuth-tt.azimuth)) > 1e-6 {
+				t.Errorf("%v at %v: crossing %d at %v, %v, want altitude %v", tt.d.Format("2 Jan"), tt.lat, i, s.Altitude, s.Azimuth, tt.altitudes[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sun
+
+import (
+	"sort"
+	"time"
+)
+
+// BandStatus says where the Sun stands relative to a band of altitude.
+type BandStatus int
+
+const (
+	InBand  BandStatus = iota // within the band
+	TooLow                    // below the band: long shadows, poor light
+	TooHigh                   // above the band: hotspots and glare
+)
+
+var bandStatusNames = [...]string{"in band", "sun too low", "sun too high"}
+
+func (s BandStatus) String() string {
+	if s < 0 || int(s) >= len(bandStatusNames) {
+		return "unknown"
+	}
+	return bandStatusNames[s]
+}
+
+// BandWindow is a stretch of time throughout which the Sun has one BandStatus.
+type BandWindow struct {
+	Period
+	Status BandStatus
+}
+
+// AltitudeBandWindows splits the time from start to end into windows according
+// to whether the altitude of the Sun is within the band from low to high
+// degrees, below it or above it. Survey flights for photogrammetry, for
+// instance, want the Sun between about 30 and 60 degrees: high enough for short
+// shadows and even light, low enough to avoid a hotspot of reflected glare in
+// nadir images. Times are to within a second and in the time zone of start, and
+// windows come in time order and cover the whole span.
+func AltitudeBandWindows(start time.Time, end time.Time, latitude float64, longitude float64, low float64, high float64) []BandWindow {
+	if !end.After(start) {
+		return nil
+	}
+	cuts := []time.Time{start, end}
+	for _, alt := range []float64{low, high} {
+		for _, c := range AltitudeCrossings(start, end, latitude, longitude, alt) {
+			cuts = append(cuts, c.Time)
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	var ws []BandWindow
+	for i := 1; i < len(cuts); i++ {
+		if !cuts[i].After(cuts[i-1]) {
+			continue
+		}
+		mid := cuts[i-1].Add(cuts[i].Sub(cuts[i-1]) / 2)
+		status := InBand
+		if alt := Altitude(mid, latitude, longitude); alt < low {
+			status = TooLow
+		} else if alt > high {
+			status = TooHigh
+		}
+		if n := len(ws); n > 0 && ws[n-1].Status == status {
+			ws[n-1].End = cuts[i]
+			continue
+		}
+		ws = append(ws, BandWindow{Period{cuts[i-1], cuts[i]}, status})
+	}
+	return ws
+}
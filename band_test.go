@@ -0,0 +1,71 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// At London on the June solstice, with noon at 12:02.3 UT, sin h = 0.3113 +
+// 0.5711 cos H puts the Sun at 30 degrees at an hour angle of 70.71 degrees,
+// 4h42.8m from noon, and at 60 degrees at 13.79 degrees, 55.2 minutes from
+// noon. It reaches 61.9 at noon, so the day goes low, in band, high, in band,
+// low.
+func TestAltitudeBandWindows(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	ws := AltitudeBandWindows(d, d.AddDate(0, 0, 1), 51.5074, -0.1278, 30, 60)
+	want := []struct {
+		status     BandStatus
+		start, end time.Time
+	}{
+		{TooLow, d, clock(d, 7, 19)},
+		{InBand, clock(d, 7, 19), clock(d, 11, 7)},
+		{TooHigh, clock(d, 11, 7), clock(d, 12, 57)},
+		{InBand, clock(d, 12, 57), clock(d, 16, 45)},
+		{TooLow, clock(d, 16, 45), d.AddDate(0, 0, 1)},
+	}
+	if len(ws) != len(want) {
+		t.Fatalf("AltitudeBandWindows = %v, want %d windows", ws, len(want))
+	}
+	for i, w := range want {
+		if ws[i].Status != w.status || !within(ws[i].Start, w.start, time.Minute) || !within(ws[i].End, w.end, time.Minute) {
+			t.Errorf("window %d = %v from %v to %v, want %v from %v to %v", i, ws[i].Status, ws[i].Start.Format("15:04:05"), ws[i].End.Format("15:04:05"), w.status, w.start.Format("15:04"), w.end.Format("15:04"))
+		}
+		if i > 0 && !ws[i].Start.Equal(ws[i-1].End) {
+			t.Errorf("window %d starts at %v, not where the last ended at %v", i, ws[i].Start, ws[i-1].End)
+		}
+	}
+
+	// in December the Sun gets no higher than 15 degrees
+	d = date(time.UTC, 2024, time.December, 21)
+	ws = AltitudeBandWindows(d, d.AddDate(0, 0, 1), 51.5074, -0.1278, 30, 60)
+	if len(ws) != 1 || ws[0].Status != TooLow || ws[0].Period != (Period{d, d.AddDate(0, 0, 1)}) {
+		t.Errorf("AltitudeBandWindows in December = %v, want one low window", ws)
+	}
+
+	// times are in the zone of start
+	bst := location(t, "Europe/London")
+	ws = AltitudeBandWindows(date(bst, 2024, time.June, 21), date(bst, 2024, time.June, 21).Add(9*time.Hour), 51.5074, -0.1278, 30, 60)
+	if len(ws) != 2 || ws[1].Start.Location() != bst || ws[1].Start.Hour() != 8 {
+		t.Errorf("AltitudeBandWindows in BST = %v, want in band from 08:19", ws)
+	}
+
+	if ws := AltitudeBandWindows(d, d, 51.5074, -0.1278, 30, 60); ws != nil {
+		t.Errorf("AltitudeBandWindows over no time = %v", ws)
+	}
+}
+
+func TestBandStatus(t *testing.T) {
+	for _, tt := range []struct {
+		s    BandStatus
+		want string
+	}{
+		{InBand, "in band"},
+		{TooLow, "sun too low"},
+		{TooHigh, "sun too high"},
+		{BandStatus(3), "unknown"},
+	} {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.s), got, tt.want)
+		}
+	}
+}
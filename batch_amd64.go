@@ -0,0 +1,8 @@
+package sun
+
+// sinAltitudes replaces each element c of x by a + b·c, four at a time in SSE2
+// registers. SSE2 has no fused multiply-add, so each result is rounded twice
+// exactly as sinAltitudesGeneric rounds it.
+//
+//go:noescape
+func sinAltitudes(a float64, b float64, x []float64)
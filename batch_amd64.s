@@ -0,0 +1,42 @@
+#include "textflag.h"
+
+// func sinAltitudes(a float64, b float64, x []float64)
+TEXT ·sinAltitudes(SB), NOSPLIT, $0-40
+	MOVSD  a+0(FP), X0
+	SHUFPD $0, X0, X0
+	MOVSD  b+8(FP), X1
+	SHUFPD $0, X1, X1
+	MOVQ   x_base+16(FP), SI
+	MOVQ   x_len+24(FP), CX
+	MOVQ   CX, DX
+	SHRQ   $2, DX
+	JZ     tail
+
+loop4:
+	MOVUPD 0(SI), X2
+	MOVUPD 16(SI), X3
+	MULPD  X1, X2
+	MULPD  X1, X3
+	ADDPD  X0, X2
+	ADDPD  X0, X3
+	MOVUPD X2, 0(SI)
+	MOVUPD X3, 16(SI)
+	ADDQ   $32, SI
+	DECQ   DX
+	JNZ    loop4
+
+tail:
+	ANDQ $3, CX
+	JZ   done
+
+loop1:
+	MOVSD (SI), X2
+	MULSD X1, X2
+	ADDSD X0, X2
+	MOVSD X2, (SI)
+	ADDQ  $8, SI
+	DECQ  CX
+	JNZ   loop1
+
+done:
+	RET
@@ -0,0 +1,8 @@
+//go:build !amd64
+
+package sun
+
+// sinAltitudes replaces each element c of x by a + b·c.
+func sinAltitudes(a float64, b float64, x []float64) {
+	sinAltitudesGeneric(a, b, x)
+}
@@ -0,0 +1,111 @@
+package sun
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSinAltitudesAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n <= 19; n++ {
+		a, b := r.Float64()*2-1, r.Float64()
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = r.Float64()*2 - 1
+		}
+		want := append([]float64(nil), x...)
+		sinAltitudesGeneric(a, b, want)
+		sinAltitudes(a, b, x)
+		for i := range x {
+			if math.Float64bits(x[i]) != math.Float64bits(want[i]) {
+				t.Errorf("n=%d: element %d = %v, generic gives %v", n, i, x[i], want[i])
+			}
+		}
+	}
+}
+
+func TestAltitudeGrid(t *testing.T) {
+	when := time.Date(2024, 6, 21, 15, 30, 0, 0, time.UTC)
+	lats := []float64{-89, -45.5, -10, 0, 23.44, 51.48, 78.2}
+	lons := []float64{-180, -122.4, -60, -0.0015, 2.35, 45, 90, 139.65, 151.21}
+	got := AltitudeGrid(when, lats, lons, []float64{1, 2, 3})
+	if len(got) != len(lats)*len(lons) {
+		t.Fatalf("len = %d, want %d", len(got), len(lats)*len(lons))
+	}
+	for i, lat := range lats {
+		for j, lon := range lons {
+			want := Altitude(when, lat, lon)
+			if got[i*len(lons)+j] != want {
+				t.Errorf("(%v, %v): %v, Altitude gives %v", lat, lon, got[i*len(lons)+j], want)
+			}
+		}
+	}
+}
+
+func TestAltitudes(t *testing.T) {
+	start := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	times := make([]time.Time, 48)
+	for i := range times {
+		times[i] = start.Add(time.Duration(i) * 30 * time.Minute)
+	}
+	got := Altitudes(times, -33.87, 151.21, nil)
+	for i, tm := range times {
+		if want := Altitude(tm, -33.87, 151.21); got[i] != want {
+			t.Errorf("%v: %v, Altitude gives %v", tm, got[i], want)
+		}
+	}
+}
+
+func gridAxes(rows int, cols int) ([]float64, []float64) {
+	lats, lons := make([]float64, rows), make([]float64, cols)
+	for i := range lats {
+		lats[i] = 90 - 180*(float64(i)+0.5)/float64(rows)
+	}
+	for j := range lons {
+		lons[j] = -180 + 360*(float64(j)+0.5)/float64(cols)
+	}
+	return lats, lons
+}
+
+func BenchmarkAltitudeGrid(b *testing.B) {
+	when := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	lats, lons := gridAxes(180, 360)
+	out := make([]float64, 0, len(lats)*len(lons))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = AltitudeGrid(when, lats, lons, out)
+	}
+}
+
+func BenchmarkAltitudeGridPointwise(b *testing.B) {
+	when := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	lats, lons := gridAxes(180, 360)
+	out := make([]float64, 0, len(lats)*len(lons))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = out[:0]
+		for _, lat := range lats {
+			for _, lon := range lons {
+				out = append(out, Altitude(when, lat, lon))
+			}
+		}
+	}
+}
+
+func BenchmarkSinAltitudes(b *testing.B) {
+	x := make([]float64, 4096)
+	b.SetBytes(int64(8 * len(x)))
+	for i := 0; i < b.N; i++ {
+		sinAltitudes(0.25, 0.5, x)
+	}
+}
+
+func BenchmarkSinAltitudesGeneric(b *testing.B) {
+	x := make([]float64, 4096)
+	b.SetBytes(int64(8 * len(x)))
+	for i := 0; i < b.N; i++ {
+		sinAltitudesGeneric(0.25, 0.5, x)
+	}
+}
@@ -0,0 +1,124 @@
+package sun
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DayInfo holds the solar events for one date at one location.
+type DayInfo struct {
+	Sunrise time.Time // zero if the Sun does not rise
+	Sunset  time.Time // zero if the Sun does not set
+	Noon    Culmination
+	Length  time.Duration
+}
+
+// DayEvents returns the solar events on the date of t at the given location,
+// with times in the time zone of t.
+func DayEvents(t time.Time, latitude float64, longitude float64) DayInfo {
+	var d DayInfo
+	d.Sunrise, _ = Sunrise(t, latitude, longitude)
+	d.Sunset, _ = Sunset(t, latitude, longitude)
+	d.Noon = Culminate(t, latitude, longitude)
+	d.Length = DayLength(t, latitude, longitude)
+	return d
+}
+
+// DefaultCache is a process wide Cache for callers that have no reason to keep
+// their own.
+var DefaultCache = newCache(4096)
+
+// Cache remembers the results of DayEvents so that a server answering many
+// requests for the same few places does not compute them again. When full it
+// forgets the least recently used day.
+//
+// A Cache is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *cacheEntry, most recently used first
+	entries map[cacheKey]*list.Element
+}
+
+// cacheKey identifies a date by the instant of clock noon on it, which is all
+// DayEvents takes from the time zone. Zones are not told apart by name, as
+// every numeric offset from time.Parse has the same empty one.
+type cacheKey struct {
+	noon      int64 // Unix seconds
+	latitude  float64
+	longitude float64
+}
+
+type cacheEntry struct {
+	key cacheKey
+	day DayInfo // in UTC
+}
+
+// NewCache returns a Cache holding at most size days. It returns an error if
+// size is not positive.
+func NewCache(size int) (*Cache, error) {
+	if size <= 0 {
+		return nil, errNonPositiveSize
+	}
+	return newCache(size), nil
+}
+
+func newCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+// DayEvents returns DayEvents(t, latitude, longitude), computing it only if it
+// is not already cached.
+//
+// The events are computed without holding the lock, so two goroutines asking
+// for the same new day at once may both compute it.
+func (c *Cache) DayEvents(t time.Time, latitude float64, longitude float64) DayInfo {
+	y, m, d := t.Date()
+	k := cacheKey{time.Date(y, m, d, 12, 0, 0, 0, t.Location()).Unix(), latitude, longitude}
+	c.mu.Lock()
+	if e, ok := c.entries[k]; ok {
+		c.order.MoveToFront(e)
+		day := e.Value.(*cacheEntry).day
+		c.mu.Unlock()
+		return day.in(t.Location())
+	}
+	c.mu.Unlock()
+
+	day := DayEvents(t, latitude, longitude)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[k]; ok {
+		c.order.MoveToFront(e)
+		return day
+	}
+	c.entries[k] = c.order.PushFront(&cacheEntry{k, day.in(time.UTC)})
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+	}
+	return day
+}
+
+// in returns d with its times in loc, leaving missing events zero
+func (d DayInfo) in(loc *time.Location) DayInfo {
+	for _, t := range []*time.Time{&d.Sunrise, &d.Sunset, &d.Noon.Time} {
+		if !t.IsZero() {
+			*t = t.In(loc)
+		}
+	}
+	return d
+}
+
+// Len returns the number of days in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,151 @@
+package sun
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheHitAndMiss(t *testing.T) {
+	c, err := NewCache(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2024, 6, 21, 9, 0, 0, 0, time.UTC)
+	want := DayEvents(day, 51.5, -0.13)
+
+	if got := c.DayEvents(day, 51.5, -0.13); got != want {
+		t.Errorf("miss: got %+v, want %+v", got, want)
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len after a miss = %d, want 1", c.Len())
+	}
+	// a later time on the same date is a hit
+	if got := c.DayEvents(day.Add(10*time.Hour), 51.5, -0.13); got != want {
+		t.Errorf("hit: got %+v, want %+v", got, want)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len after a hit = %d, want 1", c.Len())
+	}
+
+	// the same instant in another zone is another date's events
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	c.DayEvents(day.In(ny), 51.5, -0.13)
+	if c.Len() != 2 {
+		t.Errorf("Len after a miss in another zone = %d, want 2", c.Len())
+	}
+}
+
+// time.Parse gives every numeric offset a zone with an empty name, so the
+// cache must not mistake one for another.
+func TestCacheZonesWithoutNames(t *testing.T) {
+	c, err := NewCache(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"2024-06-21T09:00:00+02:00", "2024-06-21T09:00:00-05:00", "2024-06-21T18:00:00+02:00"} {
+		at, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := DayEvents(at, 51.5, -0.13)
+		got := c.DayEvents(at, 51.5, -0.13)
+		if !got.Sunrise.Equal(want.Sunrise) || !got.Sunset.Equal(want.Sunset) || !got.Noon.Time.Equal(want.Noon.Time) {
+			t.Errorf("%s: got %+v, want %+v", s, got, want)
+		}
+		if got.Sunrise.Format(time.RFC3339) != want.Sunrise.Format(time.RFC3339) {
+			t.Errorf("%s: sunrise %v, want %v", s, got.Sunrise, want.Sunrise)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len after two offsets on one date = %d, want 2", c.Len())
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c, err := NewCache(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d2, d3 := d1.AddDate(0, 0, 1), d1.AddDate(0, 0, 2)
+	c.DayEvents(d1, 10, 20)
+	c.DayEvents(d2, 10, 20)
+	// touch d1 so that d2 is the least recently used
+	c.DayEvents(d1, 10, 20)
+	c.DayEvents(d3, 10, 20)
+
+	if c.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", c.Len())
+	}
+	for _, tt := range []struct {
+		date time.Time
+		in   bool
+	}{{d1, true}, {d2, false}, {d3, true}} {
+		_, ok := c.entries[cacheKey{tt.date.Add(12 * time.Hour).Unix(), 10, 20}]
+		if ok != tt.in {
+			t.Errorf("%v cached = %v, want %v", tt.date.Format("2006-01-02"), ok, tt.in)
+		}
+	}
+}
+
+func TestNewCacheSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, err := NewCache(size); err != errNonPositiveSize {
+			t.Errorf("NewCache(%d): err = %v, want %v", size, err, errNonPositiveSize)
+		}
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c, err := NewCache(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	want := make([]DayInfo, 32)
+	for i := range want {
+		want[i] = DayEvents(start.AddDate(0, 0, i), 48.85, 2.35)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				i := (g*7 + n) % len(want)
+				if got := c.DayEvents(start.AddDate(0, 0, i), 48.85, 2.35); got != want[i] {
+					t.Errorf("day %d: got %+v, want %+v", i, got, want[i])
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c.Len() > 16 {
+		t.Errorf("Len = %d, more than the size of 16", c.Len())
+	}
+}
+
+func BenchmarkDayEvents(b *testing.B) {
+	day := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		DayEvents(day, 51.5, -0.13)
+	}
+}
+
+func BenchmarkCacheDayEvents(b *testing.B) {
+	c, err := NewCache(64)
+	if err != nil {
+		b.Fatal(err)
+	}
+	day := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.DayEvents(day, 51.5, -0.13)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package sun
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// CloudModel gives the fraction of the sky covered by cloud, from 0 for a
+// clear sky to 1 for overcast, at a time and place. The package does no
+// fetching of its own; callers back a CloudModel with a forecast or
+// observations from the weather service of their choice.
+type CloudModel interface {
+	CloudCover(ctx context.Context, t time.Time, p Point) (float64, error)
+}
+
+// CloudCoverFunc adapts a function to a CloudModel.
+type CloudCoverFunc func(ctx context.Context, t time.Time, p Point) (float64, error)
+
+// CloudCover calls f.
+func (f CloudCoverFunc) CloudCover(ctx context.Context, t time.Time, p Point) (float64, error) {
+	return f(ctx, t, p)
+}
+
+// FixedCloudCover is a CloudModel with the same cover everywhere at all times,
+// for what-if questions and for skies reported as a single number.
+type FixedCloudCover float64
+
+// CloudCover returns c.
+func (c FixedCloudCover) CloudCover(ctx context.Context, t time.Time, p Point) (float64, error) {
+	return float64(c), nil
+}
+
+// cloudTransmission returns the fraction of the clear sky global irradiance
+// reaching the ground under the given cloud cover, by the formula of Kasten
+// and Czeplak: all of it under a clear sky and a quarter under overcast
+func cloudTransmission(cover float64) float64 {
+	return 1 - 0.75*math.Pow(math.Max(0, math.Min(1, cover)), 3.4)
+}
+
+// AllSkyGHI returns the global horizontal irradiance at t in watts per square
+// metre, ClearSkyGHI reduced for the cloud cover that m reports there. Cloud
+// of a given cover varies a great deal in thickness, so single values may be
+// out by half either way, though averages over days are much better.
+func AllSkyGHI(ctx context.Context, t time.Time, latitude float64, longitude float64, m CloudModel) (float64, error) {
+	ghi := ClearSkyGHI(t, latitude, longitude)
+	if ghi == 0 {
+		return 0, nil
+	}
+	cover, err := m.CloudCover(ctx, t, Point{latitude, longitude})
+	if err != nil {
+		return 0, err
+	}
+	return ghi * cloudTransmission(cover), nil
+}
+
+// AllSkyIlluminance returns the illuminance in lux on level ground at t,
+// Illuminance reduced for the cloud cover that m reports there, in the same
+// proportion as AllSkyGHI. Cloud lit by a town can make the night sky
+// brighter rather than darker, which this leaves out.
+func AllSkyIlluminance(ctx context.Context, t time.Time, latitude float64, longitude float64, m CloudModel) (float64, error) {
+	cover, err := m.CloudCover(ctx, t, Point{latitude, longitude})
+	if err != nil {
+		return 0, err
+	}
+	return Illuminance(t, latitude, longitude) * cloudTransmission(cover), nil
+}
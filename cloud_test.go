@@ -0,0 +1,88 @@
+package sun
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Kasten and Czeplak give 1 - 0.75 (N/8)^3.4 of the clear sky irradiance
+// under N oktas: 0.9290 at four, 0.7180 at six and a quarter at eight.
+func TestCloudTransmission(t *testing.T) {
+	for _, tt := range []struct {
+		cover, want float64
+	}{
+		{0, 1},
+		{0.5, 0.9290},
+		{0.75, 0.7180},
+		{1, 0.25},
+		{-0.2, 1},
+		{1.5, 0.25},
+	} {
+		if got := cloudTransmission(tt.cover); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("cloudTransmission(%v) = %.4f, want %v", tt.cover, got, tt.want)
+		}
+	}
+}
+
+// Overhead, Haurwitz gives 1037.2 W/m² under a clear sky, as in
+// TestClearSky, so 963.5 under four oktas and 259.3 under overcast.
+func TestAllSkyGHI(t *testing.T) {
+	at := time.Date(2024, time.March, 20, 12, 7, 0, 0, time.UTC)
+	lat, lon := SubsolarPoint(at)
+	for _, tt := range []struct {
+		m    CloudModel
+		want float64
+	}{
+		{FixedCloudCover(0), 1037.2},
+		{FixedCloudCover(0.5), 963.5},
+		{FixedCloudCover(1), 259.3},
+		{CloudCoverFunc(func(ctx context.Context, t time.Time, p Point) (float64, error) {
+			// cloud over the southern hemisphere only
+			if p.Latitude < 0 {
+				return 1, nil
+			}
+			return 0, nil
+		}), 1037.2},
+	} {
+		got, err := AllSkyGHI(context.Background(), at, lat, lon, tt.m)
+		if err != nil || math.Abs(got-tt.want) > 0.5 {
+			t.Errorf("AllSkyGHI under %v = %.1f, %v, want %v", tt.m, got, err, tt.want)
+		}
+	}
+
+	failed := errors.New("forecast unavailable")
+	down := CloudCoverFunc(func(context.Context, time.Time, Point) (float64, error) { return 0, failed })
+	if _, err := AllSkyGHI(context.Background(), at, lat, lon, down); err != failed {
+		t.Errorf("error %v, want %v", err, failed)
+	}
+	// at night there is nothing to reduce, so the model is not asked
+	if got, err := AllSkyGHI(context.Background(), at, -lat, lon+180, down); got != 0 || err != nil {
+		t.Errorf("AllSkyGHI at night = %v, %v", got, err)
+	}
+}
+
+func TestAllSkyIlluminance(t *testing.T) {
+	at := time.Date(2024, time.March, 20, 12, 7, 0, 0, time.UTC)
+	lat, lon := SubsolarPoint(at)
+	clear := Illuminance(at, lat, lon)
+	for _, tt := range []struct {
+		cover, factor float64
+	}{
+		{0, 1},
+		{0.75, 0.7180},
+		{1, 0.25},
+	} {
+		got, err := AllSkyIlluminance(context.Background(), at, lat, lon, FixedCloudCover(tt.cover))
+		if err != nil || math.Abs(got/clear-tt.factor) > 1e-4 {
+			t.Errorf("AllSkyIlluminance under %v = %.0f lux, %v, want %.0f", tt.cover, got, err, clear*tt.factor)
+		}
+	}
+	failed := errors.New("forecast unavailable")
+	down := CloudCoverFunc(func(context.Context, time.Time, Point) (float64, error) { return 0, failed })
+	if _, err := AllSkyIlluminance(context.Background(), at, -lat, lon+180, down); err != failed {
+		t.Errorf("error at night %v, want %v", err, failed)
+	}
+}
@@ -0,0 +1,225 @@
+// Command gengolden regenerates the golden almanac in testdata/golden.csv,
+// sunrise, sunset, transit and positions of the Sun for reference cities on
+// the 1st and 15th of each month of reference years, and reports how far the
+// package has drifted from it. Committing the regenerated file alongside a
+// change to the models makes its effect on accuracy part of the review.
+//
+// Usage:
+//
+//	gengolden [-model meeus] [-years 2000,2024] [-o testdata/golden.csv]
+//	gengolden -diff [-tolerance 1s] [-angle 0.0001]
+//
+// The -model flag chooses the ephemeris for the altitude and azimuth columns;
+// the event columns always come from the package's event functions. With
+// -diff nothing is written, every column is compared with the file and the
+// largest drift in each is printed, and the exit status is 1 if any exceeds
+// the tolerances.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// city is a reference location
+type city struct {
+	name     string
+	lat, lon float64
+}
+
+// cities spread over the latitudes, including one with midnight sun and polar
+// night and one near the date line
+var cities = []city{
+	{"Reykjavik", 64.1466, -21.9426},
+	{"Tromso", 69.6492, 18.9553},
+	{"London", 51.4779, -0.0015},
+	{"New York", 40.7128, -74.0060},
+	{"Singapore", 1.3521, 103.8198},
+	{"Nairobi", -1.2921, 36.8219},
+	{"Sydney", -33.8688, 151.2093},
+	{"Auckland", -36.8485, 174.7633},
+	{"Ushuaia", -54.8019, -68.3030},
+}
+
+var header = []string{"city", "date", "sunrise", "sunset", "transit", "transit_altitude", "altitude_12utc", "azimuth_12utc"}
+
+// timeColumn reports whether column i holds times rather than angles
+func timeColumn(i int) bool {
+	return i >= 2 && i <= 4
+}
+
+func main() {
+	model := flag.String("model", "lowprecision", "ephemeris for positions: lowprecision, meeus, vsop87 or spa")
+	years := flag.String("years", "2000,2024", "comma separated reference years")
+	out := flag.String("o", "testdata/golden.csv", "golden file")
+	diff := flag.Bool("diff", false, "compare with the golden file instead of writing it")
+	tolerance := flag.Duration("tolerance", time.Second, "largest drift allowed in a time with -diff")
+	angle := flag.Float64("angle", 0.0001, "largest drift allowed in an angle with -diff, degrees")
+	flag.Parse()
+	log.SetFlags(0)
+
+	var eph sun.Ephemeris
+	switch *model {
+	case "lowprecision":
+		eph = sun.LowPrecision{}
+	case "meeus":
+		eph = sun.Meeus{}
+	case "vsop87":
+		eph = sun.VSOP87{}
+	case "spa":
+		eph = sun.SPA{}
+	default:
+		log.Fatalf("unknown -model %q", *model)
+	}
+	var ys []int
+	for _, s := range strings.Split(*years, ",") {
+		y, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			log.Fatalf("bad -years: %v", err)
+		}
+		ys = append(ys, y)
+	}
+	rows := generate(eph, ys)
+
+	if !*diff {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		w := csv.NewWriter(f)
+		w.Write(header)
+		w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	golden, err := csv.NewReader(f).ReadAll()
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(golden) == 0 || len(golden)-1 != len(rows) {
+		log.Fatalf("%s has %d rows, expected %d: regenerate it with the same -years", *out, len(golden)-1, len(rows))
+	}
+	if !report(golden[1:], rows, *tolerance, *angle) {
+		os.Exit(1)
+	}
+}
+
+// generate computes the almanac rows for the reference cities and years
+func generate(eph sun.Ephemeris, years []int) [][]string {
+	clock := func(t time.Time, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	deg := func(x float64) string {
+		return strconv.FormatFloat(x, 'f', 6, 64)
+	}
+	var rows [][]string
+	for _, y := range years {
+		for _, c := range cities {
+			for m := time.January; m <= time.December; m++ {
+				for _, d := range []int{1, 15} {
+					date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+					rise, ok1 := sun.Sunrise(date, c.lat, c.lon)
+					set, ok2 := sun.Sunset(date, c.lat, c.lon)
+					tr := sun.Culminate(date, c.lat, c.lon)
+					noon := date.Add(12 * time.Hour)
+					rows = append(rows, []string{
+						c.name,
+						date.Format("2006-01-02"),
+						clock(rise, ok1),
+						clock(set, ok2),
+						clock(tr.Time, true),
+						deg(tr.Altitude),
+						deg(sun.AltitudeWith(eph, noon, c.lat, c.lon)),
+						deg(sun.AzimuthWith(eph, noon, c.lat, c.lon)),
+					})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// report prints the largest drift in each column between the golden and
+// current rows, and returns whether all are within the tolerances
+func report(golden [][]string, current [][]string, tolerance time.Duration, angle float64) bool {
+	type worst struct {
+		drift float64
+		where string
+	}
+	worsts := make([]worst, len(header))
+	ok := true
+	for r := range current {
+		g, c := golden[r], current[r]
+		where := c[0] + " " + c[1]
+		if len(g) != len(c) || g[0] != c[0] || g[1] != c[1] {
+			log.Fatalf("row %d of the golden file is for %s %s, expected %s", r+2, g[0], g[1], where)
+		}
+		for i := 2; i < len(c); i++ {
+			var drift float64
+			switch {
+			case g[i] == c[i]:
+				continue
+			case g[i] == "" || c[i] == "":
+				// an event appearing or vanishing is always a failure
+				drift = math.Inf(1)
+			case timeColumn(i):
+				a, err1 := time.Parse(time.RFC3339, g[i])
+				b, err2 := time.Parse(time.RFC3339, c[i])
+				if err1 != nil || err2 != nil {
+					log.Fatalf("bad time in row %d", r+2)
+				}
+				drift = math.Abs(b.Sub(a).Seconds())
+			default:
+				a, err1 := strconv.ParseFloat(g[i], 64)
+				b, err2 := strconv.ParseFloat(c[i], 64)
+				if err1 != nil || err2 != nil {
+					log.Fatalf("bad number in row %d", r+2)
+				}
+				drift = math.Abs(math.Mod(b-a+540, 360) - 180)
+			}
+			if drift > worsts[i].drift {
+				worsts[i] = worst{drift, where}
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "column\tmax drift\tat\t")
+	for i := 2; i < len(header); i++ {
+		limit, unit := angle, "°"
+		if timeColumn(i) {
+			limit, unit = tolerance.Seconds(), "s"
+		}
+		status := ""
+		if worsts[i].drift > limit {
+			status, ok = "FAIL", false
+		}
+		fmt.Fprintf(w, "%s\t%g%s\t%s\t%s\n", header[i], worsts[i].drift, unit, worsts[i].where, status)
+	}
+	w.Flush()
+	return ok
+}
@@ -0,0 +1,299 @@
+// Command noaadiff compares the package with the NOAA Solar Calculator over a
+// matrix of dates, times and places, and writes an accuracy report in
+// Markdown, for keeping as a build artifact.
+//
+// Usage:
+//
+//	noaadiff [-years 2000,2024] [-step 3h] [-latstep 10] [-o report.md]
+//	noaadiff -replay noaa.csv [-o report.md]
+//	noaadiff -record testdata/noaa.csv
+//
+// By default the NOAA results come from a transcription of the equations of
+// NOAA's calculator spreadsheets, in noaa.go, so no network is needed. With
+// -replay they are read instead from a CSV file recorded from NOAA's own
+// calculator, with a header naming the columns time, latitude, longitude,
+// elevation and azimuth, and optionally sunrise and sunset. Times are RFC 3339
+// and elevation is geometric, without refraction; lines starting with # are
+// comments. With -record such a file is written from the transcription for a
+// few reference places and dates, which is what testdata/noaa.csv holds.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// stat accumulates the differences in one quantity
+type stat struct {
+	name, unit string
+	n          int
+	sumSq      float64
+	max        float64
+	where      string
+}
+
+func (s *stat) add(diff float64, where string) {
+	s.n++
+	s.sumSq += diff * diff
+	if math.Abs(diff) > math.Abs(s.max) {
+		s.max, s.where = diff, where
+	}
+}
+
+func (s *stat) rms() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return math.Sqrt(s.sumSq / float64(s.n))
+}
+
+// comparison holds the statistics of a run
+type comparison struct {
+	altitude, azimuth, sunrise, sunset stat
+}
+
+func newComparison() *comparison {
+	return &comparison{
+		altitude: stat{name: "altitude", unit: "°"},
+		azimuth:  stat{name: "azimuth", unit: "°"},
+		sunrise:  stat{name: "sunrise", unit: "s"},
+		sunset:   stat{name: "sunset", unit: "s"},
+	}
+}
+
+// position compares the package with NOAA's elevation and azimuth
+func (c *comparison) position(t time.Time, lat float64, lon float64, elevation float64, azimuth float64) {
+	where := fmt.Sprintf("%s %.2f,%.2f", t.UTC().Format(time.RFC3339), lat, lon)
+	c.altitude.add(sun.Altitude(t, lat, lon)-elevation, where)
+	if elevation > -1 && elevation < 85 {
+		// azimuth is ill defined near the zenith
+		c.azimuth.add(math.Mod(sun.Azimuth(t, lat, lon)-azimuth+540, 360)-180, where)
+	}
+}
+
+// event compares a sunrise or sunset with NOAA's, on the same UTC date
+func (c *comparison) event(s *stat, noaa time.Time, lat float64, lon float64, rising bool) {
+	day := time.Date(noaa.Year(), noaa.Month(), noaa.Day(), 0, 0, 0, 0, time.UTC)
+	var t time.Time
+	var ok bool
+	if rising {
+		t, ok = sun.Sunrise(day, lat, lon)
+	} else {
+		t, ok = sun.Sunset(day, lat, lon)
+	}
+	if ok {
+		s.add(t.Sub(noaa).Seconds(), fmt.Sprintf("%s %.2f,%.2f", day.Format("2006-01-02"), lat, lon))
+	}
+}
+
+func main() {
+	years := flag.String("years", "2000,2024", "comma separated years for the matrix")
+	step := flag.Duration("step", 3*time.Hour, "time between samples through each day of the matrix")
+	latStep := flag.Float64("latstep", 10, "spacing of latitudes in the matrix, degrees")
+	replay := flag.String("replay", "", "CSV file of recorded NOAA results to compare with instead of the matrix")
+	rec := flag.String("record", "", "write a CSV file of the transcription's results for replay, and nothing else")
+	out := flag.String("o", "", "report file, standard output if empty")
+	flag.Parse()
+	log.SetFlags(0)
+	if *step <= 0 || *latStep <= 0 {
+		log.Fatal("-step and -latstep must be positive")
+	}
+
+	if *rec != "" {
+		f, err := os.Create(*rec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := record(f); err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	c := newComparison()
+	source := "NOAA spreadsheet equations"
+	if *replay != "" {
+		source = *replay
+		if err := replayFile(c, *replay); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		for _, s := range strings.Split(*years, ",") {
+			y, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				log.Fatalf("bad -years: %v", err)
+			}
+			matrix(c, y, *step, *latStep)
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	fmt.Fprintf(w, "# Accuracy against the NOAA Solar Calculator\n\nReference: %s\n\n", source)
+	fmt.Fprintln(w, "| quantity | samples | rms | max | at |")
+	fmt.Fprintln(w, "|---|---|---|---|---|")
+	for _, s := range []*stat{&c.altitude, &c.azimuth, &c.sunrise, &c.sunset} {
+		fmt.Fprintf(w, "| %s | %d | %.4f%s | %.4f%s | %s |\n", s.name, s.n, s.rms(), s.unit, s.max, s.unit, s.where)
+	}
+}
+
+// matrix compares positions through the 1st and 15th of each month of year
+// at latitudes from -60 to 60 and longitudes every 45 degrees, and the
+// sunrise and sunset of those dates
+func matrix(c *comparison, year int, step time.Duration, latStep float64) {
+	for lat := -60.0; lat <= 60; lat += latStep {
+		for lon := -180.0; lon < 180; lon += 45 {
+			for m := time.January; m <= time.December; m++ {
+				for _, d := range []int{1, 15} {
+					day := time.Date(year, m, d, 0, 0, 0, 0, time.UTC)
+					for t := day; t.Before(day.AddDate(0, 0, 1)); t = t.Add(step) {
+						el, az := noaaPosition(t, lat, lon)
+						c.position(t, lat, lon, el, az)
+					}
+					if rise, set, ok := noaaEvents(day, lat, lon); ok {
+						// NOAA's times can spill into the next or previous
+						// UTC date, which the package would not report
+						if rise.Day() == d {
+							c.event(&c.sunrise, rise, lat, lon, true)
+						}
+						if set.Day() == d {
+							c.event(&c.sunset, set, lat, lon, false)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// replayFile compares the rows of a recorded CSV file
+func replayFile(c *comparison, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	rows, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("%s is empty", name)
+	}
+	col := map[string]int{}
+	for i, h := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, h := range []string{"time", "latitude", "longitude", "elevation", "azimuth"} {
+		if _, ok := col[h]; !ok {
+			return fmt.Errorf("%s has no %s column", name, h)
+		}
+	}
+	for i, r := range rows[1:] {
+		line := i + 2
+		t, err := time.Parse(time.RFC3339, r[col["time"]])
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+		var v [4]float64
+		for k, h := range []string{"latitude", "longitude", "elevation", "azimuth"} {
+			if v[k], err = strconv.ParseFloat(r[col[h]], 64); err != nil {
+				return fmt.Errorf("%s:%d: %v", name, line, err)
+			}
+		}
+		c.position(t, v[0], v[1], v[2], v[3])
+		for _, e := range []struct {
+			name   string
+			s      *stat
+			rising bool
+		}{{"sunrise", &c.sunrise, true}, {"sunset", &c.sunset, false}} {
+			k, ok := col[e.name]
+			if !ok || r[k] == "" {
+				continue
+			}
+			at, err := time.Parse(time.RFC3339, r[k])
+			if err != nil {
+				return fmt.Errorf("%s:%d: %v", name, line, err)
+			}
+			c.event(e.s, at.UTC(), v[0], v[1], e.rising)
+		}
+	}
+	return nil
+}
+
+// places are the reference locations of record, spread over the latitudes,
+// with NOAA's own Boulder among them
+var places = [][2]float64{
+	{64.1466, -21.9426},  // Reykjavik
+	{51.4779, -0.0015},   // London
+	{40.0150, -105.2705}, // Boulder
+	{35.6762, 139.6503},  // Tokyo
+	{-0.1807, -78.4678},  // Quito
+	{-33.9249, 18.4241},  // Cape Town
+	{-33.8688, 151.2093}, // Sydney
+	{-54.8019, -68.3030}, // Ushuaia
+}
+
+// record writes the transcription's positions every three hours through the
+// solstices and equinoxes of 2024 and 1 January 2000 at places, with the
+// sunrise and sunset of each date on its first row, in the format replayFile
+// reads
+func record(w io.Writer) error {
+	fmt.Fprintln(w, "# Written by noaadiff -record from the equations of the NOAA Solar Calculator")
+	fmt.Fprintln(w, "# spreadsheets, as transcribed in cmd/noaadiff/noaa.go. Elevation is geometric.")
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "latitude", "longitude", "elevation", "azimuth", "sunrise", "sunset"})
+	days := []time.Time{
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 22, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+	}
+	f := func(x float64) string { return strconv.FormatFloat(x, 'f', 6, 64) }
+	for _, p := range places {
+		lat, lon := p[0], p[1]
+		for _, day := range days {
+			var rise, set string
+			if r, s, ok := noaaEvents(day, lat, lon); ok {
+				// as in matrix, times spilling into another UTC date are left
+				// out
+				if r.Day() == day.Day() {
+					rise = r.Round(time.Second).Format(time.RFC3339)
+				}
+				if s.Day() == day.Day() {
+					set = s.Round(time.Second).Format(time.RFC3339)
+				}
+			}
+			for t := day; t.Before(day.AddDate(0, 0, 1)); t = t.Add(3 * time.Hour) {
+				el, az := noaaPosition(t, lat, lon)
+				cw.Write([]string{t.Format(time.RFC3339), f(lat), f(lon), f(el), f(az), rise, set})
+				rise, set = "", ""
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
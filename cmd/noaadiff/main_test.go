@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixture = "testdata/noaa.csv"
+
+func TestReplay(t *testing.T) {
+	c := newComparison()
+	if err := replayFile(c, fixture); err != nil {
+		t.Fatal(err)
+	}
+	// the package and NOAA's equations both follow Meeus, but NOAA's omit
+	// smaller terms and take sunrise and sunset from the Sun at noon, which at
+	// high latitudes is out by a minute or so near the equinoxes
+	for _, tt := range []struct {
+		s        *stat
+		min      int
+		rms, max float64
+	}{
+		{&c.altitude, 320, 0.005, 0.015},
+		{&c.azimuth, 150, 0.01, 0.1},
+		{&c.sunrise, 30, 30, 90},
+		{&c.sunset, 30, 30, 90},
+	} {
+		if tt.s.n < tt.min {
+			t.Errorf("%s: %d samples, want at least %d", tt.s.name, tt.s.n, tt.min)
+		}
+		if r := tt.s.rms(); r > tt.rms {
+			t.Errorf("%s: rms %.4f%s, want at most %v", tt.s.name, r, tt.s.unit, tt.rms)
+		}
+		if math.Abs(tt.s.max) > tt.max {
+			t.Errorf("%s: max %.4f%s at %s, want at most %v", tt.s.name, tt.s.max, tt.s.unit, tt.s.where, tt.max)
+		}
+	}
+}
+
+func TestRecordMatchesFixture(t *testing.T) {
+	want, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := record(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("%s differs from what noaadiff -record writes; regenerate it", fixture)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name, body string
+	}{
+		{"empty", ""},
+		{"missing column", "time,latitude,longitude,elevation\n"},
+		{"bad time", "time,latitude,longitude,elevation,azimuth\nnoon,0,0,0,0\n"},
+		{"bad number", "time,latitude,longitude,elevation,azimuth\n2024-01-01T00:00:00Z,x,0,0,0\n"},
+		{"bad sunrise", "time,latitude,longitude,elevation,azimuth,sunrise\n2024-01-01T00:00:00Z,0,0,0,0,dawn\n"},
+	} {
+		name := filepath.Join(dir, "bad.csv")
+		if err := os.WriteFile(name, []byte(tt.body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := replayFile(newComparison(), name); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+	if err := replayFile(newComparison(), filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("missing file: no error")
+	}
+}
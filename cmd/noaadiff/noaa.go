@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// This file is an independent transcription of the equations of the NOAA
+// Solar Calculator spreadsheets (NOAA_Solar_Calculations_day.xls), which
+// follow Meeus, kept apart from the package so each can check the other.
+
+func rad(d float64) float64 { return d * math.Pi / 180 }
+func deg(r float64) float64 { return r * 180 / math.Pi }
+
+// noaaSun holds the quantities of the spreadsheet that depend on the date and
+// time but not on the place
+type noaaSun struct {
+	declination    float64 // degrees
+	equationOfTime float64 // minutes
+}
+
+// julianDay returns the julian day of t, taken as UT
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/86400e9 + 2440587.5
+}
+
+func noaaAt(t time.Time) noaaSun {
+	jc := (julianDay(t) - 2451545) / 36525
+	l0 := math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360)
+	m := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	e := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+	c := math.Sin(rad(m))*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(rad(2*m))*(0.019993-0.000101*jc) + math.Sin(rad(3*m))*0.000289
+	trueLong := l0 + c
+	appLong := trueLong - 0.00569 - 0.00478*math.Sin(rad(125.04-1934.136*jc))
+	obliq0 := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := obliq0 + 0.00256*math.Cos(rad(125.04-1934.136*jc))
+	dec := deg(math.Asin(math.Sin(rad(obliq)) * math.Sin(rad(appLong))))
+	y := math.Tan(rad(obliq/2)) * math.Tan(rad(obliq/2))
+	eot := 4 * deg(y*math.Sin(2*rad(l0))-2*e*math.Sin(rad(m))+4*e*y*math.Sin(rad(m))*math.Cos(2*rad(l0))-
+		0.5*y*y*math.Sin(4*rad(l0))-1.25*e*e*math.Sin(2*rad(m)))
+	return noaaSun{dec, eot}
+}
+
+// noaaPosition returns the geometric elevation and the azimuth of the Sun at
+// t, in degrees
+func noaaPosition(t time.Time, lat float64, lon float64) (elevation float64, azimuth float64) {
+	s := noaaAt(t)
+	u := t.UTC()
+	minutes := float64(u.Hour()*60+u.Minute()) + (float64(u.Second())+float64(u.Nanosecond())/1e9)/60
+	tst := math.Mod(minutes+s.equationOfTime+4*lon, 1440)
+	if tst < 0 {
+		tst += 1440
+	}
+	ha := tst/4 - 180
+	cosZen := math.Sin(rad(lat))*math.Sin(rad(s.declination)) + math.Cos(rad(lat))*math.Cos(rad(s.declination))*math.Cos(rad(ha))
+	zen := deg(math.Acos(math.Max(-1, math.Min(1, cosZen))))
+	a := deg(math.Acos(math.Max(-1, math.Min(1,
+		(math.Sin(rad(lat))*math.Cos(rad(zen))-math.Sin(rad(s.declination)))/(math.Cos(rad(lat))*math.Sin(rad(zen)))))))
+	if ha > 0 {
+		azimuth = math.Mod(a+180, 360)
+	} else {
+		azimuth = math.Mod(540-a, 360)
+	}
+	return 90 - zen, azimuth
+}
+
+// noaaEvents returns sunrise and sunset on the UTC date of t as the
+// spreadsheet computes them, from the Sun's position at noon UT, and false if
+// the Sun does not rise or set
+func noaaEvents(t time.Time, lat float64, lon float64) (rise time.Time, set time.Time, ok bool) {
+	y, m, d := t.UTC().Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	s := noaaAt(midnight.Add(12 * time.Hour))
+	cosH := math.Cos(rad(90.833))/(math.Cos(rad(lat))*math.Cos(rad(s.declination))) -
+		math.Tan(rad(lat))*math.Tan(rad(s.declination))
+	if cosH < -1 || cosH > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	ha := deg(math.Acos(cosH))
+	noon := 720 - 4*lon - s.equationOfTime
+	minute := func(x float64) time.Time {
+		return midnight.Add(time.Duration(x * float64(time.Minute)))
+	}
+	return minute(noon - 4*ha), minute(noon + 4*ha), true
+}
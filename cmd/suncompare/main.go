@@ -0,0 +1,93 @@
+// Command suncompare reports how far apart the available ephemerides place the
+// Sun for a location and time range, to help choose an accuracy tier and to
+// catch regressions in the models.
+//
+// Usage:
+//
+//	suncompare -lat 51.48 -lon 0 -start 2024-01-01 -end 2024-12-31 -step 1h
+//
+// With -jpl and -jpldata a JPL ASCII ephemeris is included in the comparison,
+// and the other models are compared against it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+func main() {
+	lat := flag.Float64("lat", 0, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", 0, "longitude in decimal degrees, east positive")
+	start := flag.String("start", time.Now().UTC().Format("2006-01-02"), "first date, YYYY-MM-DD (UTC)")
+	end := flag.String("end", "", "last date, YYYY-MM-DD (UTC); defaults to a year after start")
+	step := flag.Duration("step", time.Hour, "interval between samples")
+	header := flag.String("jpl", "", "JPL ASCII ephemeris header file, such as header.440")
+	data := flag.String("jpldata", "", "comma separated JPL ASCII data files, such as ascp01950.440")
+	flag.Parse()
+	log.SetFlags(0)
+
+	from, err := time.Parse("2006-01-02", *start)
+	if err != nil {
+		log.Fatalf("bad -start: %v", err)
+	}
+	to := from.AddDate(1, 0, 0)
+	if *end != "" {
+		if to, err = time.Parse("2006-01-02", *end); err != nil {
+			log.Fatalf("bad -end: %v", err)
+		}
+	}
+
+	names := []string{"SPA", "VSOP87", "LowPrecision", "Meeus"}
+	ephs := []sun.Ephemeris{sun.SPA{}, sun.VSOP87{}, sun.LowPrecision{}, sun.Meeus{}}
+	if *header != "" {
+		j, err := loadJPL(*header, *data)
+		if err != nil {
+			log.Fatal(err)
+		}
+		names = append([]string{"JPL"}, names...)
+		ephs = append([]sun.Ephemeris{j}, ephs...)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "a\tb\tsamples\tmax angle\tat\trms angle\tmax alt\tmax az")
+	for i := range ephs {
+		for k := i + 1; k < len(ephs); k++ {
+			d, err := sun.CompareEphemerides(ephs[i], ephs[k], from, to, *step, *lat, *lon)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%.5f\t%s\t%.5f\t%.5f\t%.5f\n", names[i], names[k], d.Samples,
+				d.MaxAngle, d.MaxAngleTime.Format(time.RFC3339), d.RMSAngle, d.MaxAltitude, d.MaxAzimuth)
+		}
+	}
+	w.Flush()
+}
+
+func loadJPL(header string, data string) (*sun.JPL, error) {
+	h, err := os.Open(header)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+	var files []io.Reader
+	for _, name := range strings.Split(data, ",") {
+		if name == "" {
+			continue
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		files = append(files, f)
+	}
+	return sun.LoadJPL(h, files...)
+}
@@ -0,0 +1,41 @@
+// Command sundemo serves a small web page showing where it is day and night
+// on a world map. Click the map to see the live altitude and azimuth of the
+// Sun there, today's events and the sun-path diagram for the year. The page
+// is embedded in the binary and uses the sunhttp handlers for its data, so it
+// runs the HTTP and rendering code end to end.
+//
+// Usage:
+//
+//	sundemo -addr localhost:8080
+package main
+
+import (
+	"embed"
+	"flag"
+	"io/fs"
+	"log"
+	"net/http"
+
+	"github.com/exploded/sun/sunhttp"
+)
+
+//go:embed static
+var static embed.FS
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	root, err := fs.Sub(static, "static")
+	if err != nil {
+		log.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(root)))
+	mux.Handle("/sun", sunhttp.PositionHandler{})
+	mux.Handle("/sunpath.png", sunhttp.SunPathHandler{})
+	mux.Handle("/terminator.png", sunhttp.TerminatorHandler{})
+
+	log.Printf("serving on http://%s/", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
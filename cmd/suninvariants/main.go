@@ -0,0 +1,40 @@
+// Command suninvariants checks physical invariants of the package's results
+// across random dates and locations, to guard refactors of the solar model
+// such as changes to the obliquity or to how angles are resolved. It prints
+// each violation and exits with status 1 if there are any. The tests of the
+// package check the same invariants on a fixed set of cases; this runs as many
+// as wanted.
+//
+// Usage:
+//
+//	suninvariants -n 10000 -seed 1
+//
+// The same seed always checks the same cases, so a failure can be replayed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/exploded/sun/internal/invariants"
+)
+
+func main() {
+	n := flag.Int("n", 1000, "number of random cases")
+	seed := flag.Int64("seed", 1, "random seed")
+	flag.Parse()
+
+	failures, err := invariants.Run(*n, *seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range failures {
+		fmt.Println(f)
+	}
+	fmt.Printf("%d cases, %d invariants, %d failures\n", *n, len(invariants.All), len(failures))
+	if len(failures) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,215 @@
+// Command suntui shows a live dashboard of the Sun in the terminal for a
+// location: where it is now, how far through the day it has got, today's
+// events and the week ahead.
+//
+// Usage:
+//
+//	suntui -lat 51.48 -lon 0 -tz Europe/London -name Greenwich
+//
+// With -config and -profile the location comes from a named profile in a
+// configuration file read by sun.LoadProfiles instead of the other flags.
+//
+// It redraws every -refresh until interrupted. With -once it draws a single
+// frame without clearing the screen, which suits scripts and pipes.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// ANSI escape sequences
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	bold        = "\x1b[1m"
+	dim         = "\x1b[2m"
+	reset       = "\x1b[0m"
+)
+
+// barWidth is the width of the progress bar in characters
+const barWidth = 40
+
+func main() {
+	lat := flag.Float64("lat", 51.4779, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", -0.0015, "longitude in decimal degrees, east positive")
+	tz := flag.String("tz", "Local", "IANA time zone of the location")
+	name := flag.String("name", "", "name of the location for the title")
+	refresh := flag.Duration("refresh", time.Second, "time between redraws")
+	once := flag.Bool("once", false, "draw one frame and exit")
+	config := flag.String("config", "", "JSON file of location profiles")
+	profile := flag.String("profile", "", "name of the profile in -config to show")
+	flag.Parse()
+	log.SetFlags(0)
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *config != "" {
+		c, err := sun.LoadProfiles(*config)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p, err := c.Profile(*profile)
+		if err != nil {
+			log.Fatalf("%v; profiles are %s", err, strings.Join(c.Names(), ", "))
+		}
+		*lat, *lon, loc, *name = p.Latitude, p.Longitude, p.Location, p.Name
+	}
+	if *name == "" {
+		*name = fmt.Sprintf("%.4f°, %.4f°", *lat, *lon)
+	}
+	d := dashboard{name: *name, lat: *lat, lon: *lon}
+	if *once {
+		os.Stdout.Write(d.render(time.Now().In(loc)))
+		return
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	tick := time.NewTicker(*refresh)
+	defer tick.Stop()
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor)
+	for {
+		// one write per frame keeps the redraw from flickering
+		os.Stdout.Write(append([]byte(clearScreen), d.render(time.Now().In(loc))...))
+		select {
+		case <-tick.C:
+		case <-interrupt:
+			fmt.Println()
+			return
+		}
+	}
+}
+
+// dashboard draws frames for a location. The events of a date are only worked
+// out again when the date changes.
+type dashboard struct {
+	name     string
+	lat, lon float64
+	date     time.Time
+	today    dayEvents
+	week     [7]sun.DayInfo
+}
+
+// dayEvents are the events of a date shown on the dashboard, zero when they
+// do not happen
+type dayEvents struct {
+	sun.DayInfo
+	Dawn, Dusk time.Time // civil twilight
+}
+
+func (d *dashboard) render(now time.Time) []byte {
+	y, m, dd := now.Date()
+	if date := time.Date(y, m, dd, 0, 0, 0, 0, now.Location()); !date.Equal(d.date) {
+		d.date = date
+		d.today = newDayEvents(date, d.lat, d.lon)
+		for i := range d.week {
+			d.week[i] = sun.DayEvents(date.AddDate(0, 0, i), d.lat, d.lon)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s%s%s  %s\n\n", bold, d.name, reset, now.Format("Mon 2 Jan 2006 15:04:05 MST"))
+
+	alt, az := sun.Altitude(now, d.lat, d.lon), sun.Azimuth(now, d.lat, d.lon)
+	phase, golden := sun.Phase(now, d.lat, d.lon)
+	label := sun.PhaseName(phase, "en")
+	if golden {
+		label += ", golden hour"
+	}
+	fmt.Fprintf(&b, "  Altitude  %7.2f°   %s\n", alt, label)
+	fmt.Fprintf(&b, "  Azimuth   %7.2f°   %s\n\n", az, compass(az))
+
+	if p, ok := sun.DayProgress(now, d.lat, d.lon); ok {
+		fill := int(math.Round(clamp(p, 0, 1) * barWidth))
+		fmt.Fprintf(&b, "  Sunrise [%s%s] Sunset  %3.0f%%\n", strings.Repeat("█", fill), strings.Repeat("░", barWidth-fill), 100*clamp(p, 0, 1))
+		if set := d.today.Sunset; !set.IsZero() && now.Before(set) {
+			fmt.Fprintf(&b, "  %s until sunset\n", sun.FormatDuration(set.Sub(now), "en"))
+		}
+	} else {
+		fmt.Fprintf(&b, "  The Sun does not rise today\n")
+	}
+
+	b.WriteString("\n" + bold + "  Today" + reset + "\n")
+	for _, e := range []struct {
+		name string
+		at   time.Time
+	}{
+		{"Dawn", d.today.Dawn},
+		{"Sunrise", d.today.Sunrise},
+		{"Noon", d.today.Noon.Time},
+		{"Sunset", d.today.Sunset},
+		{"Dusk", d.today.Dusk},
+	} {
+		style := ""
+		if !e.at.IsZero() && e.at.Before(now) {
+			style = dim
+		}
+		fmt.Fprintf(&b, "  %s%-8s %s%s\n", style, e.name, clock(e.at), reset)
+	}
+	fmt.Fprintf(&b, "  %-8s %s, noon altitude %.1f°\n", "Length", sun.FormatDuration(d.today.Length, "en"), d.today.Noon.Altitude)
+
+	b.WriteString("\n" + bold + "  Week ahead" + reset + "\n")
+	fmt.Fprintf(&b, "  %-10s %-8s %-8s %-10s %s\n", "", "Sunrise", "Sunset", "Length", "Change")
+	for i, day := range d.week {
+		change := ""
+		if i > 0 {
+			change = signedMinutes(day.Length - d.week[i-1].Length)
+		}
+		fmt.Fprintf(&b, "  %-10s %-8s %-8s %-10s %s\n", day.Noon.Time.Format("Mon 2 Jan"), clock(day.Sunrise), clock(day.Sunset), sun.FormatDuration(day.Length, "en"), change)
+	}
+	return b.Bytes()
+}
+
+// newDayEvents returns the events on the date of t
+func newDayEvents(t time.Time, lat float64, lon float64) dayEvents {
+	e := dayEvents{DayInfo: sun.DayEvents(t, lat, lon)}
+	for _, c := range sun.AltitudeCrossings(t, t.AddDate(0, 0, 1), lat, lon, sun.CivilTwilightAltitude) {
+		if c.Increasing && e.Dawn.IsZero() {
+			e.Dawn = c.Time
+		} else if !c.Increasing {
+			e.Dusk = c.Time
+		}
+	}
+	return e
+}
+
+func clock(t time.Time) string {
+	if t.IsZero() {
+		return "--:--"
+	}
+	return t.Format("15:04")
+}
+
+// signedMinutes formats a change in day length as minutes and seconds
+func signedMinutes(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%s%dm %02ds", sign, d/time.Minute, d%time.Minute/time.Second)
+}
+
+// compass returns the sixteen point compass direction of an azimuth
+func compass(az float64) string {
+	points := [...]string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	return points[int(math.Round(az/22.5))%16]
+}
+
+func clamp(x float64, lo float64, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// At Greenwich on 21 June 2024 noon is at 12:01.7 UT and the Sun's hour angle
+// is 124.8 degrees at sunrise and sunset and 136.7 at civil dawn and dusk,
+// putting them at 04:42, 21:20, 03:55 and 22:08 BST. At 10:00 BST the day is
+// 317.5 minutes into 998.4, 32 per cent, with 11h21m of it left. At Tromsø on
+// 21 December the Sun stays 90 - 69.65 - 23.44 = 3.1 degrees down.
+func TestRender(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, tt := range []struct {
+		d    dashboard
+		now  time.Time
+		want []string
+	}{
+		{
+			dashboard{name: "Greenwich", lat: 51.4779, lon: -0.0015},
+			time.Date(2024, time.June, 21, 10, 0, 0, 0, london),
+			[]string{
+				bold + "Greenwich" + reset + "  Fri 21 Jun 2024 10:00:00 BST\n",
+				"  Azimuth    111.34°   ESE\n",
+				"] Sunset   32%\n",
+				"  11h21m until sunset\n",
+				dim + "Dawn     03:55" + reset,
+				dim + "Sunrise  04:42" + reset,
+				"  Noon     13:01" + reset,
+				"  Sunset   21:20" + reset,
+				"  Dusk     22:08" + reset,
+				"  Length   16h38m, noon altitude 62.0°\n",
+				"  Sat 22 Jun 04:43    21:21    16h38m     -0m 07s\n",
+			},
+		},
+		{
+			dashboard{name: "Tromsø", lat: 69.6492, lon: 18.9553},
+			time.Date(2024, time.December, 21, 12, 0, 0, 0, oslo),
+			[]string{
+				"   Civil twilight, golden hour\n",
+				"  The Sun does not rise today\n",
+				"  Sunrise  --:--",
+				"  Length   0h00m, noon altitude -3.1°\n",
+			},
+		},
+	} {
+		got := string(tt.d.render(tt.now))
+		for _, w := range tt.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%s frame is missing %q:\n%s", tt.d.name, w, got)
+			}
+		}
+		// a second frame the same day reuses the events
+		if again := string(tt.d.render(tt.now.Add(time.Second))); !strings.Contains(again, tt.want[len(tt.want)-1]) {
+			t.Errorf("%s second frame:\n%s", tt.d.name, again)
+		}
+	}
+}
+
+func TestCompass(t *testing.T) {
+	for _, tt := range []struct {
+		az   float64
+		want string
+	}{
+		{0, "N"}, {11.2, "N"}, {11.3, "NNE"}, {90, "E"}, {111.34, "ESE"},
+		{180, "S"}, {247.5, "WSW"}, {348.7, "NNW"}, {348.8, "N"}, {359.9, "N"},
+	} {
+		if got := compass(tt.az); got != tt.want {
+			t.Errorf("compass(%v) = %s, want %s", tt.az, got, tt.want)
+		}
+	}
+}
+
+func TestSignedMinutes(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "+0m 00s"},
+		{-7 * time.Second, "-0m 07s"},
+		{2*time.Minute + 31400*time.Millisecond, "+2m 31s"},
+		{-(4*time.Minute + 5*time.Second), "-4m 05s"},
+	} {
+		if got := signedMinutes(tt.d); got != tt.want {
+			t.Errorf("signedMinutes(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
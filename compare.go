@@ -0,0 +1,58 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Difference summarises how far apart two ephemerides place the Sun over a
+// series of times, as seen from one location. Angles are in degrees.
+type Difference struct {
+	Samples      int       // times compared
+	Skipped      int       // times one of the ephemerides did not cover
+	MaxAngle     float64   // largest angle between the two directions
+	MaxAngleTime time.Time // when MaxAngle occurred
+	RMSAngle     float64   // root mean square of the angle between directions
+	MaxAltitude  float64   // largest absolute difference in altitude
+	MaxAzimuth   float64   // largest absolute difference in azimuth
+}
+
+// CompareEphemerides compares the positions of the Sun from ephemerides a and b
+// at the given location from start up to and including end, every step.
+func CompareEphemerides(a Ephemeris, b Ephemeris, start time.Time, end time.Time, step time.Duration, latitude float64, longitude float64) (Difference, error) {
+	var d Difference
+	if step <= 0 {
+		return d, errNonPositiveStep
+	}
+	sum := 0.0
+	for t := start; !t.After(end); t = t.Add(step) {
+		altA, azA := AltitudeWith(a, t, latitude, longitude), AzimuthWith(a, t, latitude, longitude)
+		altB, azB := AltitudeWith(b, t, latitude, longitude), AzimuthWith(b, t, latitude, longitude)
+		if math.IsNaN(altA) || math.IsNaN(altB) {
+			d.Skipped++
+			continue
+		}
+		d.Samples++
+		angle := angularDistance(altA, azA, altB, azB)
+		sum += angle * angle
+		if angle > d.MaxAngle {
+			d.MaxAngle, d.MaxAngleTime = angle, t
+		}
+		d.MaxAltitude = math.Max(d.MaxAltitude, math.Abs(altA-altB))
+		d.MaxAzimuth = math.Max(d.MaxAzimuth, math.Abs(between(-180, 180, azA-azB)))
+	}
+	if d.Samples > 0 {
+		d.RMSAngle = math.Sqrt(sum / float64(d.Samples))
+	}
+	return d, nil
+}
+
+// angularDistance returns the angle in degrees between two directions given as
+// latitude-like and longitude-like angles, such as altitude and azimuth. It
+// uses the haversine form, which stays accurate for tiny angles.
+func angularDistance(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	s1 := angleSin((lat2 - lat1) / 2)
+	s2 := angleSin((lon2 - lon1) / 2)
+	h := s1*s1 + angleCos(lat1)*angleCos(lat2)*s2*s2
+	return 2 * angleAsin(math.Sqrt(math.Min(1, h)))
+}
@@ -0,0 +1,83 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCompareEphemerides(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	d, err := CompareEphemerides(LowPrecision{}, SPA{}, start, end, 7*time.Hour, 51.48, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int(end.Sub(start)/(7*time.Hour)) + 1; d.Samples != want || d.Skipped != 0 {
+		t.Errorf("%d samples and %d skipped, want %d and 0", d.Samples, d.Skipped, want)
+	}
+	// LowPrecision is documented as good to about 0.01 degree
+	if d.MaxAngle > 0.01 || d.RMSAngle > d.MaxAngle || d.RMSAngle < 0.001 {
+		t.Errorf("max angle %v, rms %v, want an rms of a few thousandths under a max of 0.01", d.MaxAngle, d.RMSAngle)
+	}
+	if d.MaxAltitude > d.MaxAngle+1e-12 {
+		t.Errorf("max altitude difference %v exceeds the max angle %v", d.MaxAltitude, d.MaxAngle)
+	}
+	if d.MaxAngleTime.Before(start) || d.MaxAngleTime.After(end) {
+		t.Errorf("max angle at %v, outside the span", d.MaxAngleTime)
+	}
+
+	same, err := CompareEphemerides(Meeus{}, Meeus{}, start, start.AddDate(0, 0, 2), time.Hour, 0, 0)
+	if err != nil || same.Samples != 49 || same.MaxAngle != 0 || same.RMSAngle != 0 {
+		t.Errorf("an ephemeris against itself = %+v, %v, want 49 identical samples", same, err)
+	}
+}
+
+func TestCompareEphemeridesSkipped(t *testing.T) {
+	j := loadJPLFixture(t)
+	// the fixture covers 26 December 2023 to 28 February 2024
+	start := time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)
+	d, err := CompareEphemerides(j, VSOP87{}, start, start.AddDate(0, 0, 4), 6*time.Hour, 51.48, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Samples+d.Skipped != 17 || d.Samples < 8 || d.Skipped < 8 {
+		t.Errorf("%d samples and %d skipped, want about half of 17 each", d.Samples, d.Skipped)
+	}
+	if d.MaxAngle > 1.0/3600 {
+		t.Errorf("JPL fixture against VSOP87: max angle %v, want under an arcsecond", d.MaxAngle)
+	}
+}
+
+func TestCompareEphemeridesStep(t *testing.T) {
+	now := time.Now()
+	for _, step := range []time.Duration{0, -time.Hour} {
+		if _, err := CompareEphemerides(LowPrecision{}, Meeus{}, now, now.Add(time.Hour), step, 0, 0); err != errNonPositiveStep {
+			t.Errorf("step %v: error %v, want %v", step, err, errNonPositiveStep)
+		}
+	}
+}
+
+func TestAngularDistance(t *testing.T) {
+	for _, tt := range []struct {
+		lat1, lon1, lat2, lon2, want float64
+	}{
+		{0, 0, 0, 90, 90},
+		{0, 0, 1, 0, 1},
+		{0, 350, 0, 10, 20},
+		{89, 0, 89, 180, 2},
+		{90, 0, -90, 0, 180},
+		{45, 30, 45, 30, 0},
+		{10, 20, 10 + 1e-9, 20, 1e-9},
+	} {
+		// the haversine keeps tiny angles to a few parts in a million where
+		// the law of cosines would lose them entirely
+		tol := 1e-12
+		if tt.want < 1e-6 {
+			tol = 1e-14
+		}
+		if got := angularDistance(tt.lat1, tt.lon1, tt.lat2, tt.lon2); math.Abs(got-tt.want) > tol {
+			t.Errorf("angularDistance(%v, %v, %v, %v) = %v, want %v", tt.lat1, tt.lon1, tt.lat2, tt.lon2, got, tt.want)
+		}
+	}
+}
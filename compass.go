@@ -0,0 +1,22 @@
+package sun
+
+import "time"
+
+// SunCompassHeading returns the true heading, in degrees east of north, of a
+// vessel or vehicle from which the Sun is seen at time t on the given relative
+// bearing, measured clockwise from dead ahead. The position need only be
+// roughly known: near noon at low latitudes, where the azimuth of the Sun
+// swings fastest, an error of a degree in longitude moves the result by a few
+// degrees, and elsewhere by much less. The reading means little with the Sun
+// close to the zenith.
+func SunCompassHeading(t time.Time, latitude float64, longitude float64, relativeBearing float64) float64 {
+	return between(0, 360, Azimuth(t, latitude, longitude)-relativeBearing)
+}
+
+// SunRelativeBearing is the inverse of SunCompassHeading: it returns the
+// bearing of the Sun from dead ahead expected at time t when steering the
+// given true heading. As for FlightSample.RelativeBearing it runs from -180 up
+// to but not including 180, positive to starboard.
+func SunRelativeBearing(t time.Time, latitude float64, longitude float64, heading float64) float64 {
+	return between(-180, 180, Azimuth(t, latitude, longitude)-heading)
+}
@@ -0,0 +1,50 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At noon the Sun is due south from London and due north from Sydney. At
+// 10:00 UT on the solstice it is at azimuth 128.45 from London, as worked out
+// in the sunhttp tests from the hour angle.
+func TestSunCompassHeading(t *testing.T) {
+	londonNoon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278).Time
+	sydneyNoon := Culminate(date(time.UTC, 2024, time.December, 21), -33.8688, 151.2093).Time
+	morning := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		t        time.Time
+		lat, lon float64
+		rb, want float64
+		tol      float64
+	}{
+		{londonNoon, 51.5074, -0.1278, 0, 180, 0.01},
+		{londonNoon, 51.5074, -0.1278, 90, 90, 0.01},
+		{londonNoon, 51.5074, -0.1278, 270, 270, 0.01},
+		{londonNoon, 51.5074, -0.1278, -90, 270, 0.01},
+		{sydneyNoon, -33.8688, 151.2093, 0, 0, 0.01},
+		{sydneyNoon, -33.8688, 151.2093, 10, 350, 0.01},
+		{morning, 51.5074, -0.1278, 0, 128.45, 0.1},
+		{morning, 51.5074, -0.1278, 128.45, 0, 0.1},
+	} {
+		got := SunCompassHeading(tt.t, tt.lat, tt.lon, tt.rb)
+		if d := math.Abs(between(-180, 180, got-tt.want)); d > tt.tol || got < 0 || got >= 360 {
+			t.Errorf("SunCompassHeading(%v, %v, %v, %v) = %.3f, want %v", tt.t.Format("Jan 2 15:04"), tt.lat, tt.lon, tt.rb, got, tt.want)
+		}
+		if rb := SunRelativeBearing(tt.t, tt.lat, tt.lon, got); math.Abs(between(-180, 180, rb-tt.rb)) > 1e-9 || rb < -180 || rb >= 180 {
+			t.Errorf("SunRelativeBearing for heading %.3f = %v, want %v", got, rb, tt.rb)
+		}
+	}
+}
+
+// With the Sun 61.9 degrees up at a London noon in June, its azimuth turns
+// at 15 cos δ / cos h = 29.2 degrees an hour, so a degree's error in longitude,
+// four minutes, turns the heading by 1.95 degrees.
+func TestSunCompassHeadingSensitivity(t *testing.T) {
+	noon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278).Time
+	d := SunCompassHeading(noon, 51.5074, 0.8722, 0) - SunCompassHeading(noon, 51.5074, -0.1278, 0)
+	if math.Abs(d-1.95) > 0.05 {
+		t.Errorf("heading moves %.3f degrees for a degree of longitude, want 1.95", d)
+	}
+}
@@ -0,0 +1,116 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// YallopCategory is the visibility class of a young crescent Moon in Yallop's
+// q-test.
+type YallopCategory int
+
+const (
+	EasilyVisible         YallopCategory = iota // A: easily visible to the naked eye
+	VisiblePerfect                              // B: visible in perfect conditions
+	MayNeedOpticalAid                           // C: may need optical aid to find
+	NeedsOpticalAid                             // D: visible only with optical aid
+	NotVisibleToTelescope                       // E: not visible with a telescope
+	BelowDanjonLimit                            // F: not visible, below the Danjon limit
+)
+
+var yallopNames = [...]string{
+	"A: easily visible",
+	"B: visible under perfect conditions",
+	"C: may need optical aid to find the crescent",
+	"D: will need optical aid to find the crescent",
+	"E: not visible with a telescope",
+	"F: not visible, below the Danjon limit",
+}
+
+func (c YallopCategory) String() string {
+	if c < 0 || int(c) >= len(yallopNames) {
+		return "unknown"
+	}
+	return yallopNames[c]
+}
+
+// CrescentSighting is the geometry of the young Moon at the best time to look
+// for it on one evening, and the verdict of Yallop's q-test.
+type CrescentSighting struct {
+	Sunset   time.Time
+	Moonset  time.Time
+	BestTime time.Time // sunset plus four ninths of the lag to moonset
+	ARCV     float64   // altitude of the Moon above the Sun, airless and geocentric, degrees
+	ARCL     float64   // elongation of the Moon from the Sun, degrees
+	DAZ      float64   // azimuth of the Sun less that of the Moon, degrees
+	Width    float64   // topocentric width of the crescent, arcminutes
+	Q        float64
+	Category YallopCategory
+}
+
+// CrescentVisibility applies Yallop's q-test (NAO Technical Note 69, 1997) to
+// the crescent Moon on the evening of the date of t at the given location,
+// for the first sighting that starts a month of a lunar calendar. ok is false
+// if the Sun does not set or the Moon sets before it, when there is nothing to
+// see. Times are in the time zone of t.
+func CrescentVisibility(t time.Time, latitude float64, longitude float64) (s CrescentSighting, ok bool) {
+	s.Sunset, ok = Sunset(t, latitude, longitude)
+	if !ok {
+		return s, false
+	}
+	ok = false
+	end := s.Sunset.Add(12 * time.Hour)
+	f := func(x float64) float64 {
+		return MoonAltitude(s.Sunset.Add(secondsToDuration(x)), latitude, longitude) - MoonriseAltitude
+	}
+	if f(0) <= 0 {
+		return s, false
+	}
+	for _, c := range crossings(s.Sunset, end, f, math.Inf(1), defaultTolerance) {
+		if !c.Increasing {
+			s.Moonset, ok = c.Time, true
+			break
+		}
+	}
+	if !ok {
+		return s, false
+	}
+	s.BestTime = s.Sunset.Add(s.Moonset.Sub(s.Sunset) * 4 / 9)
+
+	jd := timeToJD(s.BestTime)
+	sun := LowPrecision{}.Sun(s.BestTime)
+	moon := MoonPosition(s.BestTime)
+	sunHA := getHourAngle(jd, longitude, sun.RightAscension)
+	moonHA := getHourAngle(jd, longitude, moon.RightAscension)
+	alt := func(dec float64, ha float64) float64 {
+		return angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	}
+	s.ARCV = alt(moon.Declination, moonHA) - alt(sun.Declination, sunHA)
+	s.DAZ = between(-180, 180, getAzimuth(latitude, sun.Declination, sunHA)-getAzimuth(latitude, moon.Declination, moonHA))
+	s.ARCL = AngularSeparation(sun, moon)
+
+	// semi-diameter in arcminutes, enlarged for the observer being nearer the
+	// Moon than the centre of the Earth
+	parallax := angleAsin(earthEquatorialKm / (moon.Distance * auKm))
+	sd := 0.27245 * parallax * 60
+	sd *= 1 + angleSin(MoonAltitude(s.BestTime, latitude, longitude))*angleSin(parallax)
+	s.Width = sd * (1 - angleCos(s.ARCL))
+	w := s.Width
+	s.Q = (s.ARCV - (11.8371 - 6.3226*w + 0.7319*w*w - 0.1018*w*w*w)) / 10
+
+	switch {
+	case s.Q > 0.216:
+		s.Category = EasilyVisible
+	case s.Q > -0.014:
+		s.Category = VisiblePerfect
+	case s.Q > -0.160:
+		s.Category = MayNeedOpticalAid
+	case s.Q > -0.232:
+		s.Category = NeedsOpticalAid
+	case s.Q > -0.293:
+		s.Category = NotVisibleToTelescope
+	default:
+		s.Category = BelowDanjonLimit
+	}
+	return s, true
+}
@@ -0,0 +1,78 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ramadan 2024 began in Saudi Arabia on 11 March: after the new moon of 09:00
+// UT on the 10th the Moon set at Mecca 13 minutes after the Sun that evening,
+// 4.3 degrees from it and far below the Danjon limit, and was easily seen the
+// next. Eid al-Fitr followed the new moon of 18:21 UT on 8 April, after sunset
+// at Mecca, so the Moon set first; the next evening it was 12.7 degrees out,
+// easily visible.
+func TestCrescentVisibility(t *testing.T) {
+	for _, tt := range []struct {
+		d        time.Time
+		ok       bool
+		lag      time.Duration
+		arcl     float64
+		category YallopCategory
+	}{
+		{date(time.UTC, 2024, time.March, 10), true, 13 * time.Minute, 4.3, BelowDanjonLimit},
+		{date(time.UTC, 2024, time.March, 11), true, 76 * time.Minute, 18.4, EasilyVisible},
+		{date(time.UTC, 2024, time.April, 8), false, 0, 0, 0},
+		{date(time.UTC, 2024, time.April, 9), true, 52 * time.Minute, 12.7, EasilyVisible},
+	} {
+		s, ok := CrescentVisibility(tt.d, 21.4225, 39.8262)
+		if ok != tt.ok {
+			t.Errorf("%v: ok = %v, want %v", tt.d.Format("Jan 2"), ok, tt.ok)
+			continue
+		}
+		// sunset at Mecca is at about 18:30 local time, 15:30 UT
+		if !within(s.Sunset, clock(tt.d, 15, 33), 6*time.Minute) {
+			t.Errorf("%v: sunset at %v", tt.d.Format("Jan 2"), s.Sunset)
+		}
+		if !ok {
+			continue
+		}
+		if lag := s.Moonset.Sub(s.Sunset); (lag-tt.lag).Abs() > 2*time.Minute || math.Abs(s.ARCL-tt.arcl) > 0.1 || s.Category != tt.category {
+			t.Errorf("%v: lag %v, ARCL %.2f, %v, want %v, %v, %v", tt.d.Format("Jan 2"), lag, s.ARCL, s.Category, tt.lag, tt.arcl, tt.category)
+		}
+		if best := s.Sunset.Add(s.Moonset.Sub(s.Sunset) * 4 / 9); !s.BestTime.Equal(best) {
+			t.Errorf("%v: best time %v, want %v", tt.d.Format("Jan 2"), s.BestTime, best)
+		}
+		// Yallop's q from the arc of vision and the width
+		w := s.Width
+		if q := (s.ARCV - (11.8371 - 6.3226*w + 0.7319*w*w - 0.1018*w*w*w)) / 10; math.Abs(q-s.Q) > 1e-9 {
+			t.Errorf("%v: q = %v, want %v", tt.d.Format("Jan 2"), s.Q, q)
+		}
+		// the width is the semi-diameter, some 16 arcminutes, times 1 - cos ARCL
+		if sd := s.Width / (1 - angleCos(s.ARCL)); sd < 14.5 || sd > 17 {
+			t.Errorf("%v: semi-diameter %.2f arcminutes", tt.d.Format("Jan 2"), sd)
+		}
+	}
+
+	// no sunset in the Arctic summer
+	if _, ok := CrescentVisibility(date(time.UTC, 2024, time.June, 21), 78.22, 15.65); ok {
+		t.Error("CrescentVisibility in the midnight sun is ok")
+	}
+}
+
+func TestYallopCategory(t *testing.T) {
+	for _, tt := range []struct {
+		c    YallopCategory
+		want string
+	}{
+		{EasilyVisible, "A: easily visible"},
+		{NeedsOpticalAid, "D: will need optical aid to find the crescent"},
+		{BelowDanjonLimit, "F: not visible, below the Danjon limit"},
+		{YallopCategory(6), "unknown"},
+		{YallopCategory(-1), "unknown"},
+	} {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.c), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package sun
+
+import "time"
+
+// Culmination is the moment on a given day when the Sun is highest in the sky.
+type Culmination struct {
+	Time     time.Time
+	Altitude float64 // degrees
+}
+
+// Culminate returns the upper culmination of the Sun on the date of t, as seen
+// from the given location.
+//
+// The date is taken from t in its own time zone, and the returned time is in
+// the same time zone. The culmination is searched for within an hour of local
+// mean noon, which always contains it as the equation of time never exceeds
+// about 17 minutes.
+func Culminate(t time.Time, latitude float64, longitude float64) Culmination {
+	return culminate(t, latitude, longitude, defaultTolerance)
+}
+
+// Culminate returns the upper culmination as the package function Culminate
+// does, to the tolerance of o.
+func (o EventOptions) Culminate(t time.Time, latitude float64, longitude float64) Culmination {
+	return culminate(t, latitude, longitude, o.tolerance())
+}
+
+// culminate finds the culmination for Culminate to within tol seconds
+func culminate(t time.Time, latitude float64, longitude float64, tol float64) Culmination {
+	noon := meanNoon(t, longitude)
+	alt := func(s float64) float64 {
+		return Altitude(noon.Add(secondsToDuration(s)), latitude, longitude)
+	}
+	s := goldenMax(alt, -3600, 3600, tol)
+	at := noon.Add(secondsToDuration(s))
+	return Culmination{Time: at.In(t.Location()), Altitude: Altitude(at, latitude, longitude)}
+}
+
+// CulminationExtremes scans every date from start to end inclusive and returns
+// the culminations with the highest and the lowest noon altitude.
+//
+// This is handy for solar panel design, where the winter noon altitude sets
+// the row spacing and the summer one the overhang. If end is before start both
+// results are zero.
+func CulminationExtremes(start time.Time, end time.Time, latitude float64, longitude float64) (highest Culmination, lowest Culmination) {
+	first := true
+	for d := start; !afterDate(d, end); d = d.AddDate(0, 0, 1) {
+		c := Culminate(d, latitude, longitude)
+		if first || c.Altitude > highest.Altitude {
+			highest = c
+		}
+		if first || c.Altitude < lowest.Altitude {
+			lowest = c
+		}
+		first = false
+	}
+	return highest, lowest
+}
+
+// meanNoon returns local mean noon, in UTC, for the date of t at longitude.
+// It is the mean noon nearest to clock noon on that date, which matters in
+// zones such as Tonga or Kiritimati whose clocks run a day ahead of the UTC
+// date at their longitude.
+func meanNoon(t time.Time, longitude float64) time.Time {
+	y, m, d := t.Date()
+	local := time.Date(y, m, d, 12, 0, 0, 0, t.Location())
+	noon := time.Date(y, m, d, 12, 0, 0, 0, time.UTC).Add(secondsToDuration(-longitude * 240))
+	for _, days := range []int{-1, 1} {
+		if n := noon.AddDate(0, 0, days); n.Sub(local).Abs() < noon.Sub(local).Abs() {
+			noon = n
+		}
+	}
+	return noon
+}
+
+// afterDate reports whether the calendar date of a is later than that of b
+func afterDate(a time.Time, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	if ay != by {
+		return ay > by
+	}
+	if am != bm {
+		return am > bm
+	}
+	return ad > bd
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,104 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// obliquity is the obliquity of the ecliptic in 2024, in degrees
+const obliquity = 23.44
+
+// Solar noon to the minute from the timeanddate.com almanac, which follows
+// NOAA. Culminate finds the highest altitude, which comes up to half a minute
+// from the meridian transit when the declination is changing fastest, so times
+// are allowed a minute. Near the solstices the noon altitude is 90 - |latitude
+// ∓ obliquity|, about 23.44 degrees; the November and February cases, at the
+// extremes of the equation of time, check only the time. Tonga and Kiritimati
+// keep clocks a day ahead of the UTC date at their longitude, so their noon
+// falls late on the previous UTC date.
+var culminationCases = []struct {
+	zone     string
+	lat, lon float64
+	y        int
+	m        time.Month
+	d        int
+	hour     int
+	min      int
+	altitude float64
+}{
+	{"Europe/London", 51.5074, -0.1278, 2024, time.June, 21, 13, 2, 90 - 51.5074 + obliquity},
+	{"Europe/London", 51.5074, -0.1278, 2024, time.December, 21, 11, 58, 90 - 51.5074 - obliquity},
+	{"America/New_York", 40.7128, -74.0060, 2024, time.June, 20, 12, 58, 90 - 40.7128 + obliquity},
+	{"Australia/Sydney", -33.8688, 151.2093, 2024, time.December, 21, 12, 53, 90 - 33.8688 + obliquity},
+	{"UTC", 51.4779, -0.0015, 2024, time.November, 3, 11, 43, math.NaN()},
+	{"UTC", 51.4779, -0.0015, 2024, time.February, 11, 12, 14, math.NaN()},
+	{"Pacific/Tongatapu", -21.1394, -175.2018, 2024, time.March, 1, 12, 53, math.NaN()},
+	{"Pacific/Kiritimati", 1.8721, -157.4278, 2024, time.June, 21, 12, 31, 90 - obliquity + 1.8721},
+}
+
+func TestCulminate(t *testing.T) {
+	for _, tt := range culminationCases {
+		loc := location(t, tt.zone)
+		d := date(loc, tt.y, tt.m, tt.d)
+		c := Culminate(d, tt.lat, tt.lon)
+		if want := clock(d, tt.hour, tt.min); !within(c.Time, want, time.Minute) {
+			t.Errorf("Culminate(%s, %v, %v).Time = %v, want %v", d.Format("2006-01-02 MST"), tt.lat, tt.lon, c.Time, want)
+		}
+		if c.Time.Location() != loc {
+			t.Errorf("Culminate(%s) is in %v, want %v", d.Format("2006-01-02"), c.Time.Location(), loc)
+		}
+		if !math.IsNaN(tt.altitude) && math.Abs(c.Altitude-tt.altitude) > 0.02 {
+			t.Errorf("Culminate(%s, %v, %v).Altitude = %.4f, want %.4f", d.Format("2006-01-02"), tt.lat, tt.lon, c.Altitude, tt.altitude)
+		}
+		if a := Altitude(c.Time, tt.lat, tt.lon); a != c.Altitude {
+			t.Errorf("Altitude at the culmination = %v, want %v", a, c.Altitude)
+		}
+	}
+}
+
+func TestCulminateIsHighest(t *testing.T) {
+	for _, tt := range culminationCases {
+		d := date(time.UTC, tt.y, tt.m, tt.d)
+		c := Culminate(d, tt.lat, tt.lon)
+		for _, off := range []time.Duration{-time.Minute, time.Minute, -time.Hour, time.Hour} {
+			if a := Altitude(c.Time.Add(off), tt.lat, tt.lon); a > c.Altitude {
+				t.Errorf("altitude %v from the culmination on %s is %v, above %v", off, d.Format("2006-01-02"), a, c.Altitude)
+			}
+		}
+	}
+}
+
+func TestCulminationExtremes(t *testing.T) {
+	start, end := date(time.UTC, 2024, time.January, 1), date(time.UTC, 2024, time.December, 31)
+	highest, lowest := CulminationExtremes(start, end, 51.4779, -0.0015)
+	// the solstices fell at 20:51 UT on 20 June and 09:21 UT on 21 December
+	for _, tt := range []struct {
+		name     string
+		c        Culmination
+		m        time.Month
+		days     []int
+		altitude float64
+	}{
+		{"highest", highest, time.June, []int{20, 21}, 90 - 51.4779 + obliquity},
+		{"lowest", lowest, time.December, []int{21}, 90 - 51.4779 - obliquity},
+	} {
+		found := false
+		for _, d := range tt.days {
+			if tt.c.Time.Month() == tt.m && tt.c.Time.Day() == d {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s culmination on %s, want %v %v", tt.name, tt.c.Time.Format("2006-01-02"), tt.m, tt.days)
+		}
+		if math.Abs(tt.c.Altitude-tt.altitude) > 0.02 {
+			t.Errorf("%s culmination altitude = %.4f, want %.4f", tt.name, tt.c.Altitude, tt.altitude)
+		}
+	}
+
+	highest, lowest = CulminationExtremes(end, start, 51.4779, -0.0015)
+	if highest != (Culmination{}) || lowest != (Culmination{}) {
+		t.Errorf("CulminationExtremes with end before start = %v, %v, want zero", highest, lowest)
+	}
+}
@@ -0,0 +1,184 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// DEM is a digital elevation model: a grid of ground heights on square cells.
+// Row 0 is the northern edge and columns run east, as in a north-up raster.
+// The area should be small enough, a few tens of kilometres across, for the
+// Sun to be taken as in the same direction over all of it and the curvature
+// of the Earth to be ignored.
+type DEM struct {
+	Rows, Cols int
+	CellSize   float64   // metres
+	Elevation  []float64 // metres, in row order
+	// Origin is the latitude and longitude of the centre of the cell at row
+	// 0 and column 0. It places the grid, and gives the position of the Sun
+	// for the whole of it.
+	Origin Point
+}
+
+// NewDEM returns a flat DEM of rows by cols cells of the given size in
+// metres, ready for its Elevation to be filled in. A rows, cols or cellSize
+// that is not positive is an error.
+func NewDEM(rows int, cols int, cellSize float64, origin Point) (*DEM, error) {
+	if rows <= 0 || cols <= 0 || cellSize <= 0 {
+		return nil, errNonPositiveSize
+	}
+	return &DEM{rows, cols, cellSize, make([]float64, rows*cols), origin}, nil
+}
+
+// At returns the elevation of the cell at row r and column c.
+func (d *DEM) At(r int, c int) float64 {
+	return d.Elevation[r*d.Cols+c]
+}
+
+// sample returns the elevation at a fractional row and column by bilinear
+// interpolation, and false if the point is off the grid
+func (d *DEM) sample(r float64, c float64) (float64, bool) {
+	if r < 0 || c < 0 || r > float64(d.Rows-1) || c > float64(d.Cols-1) {
+		return 0, false
+	}
+	r0, c0 := int(r), int(c)
+	r1, c1 := clamp(r0+1, 0, d.Rows-1), clamp(c0+1, 0, d.Cols-1)
+	fr, fc := r-float64(r0), c-float64(c0)
+	top := d.At(r0, c0)*(1-fc) + d.At(r0, c1)*fc
+	bottom := d.At(r1, c0)*(1-fc) + d.At(r1, c1)*fc
+	return top*(1-fr) + bottom*fr, true
+}
+
+// maxElevation returns the height of the highest cell
+func (d *DEM) maxElevation() float64 {
+	m := math.Inf(-1)
+	for _, z := range d.Elevation {
+		m = math.Max(m, z)
+	}
+	return m
+}
+
+// Cell returns the row and column of the cell containing p, and false if p is
+// off the grid.
+func (d *DEM) Cell(p Point) (r int, c int, ok bool) {
+	metres := toRadians(earthRadius)
+	north := (p.Latitude - d.Origin.Latitude) * metres
+	east := between(-180, 180, p.Longitude-d.Origin.Longitude) * metres * angleCos(d.Origin.Latitude)
+	r = int(math.Floor(-north/d.CellSize + 0.5))
+	c = int(math.Floor(east/d.CellSize + 0.5))
+	if r < 0 || c < 0 || r >= d.Rows || c >= d.Cols {
+		return 0, 0, false
+	}
+	return r, c, true
+}
+
+// horizonAngle returns the elevation angle in degrees of the skyline seen from
+// height z0 over the cell at row r and column c looking towards azimuth az,
+// tracing the terrain one cell at a time to the edge of the grid. It gives up
+// early once the sight line at the larger of floor and the skyline so far
+// clears the highest ground top, so the result is only exact when it exceeds
+// floor. It is -90 if the grid edge is reached at once.
+func (d *DEM) horizonAngle(r int, c int, z0 float64, az float64, floor float64, top float64) float64 {
+	dr, dc := -angleCos(az), angleSin(az)
+	best := -90.0
+	for k := 1; ; k++ {
+		z, ok := d.sample(float64(r)+float64(k)*dr, float64(c)+float64(k)*dc)
+		if !ok {
+			break
+		}
+		dist := float64(k) * d.CellSize
+		best = math.Max(best, angleAtan2(z-z0, dist))
+		if z0+dist*angleTan(math.Max(best, floor)) > top {
+			break
+		}
+	}
+	return best
+}
+
+// Shadow returns whether each cell of the DEM, in row order, is in the shadow
+// of higher ground, or of itself facing away from the Sun, at time t. Every
+// cell is in shadow with the Sun below the horizon. Terrain beyond the edges
+// of the grid is taken to cast no shadow.
+func (d *DEM) Shadow(t time.Time) []bool {
+	alt := Altitude(t, d.Origin.Latitude, d.Origin.Longitude)
+	az := Azimuth(t, d.Origin.Latitude, d.Origin.Longitude)
+	shadow := make([]bool, d.Rows*d.Cols)
+	if alt <= 0 {
+		for i := range shadow {
+			shadow[i] = true
+		}
+		return shadow
+	}
+	slope, aspect := d.SlopeAspect()
+	top := d.maxElevation()
+	for r := 0; r < d.Rows; r++ {
+		for c := 0; c < d.Cols; c++ {
+			i := r*d.Cols + c
+			shadow[i] = cosIncidence(alt, az, slope[i], aspect[i]) <= 0 ||
+				d.horizonAngle(r, c, d.At(r, c), az, alt, top) > alt
+		}
+	}
+	return shadow
+}
+
+// Hillshade returns the direct illumination of each cell of the DEM at time t,
+// in row order: the cosine of the angle between the Sun and the ground's
+// normal, or zero where the cell is in shadow. Multiplied by 255 it gives the
+// familiar shaded relief map.
+func (d *DEM) Hillshade(t time.Time) []float64 {
+	alt := Altitude(t, d.Origin.Latitude, d.Origin.Longitude)
+	az := Azimuth(t, d.Origin.Latitude, d.Origin.Longitude)
+	shadow := d.Shadow(t)
+	slope, aspect := d.SlopeAspect()
+	shade := make([]float64, len(shadow))
+	for i, s := range shadow {
+		if !s {
+			shade[i] = cosIncidence(alt, az, slope[i], aspect[i])
+		}
+	}
+	return shade
+}
+
+// SlopeAspect returns the slope in degrees from horizontal and the aspect, the
+// direction the ground faces in degrees clockwise from north, of each cell in
+// row order, using Horn's method on the 3 by 3 neighbourhood. Edge cells use
+// their nearest neighbours inside the grid. Flat cells have aspect zero.
+func (d *DEM) SlopeAspect() (slope []float64, aspect []float64) {
+	n := d.Rows * d.Cols
+	slope, aspect = make([]float64, n), make([]float64, n)
+	z := func(r int, c int) float64 {
+		return d.At(clamp(r, 0, d.Rows-1), clamp(c, 0, d.Cols-1))
+	}
+	for r := 0; r < d.Rows; r++ {
+		for c := 0; c < d.Cols; c++ {
+			// rise towards the east and towards the north
+			east := ((z(r-1, c+1) + 2*z(r, c+1) + z(r+1, c+1)) - (z(r-1, c-1) + 2*z(r, c-1) + z(r+1, c-1))) / (8 * d.CellSize)
+			north := ((z(r-1, c-1) + 2*z(r-1, c) + z(r-1, c+1)) - (z(r+1, c-1) + 2*z(r+1, c) + z(r+1, c+1))) / (8 * d.CellSize)
+			i := r*d.Cols + c
+			slope[i] = angleAtan(math.Hypot(east, north))
+			if east != 0 || north != 0 {
+				// the ground faces down the slope
+				aspect[i] = between(0, 360, angleAtan2(-east, -north))
+			}
+		}
+	}
+	return slope, aspect
+}
+
+// clamp returns v limited to the range lo to hi
+func clamp(v int, lo int, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// cosIncidence returns the cosine of the angle between the Sun at altitude alt
+// and azimuth az and the normal to a surface tilted by slope degrees and facing
+// aspect
+func cosIncidence(alt float64, az float64, slope float64, aspect float64) float64 {
+	return angleSin(alt)*angleCos(slope) + angleCos(alt)*angleSin(slope)*angleCos(az-aspect)
+}
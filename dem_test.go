@@ -0,0 +1,144 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewDEMSize(t *testing.T) {
+	for _, tt := range []struct {
+		rows, cols int
+		cellSize   float64
+	}{{0, 10, 30}, {10, -1, 30}, {10, 10, 0}} {
+		if _, err := NewDEM(tt.rows, tt.cols, tt.cellSize, Point{}); err != errNonPositiveSize {
+			t.Errorf("NewDEM(%d, %d, %v): err = %v, want %v", tt.rows, tt.cols, tt.cellSize, err, errNonPositiveSize)
+		}
+	}
+	d, err := NewDEM(3, 4, 30, Point{45, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Elevation) != 12 {
+		t.Errorf("%d elevations, want 12", len(d.Elevation))
+	}
+}
+
+// slopeDEM returns a DEM rising northwards by rise metres a cell
+func slopeDEM(t *testing.T, n int, rise float64) *DEM {
+	d, err := NewDEM(n, n, 10, Point{46, 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			d.Elevation[r*n+c] = float64(n-1-r) * rise
+		}
+	}
+	return d
+}
+
+func TestDEMSample(t *testing.T) {
+	d, _ := NewDEM(2, 2, 10, Point{})
+	copy(d.Elevation, []float64{0, 10, 20, 30})
+	for _, tt := range []struct {
+		r, c, want float64
+		ok         bool
+	}{
+		{0, 0, 0, true},
+		{0.5, 0.5, 15, true},
+		{1, 0.25, 22.5, true},
+		{1.01, 0, 0, false},
+		{0, -0.1, 0, false},
+	} {
+		got, ok := d.sample(tt.r, tt.c)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("sample(%v, %v) = %v, %v, want %v, %v", tt.r, tt.c, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDEMCell(t *testing.T) {
+	d, _ := NewDEM(10, 10, 100, Point{50, 10})
+	metres := toRadians(earthRadius)
+	for _, tt := range []struct {
+		north, east float64
+		r, c        int
+		ok          bool
+	}{
+		{0, 0, 0, 0, true},
+		{-340, 520, 3, 5, true},
+		{-949, 949, 9, 9, true},
+		{60, 0, 0, 0, false},
+		{0, -60, 0, 0, false},
+	} {
+		p := Point{50 + tt.north/metres, 10 + tt.east/metres/angleCos(50)}
+		r, c, ok := d.Cell(p)
+		if r != tt.r || c != tt.c || ok != tt.ok {
+			t.Errorf("Cell %v m north, %v m east = %d, %d, %v, want %d, %d, %v", tt.north, tt.east, r, c, ok, tt.r, tt.c, tt.ok)
+		}
+	}
+}
+
+func TestSlopeAspect(t *testing.T) {
+	// rising 10 m a 10 m cell to the north: 45 degrees, facing south, away
+	// from the top and bottom rows where the slope is taken over one cell
+	// instead of two
+	d := slopeDEM(t, 5, 10)
+	slope, aspect := d.SlopeAspect()
+	for i := 5; i < 20; i++ {
+		if math.Abs(slope[i]-45) > 1e-9 || math.Abs(aspect[i]-180) > 1e-9 {
+			t.Errorf("cell %d: slope %v, aspect %v, want 45, 180", i, slope[i], aspect[i])
+		}
+	}
+	if want := angleAtan(0.5); math.Abs(slope[0]-want) > 1e-9 {
+		t.Errorf("top row: slope %v, want %v", slope[0], want)
+	}
+	flat, _ := NewDEM(3, 3, 10, Point{})
+	slope, aspect = flat.SlopeAspect()
+	if slope[4] != 0 || aspect[4] != 0 {
+		t.Errorf("flat: slope %v, aspect %v, want 0, 0", slope[4], aspect[4])
+	}
+}
+
+func TestCosIncidence(t *testing.T) {
+	for _, tt := range []struct {
+		alt, az, slope, aspect, want float64
+	}{
+		{90, 0, 0, 0, 1},
+		{30, 180, 0, 0, 0.5},
+		{30, 180, 60, 180, 1},  // facing the Sun square on
+		{30, 180, 60, 0, -0.5}, // facing away
+		{45, 90, 90, 90, math.Sqrt(0.5)},
+	} {
+		if got := cosIncidence(tt.alt, tt.az, tt.slope, tt.aspect); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("cosIncidence(%v, %v, %v, %v) = %v, want %v", tt.alt, tt.az, tt.slope, tt.aspect, got, tt.want)
+		}
+	}
+}
+
+func TestShadow(t *testing.T) {
+	// a wall 100 m high along row 2 of flat ground, with the Sun low in the
+	// south at local noon in midwinter: the ground north of the wall is in
+	// its shadow
+	d, _ := NewDEM(20, 5, 10, Point{46, 8})
+	for c := 0; c < 5; c++ {
+		d.Elevation[10*5+c] = 100
+	}
+	noon := Culminate(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), 46, 8).Time
+	shadow := d.Shadow(noon)
+	for r := 0; r < 20; r++ {
+		// the Sun is about 20.6 degrees up, so the shadow is 100 m / tan 20.6
+		// = 266 m, 26 cells, long, past the edge of the grid
+		want := r < 10
+		if got := shadow[r*5+2]; got != want {
+			t.Errorf("row %d: shadow %v, want %v", r, got, want)
+		}
+	}
+	night := d.Shadow(noon.Add(12 * time.Hour))
+	for i, s := range night {
+		if !s {
+			t.Fatalf("cell %d lit at night", i)
+		}
+	}
+}
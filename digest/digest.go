@@ -0,0 +1,126 @@
+// Package digest renders a day's solar events for a list of locations as chat
+// messages, for Telegram and Slack bots such as sunset reminders.
+package digest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// Location is a named place to report on.
+type Location struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	TimeZone  *time.Location // times are shown in this zone; nil means UTC
+}
+
+// entry is the formatted lines for one location
+type entry struct {
+	name  string
+	lines []string
+}
+
+// entries formats the events on the date of date at each location
+func entries(date time.Time, locs []Location, lang string) []entry {
+	m := sun.MessagesFor(lang)
+	y, mo, d := date.Date()
+	var es []entry
+	for _, l := range locs {
+		tz := l.TimeZone
+		if tz == nil {
+			tz = time.UTC
+		}
+		day := time.Date(y, mo, d, 0, 0, 0, 0, tz)
+		today := sun.DayEvents(day, l.Latitude, l.Longitude)
+		yesterday := sun.DayEvents(day.AddDate(0, 0, -1), l.Latitude, l.Longitude)
+		e := entry{name: l.Name}
+		if !today.Sunrise.IsZero() {
+			e.lines = append(e.lines, "🌅 "+m.Sunrise+" "+today.Sunrise.Format("15:04"))
+		}
+		e.lines = append(e.lines, "☀️ "+m.SolarNoon+" "+today.Noon.Time.Format("15:04"))
+		if !today.Sunset.IsZero() {
+			e.lines = append(e.lines, "🌇 "+m.Sunset+" "+today.Sunset.Format("15:04"))
+		}
+		switch {
+		case today.Sunset.IsZero() && (!today.Sunrise.IsZero() || today.Length > 0):
+			e.lines = append(e.lines, m.NoSunset)
+		case today.Sunrise.IsZero():
+			e.lines = append(e.lines, m.NoSunrise)
+		}
+		e.lines = append(e.lines, fmt.Sprintf("⏱ %s %s (%s)", m.DayLength,
+			sun.FormatDuration(today.Length, lang), signedDuration(today.Length-yesterday.Length)))
+		es = append(es, e)
+	}
+	return es
+}
+
+// signedDuration formats the change in day length as +2m13s or -45s
+func signedDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return sign + d.String()
+}
+
+// Telegram returns the digest for the date of date as a Telegram message in
+// MarkdownV2, with a bold heading per location.
+func Telegram(date time.Time, locs []Location, lang string) string {
+	var b strings.Builder
+	for i, e := range entries(date, locs, lang) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("*" + escapeTelegram(e.name) + "*\n")
+		for _, l := range e.lines {
+			b.WriteString(escapeTelegram(l) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// escapeTelegram escapes the characters MarkdownV2 reserves
+func escapeTelegram(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Slack returns the digest for the date of date as a Slack Block Kit message:
+// a header block with the date, then a section per location with its events
+// in mrkdwn. The result is JSON ready to post to chat.postMessage or an
+// incoming webhook.
+func Slack(date time.Time, locs []Location, lang string) ([]byte, error) {
+	type text struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	type block struct {
+		Type string `json:"type"`
+		Text *text  `json:"text,omitempty"`
+	}
+	blocks := []block{{Type: "header", Text: &text{"plain_text", date.Format("Monday 2 January 2006")}}}
+	for _, e := range entries(date, locs, lang) {
+		s := "*" + escapeSlack(e.name) + "*\n" + escapeSlack(strings.Join(e.lines, "\n"))
+		blocks = append(blocks, block{Type: "section", Text: &text{"mrkdwn", s}})
+	}
+	return json.Marshal(struct {
+		Blocks []block `json:"blocks"`
+	}{blocks})
+}
+
+// escapeSlack escapes the characters Slack mrkdwn treats as control sequences
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,136 @@
+package digest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func places(t *testing.T) []Location {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	return []Location{
+		{"London", 51.5074, -0.1278, london},
+		{"Tromsø", 69.6492, 18.9553, nil},
+	}
+}
+
+// The times are the timeanddate.com almanac's for London. At the equinox the
+// day lengthens by 2 tan φ dδ/dt / 15 hours a day, with dδ/dt = 0.3946
+// degrees: 3m58s at London and 8m32s at Tromsø.
+func TestTelegram(t *testing.T) {
+	got := Telegram(time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), places(t), "en")
+	want := `*London*
+🌅 Sunrise 06:02
+☀️ Solar noon 12:08
+🌇 Sunset 18:14
+⏱ Day length 12h12m \(\+3m58s\)
+
+*Tromsø*
+🌅 Sunrise 04:41
+☀️ Solar noon 10:52
+🌇 Sunset 17:03
+⏱ Day length 12h22m \(\+8m32s\)
+`
+	if got != want {
+		t.Errorf("Telegram =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSlack(t *testing.T) {
+	b, err := Slack(time.Date(2024, time.December, 21, 0, 0, 0, 0, time.UTC), places(t), "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg struct {
+		Blocks []struct {
+			Type string
+			Text struct{ Type, Text string }
+		}
+	}
+	if err := json.Unmarshal(b, &msg); err != nil {
+		t.Fatal(err)
+	}
+	// the solstice fell at 09:21 UT, so the day in London shortened by
+	// only a few seconds; Tromsø is in the polar night
+	want := []struct{ typ, textType, text string }{
+		{"header", "plain_text", "Saturday 21 December 2024"},
+		{"section", "mrkdwn", "*London*\n🌅 Sonnenaufgang 08:03\n☀️ Sonnenmittag 11:58\n🌇 Sonnenuntergang 15:53\n⏱ Tageslänge 7 Std. 50 Min. (-3s)"},
+		{"section", "mrkdwn", "*Tromsø*\n☀️ Sonnenmittag 10:42\nDie Sonne geht nicht auf\n⏱ Tageslänge 0 Std. 00 Min. (+0s)"},
+	}
+	if len(msg.Blocks) != len(want) {
+		t.Fatalf("Slack has %d blocks, want %d", len(msg.Blocks), len(want))
+	}
+	for i, w := range want {
+		bl := msg.Blocks[i]
+		if bl.Type != w.typ || bl.Text.Type != w.textType || bl.Text.Text != w.text {
+			t.Errorf("block %d = %s %s %q, want %s %s %q", i, bl.Type, bl.Text.Type, bl.Text.Text, w.typ, w.textType, w.text)
+		}
+	}
+}
+
+func TestMidnightSun(t *testing.T) {
+	got := Telegram(time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC), places(t)[1:], "en")
+	if !strings.Contains(got, "The Sun does not set\n") || strings.Contains(got, "Sunrise") || strings.Contains(got, "Sunset") {
+		t.Errorf("Telegram for the midnight sun =\n%s", got)
+	}
+	if !strings.Contains(got, "Day length 24h00m") {
+		t.Errorf("Telegram for the midnight sun has no 24 hour day:\n%s", got)
+	}
+}
+
+// Tromsø, kept in UTC, has its first sunrise since winter at 23:07 on 17 May
+// with no sunset to follow, and its last sunset of summer at 22:23 on 25 July
+// with no sunrise before it.
+func TestSunriseOrSunsetAlone(t *testing.T) {
+	for _, tt := range []struct {
+		date                time.Time
+		event, missing, not string
+	}{
+		{time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC), "Sunrise 23:07\n", "The Sun does not set\n", "The Sun does not rise"},
+		{time.Date(2024, time.July, 25, 0, 0, 0, 0, time.UTC), "Sunset 22:23\n", "The Sun does not rise\n", "The Sun does not set"},
+	} {
+		got := Telegram(tt.date, places(t)[1:], "en")
+		if !strings.Contains(got, tt.event) || !strings.Contains(got, tt.missing) || strings.Contains(got, tt.not) {
+			t.Errorf("Telegram on %v =\n%s", tt.date.Format("2 January"), got)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for _, tt := range []struct {
+		s, telegram, slack string
+	}{
+		{"St. John's (NL)", `St\. John's \(NL\)`, "St. John's (NL)"},
+		{"a_b*c", `a\_b\*c`, "a_b*c"},
+		{"<Kew & Richmond>", `<Kew & Richmond\>`, "&lt;Kew &amp; Richmond&gt;"},
+		{"+3m-2s", `\+3m\-2s`, "+3m-2s"},
+	} {
+		if got := escapeTelegram(tt.s); got != tt.telegram {
+			t.Errorf("escapeTelegram(%q) = %q, want %q", tt.s, got, tt.telegram)
+		}
+		if got := escapeSlack(tt.s); got != tt.slack {
+			t.Errorf("escapeSlack(%q) = %q, want %q", tt.s, got, tt.slack)
+		}
+	}
+}
+
+func TestSignedDuration(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{3*time.Minute + 58*time.Second, "+3m58s"},
+		{-45 * time.Second, "-45s"},
+		{-2600 * time.Millisecond, "-3s"},
+		{0, "+0s"},
+		{400 * time.Millisecond, "+0s"},
+	} {
+		if got := signedDuration(tt.d); got != tt.want {
+			t.Errorf("signedDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
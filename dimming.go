@@ -0,0 +1,94 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Easing shapes the change between two points of a dimming curve: it maps the
+// fraction of the way from one to the next, 0 to 1, to the fraction of the
+// change in level, with 0 at 0 and 1 at 1.
+type Easing func(x float64) float64
+
+// EaseLinear changes the level at a steady rate.
+func EaseLinear(x float64) float64 {
+	return x
+}
+
+// EaseSmoothstep starts and ends each change gently, with no step in the rate
+// of change at the points.
+func EaseSmoothstep(x float64) float64 {
+	return x * x * (3 - 2*x)
+}
+
+// EaseCosine follows half a cosine wave, much as EaseSmoothstep.
+func EaseCosine(x float64) float64 {
+	return (1 - math.Cos(math.Pi*x)) / 2
+}
+
+// DimPoint is the light level, in percent, at a depression of the Sun below
+// the horizon in degrees.
+type DimPoint struct {
+	Depression float64
+	Level      float64
+}
+
+// DimmingCurve maps the depression of the Sun to a light level. Points must
+// be in order of increasing depression. As depression rises in the evening
+// and falls in the morning, a curve such as 100 percent at 0, 30 at 12 gives
+// the same dimming and brightening either side of midnight.
+type DimmingCurve struct {
+	Points []DimPoint
+	Ease   Easing // nil for EaseLinear
+}
+
+// Level returns the light level at the given depression, eased between the
+// points either side and held at the first or last level beyond them. It is 0
+// for a curve without points.
+func (c DimmingCurve) Level(depression float64) float64 {
+	ps := c.Points
+	if len(ps) == 0 {
+		return 0
+	}
+	if depression <= ps[0].Depression {
+		return ps[0].Level
+	}
+	ease := c.Ease
+	if ease == nil {
+		ease = EaseLinear
+	}
+	for i := 1; i < len(ps); i++ {
+		if depression < ps[i].Depression {
+			x := (depression - ps[i-1].Depression) / (ps[i].Depression - ps[i-1].Depression)
+			return ps[i-1].Level + ease(x)*(ps[i].Level-ps[i-1].Level)
+		}
+	}
+	return ps[len(ps)-1].Level
+}
+
+// DimSample is the light level at one time.
+type DimSample struct {
+	Time       time.Time
+	Depression float64
+	Level      float64
+}
+
+// DimmingProfile samples the curve at every step from sunset on the date of t
+// to sunrise the next morning, for a lighting controller to load. It returns
+// nil if the Sun does not set, and an error if step is not positive. Polar
+// night runs noon to noon, as for NightPeriod.
+func DimmingProfile(t time.Time, latitude float64, longitude float64, curve DimmingCurve, step time.Duration) ([]DimSample, error) {
+	if step <= 0 {
+		return nil, errNonPositiveStep
+	}
+	night, ok := NightPeriod(t, latitude, longitude)
+	if !ok {
+		return nil, nil
+	}
+	var ss []DimSample
+	for at := night.Start; !at.After(night.End); at = at.Add(step) {
+		dep := -Altitude(at, latitude, longitude)
+		ss = append(ss, DimSample{at, dep, curve.Level(dep)})
+	}
+	return ss, nil
+}
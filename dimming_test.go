@@ -0,0 +1,100 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEasing(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ease Easing
+		x    float64
+		want float64
+	}{
+		{"linear", EaseLinear, 0.3, 0.3},
+		{"smoothstep", EaseSmoothstep, 0, 0},
+		{"smoothstep", EaseSmoothstep, 0.25, 0.15625},
+		{"smoothstep", EaseSmoothstep, 0.5, 0.5},
+		{"smoothstep", EaseSmoothstep, 1, 1},
+		{"cosine", EaseCosine, 0, 0},
+		{"cosine", EaseCosine, 0.25, (1 - math.Sqrt2/2) / 2},
+		{"cosine", EaseCosine, 0.5, 0.5},
+		{"cosine", EaseCosine, 1, 1},
+	} {
+		if got := tt.ease(tt.x); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s(%v) = %v, want %v", tt.name, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestDimmingCurveLevel(t *testing.T) {
+	linear := DimmingCurve{Points: []DimPoint{{0, 100}, {12, 30}, {18, 20}}}
+	smooth := DimmingCurve{Points: linear.Points, Ease: EaseSmoothstep}
+	for _, tt := range []struct {
+		c          DimmingCurve
+		depression float64
+		want       float64
+	}{
+		{linear, -5, 100},
+		{linear, 0, 100},
+		{linear, 6, 65},
+		{linear, 12, 30},
+		{linear, 15, 25},
+		{linear, 40, 20},
+		// a quarter of the way the smoothstep has made 0.15625 of the change
+		{smooth, 3, 100 - 70*0.15625},
+		{smooth, 6, 65},
+		{DimmingCurve{}, 6, 0},
+	} {
+		if got := tt.c.Level(tt.depression); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Level(%v) = %v, want %v", tt.depression, got, tt.want)
+		}
+	}
+}
+
+// At London on the December solstice the Sun sets at 15:53:38 UT at a
+// depression of 0.833 degrees, which the curve of 100 at 0 and 30 at 12 puts
+// at 95.1 percent. Noon is at 11:58 UT, and twelve hours later the Sun is at
+// its lower culmination and greatest depression, 90 - φ - δ = 90 - 51.51 +
+// 23.44 = 61.93 degrees.
+func TestDimmingProfile(t *testing.T) {
+	d := date(time.UTC, 2024, time.December, 21)
+	curve := DimmingCurve{Points: []DimPoint{{0, 100}, {12, 30}}}
+	ss, err := DimmingProfile(d, 51.5074, -0.1278, curve, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	night, _ := NightPeriod(d, 51.5074, -0.1278)
+	if want := int(night.Duration()/(15*time.Minute)) + 1; len(ss) != want {
+		t.Fatalf("%d samples, want %d", len(ss), want)
+	}
+	first := ss[0]
+	if !within(first.Time, clock(d, 15, 53).Add(38*time.Second), time.Minute) || math.Abs(first.Depression-0.833) > 0.01 || math.Abs(first.Level-95.14) > 0.1 {
+		t.Errorf("first sample = %+v, want 95.1 percent at sunset", first)
+	}
+	deepest := first
+	for i, s := range ss {
+		if i > 0 && s.Time.Sub(ss[i-1].Time) != 15*time.Minute {
+			t.Errorf("sample %d at %v, not 15 minutes after the last", i, s.Time)
+		}
+		if s.Depression > deepest.Depression {
+			deepest = s
+		}
+		if s.Level != curve.Level(s.Depression) {
+			t.Errorf("sample %d level %v, want %v", i, s.Level, curve.Level(s.Depression))
+		}
+	}
+	if !within(deepest.Time, clock(d, 23, 58), 8*time.Minute) || math.Abs(deepest.Depression-61.93) > 0.05 || deepest.Level != 30 {
+		t.Errorf("deepest sample = %+v, want 61.93 degrees at 23:58", deepest)
+	}
+
+	if _, err := DimmingProfile(d, 51.5074, -0.1278, curve, 0); err != errNonPositiveStep {
+		t.Errorf("zero step: err = %v, want %v", err, errNonPositiveStep)
+	}
+	// no night in the midnight sun
+	if ss, err := DimmingProfile(date(time.UTC, 2024, time.June, 21), 69.6492, 18.9553, curve, time.Hour); ss != nil || err != nil {
+		t.Errorf("DimmingProfile in the midnight sun = %v, %v", ss, err)
+	}
+}
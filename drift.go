@@ -0,0 +1,106 @@
+package sun
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventFunc finds a solar event on the date of t at a location, as Sunrise and
+// Sunset do, with ok false if there is none that day.
+type EventFunc func(t time.Time, latitude float64, longitude float64) (at time.Time, ok bool)
+
+// DriftDay is the time of an event on one date and how it has moved since the
+// date before.
+type DriftDay struct {
+	Date time.Time // midnight at the start of the date
+	Time time.Time // zero if there is no event that date
+	// Drift is how much later in the day the event falls than the day
+	// before, negative if earlier, leaving out any change of the clocks.
+	Drift time.Duration
+	// ClockChange is how far the clocks went forward between the two events,
+	// as at the start or end of summer time, which moves the event by as
+	// much again on the clock. Both are zero if either date has no event.
+	ClockChange time.Duration
+}
+
+// EventDrift returns the time of event on each of days dates from the date of
+// start, in its time zone, with how it moves from day to day, for telling the
+// users of a schedule tied to the event what to expect.
+func EventDrift(start time.Time, days int, latitude float64, longitude float64, event EventFunc) []DriftDay {
+	y, m, d := start.Date()
+	out := make([]DriftDay, days)
+	for i := range out {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, start.Location())
+		out[i].Date = date
+		at, ok := event(date, latitude, longitude)
+		if !ok {
+			continue
+		}
+		out[i].Time = at
+		if i > 0 && !out[i-1].Time.IsZero() {
+			prev := out[i-1].Time
+			_, offset0 := prev.Zone()
+			_, offset1 := at.Zone()
+			out[i].Drift = at.Sub(prev) - 24*time.Hour
+			out[i].ClockChange = time.Duration(offset1-offset0) * time.Second
+		}
+	}
+	return out
+}
+
+// DriftMonth sums up the day to day drift of an event over a calendar month.
+type DriftMonth struct {
+	Month time.Time // midnight at the start of the first of the month
+	// Mean, Least and Most are the average, smallest and largest daily
+	// Drift over the days of the month with an event on them and the day
+	// before, which number Days.
+	Mean  time.Duration
+	Least time.Duration
+	Most  time.Duration
+	Days  int
+}
+
+// Describe returns a sentence for users such as "sunset moves 1m42s earlier
+// per day in September 2024", naming the event as given.
+func (m DriftMonth) Describe(event string) string {
+	when := m.Month.Format("January 2006")
+	if m.Days == 0 {
+		return fmt.Sprintf("there is no %s in %s", event, when)
+	}
+	mean := m.Mean.Round(time.Second)
+	switch {
+	case mean > 0:
+		return fmt.Sprintf("%s moves %s later per day in %s", event, mean, when)
+	case mean < 0:
+		return fmt.Sprintf("%s moves %s earlier per day in %s", event, -mean, when)
+	}
+	return fmt.Sprintf("%s stays at much the same time through %s", event, when)
+}
+
+// MonthlyDrift sums up days from EventDrift by calendar month, in order.
+func MonthlyDrift(days []DriftDay) []DriftMonth {
+	var months []DriftMonth
+	var sum time.Duration
+	for i, d := range days {
+		y, m, _ := d.Date.Date()
+		first := time.Date(y, m, 1, 0, 0, 0, 0, d.Date.Location())
+		if len(months) == 0 || !months[len(months)-1].Month.Equal(first) {
+			months = append(months, DriftMonth{Month: first})
+			sum = 0
+		}
+		cur := &months[len(months)-1]
+		if i == 0 || d.Time.IsZero() || days[i-1].Time.IsZero() {
+			continue
+		}
+		if cur.Days == 0 || d.Drift < cur.Least {
+			cur.Least = d.Drift
+		}
+		if cur.Days == 0 || d.Drift > cur.Most {
+			cur.Most = d.Drift
+		}
+		cur.Days++
+		sum += d.Drift
+		cur.Mean = sum / time.Duration(cur.Days)
+	}
+	return months
+}
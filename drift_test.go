@@ -0,0 +1,83 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// Sunset in London, from the almanac 19:46 BST on 1 September 2024 and 18:37
+// on 1 October, moves 69 minutes earlier over the month, 2m17s a day.
+func TestMonthlyDrift(t *testing.T) {
+	london := location(t, "Europe/London")
+	days := EventDrift(date(london, 2024, time.August, 31), 32, 51.5074, -0.1278, Sunset)
+	if len(days) != 32 || !within(days[1].Time, clock(days[1].Date, 19, 46), time.Minute) || !within(days[31].Time, clock(days[31].Date, 18, 37), time.Minute) {
+		t.Fatalf("sunsets %v to %v, want 19:46 and 18:37", days[1].Time, days[31].Time)
+	}
+	months := MonthlyDrift(days)
+	if len(months) != 3 {
+		t.Fatalf("%d months, want 3", len(months))
+	}
+	sep := months[1]
+	if !sep.Month.Equal(date(london, 2024, time.September, 1)) || sep.Days != 30 ||
+		(sep.Mean+137*time.Second).Abs() > time.Second || sep.Least > sep.Mean || sep.Most < sep.Mean {
+		t.Errorf("September %+v, want a mean of -2m17s over 30 days", sep)
+	}
+	if got := sep.Describe("sunset"); got != "sunset moves 2m17s earlier per day in September 2024" {
+		t.Errorf("Describe = %q", got)
+	}
+	// the first day has nothing before it
+	if months[0].Days != 0 || months[0].Describe("sunset") != "there is no sunset in August 2024" {
+		t.Errorf("August %+v", months[0])
+	}
+}
+
+// Near the March equinox, with the declination rising 0.395 degree a day,
+// sunset at 51.5 N moves tan 51.5 × 0.395 = 0.496 degree, 1.98 minutes, a day
+// later in hour angle, less the 17 seconds a day noon comes earlier: 1m41s.
+// The clocks go forward on 31 March.
+func TestEventDrift(t *testing.T) {
+	london := location(t, "Europe/London")
+	days := EventDrift(date(london, 2024, time.March, 29), 4, 51.5074, -0.1278, Sunset)
+	for i, d := range days {
+		var wantDrift, wantChange time.Duration
+		if i > 0 {
+			wantDrift = 101 * time.Second
+		}
+		if i == 2 {
+			wantChange = time.Hour
+		}
+		if (d.Drift-wantDrift).Abs() > 2*time.Second || d.ClockChange != wantChange {
+			t.Errorf("%v: drift %v, clocks %v, want %v and %v", d.Date.Format("2 Jan"), d.Drift, d.ClockChange, wantDrift, wantChange)
+		}
+	}
+	if days[2].Time.Format("15:04") != "19:32" {
+		t.Errorf("sunset on 31 March at %v, want 19:32 BST", days[2].Time)
+	}
+
+	// the Sun returns to Tromsø on 15 January 2025, which has no drift as
+	// there was no sunrise the day before
+	oslo := location(t, "Europe/Oslo")
+	days = EventDrift(date(oslo, 2025, time.January, 1), 31, 69.6492, 18.9553, Sunrise)
+	if !days[13].Time.IsZero() || days[14].Time.IsZero() || days[14].Drift != 0 || days[15].Drift >= 0 {
+		t.Errorf("return of the Sun: %+v, %+v, %+v", days[13], days[14], days[15])
+	}
+	if m := MonthlyDrift(days)[0]; m.Days != 16 || m.Describe("sunrise") != "sunrise moves 7m17s earlier per day in January 2025" {
+		t.Errorf("January %+v: %s", m, m.Describe("sunrise"))
+	}
+}
+
+func TestDriftMonthDescribe(t *testing.T) {
+	june := date(time.UTC, 2024, time.June, 1)
+	for _, tt := range []struct {
+		m    DriftMonth
+		want string
+	}{
+		{DriftMonth{Month: june, Mean: 5280 * time.Millisecond, Days: 15}, "sunset moves 5s later per day in June 2024"},
+		{DriftMonth{Month: june, Mean: -400 * time.Millisecond, Days: 30}, "sunset stays at much the same time through June 2024"},
+		{DriftMonth{Month: june}, "there is no sunset in June 2024"},
+	} {
+		if got := tt.m.Describe("sunset"); got != tt.want {
+			t.Errorf("Describe = %q, want %q", got, tt.want)
+		}
+	}
+}
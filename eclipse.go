@@ -0,0 +1,139 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// moonPositionError is the error in degrees allowed for the lunar theory and
+// the neglect of the Earth's flattening when judging whether an eclipse is
+// possible
+const moonPositionError = 0.05
+
+// SolarEclipseCandidate is a new moon at which the Moon may pass in front of
+// the Sun as seen from a location.
+type SolarEclipseCandidate struct {
+	NewMoon time.Time
+	// Closest is when the centres of the Sun and Moon are nearest as seen
+	// from the location with the Sun up, and Separation their distance apart
+	// then in degrees.
+	Closest    time.Time
+	Separation float64
+	// Overlap is the sum of the semi-diameters of the Sun and Moon less the
+	// separation, in degrees: positive if the discs overlap, by the model.
+	Overlap     float64
+	SunAltitude float64
+}
+
+// SolarEclipseCandidates returns the new moons between start and end at which a
+// solar eclipse may be visible from the given location, for a detailed
+// computation of local circumstances to confirm. A new moon is kept if, with
+// the Sun above the horizon, the discs of the Sun and Moon come within 0.05
+// degree of touching, which allows for the errors of the model, so some
+// candidates will turn out to be near misses. Times are in the
+// time zone of start.
+func SolarEclipseCandidates(start time.Time, end time.Time, latitude float64, longitude float64) []SolarEclipseCandidate {
+	var cs []SolarEclipseCandidate
+	for _, nm := range NewMoons(start, end) {
+		// the Moon is more than 1.6 degrees from the ecliptic at most new
+		// moons, too far to touch the Sun from anywhere on Earth
+		if m := MoonPosition(nm); math.Abs(m.EclipticLatitude) > 1.6+moonPositionError {
+			continue
+		}
+		sep := func(s float64) float64 {
+			at := nm.Add(secondsToDuration(s))
+			if Altitude(at, latitude, longitude) < SunriseAltitude {
+				return 180
+			}
+			return vectorAngle(enuAt(at, latitude, longitude), moonTopocentric(at, latitude, longitude))
+		}
+		// from anywhere on Earth the Moon passes the Sun within a few hours
+		// of the geocentric new moon
+		best, bestSep := 0.0, math.Inf(1)
+		for s := -6 * 3600.0; s <= 6*3600; s += 600 {
+			if v := sep(s); v < bestSep {
+				best, bestSep = s, v
+			}
+		}
+		if bestSep >= 180 {
+			continue
+		}
+		best = goldenMax(func(s float64) float64 { return -sep(s) }, best-600, best+600, defaultTolerance)
+		at := nm.Add(secondsToDuration(best))
+		c := SolarEclipseCandidate{
+			NewMoon:     nm,
+			Closest:     at,
+			Separation:  sep(best),
+			SunAltitude: Altitude(at, latitude, longitude),
+		}
+		c.Overlap = sunSemiDiameter + moonSemiDiameter(at, latitude, longitude) - c.Separation
+		if c.Overlap > -moonPositionError {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// NewMoons returns the times of new moon, when the Moon and Sun have the same
+// ecliptic longitude, between start and end, in the time zone of start. They
+// are good to a minute or so.
+func NewMoons(start time.Time, end time.Time) []time.Time {
+	return moonPhases(start, end, 0)
+}
+
+// FullMoons returns the times of full moon, when the Moon is opposite the Sun
+// in ecliptic longitude, between start and end, as for NewMoons.
+func FullMoons(start time.Time, end time.Time) []time.Time {
+	return moonPhases(start, end, 180)
+}
+
+// moonPhases returns the times between start and end that the ecliptic
+// longitude of the Moon is that of the Sun plus elongation
+func moonPhases(start time.Time, end time.Time, elongation float64) []time.Time {
+	f := func(s float64) float64 {
+		t := start.Add(secondsToDuration(s))
+		return between(-180, 180, MoonPosition(t).EclipticLongitude-LowPrecision{}.Sun(t).EclipticLongitude-elongation)
+	}
+	var ts []time.Time
+	for _, c := range crossings(start, end, f, 180, defaultTolerance) {
+		ts = append(ts, c.Time)
+	}
+	return ts
+}
+
+// moonTopocentric returns the unit vector towards the Moon at t in the local
+// east, north, up frame, allowing for the observer's place on the surface of
+// a spherical Earth
+func moonTopocentric(t time.Time, latitude float64, longitude float64) [3]float64 {
+	v, _ := moonTopocentricDistance(t, latitude, longitude)
+	return v
+}
+
+// moonTopocentricDistance returns the unit vector of moonTopocentric and the
+// distance of the Moon from the observer in Earth radii
+func moonTopocentricDistance(t time.Time, latitude float64, longitude float64) ([3]float64, float64) {
+	jd := timeToJD(t)
+	T := getJdn(tdbJD(t)) / 36525
+	lon, lat, parallax := moonEcliptic(T)
+	ra, dec := eclipticToEquatorial(lon, lat, meanObliquity(T))
+	e, n, u := getENU(latitude, dec, getHourAngle(jd, longitude, ra))
+	r := 1 / angleSin(parallax)
+	v := [3]float64{e * r, n * r, u*r - 1}
+	d := vecLen(v)
+	return scaleVector(v, 1/d), d
+}
+
+// moonSemiDiameter returns the angular radius of the Moon in degrees as seen
+// from the location
+func moonSemiDiameter(t time.Time, latitude float64, longitude float64) float64 {
+	_, d := moonTopocentricDistance(t, latitude, longitude)
+	// the Moon's radius is 0.2725 of the Earth's
+	return angleAsin(0.2725 / d)
+}
+
+// vectorAngle returns the angle in degrees between unit vectors a and b
+func vectorAngle(a [3]float64, b [3]float64) float64 {
+	// the chord between the tips keeps small angles accurate
+	d := [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+	return 2 * angleAsin(math.Min(1, vecLen(d)/2))
+}
@@ -0,0 +1,93 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The USNO gives the new and full moons of 2024 to the minute.
+func TestMoonPhases2024(t *testing.T) {
+	start, end := date(time.UTC, 2024, time.January, 1), date(time.UTC, 2025, time.January, 1)
+	for _, tt := range []struct {
+		name  string
+		f     func(time.Time, time.Time) []time.Time
+		times [][4]int
+	}{
+		{"new", NewMoons, [][4]int{
+			{1, 11, 11, 57}, {2, 9, 22, 59}, {3, 10, 9, 0}, {4, 8, 18, 21}, {5, 8, 3, 22}, {6, 6, 12, 38}, {7, 5, 22, 57},
+			{8, 4, 11, 13}, {9, 3, 1, 55}, {10, 2, 18, 49}, {11, 1, 12, 47}, {12, 1, 6, 21}, {12, 30, 22, 27},
+		}},
+		{"full", FullMoons, [][4]int{
+			{1, 25, 17, 54}, {2, 24, 12, 30}, {3, 25, 7, 0}, {4, 23, 23, 49}, {5, 23, 13, 53}, {6, 22, 1, 8},
+			{7, 21, 10, 17}, {8, 19, 18, 26}, {9, 18, 2, 34}, {10, 17, 11, 26}, {11, 15, 21, 28}, {12, 15, 9, 2},
+		}},
+	} {
+		got := tt.f(start, end)
+		if len(got) != len(tt.times) {
+			t.Fatalf("%d %s moons, want %d", len(got), tt.name, len(tt.times))
+		}
+		for i, w := range tt.times {
+			want := time.Date(2024, time.Month(w[0]), w[1], w[2], w[3], 0, 0, time.UTC)
+			if !within(got[i], want, 2*time.Minute) {
+				t.Errorf("%s moon %d at %v, want %v", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+// The total eclipse of 8 April 2024 was greatest at Dallas at 18:42:40 UT with
+// the Moon larger than the Sun; the annular one of 2 October 2024 crossed
+// Easter Island at about 19:05 UT with the Moon smaller. London saw neither, but saw 40 percent
+// of the Sun's diameter covered at 11:03 UT on 29 March 2025 and 90 percent
+// low in the west at 18:13 UT on 12 August 2026.
+func TestSolarEclipseCandidates(t *testing.T) {
+	start, end := date(time.UTC, 2024, time.January, 1), date(time.UTC, 2025, time.January, 1)
+	for _, tt := range []struct {
+		name     string
+		lat, lon float64
+		closest  time.Time
+		tol      time.Duration
+		total    bool
+	}{
+		{"Dallas", 32.78, -96.80, time.Date(2024, time.April, 8, 18, 42, 40, 0, time.UTC), time.Minute, true},
+		{"Easter Island", -27.11, -109.35, time.Date(2024, time.October, 2, 19, 5, 0, 0, time.UTC), 5 * time.Minute, false},
+	} {
+		cs := SolarEclipseCandidates(start, end, tt.lat, tt.lon)
+		if len(cs) != 1 {
+			t.Fatalf("%s: %d candidates, want 1", tt.name, len(cs))
+		}
+		c := cs[0]
+		if !within(c.Closest, tt.closest, tt.tol) || c.Separation > 0.02 || c.SunAltitude < 60 {
+			t.Errorf("%s: %+v, want central at %v", tt.name, c, tt.closest)
+		}
+		// the overlap and separation less the Sun's semi-diameter leave the Moon's
+		if moon := c.Overlap + c.Separation - sunSemiDiameter; (moon > sunSemiDiameter) != tt.total {
+			t.Errorf("%s: Moon's semi-diameter %.4f against the Sun's %v, want total %v", tt.name, moon, sunSemiDiameter, tt.total)
+		}
+	}
+
+	london := func(y int) []SolarEclipseCandidate {
+		return SolarEclipseCandidates(date(time.UTC, y, time.January, 1), date(time.UTC, y+1, time.January, 1), 51.5074, -0.1278)
+	}
+	if cs := london(2024); len(cs) != 0 {
+		t.Errorf("London candidates in 2024: %+v", cs)
+	}
+	for _, tt := range []struct {
+		year      int
+		closest   time.Time
+		magnitude float64
+	}{
+		{2025, time.Date(2025, time.March, 29, 11, 3, 0, 0, time.UTC), 0.40},
+		{2026, time.Date(2026, time.August, 12, 18, 13, 0, 0, time.UTC), 0.90},
+	} {
+		cs := london(tt.year)
+		if len(cs) != 1 {
+			t.Fatalf("London: %d candidates in %d, want 1", len(cs), tt.year)
+		}
+		m := cs[0].Overlap / (2 * sunSemiDiameter)
+		if !within(cs[0].Closest, tt.closest, 2*time.Minute) || math.Abs(m-tt.magnitude) > 0.03 {
+			t.Errorf("London %d: closest at %v, magnitude %.3f, want %v, %v", tt.year, cs[0].Closest, m, tt.closest, tt.magnitude)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package sun
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrOffGrid is returned by an ElevationProvider for a point outside its data.
+var ErrOffGrid = errors.New("sun: point is off the elevation grid")
+
+// ElevationProvider gives the height of the ground in metres above sea level
+// at a point, from a DEM, a web service or whatever else the caller has. It
+// should return ErrOffGrid for points it has no data for.
+type ElevationProvider interface {
+	ElevationAt(ctx context.Context, p Point) (float64, error)
+}
+
+// ElevationFunc adapts a function to an ElevationProvider.
+type ElevationFunc func(ctx context.Context, p Point) (float64, error)
+
+// ElevationAt calls f.
+func (f ElevationFunc) ElevationAt(ctx context.Context, p Point) (float64, error) {
+	return f(ctx, p)
+}
+
+// ElevationAt returns the ground height at p, interpolated between the
+// centres of the cells around it, so a DEM can serve as an ElevationProvider.
+func (d *DEM) ElevationAt(ctx context.Context, p Point) (float64, error) {
+	metres := toRadians(earthRadius)
+	north := (p.Latitude - d.Origin.Latitude) * metres
+	east := between(-180, 180, p.Longitude-d.Origin.Longitude) * metres * angleCos(d.Origin.Latitude)
+	z, ok := d.sample(-north/d.CellSize, east/d.CellSize)
+	if !ok {
+		return 0, ErrOffGrid
+	}
+	return z, nil
+}
+
+// WithElevation returns the profile with its Elevation looked up from e, for
+// the dip of the horizon, unless it already has one. It is meant for sites
+// looking out over the sea or a wide plain, where the height above sea level
+// is the height above the horizon; in broken country a skyline from
+// ElevationGrid and DEM.Horizon serves better.
+func (p Profile) WithElevation(ctx context.Context, e ElevationProvider) (Profile, error) {
+	if p.Elevation != 0 {
+		return p, nil
+	}
+	z, err := e.ElevationAt(ctx, p.Point)
+	if err != nil {
+		return p, err
+	}
+	p.Elevation = math.Max(z, 0)
+	return p, nil
+}
+
+// ElevationGrid samples e at the centres of rows by cols cells of the given
+// size in metres around centre, to give a DEM for the terrain functions such
+// as Horizon, TerrainDay and Shadow. The cells are fetched one at a time, so a
+// provider backed by a web service should cache or batch its requests. A rows,
+// cols or cellSize that is not positive is an error, as for NewDEM.
+func ElevationGrid(ctx context.Context, e ElevationProvider, centre Point, rows int, cols int, cellSize float64) (*DEM, error) {
+	metres := toRadians(earthRadius)
+	// columns are spaced at the latitude of the origin, as DEM.Cell takes them
+	north := centre.Latitude + float64(rows-1)/2*cellSize/metres
+	origin := Point{north, between(-180, 180, centre.Longitude-float64(cols-1)/2*cellSize/metres/angleCos(north))}
+	d, err := NewDEM(rows, cols, cellSize, origin)
+	if err != nil {
+		return nil, err
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			p := Point{
+				Latitude:  origin.Latitude - float64(r)*cellSize/metres,
+				Longitude: between(-180, 180, origin.Longitude+float64(c)*cellSize/metres/angleCos(origin.Latitude)),
+			}
+			z, err := e.ElevationAt(ctx, p)
+			if err != nil {
+				return nil, err
+			}
+			d.Elevation[r*cols+c] = z
+		}
+	}
+	return d, nil
+}
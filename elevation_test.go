@@ -0,0 +1,113 @@
+package sun
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// slopeDEM(t, 11, 5) rises 5 m a 10 m row to the north, so 25 m south of its
+// north west corner, at row 2.5, the ground is (10 - 2.5) × 5 = 37.5 m up.
+func TestDEMElevationAt(t *testing.T) {
+	d := slopeDEM(t, 11, 5)
+	metres := toRadians(earthRadius)
+	for _, tt := range []struct {
+		north, east float64
+		want        float64
+		err         error
+	}{
+		{0, 0, 50, nil},
+		{-25, 0, 37.5, nil},
+		{-25, 42.4, 37.5, nil},
+		{-100, 100, 0, nil},
+		{10, 0, 0, ErrOffGrid},
+		{0, -10, 0, ErrOffGrid},
+		{-101, 0, 0, ErrOffGrid},
+	} {
+		p := Point{46 + tt.north/metres, 8 + tt.east/metres/angleCos(46)}
+		got, err := d.ElevationAt(context.Background(), p)
+		if err != tt.err || math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("ElevationAt(%v m north, %v m east) = %v, %v, want %v, %v", tt.north, tt.east, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+// tiltedPlane is ground 1000 m up at centre that rises 0.1 m a metre to the
+// north
+func tiltedPlane(centre Point) ElevationFunc {
+	return func(ctx context.Context, p Point) (float64, error) {
+		return 1000 + (p.Latitude-centre.Latitude)*toRadians(earthRadius)*0.1, nil
+	}
+}
+
+// A 5 by 5 grid of 100 m cells reaches 200 m either side of its centre, so
+// its rows run from 1020 m in the north to 980 m in the south.
+func TestElevationGrid(t *testing.T) {
+	centre := Point{46.5, 7.9}
+	d, err := ElevationGrid(context.Background(), tiltedPlane(centre), centre, 5, 5, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r, want := range []float64{1020, 1010, 1000, 990, 980} {
+		for c := 0; c < 5; c++ {
+			if got := d.At(r, c); math.Abs(got-want) > 0.01 {
+				t.Errorf("cell %d, %d = %.3f, want %v", r, c, got, want)
+			}
+		}
+	}
+	if r, c, ok := d.Cell(centre); !ok || r != 2 || c != 2 {
+		t.Errorf("centre in cell %d, %d, %v, want 2, 2", r, c, ok)
+	}
+	if z, err := d.ElevationAt(context.Background(), centre); err != nil || math.Abs(z-1000) > 0.01 {
+		t.Errorf("ElevationAt(centre) = %v, %v, want 1000", z, err)
+	}
+
+	// the grid straddles the date line
+	if _, err := ElevationGrid(context.Background(), tiltedPlane(Point{0, 180}), Point{0, 180}, 3, 3, 1000); err != nil {
+		t.Errorf("grid over the date line: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ElevationGrid(ctx, tiltedPlane(centre), centre, 5, 5, 100); err != context.Canceled {
+		t.Errorf("cancelled: error %v", err)
+	}
+	if _, err := ElevationGrid(context.Background(), tiltedPlane(centre), centre, 0, 5, 100); err != errNonPositiveSize {
+		t.Errorf("no rows: error %v", err)
+	}
+	failed := errors.New("timeout")
+	down := ElevationFunc(func(context.Context, Point) (float64, error) { return 0, failed })
+	if _, err := ElevationGrid(context.Background(), down, centre, 5, 5, 100); err != failed {
+		t.Errorf("provider error %v, want %v", err, failed)
+	}
+}
+
+// A cliff top 100 m above the sea sees sunrise 2.5 minutes early, as in
+// TestProfileSunrise.
+func TestWithElevation(t *testing.T) {
+	cliff := ElevationFunc(func(context.Context, Point) (float64, error) { return 100, nil })
+	below := ElevationFunc(func(context.Context, Point) (float64, error) { return -28, nil })
+	place := Place{Name: "London", Point: Point{51.5074, -0.1278}, Location: time.UTC}
+
+	p, err := Profile{Place: place}.WithElevation(context.Background(), cliff)
+	if err != nil || p.Elevation != 100 {
+		t.Fatalf("WithElevation = %v, %v, want 100", p.Elevation, err)
+	}
+	d := date(time.UTC, 2024, time.June, 21)
+	rise, _ := Sunrise(d, place.Latitude, place.Longitude)
+	if r, _ := p.Sunrise(d); !within(r, rise.Add(-150*time.Second), 10*time.Second) {
+		t.Errorf("Sunrise from the cliff = %v, want %v", r, rise.Add(-150*time.Second))
+	}
+
+	if p, _ := (Profile{Place: place, Elevation: 20}).WithElevation(context.Background(), cliff); p.Elevation != 20 {
+		t.Errorf("an elevation already set was replaced by %v", p.Elevation)
+	}
+	if p, _ := (Profile{Place: place}).WithElevation(context.Background(), below); p.Elevation != 0 {
+		t.Errorf("below sea level gave %v, want 0", p.Elevation)
+	}
+	if _, err := (Profile{Place: place}).WithElevation(context.Background(), slopeDEM(t, 3, 1)); err != ErrOffGrid {
+		t.Errorf("off the DEM: error %v", err)
+	}
+}
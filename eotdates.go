@@ -0,0 +1,77 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// EquationOfTimeExtreme is a turning point of the equation of time.
+type EquationOfTimeExtreme struct {
+	Time  time.Time
+	Value time.Duration // the equation of time then, sundial less clock
+}
+
+// EquationOfTimeZeros returns the times in the given year, in the time zone
+// loc, when the equation of time is zero and a sundial agrees with local mean
+// time: about 15 April, 13 June, 1 September and 25 December.
+func EquationOfTimeZeros(year int, loc *time.Location) []time.Time {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+	eot := func(s float64) float64 {
+		return equationOfTime(timeToJD(start.Add(secondsToDuration(s))))
+	}
+	var zeros []time.Time
+	for _, s := range dailyRoots(eot, end.Sub(start).Seconds()) {
+		zeros = append(zeros, start.Add(secondsToDuration(s)))
+	}
+	return zeros
+}
+
+// EquationOfTimeExtremes returns the turning points of the equation of time in
+// the given year, in the time zone loc, in time order: minima of about -14
+// minutes in mid February and -6 in late July, and maxima of about +4 in mid
+// May and +16 in early November. The values are good to a second or so; the
+// times, where the curve is flattest, only to a few minutes.
+func EquationOfTimeExtremes(year int, loc *time.Location) []EquationOfTimeExtreme {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+	eot := func(s float64) float64 {
+		return equationOfTime(timeToJD(start.Add(secondsToDuration(s))))
+	}
+	// the rate over an hour finds the day of each turning point; it is too
+	// small near the turning point to place it closer than an hour or so
+	rate := func(s float64) float64 {
+		return eot(s+1800) - eot(s-1800)
+	}
+	var es []EquationOfTimeExtreme
+	for _, s := range dailyRoots(rate, end.Sub(start).Seconds()) {
+		// falling before a minimum, rising before a maximum
+		sign := 1.0
+		if rate(s-86400) < 0 {
+			sign = -1
+		}
+		// the equation of time is so flat at its turning points that a
+		// minute is as close as the model can tell them
+		s = goldenMax(func(x float64) float64 { return sign * eot(x) }, s-86400, s+86400, 60)
+		at := start.Add(secondsToDuration(s))
+		es = append(es, EquationOfTimeExtreme{at, secondsToDuration(eot(s))})
+	}
+	return es
+}
+
+// dailyRoots returns the roots of f, a function of seconds, in [0, end),
+// sampling it once a day, which suits slowly changing quantities such as the
+// equation of time
+func dailyRoots(f func(float64) float64, end float64) []float64 {
+	var roots []float64
+	x0, f0 := 0.0, f(0)
+	for x0 < end {
+		x1 := math.Min(x0+86400, end)
+		f1 := f(x1)
+		if f0*f1 < 0 {
+			roots = append(roots, brent(f, x0, x1, f0, f1, 1))
+		}
+		x0, f0 = x1, f1
+	}
+	return roots
+}
@@ -0,0 +1,56 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// As in TestEquationOfTime, the equation of time passes through nought about
+// 15 April, 13 June, 1 September and 25 December.
+func TestEquationOfTimeZeros(t *testing.T) {
+	zeros := EquationOfTimeZeros(2024, time.UTC)
+	want := []time.Time{
+		time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.June, 13, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.September, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC),
+	}
+	if len(zeros) != len(want) {
+		t.Fatalf("%d zeros, want %d", len(zeros), len(want))
+	}
+	for i, z := range zeros {
+		if !within(z, want[i], 24*time.Hour) || EquationOfTime(z).Abs() > time.Second {
+			t.Errorf("zero %d at %v, equation of time %v, want about %v", i, z, EquationOfTime(z), want[i].Format("2 January"))
+		}
+	}
+	sydney := location(t, "Australia/Sydney")
+	if z := EquationOfTimeZeros(2024, sydney); len(z) != 4 || z[0].Location() != sydney || !within(z[0], zeros[0], time.Second) {
+		t.Errorf("zeros in Sydney %v", z)
+	}
+}
+
+// The turning points of 2024: -14m12s on 11 February, +3m39s on 14 May,
+// -6m33s on 26 July and +16m26s on 3 November.
+func TestEquationOfTimeExtremes(t *testing.T) {
+	want := []EquationOfTimeExtreme{
+		{time.Date(2024, time.February, 11, 12, 0, 0, 0, time.UTC), -(14*time.Minute + 12*time.Second)},
+		{time.Date(2024, time.May, 14, 12, 0, 0, 0, time.UTC), 3*time.Minute + 39*time.Second},
+		{time.Date(2024, time.July, 26, 12, 0, 0, 0, time.UTC), -(6*time.Minute + 33*time.Second)},
+		{time.Date(2024, time.November, 3, 12, 0, 0, 0, time.UTC), 16*time.Minute + 26*time.Second},
+	}
+	es := EquationOfTimeExtremes(2024, time.UTC)
+	if len(es) != len(want) {
+		t.Fatalf("%d extremes, want %d", len(es), len(want))
+	}
+	for i, e := range es {
+		if !within(e.Time, want[i].Time, 24*time.Hour) || (e.Value-want[i].Value).Abs() > 2*time.Second {
+			t.Errorf("extreme %d = %v at %v, want %v about %v", i, e.Value, e.Time, want[i].Value, want[i].Time.Format("2 January"))
+		}
+		// a day either side is no further from nought
+		for _, d := range []time.Duration{-24 * time.Hour, 24 * time.Hour} {
+			if v := EquationOfTime(e.Time.Add(d)); v.Abs() > e.Value.Abs() {
+				t.Errorf("extreme %d: %v a day off is %v, beyond %v", i, d, v, e.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package sun
+
+import "time"
+
+// Coords is the geocentric position of the Sun.
+type Coords struct {
+	EclipticLongitude float64 // degrees, apparent, in [0, 360)
+	EclipticLatitude  float64 // degrees
+	RightAscension    float64 // degrees, in [0, 360)
+	Declination       float64 // degrees
+	Distance          float64 // astronomical units
+}
+
+// Ephemeris is a source of solar positions. The package functions such as
+// Altitude use LowPrecision; the ...With functions accept any Ephemeris, so a
+// more accurate model or a reader for published ephemeris files can be plugged
+// in. In increasing order of accuracy the package provides LowPrecision,
+// Meeus, VSOP87, SPA and JPL.
+type Ephemeris interface {
+	Sun(t time.Time) Coords
+}
+
+// LowPrecision is the model used throughout the package, from the Astronomical
+// Almanac's low precision formulae. It is good to about 0.01 degree between
+// 1950 and 2050 and degrades slowly outside that.
+type LowPrecision struct{}
+
+// Sun returns the position of the Sun at t.
+func (LowPrecision) Sun(t time.Time) Coords {
+	jd := timeToJD(t)
+	ecLong, rAsc, dec := getSunCoords(jd)
+	g := between(0, 360, 357.528) + 0.9856003*getJdn(jd)
+	return Coords{
+		EclipticLongitude: between(0, 360, ecLong),
+		RightAscension:    between(0, 360, rAsc),
+		Declination:       dec,
+		Distance:          1.00014 - 0.01671*angleCos(g) - 0.00014*angleCos(2*g),
+	}
+}
+
+// Meeus is the solar model of chapter 25 of Meeus, Astronomical Algorithms,
+// which adds the secular terms, nutation and aberration missing from
+// LowPrecision. It is good to about 0.003 degree over several centuries
+// either side of 2000.
+type Meeus struct{}
+
+// Sun returns the position of the Sun at t.
+func (Meeus) Sun(t time.Time) Coords {
+	T := getJdn(timeToJD(t)) / 36525
+	l0 := 280.46646 + 36000.76983*T + 0.0003032*T*T
+	m := 357.52911 + 35999.05029*T - 0.0001537*T*T
+	e := 0.016708634 - 0.000042037*T - 0.0000001267*T*T
+	c := (1.914602-0.004817*T-0.000014*T*T)*angleSin(m) +
+		(0.019993-0.000101*T)*angleSin(2*m) +
+		0.000289*angleSin(3*m)
+	trueLong := l0 + c
+	v := m + c
+	r := 1.000001018 * (1 - e*e) / (1 + e*angleCos(v))
+
+	omega := 125.04 - 1934.136*T
+	lambda := trueLong - 0.00569 - 0.00478*angleSin(omega)
+	eps := meanObliquity(T) + 0.00256*angleCos(omega)
+
+	return Coords{
+		EclipticLongitude: between(0, 360, lambda),
+		RightAscension:    between(0, 360, angleAtan2(angleCos(eps)*angleSin(lambda), angleCos(lambda))),
+		Declination:       angleAsin(angleSin(eps) * angleSin(lambda)),
+		Distance:          r,
+	}
+}
+
+// AltitudeWith returns the altitude of the Sun like Altitude, using the given
+// ephemeris.
+func AltitudeWith(eph Ephemeris, t time.Time, latitude float64, longitude float64) float64 {
+	c := eph.Sun(t)
+	ha := getHourAngle(timeToJD(t), longitude, c.RightAscension)
+	return angleAsin(angleSin(latitude)*angleSin(c.Declination) + angleCos(latitude)*angleCos(c.Declination)*angleCos(ha))
+}
+
+// AzimuthWith returns the azimuth of the Sun like Azimuth, using the given
+// ephemeris.
+func AzimuthWith(eph Ephemeris, t time.Time, latitude float64, longitude float64) float64 {
+	c := eph.Sun(t)
+	ha := getHourAngle(timeToJD(t), longitude, c.RightAscension)
+	return getAzimuth(latitude, c.Declination, ha)
+}
@@ -0,0 +1,16 @@
+package sun
+
+import "errors"
+
+var (
+	errNonPositiveStep  = errors.New("sun: step must be positive")
+	errNonPositiveSpeed = errors.New("sun: speed must be positive")
+	errNonPositiveSize  = errors.New("sun: size must be positive")
+	errTooFewWaypoints  = errors.New("sun: a route needs at least two waypoints")
+	errTooFewSights     = errors.New("sun: a fix needs at least two sights")
+	errNoFix            = errors.New("sun: sights do not give a fix")
+	errNoSites          = errors.New("sun: no sites given")
+	errEmptyHorizon     = errors.New("sun: horizon has no elevations")
+	errEmptySpan        = errors.New("sun: end must be after start")
+	errWriterClosed     = errors.New("sun: write after the end of the file")
+)
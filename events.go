@@ -0,0 +1,99 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// SunriseAltitude is the geometric altitude of the centre of the Sun, in
+// degrees, at the moment its upper limb appears on the horizon. It allows for
+// the mean refraction at the horizon and the semi-diameter of the Sun.
+const SunriseAltitude float64 = -0.833
+
+// Sunrise returns the time of sunrise on the date of t, as seen from the given
+// location. The date is taken from t in its own time zone, and the returned
+// time is in the same time zone.
+//
+// ok is false when the Sun does not rise or set that day, as happens inside
+// the polar circles.
+func Sunrise(t time.Time, latitude float64, longitude float64) (sunrise time.Time, ok bool) {
+	return crossing(t, latitude, longitude, SunriseAltitude, true, defaultTolerance)
+}
+
+// Sunrise returns the time of sunrise as the package function Sunrise does,
+// to the tolerance of o.
+func (o EventOptions) Sunrise(t time.Time, latitude float64, longitude float64) (sunrise time.Time, ok bool) {
+	return crossing(t, latitude, longitude, SunriseAltitude, true, o.tolerance())
+}
+
+// Sunset returns the time of sunset on the date of t, as seen from the given
+// location. The date is taken from t in its own time zone, and the returned
+// time is in the same time zone.
+//
+// ok is false when the Sun does not rise or set that day, as happens inside
+// the polar circles.
+func Sunset(t time.Time, latitude float64, longitude float64) (sunset time.Time, ok bool) {
+	return crossing(t, latitude, longitude, SunriseAltitude, false, defaultTolerance)
+}
+
+// Sunset returns the time of sunset as the package function Sunset does, to
+// the tolerance of o.
+func (o EventOptions) Sunset(t time.Time, latitude float64, longitude float64) (sunset time.Time, ok bool) {
+	return crossing(t, latitude, longitude, SunriseAltitude, false, o.tolerance())
+}
+
+// DayLength returns the time between sunrise and sunset on the date of t.
+//
+// It is 24 hours on a day when the Sun never sets and zero on a day when it
+// never rises.
+func DayLength(t time.Time, latitude float64, longitude float64) time.Duration {
+	rise, ok1 := Sunrise(t, latitude, longitude)
+	set, ok2 := Sunset(t, latitude, longitude)
+	if ok1 && ok2 {
+		return set.Sub(rise)
+	}
+	if Culminate(t, latitude, longitude).Altitude > SunriseAltitude {
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// crossing returns the time the Sun passes through altitude h0 either before
+// (rising) or after the culmination on the date of t.
+//
+// The altitude climbs steadily from the lower culmination, taken as 12 hours
+// from noon, to noon and falls after it, so each half day holds at most one
+// crossing. The first estimate comes from the hour angle at which a Sun fixed
+// at the noon declination would reach h0; a bracket of ten minutes either side
+// of it is tried first, and the whole half day if that misses, before the
+// time is refined with brent to within tol seconds.
+func crossing(t time.Time, latitude float64, longitude float64, h0 float64, rising bool, tol float64) (time.Time, bool) {
+	c := culminate(t, latitude, longitude, tol)
+	f := func(s float64) float64 {
+		return Altitude(c.Time.Add(secondsToDuration(s)), latitude, longitude) - h0
+	}
+	far := 12 * 3600.0
+	if rising {
+		far = -far
+	}
+	fNoon, fFar := c.Altitude-h0, f(far)
+	if fNoon <= 0 || fFar >= 0 {
+		return time.Time{}, false
+	}
+	a, b, fa, fb := far, 0.0, fFar, fNoon
+
+	_, _, dec := getSunCoords(timeToJD(c.Time))
+	cosH := (angleSin(h0) - angleSin(latitude)*angleSin(dec)) / (angleCos(latitude) * angleCos(dec))
+	if cosH > -1 && cosH < 1 {
+		est := angleAcos(cosH) * 240
+		if rising {
+			est = -est
+		}
+		lo, hi := est-600, est+600
+		if flo, fhi := f(lo), f(hi); flo*fhi < 0 && math.Abs(lo) < math.Abs(far) && math.Abs(hi) < math.Abs(far) {
+			a, b, fa, fb = lo, hi, flo, fhi
+		}
+	}
+	s := brent(f, a, b, fa, fb, tol)
+	return c.Time.Add(secondsToDuration(s)).In(t.Location()), true
+}
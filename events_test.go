@@ -0,0 +1,93 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// Sunrise and sunset to the minute from the timeanddate.com almanac, which
+// follows NOAA; the package and NOAA round differently and differ by up to a
+// minute in the refraction near the horizon, so a minute either way is
+// allowed. The Reykjavik sunset falls after midnight, with the Sun so close to
+// skimming the horizon that it is allowed two.
+func TestSunriseSunset(t *testing.T) {
+	for _, tt := range []struct {
+		zone     string
+		lat, lon float64
+		y        int
+		m        time.Month
+		d        int
+		rise     [2]int
+		set      [2]int
+		setDay   int
+		tol      time.Duration
+	}{
+		{"Europe/London", 51.5074, -0.1278, 2024, time.June, 21, [2]int{4, 43}, [2]int{21, 21}, 0, time.Minute},
+		{"Europe/London", 51.5074, -0.1278, 2024, time.December, 21, [2]int{8, 3}, [2]int{15, 53}, 0, time.Minute},
+		{"America/New_York", 40.7128, -74.0060, 2024, time.June, 20, [2]int{5, 25}, [2]int{20, 31}, 0, time.Minute},
+		{"Australia/Sydney", -33.8688, 151.2093, 2024, time.December, 21, [2]int{5, 41}, [2]int{20, 5}, 0, time.Minute},
+		{"Atlantic/Reykjavik", 64.1466, -21.9426, 2024, time.June, 21, [2]int{2, 55}, [2]int{0, 3}, 1, 2 * time.Minute},
+	} {
+		loc := location(t, tt.zone)
+		d := date(loc, tt.y, tt.m, tt.d)
+		rise, ok := Sunrise(d, tt.lat, tt.lon)
+		if want := clock(d, tt.rise[0], tt.rise[1]); !ok || !within(rise, want, tt.tol) {
+			t.Errorf("Sunrise(%s, %v, %v) = %v, %v, want %v", d.Format("2006-01-02 MST"), tt.lat, tt.lon, rise, ok, want)
+		}
+		set, ok := Sunset(d, tt.lat, tt.lon)
+		if want := clock(d.AddDate(0, 0, tt.setDay), tt.set[0], tt.set[1]); !ok || !within(set, want, tt.tol) {
+			t.Errorf("Sunset(%s, %v, %v) = %v, %v, want %v", d.Format("2006-01-02 MST"), tt.lat, tt.lon, set, ok, want)
+		}
+		if rise.Location() != loc || set.Location() != loc {
+			t.Errorf("events on %s are in %v and %v, want %v", d.Format("2006-01-02"), rise.Location(), set.Location(), loc)
+		}
+		if got := DayLength(d, tt.lat, tt.lon); got != set.Sub(rise) {
+			t.Errorf("DayLength(%s) = %v, want %v", d.Format("2006-01-02"), got, set.Sub(rise))
+		}
+	}
+}
+
+func TestSunriseAltitude(t *testing.T) {
+	d := date(time.UTC, 2024, time.March, 20)
+	for _, lat := range []float64{-50, 0, 30, 60} {
+		for _, f := range []func(time.Time, float64, float64) (time.Time, bool){Sunrise, Sunset} {
+			at, ok := f(d, lat, 10)
+			if !ok {
+				t.Fatalf("no event at latitude %v", lat)
+			}
+			if a := Altitude(at, lat, 10); a < SunriseAltitude-0.001 || a > SunriseAltitude+0.001 {
+				t.Errorf("altitude at the event at latitude %v = %v, want %v", lat, a, SunriseAltitude)
+			}
+		}
+	}
+}
+
+// Tromsø, at 69.65 N, has the midnight sun from late May to late July and
+// polar night from late November to mid January.
+func TestPolarDays(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Time
+		want time.Duration
+	}{
+		{date(time.UTC, 2024, time.June, 21), 24 * time.Hour},
+		{date(time.UTC, 2024, time.December, 21), 0},
+	} {
+		if _, ok := Sunrise(tt.d, 69.6492, 18.9553); ok {
+			t.Errorf("Sunrise on %s: ok, want no sunrise", tt.d.Format("2006-01-02"))
+		}
+		if _, ok := Sunset(tt.d, 69.6492, 18.9553); ok {
+			t.Errorf("Sunset on %s: ok, want no sunset", tt.d.Format("2006-01-02"))
+		}
+		if got := DayLength(tt.d, 69.6492, 18.9553); got != tt.want {
+			t.Errorf("DayLength on %s = %v, want %v", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestEquatorialDayLength(t *testing.T) {
+	// refraction and the semi-diameter add about 7 minutes to the 12 hours
+	got := DayLength(date(time.UTC, 2024, time.March, 20), 0, 0)
+	if got < 12*time.Hour+6*time.Minute || got > 12*time.Hour+9*time.Minute {
+		t.Errorf("DayLength at the equator = %v, want about 12h7m", got)
+	}
+}
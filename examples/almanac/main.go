@@ -0,0 +1,62 @@
+// Command almanac writes a year of sunrise, transit and sunset times for a
+// location as CSV, in the location's time zone.
+//
+// Usage:
+//
+//	almanac -lat 40.71 -lon -74.01 -tz America/New_York -year 2024 > almanac.csv
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+func main() {
+	lat := flag.Float64("lat", 51.4779, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", -0.0015, "longitude in decimal degrees, east positive")
+	tz := flag.String("tz", "UTC", "IANA time zone of the location")
+	year := flag.Int("year", time.Now().Year(), "year")
+	flag.Parse()
+	log.SetFlags(0)
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeAlmanac(os.Stdout, *lat, *lon, loc, *year); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeAlmanac writes the almanac for year at lat, lon as CSV, with times in
+// loc
+func writeAlmanac(out io.Writer, lat float64, lon float64, loc *time.Location, year int) error {
+	clock := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("15:04:05")
+	}
+	w := csv.NewWriter(out)
+	w.Write([]string{"date", "sunrise", "transit", "sunset", "transit_altitude", "day_length_hours"})
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		e := sun.DayEvents(d, lat, lon)
+		w.Write([]string{
+			d.Format("2006-01-02"),
+			clock(e.Sunrise),
+			clock(e.Noon.Time),
+			clock(e.Sunset),
+			strconv.FormatFloat(e.Noon.Altitude, 'f', 2, 64),
+			strconv.FormatFloat(e.Length.Hours(), 'f', 3, 64),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+// New York sunrise and sunset from the USNO: 05:29 and 20:31 EDT on 1 July
+// 2024, and 07:17 and 16:32 EST on 21 December.
+func TestWriteAlmanac(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	var b bytes.Buffer
+	if err := writeAlmanac(&b, 40.7128, -74.0060, loc, 2024); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 367 || rows[0][0] != "date" {
+		t.Fatalf("%d rows, want a header and 366 days", len(rows))
+	}
+	byDate := make(map[string][]string)
+	for _, r := range rows[1:] {
+		byDate[r[0]] = r
+	}
+	for _, tt := range []struct {
+		date            string
+		sunrise, sunset string
+	}{
+		{"2024-07-01", "05:29", "20:31"},
+		{"2024-12-21", "07:17", "16:32"},
+	} {
+		r := byDate[tt.date]
+		if r == nil {
+			t.Fatalf("no row for %s", tt.date)
+		}
+		for i, want := range []string{tt.sunrise, tt.sunset} {
+			got, err1 := time.Parse("15:04:05", r[1+2*i])
+			w, err2 := time.Parse("15:04", want)
+			if err1 != nil || err2 != nil || got.Sub(w) < -time.Minute || got.Sub(w) > time.Minute {
+				t.Errorf("%s column %d = %s, want %s", tt.date, 1+2*i, r[1+2*i], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Command paneltilt finds the fixed tilt of a solar panel facing the equator
+// that collects the most clear sky irradiation over a year, and what other
+// tilts give up against it.
+//
+// Usage:
+//
+//	paneltilt -lat 51.48 -lon 0
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+func main() {
+	lat := flag.Float64("lat", 51.4779, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", -0.0015, "longitude in decimal degrees, east positive")
+	year := flag.Int("year", time.Now().Year(), "year")
+	flag.Parse()
+
+	facing := 180.0
+	if *lat < 0 {
+		facing = 0
+	}
+	yields, bestTilt := annualYields(*lat, *lon, *year, facing)
+	best := yields[int(bestTilt)]
+	fmt.Printf("Best tilt %.0f° facing %.0f°: %.0f kWh/m² a year under clear skies\n", bestTilt, facing, best)
+	for tilt := 0; tilt <= 90; tilt += 15 {
+		fmt.Printf("  %2d°  %5.0f kWh/m²  %5.1f%% of best\n", tilt, yields[tilt], 100*yields[tilt]/best)
+	}
+}
+
+// annualYields returns the clear sky irradiation over year, in kilowatt hours
+// a square metre, of a panel facing the azimuth facing at each tilt from 0 to
+// 90 degrees, and the tilt that collects the most
+func annualYields(lat float64, lon float64, year int, facing float64) ([]float64, float64) {
+	// one day a week, every half hour, is plenty to rank the tilts
+	var times []time.Time
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 7) {
+		for m := 0; m < 24*60; m += 30 {
+			times = append(times, d.Add(time.Duration(m)*time.Minute))
+		}
+	}
+	annual := func(tilt float64) float64 {
+		var sum float64
+		for _, t := range times {
+			sum += sun.PlaneOfArray(t, lat, lon, tilt, facing)
+		}
+		return sum * 0.5 * 7 / 1000
+	}
+
+	best, bestTilt := 0.0, 0.0
+	yields := make([]float64, 91)
+	for tilt := 0; tilt <= 90; tilt++ {
+		yields[tilt] = annual(float64(tilt))
+		if yields[tilt] > best {
+			best, bestTilt = yields[tilt], float64(tilt)
+		}
+	}
+	return yields, bestTilt
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// The best fixed tilt is close to 0.76 × latitude + 3.1 degrees, the fit of
+// Jacobson and Jadhav (2018) to clear sky irradiation.
+func TestAnnualYields(t *testing.T) {
+	for _, tt := range []struct {
+		lat, lon, facing float64
+	}{
+		{0, 0, 180},
+		{23.5, 0, 180},
+		{35, 0, 180},
+		{51.4779, -0.0015, 180},
+		{-33.8688, 151.2093, 0},
+	} {
+		yields, best := annualYields(tt.lat, tt.lon, 2024, tt.facing)
+		if len(yields) != 91 {
+			t.Fatalf("%d yields, want 91", len(yields))
+		}
+		if want := 0.76*math.Abs(tt.lat) + 3.1; math.Abs(best-want) > 3.5 {
+			t.Errorf("best tilt at %v = %v, want %.1f", tt.lat, best, want)
+		}
+		for tilt, y := range yields {
+			if y > yields[int(best)] {
+				t.Errorf("tilt %d yields %v, more than the best", tilt, y)
+			}
+		}
+	}
+}
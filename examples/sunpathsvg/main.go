@@ -0,0 +1,80 @@
+// Command sunpathsvg writes an SVG chart of the Sun's path across the sky on
+// the solstices and an equinox, altitude against azimuth, to standard output.
+//
+// Usage:
+//
+//	sunpathsvg -lat 51.48 -lon 0 -year 2024 > sunpath.svg
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+const (
+	width, height = 720, 300
+	margin        = 30
+)
+
+func main() {
+	lat := flag.Float64("lat", 51.4779, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", -0.0015, "longitude in decimal degrees, east positive")
+	year := flag.Int("year", time.Now().Year(), "year")
+	flag.Parse()
+
+	writeSunPath(os.Stdout, *lat, *lon, *year)
+}
+
+// writeSunPath writes the chart for year at lat, lon to w
+func writeSunPath(w io.Writer, lat float64, lon float64, year int) {
+	// pixel coordinates for an azimuth and altitude
+	x := func(az float64) float64 { return margin + az/360*(width-2*margin) }
+	y := func(alt float64) float64 { return height - margin - alt/90*(height-2*margin) }
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", width, height)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="#0c0e28"/>`+"\n")
+	for az := 0; az <= 360; az += 45 {
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#334"/>`+"\n", x(float64(az)), y(0), x(float64(az)), y(90))
+		fmt.Fprintf(w, `<text x="%.1f" y="%d" fill="#aab" text-anchor="middle">%d°</text>`+"\n", x(float64(az)), height-10, az)
+	}
+	for alt := 0; alt <= 90; alt += 30 {
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#334"/>`+"\n", x(0), y(float64(alt)), x(360), y(float64(alt)))
+	}
+
+	days := []struct {
+		longitude float64
+		colour    string
+	}{{90, "#f5962a"}, {0, "#9ad"}, {270, "#5af"}}
+	for _, d := range days {
+		t := sun.SolarLongitudeCrossing(d.longitude, time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		var points []string
+		flush := func() {
+			if len(points) > 1 {
+				fmt.Fprintf(w, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n", d.colour, strings.Join(points, " "))
+			}
+			points = points[:0]
+		}
+		prev := -1.0
+		for m := 0; m <= 24*60; m += 5 {
+			at := day.Add(time.Duration(m) * time.Minute)
+			alt, az := sun.Altitude(at, lat, lon), sun.Azimuth(at, lat, lon)
+			// break the line below the horizon and where it wraps past north
+			if alt < 0 || (prev >= 0 && az < prev-180) {
+				flush()
+			}
+			if alt >= 0 {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(az), y(alt)))
+			}
+			prev = az
+		}
+		flush()
+	}
+	fmt.Fprintln(w, "</svg>")
+}
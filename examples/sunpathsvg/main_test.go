@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// At Greenwich the Sun culminates at 90 - 51.48 + 23.44 = 61.96 degrees at
+// the June solstice and 15.08 at the December solstice, which the chart puts
+// 240 pixels to 90 degrees up from y = 270.
+func TestWriteSunPath(t *testing.T) {
+	var b bytes.Buffer
+	writeSunPath(&b, 51.4779, -0.0015, 2024)
+	svg := b.String()
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Fatalf("not an SVG document:\n%s", svg)
+	}
+	lines := regexp.MustCompile(`stroke="(#[0-9a-f]+)" stroke-width="2" points="([^"]+)"`).FindAllStringSubmatch(svg, -1)
+	if len(lines) != 3 {
+		t.Fatalf("%d paths, want 3", len(lines))
+	}
+	for _, tt := range []struct {
+		colour string
+		top    float64
+	}{
+		{"#f5962a", 270 - 61.96/90*240},
+		{"#5af", 270 - 15.08/90*240},
+	} {
+		top := math.Inf(1)
+		for _, l := range lines {
+			if l[1] != tt.colour {
+				continue
+			}
+			for _, p := range strings.Fields(l[2]) {
+				y, err := strconv.ParseFloat(p[strings.Index(p, ",")+1:], 64)
+				if err != nil {
+					t.Fatal(err)
+				}
+				top = math.Min(top, y)
+			}
+		}
+		if math.Abs(top-tt.top) > 0.5 {
+			t.Errorf("path %s peaks at y = %.1f, want %.1f", tt.colour, top, tt.top)
+		}
+	}
+}
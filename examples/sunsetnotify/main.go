@@ -0,0 +1,48 @@
+// Command sunsetnotify prints a reminder a set time before each sunset, for
+// example to bring the washing in or head out for a photograph.
+//
+// Usage:
+//
+//	sunsetnotify -lat 51.48 -lon 0 -before 20m
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+func main() {
+	lat := flag.Float64("lat", 51.4779, "latitude in decimal degrees, north positive")
+	lon := flag.Float64("lon", -0.0015, "longitude in decimal degrees, east positive")
+	before := flag.Duration("before", 15*time.Minute, "how long before sunset to remind")
+	flag.Parse()
+
+	for {
+		now := time.Now()
+		set, ok := nextSunset(now, *lat, *lon)
+		if !ok {
+			fmt.Println("The Sun does not set in the next year here.")
+			return
+		}
+		at := set.Add(-*before)
+		fmt.Printf("Next sunset %s; reminder at %s\n", set.Format("Mon 2 Jan 15:04"), at.Format("15:04"))
+		time.Sleep(time.Until(at))
+		fmt.Printf("Sunset in %s, at %s\n", time.Until(set).Round(time.Minute), set.Format("15:04"))
+		time.Sleep(time.Until(set) + time.Minute)
+	}
+}
+
+// nextSunset returns the first sunset after now, looking up to a year ahead
+// to get through polar day
+func nextSunset(now time.Time, lat float64, lon float64) (time.Time, bool) {
+	for d := 0; d < 366; d++ {
+		set, ok := sun.Sunset(now.AddDate(0, 0, d), lat, lon)
+		if ok && set.After(now) {
+			return set, true
+		}
+	}
+	return time.Time{}, false
+}
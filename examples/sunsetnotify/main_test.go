@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextSunset(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		now      time.Time
+		lat, lon float64
+		want     time.Time
+	}{
+		// London sets at 20:21 UT, 21:21 BST, at midsummer
+		{"London", time.Date(2024, time.June, 21, 12, 0, 0, 0, time.UTC), 51.4779, -0.0015, time.Date(2024, time.June, 21, 20, 21, 0, 0, time.UTC)},
+		{"London after sunset", time.Date(2024, time.June, 21, 21, 0, 0, 0, time.UTC), 51.4779, -0.0015, time.Date(2024, time.June, 22, 20, 21, 9, 0, time.UTC)},
+		// the midnight sun at Tromsø ends when the declination falls to
+		// 90 - 69.65 - 0.83 = 19.52 degrees, on 25 July
+		{"Tromsø", time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), 69.6492, 18.9553, time.Date(2024, time.July, 25, 22, 23, 20, 0, time.UTC)},
+		// at the pole the Sun sets when the declination reaches -0.83 degree,
+		// two days after the equinox of 22 September at 12:44
+		{"North Pole", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 90, 0, time.Date(2024, time.September, 24, 16, 0, 24, 0, time.UTC)},
+	} {
+		got, ok := nextSunset(tt.now, tt.lat, tt.lon)
+		if d := got.Sub(tt.want); !ok || d < -time.Second || d > time.Second {
+			t.Errorf("%s: nextSunset = %v, %v, want %v", tt.name, got, ok, tt.want)
+		}
+	}
+}
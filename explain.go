@@ -0,0 +1,81 @@
+package sun
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Step is one stage of a worked calculation.
+type Step struct {
+	Symbol  string
+	Name    string
+	Formula string
+	Value   float64
+	Unit    string
+}
+
+// Explanation is the worked calculation of the position of the Sun, step by
+// step, as done by Altitude and Azimuth.
+type Explanation struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Steps     []Step
+}
+
+// Explain works through the calculation of the altitude and azimuth of the Sun
+// at time t and the given location, returning every intermediate value with its
+// symbol, name and formula. The values are those Altitude and Azimuth use, so
+// the last two steps match them exactly.
+//
+// It is meant for teaching and for checking the package against the worked
+// examples in textbooks.
+func Explain(t time.Time, latitude float64, longitude float64) Explanation {
+	jd := timeToJD(t)
+	n := getJdn(jd)
+	l := getMeanLong(n)
+	g := between(0, 360, 357.528) + 0.9856003*n
+	ecLong, rAsc, dec := getSunCoords(jd)
+	jdm := getLastJdMidnight(jd)
+	ut := getUtHours(jd, jdm)
+	gst := getGst(jd)
+	ha := getHourAngle(jd, longitude, rAsc)
+	alt := angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+
+	return Explanation{
+		Time:      t.UTC(),
+		Latitude:  latitude,
+		Longitude: longitude,
+		Steps: []Step{
+			{"JD", "Julian day", "from the UTC calendar date and time (Meeus 7.1)", jd, "d"},
+			{"n", "Days since J2000.0", "JD − 2451545.0", n, "d"},
+			{"L", "Mean longitude", "280.460 + 0.9856474·n", between(0, 360, l), "°"},
+			{"g", "Mean anomaly", "357.528 + 0.9856003·n", between(0, 360, g), "°"},
+			{"λ", "Ecliptic longitude", "L + 1.915·sin g + 0.020·sin 2g", between(0, 360, ecLong), "°"},
+			{"ε", "Obliquity of the ecliptic", "fixed", axialTilt, "°"},
+			{"α", "Right ascension", "atan(cos ε·tan λ), in the quadrant of λ", between(0, 360, rAsc), "°"},
+			{"δ", "Declination", "asin(sin ε·sin λ)", dec, "°"},
+			{"E", "Equation of time", "4·(L − α)", 4 * between(-180, 180, l-rAsc), "min"},
+			{"n₀", "Days since J2000.0 at 0h UT", "JD at the last midnight − 2451545.0", getJdn(jdm), "d"},
+			{"UT", "Universal time", "hours since 0h UT", ut, "h"},
+			{"θ₀", "Greenwich sidereal time", "15·(6.697374558 + 0.06570982441908·n₀ + 1.00273790935·UT)", gst, "°"},
+			{"H", "Local hour angle", "θ₀ + longitude − α", between(-180, 180, ha), "°"},
+			{"h", "Altitude", "asin(sin φ·sin δ + cos φ·cos δ·cos H)", alt, "°"},
+			{"A", "Azimuth", "atan2(sin H, cos H·sin φ − tan δ·cos φ) + 180°", getAzimuth(latitude, dec, ha), "°"},
+		},
+	}
+}
+
+// String returns the explanation as a table, one step per line.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sun position at %s for φ = %g°, longitude = %g°\n", e.Time.Format(time.RFC3339), e.Latitude, e.Longitude)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, s := range e.Steps {
+		fmt.Fprintf(w, "%s\t%s\t%s\t= %.6f %s\n", s.Symbol, s.Name, s.Formula, s.Value, s.Unit)
+	}
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package sun
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// At J2000.0 the low precision formulae of the Astronomical Almanac work out
+// by hand as L = 280.460, g = 357.528, λ = 280.460 + 1.915 sin g + 0.020
+// sin 2g = 280.3757 and θ₀ = 15·18.697374558 = 280.4606.
+func TestExplain(t *testing.T) {
+	at := time.Date(2000, 1, 1, 13, 0, 0, 0, time.FixedZone("X", 3600))
+	e := Explain(at, 51.5, -0.1)
+	if !e.Time.Equal(at) || e.Time.Location() != time.UTC {
+		t.Errorf("Time = %v, want %v in UTC", e.Time, at)
+	}
+	steps := map[string]float64{}
+	for _, s := range e.Steps {
+		steps[s.Symbol] = s.Value
+	}
+	if len(steps) != 15 {
+		t.Errorf("%d steps, want 15", len(steps))
+	}
+	for _, tt := range []struct {
+		symbol string
+		want   float64
+		tol    float64
+	}{
+		{"JD", 2451545, 0},
+		{"n", 0, 0},
+		{"L", 280.460, 1e-9},
+		{"g", 357.528, 1e-9},
+		{"λ", 280.3757, 0.0001},
+		{"ε", axialTilt, 0},
+		{"δ", -23.03, 0.01},
+		{"E", -3.3, 0.05},
+		{"n₀", -0.5, 0},
+		{"UT", 12, 0},
+		{"θ₀", 280.4606, 0.0001},
+	} {
+		if got := steps[tt.symbol]; math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("%s = %.6f, want %.6f", tt.symbol, got, tt.want)
+		}
+	}
+	if got, want := steps["h"], Altitude(at, 51.5, -0.1); got != want {
+		t.Errorf("h = %v, want Altitude %v", got, want)
+	}
+	if got, want := steps["A"], Azimuth(at, 51.5, -0.1); got != want {
+		t.Errorf("A = %v, want Azimuth %v", got, want)
+	}
+
+	s := e.String()
+	if !strings.HasPrefix(s, "Sun position at 2000-01-01T12:00:00Z for φ = 51.5°, longitude = -0.1°\n") {
+		t.Errorf("String starts %q", strings.SplitN(s, "\n", 2)[0])
+	}
+	if n := strings.Count(s, "\n"); n != 16 {
+		t.Errorf("String has %d lines, want 16", n)
+	}
+	if !strings.Contains(s, "Declination") || !strings.Contains(s, "= -23.0") {
+		t.Errorf("String has no declination line:\n%s", s)
+	}
+}
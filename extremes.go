@@ -0,0 +1,55 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// EventExtremes holds the earliest and latest sunrise and sunset of a year.
+// Days without a sunrise or sunset are skipped, and a field is zero if there
+// was no such event all year.
+type EventExtremes struct {
+	EarliestSunrise time.Time
+	LatestSunrise   time.Time
+	EarliestSunset  time.Time
+	LatestSunset    time.Time
+}
+
+// YearEventExtremes returns the earliest and latest sunrise and sunset in the
+// given year, with the dates and results in the time zone loc.
+//
+// Because of the equation of time these do not fall on the solstices: in
+// mid northern latitudes the earliest sunset comes in early December and the
+// latest sunrise in early January. Times of day are compared in local mean
+// time, so daylight saving does not move the results.
+func YearEventExtremes(year int, loc *time.Location, latitude float64, longitude float64) EventExtremes {
+	var e EventExtremes
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		if rise, ok := Sunrise(d, latitude, longitude); ok {
+			s := meanTimeOfDay(rise, longitude)
+			if e.EarliestSunrise.IsZero() || s < meanTimeOfDay(e.EarliestSunrise, longitude) {
+				e.EarliestSunrise = rise
+			}
+			if e.LatestSunrise.IsZero() || s > meanTimeOfDay(e.LatestSunrise, longitude) {
+				e.LatestSunrise = rise
+			}
+		}
+		if set, ok := Sunset(d, latitude, longitude); ok {
+			s := meanTimeOfDay(set, longitude)
+			if e.EarliestSunset.IsZero() || s < meanTimeOfDay(e.EarliestSunset, longitude) {
+				e.EarliestSunset = set
+			}
+			if e.LatestSunset.IsZero() || s > meanTimeOfDay(e.LatestSunset, longitude) {
+				e.LatestSunset = set
+			}
+		}
+	}
+	return e
+}
+
+// meanTimeOfDay returns the local mean time of t at longitude as seconds after
+// midnight
+func meanTimeOfDay(t time.Time, longitude float64) float64 {
+	s := float64(t.Unix()%86400) + float64(t.Nanosecond())/1e9 + longitude*240
+	return math.Mod(s+2*86400, 86400)
+}
@@ -0,0 +1,75 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// For London in 2024 the almanac gives the earliest sunrise, 04:43 BST, from
+// 14 to 19 June, the latest sunset, 21:22 BST, from 21 to 27 June, the
+// earliest sunset, 15:51 GMT, from 10 to 14 December, and the latest sunrise,
+// 08:06 GMT, from 27 December into January. The flat stretches are several
+// days long, so the date is allowed three days either side of the middle.
+func TestYearEventExtremes(t *testing.T) {
+	loc := location(t, "Europe/London")
+	e := YearEventExtremes(2024, loc, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"earliest sunrise", e.EarliestSunrise, time.Date(2024, time.June, 16, 4, 43, 0, 0, loc)},
+		{"latest sunset", e.LatestSunset, time.Date(2024, time.June, 24, 21, 22, 0, 0, loc)},
+		{"earliest sunset", e.EarliestSunset, time.Date(2024, time.December, 12, 15, 51, 0, 0, loc)},
+		{"latest sunrise", e.LatestSunrise, time.Date(2024, time.December, 30, 8, 6, 0, 0, loc)},
+	} {
+		if tt.got.Location() != loc {
+			t.Errorf("%s is in %v, want %v", tt.name, tt.got.Location(), loc)
+		}
+		if !within(tt.got, tt.want, 3*24*time.Hour+time.Minute) {
+			t.Errorf("%s on %s, want within three days of %s", tt.name, tt.got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+		if d := clockTime(tt.got) - clockTime(tt.want); d < -time.Minute || d > time.Minute {
+			t.Errorf("%s at %s, want %s", tt.name, tt.got.Format("15:04:05"), tt.want.Format("15:04"))
+		}
+	}
+}
+
+// Tromsø has no sunrise or sunset in the midnight sun and polar night, so the
+// extremes come at their edges: the earliest sunrise and latest sunset just
+// before and after the midnight sun, and the latest sunrise and earliest
+// sunset just after and before the polar night.
+func TestYearEventExtremesPolar(t *testing.T) {
+	e := YearEventExtremes(2024, time.UTC, 69.6492, 18.9553)
+	for _, tt := range []struct {
+		name     string
+		got      time.Time
+		from, to time.Time
+	}{
+		{"earliest sunrise", e.EarliestSunrise, date(time.UTC, 2024, time.May, 10), date(time.UTC, 2024, time.May, 22)},
+		{"latest sunset", e.LatestSunset, date(time.UTC, 2024, time.July, 20), date(time.UTC, 2024, time.July, 31)},
+		{"earliest sunset", e.EarliestSunset, date(time.UTC, 2024, time.November, 20), date(time.UTC, 2024, time.December, 1)},
+		{"latest sunrise", e.LatestSunrise, date(time.UTC, 2024, time.January, 10), date(time.UTC, 2024, time.January, 20)},
+	} {
+		if tt.got.Before(tt.from) || tt.got.After(tt.to) {
+			t.Errorf("%s on %s, want between %s and %s", tt.name, tt.got.Format("2006-01-02"), tt.from.Format("2006-01-02"), tt.to.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestMeanTimeOfDay(t *testing.T) {
+	for _, tt := range []struct {
+		t         time.Time
+		longitude float64
+		want      float64
+	}{
+		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 0, 43200},
+		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 46800},
+		{time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC), -15, 84600},
+		{time.Date(2024, 1, 1, 12, 0, 0, 500000000, time.FixedZone("X", 3600)), 0, 39600.5},
+	} {
+		if got := meanTimeOfDay(tt.t, tt.longitude); got != tt.want {
+			t.Errorf("meanTimeOfDay(%v, %v) = %v, want %v", tt.t, tt.longitude, got, tt.want)
+		}
+	}
+}
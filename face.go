@@ -0,0 +1,45 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// FaceDay is the direct sunshine on one date on a sloping face, such as a rock
+// face, a couloir or a bank of vines.
+type FaceDay struct {
+	// FirstSun and LastSun are when direct sun first strikes the face and
+	// last leaves it, zero if it gets none that day.
+	FirstSun time.Time
+	LastSun  time.Time
+	// Sunlit holds the periods between, broken where the Sun passes behind
+	// the skyline or round the edge of the face.
+	Sunlit []Period
+}
+
+// FaceSun returns the direct sunshine on the date of t on a face tilted slope
+// degrees from horizontal and facing aspect degrees clockwise from north, with
+// times in the time zone of t: for alpinists, when the Sun reaches a couloir
+// and starts to loosen it. The Sun shines on the face while its centre is
+// above the skyline h, which may be empty for open ground, and in front of the
+// face, so a face steeper than the Sun is high gets sun only while the Sun is
+// round to its side. Periods shorter than ten minutes may be missed.
+func FaceSun(t time.Time, latitude float64, longitude float64, slope float64, aspect float64, h Horizon) FaceDay {
+	return faceSunAbove(t, latitude, longitude, slope, aspect, h, 0)
+}
+
+// faceSunAbove returns the FaceSun of the date of t counting only sun whose
+// rays strike the face at least minAngle degrees above its plane
+func faceSunAbove(t time.Time, latitude float64, longitude float64, slope float64, aspect float64, h Horizon, minAngle float64) FaceDay {
+	start, end := dateSpan(t)
+	var day FaceDay
+	day.Sunlit = periodsWhere(start, end, func(at time.Time) float64 {
+		alt, az := Altitude(at, latitude, longitude), Azimuth(at, latitude, longitude)
+		c := math.Max(-1, math.Min(1, cosIncidence(alt, az, slope, aspect)))
+		return math.Min(alt-h.At(az), angleAsin(c)-minAngle)
+	})
+	if n := len(day.Sunlit); n > 0 {
+		day.FirstSun, day.LastSun = day.Sunlit[0].Start, day.Sunlit[n-1].End
+	}
+	return day
+}
@@ -0,0 +1,52 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// At London, noon is at 12:02.4 UT on 21 June and 11:58.8 on 21 December. A
+// face tilted 38.5 degrees to the north faces the celestial pole and is lit
+// whenever the Sun is up in summer and never in winter; one tilted 51.5
+// degrees to the south faces the celestial equator and is lit six hours
+// either side of noon, or from sunrise to sunset when that is shorter. The
+// Sun's centre is up for H = 123.03 degrees either side of noon in June and
+// 56.97 in December, 10 degrees up for H = 103.95 in June, and due east at
+// 07:23.
+func TestFaceSun(t *testing.T) {
+	june := date(time.UTC, 2024, time.June, 21)
+	december := date(time.UTC, 2024, time.December, 21)
+	at := func(d time.Time, h, m, s int) time.Time {
+		return clock(d, h, m).Add(time.Duration(s) * time.Second)
+	}
+	for _, tt := range []struct {
+		name          string
+		d             time.Time
+		slope, aspect float64
+		h             Horizon
+		sunlit        []Period
+	}{
+		{"flat", june, 0, 0, Horizon{}, []Period{{at(june, 3, 50, 13), at(june, 20, 14, 38)}}},
+		{"flat under a 10 degree skyline", june, 0, 0, NewHorizon([]float64{10}), []Period{{at(june, 5, 6, 34), at(june, 18, 58, 17)}}},
+		{"polar face in June", june, 38.4926, 0, Horizon{}, []Period{{at(june, 3, 50, 13), at(june, 20, 14, 38)}}},
+		{"polar face in December", december, 38.4926, 0, Horizon{}, nil},
+		{"equatorial face in June", june, 51.5074, 180, Horizon{}, []Period{{at(june, 6, 2, 23), at(june, 18, 2, 30)}}},
+		{"equatorial face in December", december, 51.5074, 180, Horizon{}, []Period{{at(december, 8, 10, 53), at(december, 15, 46, 45)}}},
+		{"north wall", june, 90, 0, Horizon{}, []Period{{at(june, 3, 50, 13), at(june, 7, 23, 4)}, {at(june, 16, 41, 49), at(june, 20, 14, 38)}}},
+	} {
+		got := FaceSun(tt.d, 51.5074, -0.1278, tt.slope, tt.aspect, tt.h)
+		if len(got.Sunlit) != len(tt.sunlit) {
+			t.Errorf("%s: sunlit %v, want %v", tt.name, got.Sunlit, tt.sunlit)
+			continue
+		}
+		for i, p := range got.Sunlit {
+			if !within(p.Start, tt.sunlit[i].Start, 30*time.Second) || !within(p.End, tt.sunlit[i].End, 30*time.Second) {
+				t.Errorf("%s: period %d %v to %v, want %v to %v", tt.name, i, p.Start, p.End, tt.sunlit[i].Start, tt.sunlit[i].End)
+			}
+		}
+		if n := len(tt.sunlit); n == 0 && (!got.FirstSun.IsZero() || !got.LastSun.IsZero()) ||
+			n > 0 && (!got.FirstSun.Equal(got.Sunlit[0].Start) || !got.LastSun.Equal(got.Sunlit[n-1].End)) {
+			t.Errorf("%s: first and last sun %v, %v", tt.name, got.FirstSun, got.LastSun)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Sight is a timed observation of the altitude of the Sun. Altitude is the
+// geometric altitude of the centre of the Sun in degrees, as Altitude returns,
+// so a sextant reading must first be corrected for dip, refraction and
+// semi-diameter.
+type Sight struct {
+	Time     time.Time
+	Altitude float64
+}
+
+// FixPosition returns the position from which the given sights, taken from one
+// place, would be seen, starting the search from estimate. See RunningFix.
+func FixPosition(sights []Sight, estimate Point) (Point, error) {
+	return RunningFix(sights, estimate, 0, 0)
+}
+
+// RunningFix returns the position at the time of the last sight of an observer
+// making good course degrees true at speed metres per second, found by least
+// squares from two or more sights. Earlier sights are carried forward along
+// the course, as a navigator advances a position line.
+//
+// The search starts from estimate and uses the Gauss-Newton method. Two
+// circles of position cross at two points, and the result is the one nearer
+// the estimate, which should be within a few hundred miles. An error is
+// returned if there are fewer than two sights or if they do not fix a
+// position, as when all were taken with the Sun on the same bearing.
+func RunningFix(sights []Sight, estimate Point, course float64, speed float64) (Point, error) {
+	if len(sights) < 2 {
+		return Point{}, errTooFewSights
+	}
+	last := sights[0].Time
+	for _, s := range sights {
+		if s.Time.After(last) {
+			last = s.Time
+		}
+	}
+	residuals := func(p Point) []float64 {
+		r := make([]float64, len(sights))
+		for i, s := range sights {
+			at := p
+			if speed != 0 {
+				at = Destination(p, course, speed*s.Time.Sub(last).Seconds())
+			}
+			r[i] = Altitude(s.Time, at.Latitude, at.Longitude) - s.Altitude
+		}
+		return r
+	}
+
+	const delta = 1e-5 // degrees, for the numerical derivatives
+	p := estimate
+	for i := 0; i < 50; i++ {
+		r := residuals(p)
+		rLat := residuals(Point{p.Latitude + delta, p.Longitude})
+		rLon := residuals(Point{p.Latitude, p.Longitude + delta})
+		// normal equations of the linearised problem
+		var a11, a12, a22, b1, b2 float64
+		for j := range r {
+			jLat := (rLat[j] - r[j]) / delta
+			jLon := (rLon[j] - r[j]) / delta
+			a11 += jLat * jLat
+			a12 += jLat * jLon
+			a22 += jLon * jLon
+			b1 -= jLat * r[j]
+			b2 -= jLon * r[j]
+		}
+		det := a11*a22 - a12*a12
+		if math.Abs(det) < 1e-9*(a11*a22+1e-300) {
+			return Point{}, errNoFix
+		}
+		dLat := (a22*b1 - a12*b2) / det
+		dLon := (a11*b2 - a12*b1) / det
+		// keep each step small enough for the linearisation to hold
+		if m := math.Max(math.Abs(dLat), math.Abs(dLon)); m > 5 {
+			dLat, dLon = dLat*5/m, dLon*5/m
+		}
+		p = Point{math.Max(-90, math.Min(90, p.Latitude+dLat)), between(-180, 180, p.Longitude+dLon)}
+		if math.Abs(dLat) < 1e-8 && math.Abs(dLon) < 1e-8 {
+			return p, nil
+		}
+	}
+	return Point{}, errNoFix
+}
@@ -0,0 +1,86 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// sightsFrom returns the sights that would be taken at the given times from a
+// vessel that is at last at the time of the final sight, having made good
+// course at speed metres per second
+func sightsFrom(last Point, course float64, speed float64, times ...time.Time) []Sight {
+	end := times[len(times)-1]
+	var ss []Sight
+	for _, at := range times {
+		p := Destination(last, course, speed*at.Sub(end).Seconds())
+		ss = append(ss, Sight{at, Altitude(at, p.Latitude, p.Longitude)})
+	}
+	return ss
+}
+
+func TestFixPosition(t *testing.T) {
+	day := date(time.UTC, 2024, time.March, 20)
+	for _, tt := range []struct {
+		name     string
+		truth    Point
+		estimate Point
+		hours    []int
+	}{
+		{"mid Atlantic", Point{45, -30}, Point{47, -33}, []int{11, 14, 17}},
+		{"morning and afternoon", Point{-20, 60}, Point{-18, 62}, []int{5, 9}},
+		{"Southern Ocean", Point{-50, 170}, Point{-52, 173}, []int{22, 1, 3}},
+	} {
+		var times []time.Time
+		for _, h := range tt.hours {
+			at := clock(day, h, 0)
+			if len(times) > 0 && at.Before(times[len(times)-1]) {
+				at = at.AddDate(0, 0, 1)
+			}
+			times = append(times, at)
+		}
+		got, err := FixPosition(sightsFrom(tt.truth, 0, 0, times...), tt.estimate)
+		if err != nil || Distance(got, tt.truth) > 10 {
+			t.Errorf("%s: FixPosition = %v, %v, want %v", tt.name, got, err, tt.truth)
+		}
+	}
+}
+
+func TestRunningFix(t *testing.T) {
+	// six knots due east for six hours, with a morning, noon and afternoon
+	// sight; the fix is for the time of the last
+	truth := Point{40, -20}
+	day := date(time.UTC, 2024, time.June, 21)
+	sights := sightsFrom(truth, 90, 6*Knot, clock(day, 10, 0), clock(day, 13, 0), clock(day, 16, 0))
+	got, err := RunningFix(sights, Point{41, -22}, 90, 6*Knot)
+	if err != nil || Distance(got, truth) > 10 {
+		t.Errorf("RunningFix = %v, %v, want %v", got, err, truth)
+	}
+	// treating the same sights as from one place puts the vessel miles out
+	if p, err := FixPosition(sights, Point{41, -22}); err == nil && Distance(p, truth) < 10e3 {
+		t.Errorf("FixPosition of a moving vessel = %v, %.0f m from %v", p, Distance(p, truth), truth)
+	}
+}
+
+func TestFixPositionErrors(t *testing.T) {
+	at := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+	if _, err := FixPosition([]Sight{{at, 40}}, Point{}); err != errTooFewSights {
+		t.Errorf("one sight: err = %v, want %v", err, errTooFewSights)
+	}
+	// two sights at once give the same circle twice
+	ss := sightsFrom(Point{45, -30}, 0, 0, at, at)
+	if p, err := FixPosition(ss, Point{47, -33}); err != errNoFix {
+		t.Errorf("two sights at once = %v, %v, want %v", p, err, errNoFix)
+	}
+	// a fix from good sights is a point where both altitudes match
+	ss = sightsFrom(Point{45, -30}, 0, 0, at, at.Add(3*time.Hour))
+	p, err := FixPosition(ss, Point{47, -33})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range ss {
+		if a := Altitude(s.Time, p.Latitude, p.Longitude); math.Abs(a-s.Altitude) > 1e-6 {
+			t.Errorf("altitude at the fix %v, want %v", a, s.Altitude)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sun
+
+import "time"
+
+// clearSkyDiffuseFraction is the share of clear sky global irradiance that
+// comes from the sky rather than straight from the Sun
+const clearSkyDiffuseFraction = 0.15
+
+// pvPerformanceRatio is the share of its rated output a PV system delivers
+// after losses in heat, wiring and the inverter
+const pvPerformanceRatio = 0.85
+
+// PlaneOfArray returns the clear sky irradiance in watts per square metre on a
+// surface tilted by tilt degrees from horizontal and facing azimuth degrees
+// clockwise from north, such as a solar panel. ClearSkyGHI is split into 85
+// percent direct beam and 15 percent diffuse light from a uniform sky, and the
+// ground reflects nothing.
+func PlaneOfArray(t time.Time, latitude float64, longitude float64, tilt float64, azimuth float64) float64 {
+	ghi := ClearSkyGHI(t, latitude, longitude)
+	if ghi == 0 {
+		return 0
+	}
+	alt := Altitude(t, latitude, longitude)
+	diffuse := clearSkyDiffuseFraction * ghi
+	poa := diffuse * (1 + angleCos(tilt)) / 2
+	if c := cosIncidence(alt, Azimuth(t, latitude, longitude), tilt, azimuth); c > 0 {
+		poa += (ghi - diffuse) / angleSin(alt) * c
+	}
+	return poa
+}
+
+// PVSite is one solar installation in a fleet.
+type PVSite struct {
+	Point
+	Capacity float64 // peak rating, kilowatts
+	Tilt     float64 // degrees from horizontal
+	Azimuth  float64 // direction the panels face, degrees clockwise from north
+}
+
+// FleetSample is the output of a fleet at one time.
+type FleetSample struct {
+	Time  time.Time
+	Power float64 // kilowatts
+}
+
+// FleetProfile returns the clear sky output of a fleet of PV sites over the
+// date of t, every step from midnight, in the time zone of t. Each site makes
+// its capacity times PlaneOfArray over 1000 watts per square metre, less 15
+// percent of losses. Set against demand it gives the midday trough of the duck
+// curve. It returns an error if step is not positive or there are no sites.
+func FleetProfile(t time.Time, sites []PVSite, step time.Duration) ([]FleetSample, error) {
+	if step <= 0 {
+		return nil, errNonPositiveStep
+	}
+	if len(sites) == 0 {
+		return nil, errNoSites
+	}
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	var ss []FleetSample
+	for at := start; at.Before(end); at = at.Add(step) {
+		var kw float64
+		for _, s := range sites {
+			kw += s.Capacity * PlaneOfArray(at, s.Latitude, s.Longitude, s.Tilt, s.Azimuth) / 1000
+		}
+		ss = append(ss, FleetSample{at, kw * pvPerformanceRatio})
+	}
+	return ss, nil
+}
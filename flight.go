@@ -0,0 +1,78 @@
+package sun
+
+import "time"
+
+// FlightSample is the Sun as seen from an aircraft at one moment of a flight.
+type FlightSample struct {
+	Time     time.Time
+	Position Point
+	Heading  float64 // track over the ground, degrees east of north
+	Altitude float64 // of the Sun, degrees
+	Azimuth  float64 // of the Sun, degrees east of north
+	// RelativeBearing is the direction of the Sun from the nose, in degrees
+	// from -180 up to but not including 180, positive to the right, so the
+	// Sun shines in the windows on the right hand side when it is positive
+	// and up. It is what SunRelativeBearing gives for the heading.
+	RelativeBearing float64
+}
+
+// FlightProfile is the exposure of a flight to the Sun.
+type FlightProfile struct {
+	Departure time.Time
+	Arrival   time.Time
+	Samples   []FlightSample
+	// Daylight holds the parts of the flight with the Sun above the horizon
+	// and DaylightFraction the share of the flight time they make up.
+	Daylight         []Period
+	DaylightFraction float64
+}
+
+// FlightExposure follows a flight along the great circle from one airport to
+// another, leaving at departure and cruising at speed metres per second
+// throughout, and samples the Sun every step. Sunrise and sunset are judged as
+// on the ground, ignoring the lower horizon seen from cruising height.
+// It returns an error if step or speed is not positive.
+func FlightExposure(from Point, to Point, departure time.Time, speed float64, step time.Duration) (FlightProfile, error) {
+	if step <= 0 {
+		return FlightProfile{}, errNonPositiveStep
+	}
+	if speed <= 0 {
+		return FlightProfile{}, errNonPositiveSpeed
+	}
+	distance := Distance(from, to)
+	arrival := departure.Add(secondsToDuration(distance / speed))
+	at := func(t time.Time) Point {
+		if distance == 0 {
+			return from
+		}
+		return Intermediate(from, to, t.Sub(departure).Seconds()*speed/distance)
+	}
+	p := FlightProfile{Departure: departure, Arrival: arrival}
+	for t := departure; !t.After(arrival); t = t.Add(step) {
+		pos := at(t)
+		s := FlightSample{
+			Time:     t,
+			Position: pos,
+			Heading:  Bearing(pos, to),
+			Altitude: Altitude(t, pos.Latitude, pos.Longitude),
+			Azimuth:  Azimuth(t, pos.Latitude, pos.Longitude),
+		}
+		if t.Equal(arrival) || Distance(pos, to) < 1 {
+			s.Heading = between(0, 360, Bearing(to, from)+180)
+		}
+		s.RelativeBearing = between(-180, 180, s.Azimuth-s.Heading)
+		p.Samples = append(p.Samples, s)
+	}
+	p.Daylight = periodsWhere(departure, arrival, func(t time.Time) float64 {
+		pos := at(t)
+		return Altitude(t, pos.Latitude, pos.Longitude) - SunriseAltitude
+	})
+	if arrival.After(departure) {
+		var day time.Duration
+		for _, d := range p.Daylight {
+			day += d.Duration()
+		}
+		p.DaylightFraction = float64(day) / float64(arrival.Sub(departure))
+	}
+	return p, nil
+}
@@ -0,0 +1,91 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	heathrow = Point{51.4700, -0.4543}
+	kennedy  = Point{40.6413, -73.7781}
+)
+
+// Heathrow to Kennedy is 5540 km on the great circle, setting off on a course
+// of 288 degrees; at 250 m/s that takes 6h09m20s.
+func TestFlightExposure(t *testing.T) {
+	dep := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	p, err := FlightExposure(heathrow, kennedy, dep, 250, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dep.Add(6*time.Hour + 9*time.Minute + 20*time.Second); !within(p.Arrival, want, time.Second) || !p.Departure.Equal(dep) {
+		t.Errorf("flight %v to %v, want %v to %v", p.Departure, p.Arrival, dep, want)
+	}
+	if len(p.Samples) != 13 {
+		t.Fatalf("%d samples, want 13", len(p.Samples))
+	}
+	first, last := p.Samples[0], p.Samples[12]
+	if first.Position != heathrow || math.Abs(first.Heading-288) > 0.5 {
+		t.Errorf("first sample at %v heading %.1f, want Heathrow and 288", first.Position, first.Heading)
+	}
+	if !last.Time.Equal(dep.Add(6*time.Hour)) || math.Abs(Distance(last.Position, kennedy)-140e3) > 100 {
+		t.Errorf("last sample at %v, %v, want 6 hours out and 140 km from Kennedy", last.Time, last.Position)
+	}
+	// mid-morning in summer the Sun is in the south east, behind the left
+	// wing of a plane heading west, and high all the way
+	if math.Abs(first.RelativeBearing+160) > 1 {
+		t.Errorf("relative bearing at departure %.1f, want -160", first.RelativeBearing)
+	}
+	for _, s := range p.Samples {
+		if s.Altitude != Altitude(s.Time, s.Position.Latitude, s.Position.Longitude) || s.Altitude < 50 {
+			t.Errorf("at %v the Sun is at %.1f", s.Time.Format("15:04"), s.Altitude)
+		}
+		if want := SunRelativeBearing(s.Time, s.Position.Latitude, s.Position.Longitude, s.Heading); s.RelativeBearing != want {
+			t.Errorf("at %v relative bearing %v, want %v", s.Time.Format("15:04"), s.RelativeBearing, want)
+		}
+	}
+	if p.DaylightFraction != 1 || len(p.Daylight) != 1 || p.Daylight[0] != (Period{p.Departure, p.Arrival}) {
+		t.Errorf("daylight %v, fraction %v, want the whole flight", p.Daylight, p.DaylightFraction)
+	}
+}
+
+// The overnight flight back leaves New York in the evening and lands in London
+// before dawn. One leaving later sees the Sun rise off the west of Ireland,
+// near 52.8 degrees north and 9 west, where sunrise on 22 December is at
+// 08:40 UT, London's 08:04 plus 36 minutes for the longitude.
+func TestFlightExposureNight(t *testing.T) {
+	p, err := FlightExposure(kennedy, heathrow, time.Date(2024, time.December, 21, 23, 0, 0, 0, time.UTC), 250, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.DaylightFraction != 0 || len(p.Daylight) != 0 {
+		t.Errorf("night flight daylight %v, fraction %v", p.Daylight, p.DaylightFraction)
+	}
+
+	p, err = FlightExposure(kennedy, heathrow, time.Date(2024, time.December, 22, 3, 0, 0, 0, time.UTC), 250, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Daylight) != 1 || !within(p.Daylight[0].Start, time.Date(2024, time.December, 22, 8, 40, 0, 0, time.UTC), 5*time.Minute) || p.Daylight[0].End != p.Arrival {
+		t.Fatalf("daylight %v, want from about 08:40 to landing", p.Daylight)
+	}
+	if want := float64(p.Daylight[0].Duration()) / float64(p.Arrival.Sub(p.Departure)); p.DaylightFraction != want {
+		t.Errorf("fraction %v, want %v", p.DaylightFraction, want)
+	}
+}
+
+func TestFlightExposureErrors(t *testing.T) {
+	dep := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	if _, err := FlightExposure(heathrow, kennedy, dep, 250, 0); err != errNonPositiveStep {
+		t.Errorf("zero step: err = %v, want %v", err, errNonPositiveStep)
+	}
+	if _, err := FlightExposure(heathrow, kennedy, dep, -1, time.Minute); err != errNonPositiveSpeed {
+		t.Errorf("negative speed: err = %v, want %v", err, errNonPositiveSpeed)
+	}
+	// going nowhere takes no time and has one sample
+	p, err := FlightExposure(heathrow, heathrow, dep, 250, time.Minute)
+	if err != nil || !p.Arrival.Equal(dep) || len(p.Samples) != 1 || p.DaylightFraction != 0 {
+		t.Errorf("zero length flight = %+v, %v", p, err)
+	}
+}
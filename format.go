@@ -0,0 +1,89 @@
+package sun
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Messages holds the words used to describe solar events in one language.
+type Messages struct {
+	Sunrise    string
+	Sunset     string
+	SolarNoon  string
+	DayLength  string
+	NoSunset   string // for a day when the Sun never sets
+	NoSunrise  string // for a day when the Sun never rises
+	Hour       string // suffix for hours in a duration
+	Minute     string // suffix for minutes in a duration
+	Separator  string // between the parts of a summary
+	PhaseNames [5]string
+}
+
+// Catalog holds Messages by language tag. Callers may add languages or replace
+// entries before formatting; it is not safe to change concurrently with use.
+var Catalog = map[string]Messages{
+	"en": {"Sunrise", "Sunset", "Solar noon", "Day length", "The Sun does not set", "The Sun does not rise", "h", "m", ", ",
+		[5]string{"Night", "Astronomical twilight", "Nautical twilight", "Civil twilight", "Day"}},
+	"de": {"Sonnenaufgang", "Sonnenuntergang", "Sonnenmittag", "Tageslänge", "Die Sonne geht nicht unter", "Die Sonne geht nicht auf", " Std. ", " Min.", ", ",
+		[5]string{"Nacht", "Astronomische Dämmerung", "Nautische Dämmerung", "Bürgerliche Dämmerung", "Tag"}},
+	"fr": {"Lever du soleil", "Coucher du soleil", "Midi solaire", "Durée du jour", "Le soleil ne se couche pas", "Le soleil ne se lève pas", " h ", " min", ", ",
+		[5]string{"Nuit", "Crépuscule astronomique", "Crépuscule nautique", "Crépuscule civil", "Jour"}},
+	"es": {"Salida del sol", "Puesta del sol", "Mediodía solar", "Duración del día", "El sol no se pone", "El sol no sale", " h ", " min", ", ",
+		[5]string{"Noche", "Crepúsculo astronómico", "Crepúsculo náutico", "Crepúsculo civil", "Día"}},
+	"it": {"Alba", "Tramonto", "Mezzogiorno solare", "Durata del giorno", "Il sole non tramonta", "Il sole non sorge", " h ", " min", ", ",
+		[5]string{"Notte", "Crepuscolo astronomico", "Crepuscolo nautico", "Crepuscolo civile", "Giorno"}},
+	"nl": {"Zonsopkomst", "Zonsondergang", "Zonnemiddag", "Daglengte", "De zon gaat niet onder", "De zon komt niet op", " u ", " min", ", ",
+		[5]string{"Nacht", "Astronomische schemering", "Nautische schemering", "Burgerlijke schemering", "Dag"}},
+	"pt": {"Nascer do sol", "Pôr do sol", "Meio-dia solar", "Duração do dia", "O sol não se põe", "O sol não nasce", " h ", " min", ", ",
+		[5]string{"Noite", "Crepúsculo astronômico", "Crepúsculo náutico", "Crepúsculo civil", "Dia"}},
+}
+
+// MessagesFor returns the Messages for a language tag such as "de" or "pt-BR",
+// falling back from the region to the base language and then to English.
+func MessagesFor(lang string) Messages {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	if m, ok := Catalog[lang]; ok {
+		return m
+	}
+	if i := strings.IndexByte(lang, '-'); i > 0 {
+		if m, ok := Catalog[lang[:i]]; ok {
+			return m
+		}
+	}
+	return Catalog["en"]
+}
+
+// PhaseName returns the name of p in the given language.
+func PhaseName(p DayPhase, lang string) string {
+	if p < 0 || int(p) >= len(dayPhaseNames) {
+		return p.String()
+	}
+	return MessagesFor(lang).PhaseNames[p]
+}
+
+// FormatDay returns a one line summary of a day's events in the given language,
+// such as "Sunrise 06:12, Sunset 20:45, Day length 14h33m". Times are shown in
+// the time zone they carry.
+func FormatDay(d DayInfo, lang string) string {
+	m := MessagesFor(lang)
+	var parts []string
+	switch {
+	case !d.Sunrise.IsZero() && !d.Sunset.IsZero():
+		parts = append(parts, m.Sunrise+" "+d.Sunrise.Format("15:04"), m.Sunset+" "+d.Sunset.Format("15:04"))
+	case d.Length > 0:
+		parts = append(parts, m.NoSunset)
+	default:
+		parts = append(parts, m.NoSunrise)
+	}
+	parts = append(parts, m.DayLength+" "+FormatDuration(d.Length, lang))
+	return strings.Join(parts, m.Separator)
+}
+
+// FormatDuration formats d to the nearest minute as hours and minutes in the
+// given language, such as 14h33m in English or 14 Std. 33 Min. in German.
+func FormatDuration(d time.Duration, lang string) string {
+	m := MessagesFor(lang)
+	mins := int(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%d%s%02d%s", mins/60, m.Hour, mins%60, m.Minute)
+}
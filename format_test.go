@@ -0,0 +1,103 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMessagesFor(t *testing.T) {
+	for _, tt := range []struct {
+		lang, sunrise string
+	}{
+		{"en", "Sunrise"},
+		{"de", "Sonnenaufgang"},
+		{"DE", "Sonnenaufgang"},
+		{"pt-BR", "Nascer do sol"},
+		{"pt_BR", "Nascer do sol"},
+		{"fr-CA", "Lever du soleil"},
+		{"xx", "Sunrise"},
+		{"", "Sunrise"},
+		{"-de", "Sunrise"},
+	} {
+		if got := MessagesFor(tt.lang).Sunrise; got != tt.sunrise {
+			t.Errorf("MessagesFor(%q).Sunrise = %q, want %q", tt.lang, got, tt.sunrise)
+		}
+	}
+}
+
+func TestPhaseName(t *testing.T) {
+	for _, tt := range []struct {
+		p    DayPhase
+		lang string
+		want string
+	}{
+		{Night, "en", "Night"},
+		{CivilTwilight, "de", "Bürgerliche Dämmerung"},
+		{Day, "it", "Giorno"},
+		{NauticalTwilight, "nl-BE", "Nautische schemering"},
+		{AstronomicalTwilight, "xx", "Astronomical twilight"},
+		{DayPhase(9), "fr", DayPhase(9).String()},
+	} {
+		if got := PhaseName(tt.p, tt.lang); got != tt.want {
+			t.Errorf("PhaseName(%v, %q) = %q, want %q", tt.p, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		lang string
+		want string
+	}{
+		{16*time.Hour + 38*time.Minute, "en", "16h38m"},
+		{16*time.Hour + 38*time.Minute, "de", "16 Std. 38 Min."},
+		{7*time.Hour + 50*time.Minute + 29*time.Second, "fr", "7 h 50 min"},
+		{7*time.Hour + 50*time.Minute + 30*time.Second, "en", "7h51m"},
+		{59*time.Minute + 45*time.Second, "en", "1h00m"},
+		{0, "en", "0h00m"},
+		{24 * time.Hour, "es", "24 h 00 min"},
+	} {
+		if got := FormatDuration(tt.d, tt.lang); got != tt.want {
+			t.Errorf("FormatDuration(%v, %q) = %q, want %q", tt.d, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDay(t *testing.T) {
+	london := location(t, "Europe/London")
+	d := date(london, 2024, time.June, 21)
+	day := DayInfo{Sunrise: clock(d, 4, 43), Sunset: clock(d, 21, 21), Length: 16*time.Hour + 38*time.Minute}
+	for _, tt := range []struct {
+		lang, want string
+	}{
+		{"en", "Sunrise 04:43, Sunset 21:21, Day length 16h38m"},
+		{"de", "Sonnenaufgang 04:43, Sonnenuntergang 21:21, Tageslänge 16 Std. 38 Min."},
+		{"pt-BR", "Nascer do sol 04:43, Pôr do sol 21:21, Duração do dia 16 h 38 min"},
+	} {
+		if got := FormatDay(day, tt.lang); got != tt.want {
+			t.Errorf("FormatDay(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+
+	// the times are shown in the zone they carry, not the local one
+	utc := day
+	utc.Sunrise, utc.Sunset = day.Sunrise.UTC(), day.Sunset.UTC()
+	if got, want := FormatDay(utc, "en"), "Sunrise 03:43, Sunset 20:21, Day length 16h38m"; got != want {
+		t.Errorf("FormatDay in UTC = %q, want %q", got, want)
+	}
+
+	// Tromsø has the midnight sun in June and the polar night in December
+	for _, tt := range []struct {
+		m    time.Month
+		want string
+	}{
+		{time.June, "The Sun does not set, Day length 24h00m"},
+		{time.December, "The Sun does not rise, Day length 0h00m"},
+	} {
+		day := DayEvents(date(time.UTC, 2024, tt.m, 21), 69.6492, 18.9553)
+		if got := FormatDay(day, "en"); got != tt.want {
+			t.Errorf("FormatDay(Tromsø, %v) = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package sun
+
+import "time"
+
+// ShadeRule sets what counts as warming sun on a surface and which mornings
+// to flag.
+type ShadeRule struct {
+	// MinAngle is the least angle in degrees of the Sun's rays above the
+	// surface for them to count, as grazing sun warms it little.
+	MinAngle float64
+	// Alert is the shade after sunrise at or below which a morning is
+	// flagged. Sun reaching frosted buds or blossom soon after dawn thaws
+	// them fast and does the most harm, which is why growers avoid east
+	// facing slopes for early flowering fruit.
+	Alert time.Duration
+}
+
+// MorningShade is how long a surface stays in shade after sunrise on one date.
+type MorningShade struct {
+	Date     time.Time // midnight at the start of the date
+	Sunrise  time.Time
+	FirstSun time.Time // zero if no sun counts that day
+	// Shade runs from sunrise to the first sun, or to sunset if there is
+	// none.
+	Shade time.Duration
+	Alert bool
+}
+
+// MorningShades returns the morning shade on a surface for each of days dates
+// from the date of start, in its time zone, under rule: for frost and scorch
+// risk in orchards and vineyards. The surface is tilted slope degrees from
+// horizontal, facing aspect degrees clockwise from north, with skyline h, as
+// for FaceSun; for a point in rough terrain they come from DEM.SlopeAspect and
+// DEM.Horizon. Sunrise is over a level horizon, as the air cools and warms
+// with the open sky, and dates without one are left out.
+func MorningShades(start time.Time, days int, latitude float64, longitude float64, slope float64, aspect float64, h Horizon, rule ShadeRule) []MorningShade {
+	var ms []MorningShade
+	y, m, d := start.Date()
+	for i := 0; i < days; i++ {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, start.Location())
+		rise, ok := Sunrise(date, latitude, longitude)
+		if !ok {
+			continue
+		}
+		s := MorningShade{Date: date, Sunrise: rise}
+		for _, p := range faceSunAbove(date, latitude, longitude, slope, aspect, h, rule.MinAngle).Sunlit {
+			if p.End.After(rise) {
+				s.FirstSun = p.Start
+				break
+			}
+		}
+		switch {
+		case s.FirstSun.IsZero():
+			if set, ok := Sunset(date, latitude, longitude); ok && set.After(rise) {
+				s.Shade = set.Sub(rise)
+			}
+		case s.FirstSun.After(rise):
+			s.Shade = s.FirstSun.Sub(rise)
+			s.Alert = s.Shade <= rule.Alert
+		default:
+			// lit from the moment the Sun rises
+			s.Alert = true
+		}
+		ms = append(ms, s)
+	}
+	return ms
+}
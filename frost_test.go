@@ -0,0 +1,59 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// At London on 1 April the Sun climbs about 9.3 degrees an hour at sunrise,
+// so its centre clears the level horizon 0.833 / 9.3 hours, 5.4 minutes,
+// after sunrise at 05:35 UT. A slope facing east is already square to it by
+// then and gets sun at once; one facing west waits until mid morning. In
+// December a slope facing the celestial pole is in shade from sunrise to
+// sunset, 7h49m39s, and Tromsø has no sunrise to count from.
+func TestMorningShades(t *testing.T) {
+	april := date(time.UTC, 2024, time.April, 1)
+	rise := clock(april, 5, 34).Add(58 * time.Second)
+	rule := ShadeRule{MinAngle: 5, Alert: time.Hour}
+	for _, tt := range []struct {
+		name          string
+		slope, aspect float64
+		shade         time.Duration
+		alert         bool
+	}{
+		{"flat", 0, 0, 5*time.Minute + 24*time.Second, true},
+		{"east slope", 30, 90, 5*time.Minute + 24*time.Second, true},
+		{"west slope", 30, 270, 3*time.Hour + 30*time.Minute + 14*time.Second, false},
+	} {
+		r := rule
+		if tt.slope == 0 {
+			r.MinAngle = 0
+		}
+		ms := MorningShades(april, 1, 51.5074, -0.1278, tt.slope, tt.aspect, Horizon{}, r)
+		if len(ms) != 1 {
+			t.Fatalf("%s: %d mornings", tt.name, len(ms))
+		}
+		m := ms[0]
+		if !within(m.Sunrise, rise, time.Second) || (m.Shade-tt.shade).Abs() > 30*time.Second || m.Alert != tt.alert || !m.FirstSun.Equal(m.Sunrise.Add(m.Shade)) {
+			t.Errorf("%s: %+v, want %v of shade, alert %v", tt.name, m, tt.shade, tt.alert)
+		}
+	}
+
+	december := date(time.UTC, 2024, time.December, 21)
+	ms := MorningShades(december, 1, 51.5074, -0.1278, 38.4926, 0, Horizon{}, rule)
+	if len(ms) != 1 || !ms[0].FirstSun.IsZero() || (ms[0].Shade-(7*time.Hour+49*time.Minute+39*time.Second)).Abs() > time.Second || ms[0].Alert {
+		t.Errorf("polar face in December: %+v", ms)
+	}
+	// an east face on a summit, with the skyline 2 degrees down, has the Sun
+	// before it rises over a level horizon
+	ms = MorningShades(april, 1, 51.5074, -0.1278, 90, 90, NewHorizon([]float64{-2}), ShadeRule{Alert: time.Hour})
+	if len(ms) != 1 || !ms[0].FirstSun.Before(ms[0].Sunrise) || ms[0].Shade != 0 || !ms[0].Alert {
+		t.Errorf("east face on a summit: %+v", ms)
+	}
+	if ms := MorningShades(december, 3, 69.6492, 18.9553, 0, 0, Horizon{}, rule); len(ms) != 0 {
+		t.Errorf("Tromsø in the polar night: %+v", ms)
+	}
+	if ms := MorningShades(april, 7, 51.5074, -0.1278, 0, 0, Horizon{}, rule); len(ms) != 7 || !ms[6].Date.Equal(date(time.UTC, 2024, time.April, 7)) {
+		t.Errorf("a week of mornings: %d", len(ms))
+	}
+}
@@ -0,0 +1,75 @@
+package sun
+
+import "time"
+
+// SunExposure is the light a spot gets, in the terms of plant labels.
+type SunExposure int
+
+const (
+	FullSun    SunExposure = iota // six hours or more of direct sun a day
+	PartialSun                    // three to six hours
+	Shade                         // less than three hours
+)
+
+var sunExposureNames = [...]string{"full sun", "partial sun", "shade"}
+
+func (e SunExposure) String() string {
+	if e < 0 || int(e) >= len(sunExposureNames) {
+		return "unknown"
+	}
+	return sunExposureNames[e]
+}
+
+// ClassifySunExposure returns the exposure for the given hours of direct sun a
+// day.
+func ClassifySunExposure(d time.Duration) SunExposure {
+	switch {
+	case d >= 6*time.Hour:
+		return FullSun
+	case d >= 3*time.Hour:
+		return PartialSun
+	}
+	return Shade
+}
+
+// DirectSunHours returns how long the centre of the Sun is above horizon h on
+// the date of t, as seen from the given location. h holds the obstructions
+// round the spot, such as walls, hedges and trees, as elevations; an empty
+// Horizon is open ground. Periods of sun shorter than ten minutes, as through
+// a narrow gap, may be missed.
+func DirectSunHours(t time.Time, latitude float64, longitude float64, h Horizon) time.Duration {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	var sum time.Duration
+	for _, p := range periodsWhere(start, end, func(at time.Time) float64 {
+		return Altitude(at, latitude, longitude) - h.At(Azimuth(at, latitude, longitude))
+	}) {
+		sum += p.Duration()
+	}
+	return sum
+}
+
+// GardenSeason is the average daily direct sun on a spot over a season.
+type GardenSeason struct {
+	Season   Season
+	Hours    time.Duration
+	Exposure SunExposure
+}
+
+// GardenExposure classifies a spot for each season of the given year by its
+// average DirectSunHours, from dates spread through each season. Seasons
+// follow the hemisphere of the spot.
+func GardenExposure(year int, loc *time.Location, latitude float64, longitude float64, h Horizon) [4]GardenSeason {
+	var gs [4]GardenSeason
+	for s := Winter; s <= Autumn; s++ {
+		dates := seasonDates(year, loc, s, latitude)
+		var sum time.Duration
+		for _, d := range dates {
+			sum += DirectSunHours(d, latitude, longitude, h)
+		}
+		avg := sum / time.Duration(len(dates))
+		gs[s] = GardenSeason{s, avg, ClassifySunExposure(avg)}
+	}
+	return gs
+}
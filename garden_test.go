@@ -0,0 +1,89 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// With the Sun's centre on the horizon sin h = ±0.3113 + 0.5711 cos H at
+// London on the solstices, so open ground has 2 × 8h12.1m = 16h24.2m of sun
+// in June and 2 × 3h47.9m = 7h35.8m in December. Above a 10 degree skyline,
+// sin 10 = 0.1736, that falls to 13h51.6m and 4h15.1m. With the south half of
+// the sky walled off the June Sun shines only while north of the prime
+// vertical, 2 × 3h32.8m = 7h05.6m, and in December not at all. The wall here
+// rises over a degree from azimuth 90, so the Sun, sin h = sin δ / sin φ, 30.5
+// degrees up, clears it to 90.34, which at 15 sin φ = 11.7 degrees of azimuth
+// an hour adds 1.7 minutes each side.
+func TestDirectSunHours(t *testing.T) {
+	hm := func(h, m int, s int) time.Duration {
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+	ten := NewHorizon([]float64{10, 10, 10, 10})
+	wall := make([]float64, 360)
+	for az := 91; az < 270; az++ {
+		wall[az] = 90
+	}
+	south := NewHorizon(wall)
+	june, december := date(time.UTC, 2024, time.June, 21), date(time.UTC, 2024, time.December, 21)
+	for _, tt := range []struct {
+		name string
+		d    time.Time
+		h    Horizon
+		want time.Duration
+		ex   SunExposure
+	}{
+		{"open in June", june, Horizon{}, hm(16, 24, 12), FullSun},
+		{"open in December", december, Horizon{}, hm(7, 35, 48), FullSun},
+		{"10 degrees in June", june, ten, hm(13, 51, 36), FullSun},
+		{"10 degrees in December", december, ten, hm(4, 15, 6), PartialSun},
+		{"walled to the south in June", june, south, hm(7, 9, 0), FullSun},
+		{"walled to the south in December", december, south, 0, Shade},
+	} {
+		got := DirectSunHours(tt.d, 51.5074, -0.1278, tt.h)
+		if (got-tt.want).Abs() > 3*time.Minute || ClassifySunExposure(got) != tt.ex {
+			t.Errorf("%s: %v, %v, want %v, %v", tt.name, got, ClassifySunExposure(got), tt.want, tt.ex)
+		}
+	}
+}
+
+func TestClassifySunExposure(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want SunExposure
+	}{
+		{0, Shade},
+		{3*time.Hour - time.Second, Shade},
+		{3 * time.Hour, PartialSun},
+		{6*time.Hour - time.Second, PartialSun},
+		{6 * time.Hour, FullSun},
+		{16 * time.Hour, FullSun},
+	} {
+		if got := ClassifySunExposure(tt.d); got != tt.want {
+			t.Errorf("ClassifySunExposure(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+	for e, want := range map[SunExposure]string{FullSun: "full sun", PartialSun: "partial sun", Shade: "shade", SunExposure(3): "unknown"} {
+		if got := e.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(e), got, want)
+		}
+	}
+}
+
+// Behind a 10 degree skyline a London garden has full sun from spring to
+// autumn and partial sun in winter; in Sydney the winter is June to August.
+func TestGardenExposure(t *testing.T) {
+	ten := NewHorizon([]float64{10, 10, 10, 10})
+	gs := GardenExposure(2024, time.UTC, 51.5074, -0.1278, ten)
+	for s, want := range [4]SunExposure{PartialSun, FullSun, FullSun, FullSun} {
+		if gs[s].Season != Season(s) || gs[s].Exposure != want || gs[s].Exposure != ClassifySunExposure(gs[s].Hours) {
+			t.Errorf("London %v = %+v, want %v", Season(s), gs[s], want)
+		}
+	}
+	if !(gs[Summer].Hours > gs[Spring].Hours && gs[Spring].Hours > gs[Winter].Hours) {
+		t.Errorf("London hours out of order: %+v", gs)
+	}
+	sydney := GardenExposure(2024, time.UTC, -33.8688, 151.2093, Horizon{})
+	if sydney[Winter].Hours >= sydney[Summer].Hours {
+		t.Errorf("Sydney winter %v, summer %v", sydney[Winter].Hours, sydney[Summer].Hours)
+	}
+}
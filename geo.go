@@ -0,0 +1,131 @@
+package sun
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// earthRadius is the mean radius of the Earth in metres
+const earthRadius = 6371008.8
+
+// Point is a place on the Earth in decimal degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Destination returns the point reached by travelling distance metres from p
+// along the great circle starting on the given bearing, in degrees east of
+// north.
+func Destination(p Point, bearing float64, distance float64) Point {
+	d := toAngle(distance / earthRadius)
+	lat := angleAsin(angleSin(p.Latitude)*angleCos(d) + angleCos(p.Latitude)*angleSin(d)*angleCos(bearing))
+	lon := p.Longitude + angleAtan2(angleSin(bearing)*angleSin(d)*angleCos(p.Latitude), angleCos(d)-angleSin(p.Latitude)*angleSin(lat))
+	return Point{lat, between(-180, 180, lon)}
+}
+
+// Distance returns the great circle distance in metres from a to b.
+func Distance(a Point, b Point) float64 {
+	return toRadians(angularDistance(a.Latitude, a.Longitude, b.Latitude, b.Longitude)) * earthRadius
+}
+
+// Bearing returns the initial bearing of the great circle from a to b, in
+// degrees east of north.
+func Bearing(a Point, b Point) float64 {
+	dLon := b.Longitude - a.Longitude
+	y := angleSin(dLon) * angleCos(b.Latitude)
+	x := angleCos(a.Latitude)*angleSin(b.Latitude) - angleSin(a.Latitude)*angleCos(b.Latitude)*angleCos(dLon)
+	return between(0, 360, angleAtan2(y, x))
+}
+
+// Intermediate returns the point the fraction f of the way along the great
+// circle from a to b.
+func Intermediate(a Point, b Point, f float64) Point {
+	return Destination(a, Bearing(a, b), f*Distance(a, b))
+}
+
+// ShadowLength returns the length of the shadow cast on level ground by an
+// object of the given height when the Sun is at altitude alt degrees. It is
+// +Inf when the Sun is on or below the horizon.
+func ShadowLength(height float64, alt float64) float64 {
+	if alt <= 0 {
+		return math.Inf(1)
+	}
+	return height / angleTan(alt)
+}
+
+// ShadowPolygon returns the outline of the shadow cast on level ground at time
+// t by a block of the given height in metres standing on footprint, which
+// should be convex and small enough to treat as flat. The outline is the
+// convex hull of the footprint and its corners pushed away from the Sun, in
+// counter-clockwise order. It is nil when the Sun is below the horizon.
+func ShadowPolygon(t time.Time, footprint []Point, height float64) []Point {
+	if len(footprint) == 0 {
+		return nil
+	}
+	ref := footprint[0]
+	alt := Altitude(t, ref.Latitude, ref.Longitude)
+	if alt <= 0 {
+		return nil
+	}
+	bearing := between(0, 360, Azimuth(t, ref.Latitude, ref.Longitude)+180)
+	length := ShadowLength(height, alt)
+	pts := append([]Point(nil), footprint...)
+	for _, p := range footprint {
+		pts = append(pts, Destination(p, bearing, length))
+	}
+	return convexHull(pts)
+}
+
+// convexHull returns the convex hull of pts counter-clockwise, treating
+// longitude and latitude as plane coordinates (Andrew's monotone chain)
+func convexHull(pts []Point) []Point {
+	pts = append([]Point(nil), pts...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].Longitude != pts[j].Longitude {
+			return pts[i].Longitude < pts[j].Longitude
+		}
+		return pts[i].Latitude < pts[j].Latitude
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+	cross := func(o, a, b Point) float64 {
+		return (a.Longitude-o.Longitude)*(b.Latitude-o.Latitude) - (a.Latitude-o.Latitude)*(b.Longitude-o.Longitude)
+	}
+	hull := make([]Point, 0, 2*len(pts))
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
+
+// groundPath returns the path of the Sun over the date of t drawn on the
+// ground around p as on a polar sun chart: each position above the horizon
+// becomes the point on its azimuth at radius·(1 - altitude/90), so the horizon
+// is the circle of the given radius in metres and the zenith is p itself
+func groundPath(t time.Time, p Point, radius float64) []Point {
+	noon := Culminate(t, p.Latitude, p.Longitude).Time
+	var path []Point
+	for s := noon.Add(-12 * time.Hour); !s.After(noon.Add(12 * time.Hour)); s = s.Add(10 * time.Minute) {
+		alt := Altitude(s, p.Latitude, p.Longitude)
+		if alt < 0 {
+			continue
+		}
+		az := Azimuth(s, p.Latitude, p.Longitude)
+		path = append(path, Destination(p, az, radius*(1-alt/90)))
+	}
+	return path
+}
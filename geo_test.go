@@ -0,0 +1,151 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	london = Point{51.5074, -0.1278}
+	paris  = Point{48.8566, 2.3522}
+)
+
+// London to Paris is 343.5 km by the haversine formula with the mean radius,
+// setting off on a bearing of 148.1 degrees.
+func TestDistanceAndBearing(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		a, b     Point
+		distance float64
+		bearing  float64
+	}{
+		{"London to Paris", london, paris, 343.5e3, 148.1},
+		{"Paris to London", paris, london, 343.5e3, 330.0},
+		{"along the equator", Point{0, 0}, Point{0, 90}, math.Pi / 2 * earthRadius, 90},
+		{"to the pole", Point{0, 10}, Point{90, 0}, math.Pi / 2 * earthRadius, 0},
+		{"due west across the date line", Point{0, 179}, Point{0, -179}, toRadians(2) * earthRadius, 90},
+	} {
+		if got := Distance(tt.a, tt.b); math.Abs(got-tt.distance) > 100 {
+			t.Errorf("%s: Distance = %.0f, want %.0f", tt.name, got, tt.distance)
+		}
+		if got := Bearing(tt.a, tt.b); math.Abs(between(-180, 180, got-tt.bearing)) > 0.1 {
+			t.Errorf("%s: Bearing = %.2f, want %.2f", tt.name, got, tt.bearing)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	for _, tt := range []struct {
+		p        Point
+		bearing  float64
+		distance float64
+		want     Point
+	}{
+		{Point{0, 0}, 90, math.Pi / 2 * earthRadius, Point{0, 90}},
+		{Point{0, 0}, 0, math.Pi / 4 * earthRadius, Point{45, 0}},
+		{Point{0, 170}, 90, toRadians(20) * earthRadius, Point{0, -170}},
+		{london, 0, 0, london},
+	} {
+		got := Destination(tt.p, tt.bearing, tt.distance)
+		if math.Abs(got.Latitude-tt.want.Latitude) > 1e-9 || math.Abs(between(-180, 180, got.Longitude-tt.want.Longitude)) > 1e-9 {
+			t.Errorf("Destination(%v, %v, %v) = %v, want %v", tt.p, tt.bearing, tt.distance, got, tt.want)
+		}
+	}
+	// going there and back again
+	there := Destination(london, Bearing(london, paris), Distance(london, paris))
+	if d := Distance(there, paris); d > 0.01 {
+		t.Errorf("Destination along the bearing to Paris stops %v m short", d)
+	}
+	mid := Intermediate(london, paris, 0.5)
+	if d := Distance(london, mid) - Distance(mid, paris); math.Abs(d) > 0.01 {
+		t.Errorf("Intermediate halfway is %v m nearer London than Paris", -d)
+	}
+}
+
+func TestShadowLength(t *testing.T) {
+	for _, tt := range []struct {
+		height, alt, want float64
+	}{
+		{10, 45, 10},
+		{10, 30, 10 * math.Sqrt(3)},
+		{10, 60, 10 / math.Sqrt(3)},
+		{10, 90, 0},
+		{10, 0, math.Inf(1)},
+		{10, -5, math.Inf(1)},
+	} {
+		if got := ShadowLength(tt.height, tt.alt); math.Abs(got-tt.want) > 1e-9 && got != tt.want {
+			t.Errorf("ShadowLength(%v, %v) = %v, want %v", tt.height, tt.alt, got, tt.want)
+		}
+	}
+}
+
+func TestShadowPolygon(t *testing.T) {
+	// a 10 m square block in London, at noon on the equinox, when the Sun is
+	// due south at 38.6 degrees and the shadow 12.5 m long points north
+	d := 10 / earthRadius * 180 / math.Pi
+	footprint := []Point{london, {london.Latitude, london.Longitude + d/math.Cos(toRadians(london.Latitude))},
+		{london.Latitude + d, london.Longitude + d/math.Cos(toRadians(london.Latitude))}, {london.Latitude + d, london.Longitude}}
+	noon := Culminate(date(time.UTC, 2024, time.March, 20), london.Latitude, london.Longitude).Time
+	shadow := ShadowPolygon(noon, footprint, 10)
+	if len(shadow) < 4 {
+		t.Fatalf("shadow has %d corners", len(shadow))
+	}
+	var north float64
+	ring := make([][2]float64, 0, len(shadow)+1)
+	for _, p := range append(shadow, shadow[0]) {
+		north = math.Max(north, p.Latitude)
+		ring = append(ring, [2]float64{p.Longitude, p.Latitude})
+	}
+	if a := signedArea(ring); a <= 0 {
+		t.Errorf("shadow runs clockwise")
+	}
+	length := (north - london.Latitude - d) * math.Pi / 180 * earthRadius
+	if want := 10 / math.Tan(toRadians(90-london.Latitude+0.1)); math.Abs(length-want) > 0.1 {
+		t.Errorf("shadow reaches %.2f m north of the block, want %.2f", length, want)
+	}
+	if s := ShadowPolygon(noon.Add(12*time.Hour), footprint, 10); s != nil {
+		t.Errorf("shadow at midnight = %v, want nil", s)
+	}
+	if s := ShadowPolygon(noon, nil, 10); s != nil {
+		t.Errorf("shadow of nothing = %v, want nil", s)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	pts := []Point{{0, 0}, {0, 2}, {2, 2}, {2, 0}, {1, 1}, {1, 0}}
+	hull := convexHull(pts)
+	// counter-clockwise with longitude across and latitude up
+	want := []Point{{0, 0}, {0, 2}, {2, 2}, {2, 0}}
+	if len(hull) != len(want) {
+		t.Fatalf("convexHull = %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Errorf("convexHull = %v, want %v", hull, want)
+			break
+		}
+	}
+	if got := convexHull([]Point{{1, 1}, {0, 0}}); len(got) != 2 || got[0] != (Point{0, 0}) {
+		t.Errorf("convexHull of two points = %v", got)
+	}
+}
+
+func TestGroundPath(t *testing.T) {
+	path := groundPath(date(time.UTC, 2024, time.June, 21), london, 100)
+	if len(path) < 90 {
+		t.Fatalf("%d points, want one every ten minutes of a 16 hour day", len(path))
+	}
+	// the noon point is due south at 100·(1 - 61.9/90) m
+	var closest float64 = math.Inf(1)
+	for _, p := range path {
+		d := Distance(london, p)
+		if d > 100.01 {
+			t.Errorf("point %v is %v m out, beyond the horizon circle", p, d)
+		}
+		closest = math.Min(closest, d)
+	}
+	if want := 100 * (1 - 61.9/90); math.Abs(closest-want) > 0.2 {
+		t.Errorf("closest point %v m out, want %v", closest, want)
+	}
+}
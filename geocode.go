@@ -0,0 +1,81 @@
+package sun
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrPlaceNotFound is returned by a Geocoder that knows no place by the name
+// it was asked for.
+var ErrPlaceNotFound = errors.New("sun: place not found")
+
+// GeocodeResult is a place found by a Geocoder.
+type GeocodeResult struct {
+	Name string
+	Point
+	// Elevation is the height of the ground in metres above sea level, zero
+	// if the geocoder does not know it.
+	Elevation float64
+	// Location is the time zone kept there, nil if the geocoder does not know
+	// it.
+	Location *time.Location
+}
+
+// Geocoder resolves place names such as "Paris, France" to locations. The
+// package has no geocoder of its own, so as to stay free of network access;
+// callers supply one backed by the service or gazetteer of their choice, and
+// the tools and HTTP handlers accept place names when given one. It should
+// return ErrPlaceNotFound when there is no such place.
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (GeocodeResult, error)
+}
+
+// GeocoderFunc adapts a function to a Geocoder.
+type GeocoderFunc func(ctx context.Context, query string) (GeocodeResult, error)
+
+// Geocode calls f.
+func (f GeocoderFunc) Geocode(ctx context.Context, query string) (GeocodeResult, error) {
+	return f(ctx, query)
+}
+
+// Resolve returns the location given by query, either decimal degrees of
+// latitude and longitude separated by a comma, such as "48.857, 2.352", or a
+// place name looked up with g. g may be nil if only coordinates are expected.
+func Resolve(ctx context.Context, query string, g Geocoder) (GeocodeResult, error) {
+	if p, ok := parsePoint(query); ok {
+		return GeocodeResult{Name: strings.TrimSpace(query), Point: p}, nil
+	}
+	if g == nil {
+		return GeocodeResult{}, ErrPlaceNotFound
+	}
+	return g.Geocode(ctx, query)
+}
+
+// parsePoint parses "latitude, longitude" in decimal degrees
+func parsePoint(s string) (Point, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return Point{}, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err1 != nil || err2 != nil || math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return Point{}, false
+	}
+	return Point{lat, lon}, true
+}
+
+// Geocode looks up a profile by name, ignoring case, so a configuration can
+// serve as a small gazetteer of its own.
+func (c *ProfileConfig) Geocode(ctx context.Context, query string) (GeocodeResult, error) {
+	for name, p := range c.Profiles {
+		if strings.EqualFold(name, strings.TrimSpace(query)) {
+			return GeocodeResult{Name: name, Point: p.Point, Elevation: p.Elevation, Location: p.Location}, nil
+		}
+	}
+	return GeocodeResult{}, ErrPlaceNotFound
+}
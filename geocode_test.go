@@ -0,0 +1,57 @@
+package sun
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	paris := GeocodeResult{Name: "Paris", Point: Point{48.8566, 2.3522}, Elevation: 35}
+	g := GeocoderFunc(func(ctx context.Context, query string) (GeocodeResult, error) {
+		if strings.HasPrefix(query, "Paris") {
+			return paris, nil
+		}
+		return GeocodeResult{}, ErrPlaceNotFound
+	})
+	for _, tt := range []struct {
+		query string
+		g     Geocoder
+		want  GeocodeResult
+		err   error
+	}{
+		// the Eiffel Tower
+		{" 48.858, 2.2945 ", nil, GeocodeResult{Name: "48.858, 2.2945", Point: Point{48.858, 2.2945}}, nil},
+		{"-33.8568,151.2153", g, GeocodeResult{Name: "-33.8568,151.2153", Point: Point{-33.8568, 151.2153}}, nil},
+		{"Paris, France", g, paris, nil},
+		{"Paris, France", nil, GeocodeResult{}, ErrPlaceNotFound},
+		{"91, 0", g, GeocodeResult{}, ErrPlaceNotFound},
+		{"Atlantis", g, GeocodeResult{}, ErrPlaceNotFound},
+	} {
+		got, err := Resolve(context.Background(), tt.query, tt.g)
+		if got != tt.want || err != tt.err {
+			t.Errorf("Resolve(%q) = %+v, %v, want %+v, %v", tt.query, got, err, tt.want, tt.err)
+		}
+	}
+
+	failed := errors.New("timeout")
+	down := GeocoderFunc(func(context.Context, string) (GeocodeResult, error) { return GeocodeResult{}, failed })
+	if _, err := Resolve(context.Background(), "Paris", down); err != failed {
+		t.Errorf("error %v, want %v", err, failed)
+	}
+}
+
+func TestProfileConfigGeocode(t *testing.T) {
+	c := &ProfileConfig{Profiles: map[string]Profile{
+		"Home": {Place: Place{Name: "Home", Point: Point{51.48, 0}, Location: time.UTC}, Elevation: 46},
+	}}
+	got, err := c.Geocode(context.Background(), " home ")
+	if err != nil || got.Name != "Home" || got.Point != (Point{51.48, 0}) || got.Elevation != 46 || got.Location != time.UTC {
+		t.Errorf("Geocode(home) = %+v, %v", got, err)
+	}
+	if _, err := c.Geocode(context.Background(), "office"); err != ErrPlaceNotFound {
+		t.Errorf("Geocode(office) error %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// GeoJSONFeature is a GeoJSON feature, ready for encoding/json.
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONGeometry is a GeoJSON geometry. Coordinates holds [longitude,
+// latitude] pairs nested as the geometry type requires.
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSONFeatureCollection is a GeoJSON feature collection.
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// NewGeoJSONFeatureCollection returns a collection of the given features.
+func NewGeoJSONFeatureCollection(features ...GeoJSONFeature) GeoJSONFeatureCollection {
+	return GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// EventRayFeatures returns LineString features of the given length in metres
+// from p along the bearings of sunrise and sunset on the date of t. The
+// properties are event ("sunrise" or "sunset"), time (RFC 3339) and azimuth.
+// Events that do not happen that day are left out.
+func EventRayFeatures(t time.Time, p Point, length float64) []GeoJSONFeature {
+	var fs []GeoJSONFeature
+	add := func(event string, at time.Time) {
+		az := Azimuth(at, p.Latitude, p.Longitude)
+		fs = append(fs, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: GeoJSONGeometry{"LineString", lonLats([]Point{p, Destination(p, az, length)})},
+			Properties: map[string]interface{}{
+				"event":   event,
+				"time":    at.Format(time.RFC3339),
+				"azimuth": az,
+			},
+		})
+	}
+	if rise, ok := Sunrise(t, p.Latitude, p.Longitude); ok {
+		add("sunrise", rise)
+	}
+	if set, ok := Sunset(t, p.Latitude, p.Longitude); ok {
+		add("sunset", set)
+	}
+	return fs
+}
+
+// SunSectorFeature returns a Polygon feature for the sector of directions the
+// Sun sweeps through above the horizon on the date of t, as a wedge of the
+// given radius in metres from p: from the sunrise bearing round past the noon
+// bearing to the sunset bearing. On a day when the Sun never sets it is the
+// whole circle; on a day it never rises ok is false. The properties are
+// sunriseAzimuth and sunsetAzimuth when the Sun rises and sets. The ring runs
+// anticlockwise, as RFC 7946 requires of an exterior ring.
+func SunSectorFeature(t time.Time, p Point, radius float64) (feature GeoJSONFeature, ok bool) {
+	rise, ok1 := Sunrise(t, p.Latitude, p.Longitude)
+	set, ok2 := Sunset(t, p.Latitude, p.Longitude)
+	props := map[string]interface{}{}
+	var ring []Point
+	if ok1 && ok2 {
+		from, to := Azimuth(rise, p.Latitude, p.Longitude), Azimuth(set, p.Latitude, p.Longitude)
+		noon := Azimuth(Culminate(t, p.Latitude, p.Longitude).Time, p.Latitude, p.Longitude)
+		// sweep clockwise if that passes the noon azimuth, otherwise anticlockwise
+		sweep := between(0, 360, to-from)
+		if between(0, 360, noon-from) > sweep {
+			sweep -= 360
+		}
+		// walk the arc against the compass, which turns clockwise
+		start := from
+		if sweep > 0 {
+			start, sweep = to, -sweep
+		}
+		ring = append(ring, p)
+		n := int(math.Ceil(math.Abs(sweep)/2)) + 1
+		for i := 0; i <= n; i++ {
+			ring = append(ring, Destination(p, start+sweep*float64(i)/float64(n), radius))
+		}
+		ring = append(ring, p)
+		props["sunriseAzimuth"], props["sunsetAzimuth"] = from, to
+	} else {
+		if Culminate(t, p.Latitude, p.Longitude).Altitude < SunriseAltitude {
+			return feature, false
+		}
+		for az := 360.0; az >= 0; az -= 2 {
+			ring = append(ring, Destination(p, az, radius))
+		}
+	}
+	return GeoJSONFeature{
+		Type:       "Feature",
+		Geometry:   GeoJSONGeometry{"Polygon", [][][2]float64{lonLats(ring)}},
+		Properties: props,
+	}, true
+}
+
+// lonLats returns pts as GeoJSON positions
+func lonLats(pts []Point) [][2]float64 {
+	c := make([][2]float64, len(pts))
+	for i, p := range pts {
+		c[i] = [2]float64{p.Longitude, p.Latitude}
+	}
+	return c
+}
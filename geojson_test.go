@@ -0,0 +1,80 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// signedArea returns twice the signed area of a closed ring of [longitude,
+// latitude] positions, positive when it runs anticlockwise
+func signedArea(ring [][2]float64) float64 {
+	var a float64
+	for i := 0; i+1 < len(ring); i++ {
+		a += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return a
+}
+
+func TestSunSectorFeatureWinding(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		t    time.Time
+		p    Point
+	}{
+		{"London midsummer", time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), Point{51.5, -0.13}},
+		{"London midwinter", time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), Point{51.5, -0.13}},
+		{"Sydney", time.Date(2024, 6, 21, 2, 0, 0, 0, time.UTC), Point{-33.87, 151.21}},
+		{"Quito", time.Date(2024, 3, 20, 17, 0, 0, 0, time.UTC), Point{-0.18, -78.47}},
+		{"Tromsø polar day", time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), Point{69.65, 18.96}},
+	} {
+		f, ok := SunSectorFeature(tt.t, tt.p, 1000)
+		if !ok {
+			t.Errorf("%s: no sector", tt.name)
+			continue
+		}
+		ring := f.Geometry.Coordinates.([][][2]float64)[0]
+		if ring[0] != ring[len(ring)-1] {
+			t.Errorf("%s: ring is not closed", tt.name)
+		}
+		if a := signedArea(ring); a <= 0 {
+			t.Errorf("%s: ring runs clockwise (signed area %g)", tt.name, a)
+		}
+	}
+}
+
+func TestSunSectorFeaturePolarNight(t *testing.T) {
+	if _, ok := SunSectorFeature(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), Point{69.65, 18.96}, 1000); ok {
+		t.Error("sector returned in the polar night")
+	}
+}
+
+func TestSunSectorFeatureProperties(t *testing.T) {
+	// at Greenwich on the June solstice, with δ = 23.44 and h = -0.833, the
+	// bearing of sunrise is acos((sin δ - sin φ sin h) / (cos φ cos h)) = 48.91
+	f, _ := SunSectorFeature(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), Point{51.4779, -0.0015}, 1000)
+	for _, tt := range []struct {
+		key  string
+		want float64
+	}{{"sunriseAzimuth", 48.91}, {"sunsetAzimuth", 311.09}} {
+		if got := f.Properties[tt.key].(float64); math.Abs(got-tt.want) > 0.1 {
+			t.Errorf("%s = %.2f, want %.2f", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestEventRayFeatures(t *testing.T) {
+	fs := EventRayFeatures(time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC), Point{0, 0}, 10000)
+	if len(fs) != 2 {
+		t.Fatalf("%d features, want 2", len(fs))
+	}
+	// at the equinox on the equator the Sun rises due east and sets due west
+	for i, want := range []float64{90, 270} {
+		if got := fs[i].Properties["azimuth"].(float64); math.Abs(got-want) > 0.5 {
+			t.Errorf("%s azimuth = %.2f, want %v", fs[i].Properties["event"], got, want)
+		}
+	}
+	if fs := EventRayFeatures(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), Point{78.22, 15.65}, 1000); len(fs) != 0 {
+		t.Errorf("%d features in the polar night, want 0", len(fs))
+	}
+}
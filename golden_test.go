@@ -0,0 +1,136 @@
+package sun
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// goldenCities are the reference cities of cmd/gengolden
+var goldenCities = map[string][2]float64{
+	"Reykjavik": {64.1466, -21.9426},
+	"Tromso":    {69.6492, 18.9553},
+	"London":    {51.4779, -0.0015},
+	"New York":  {40.7128, -74.0060},
+	"Singapore": {1.3521, 103.8198},
+	"Nairobi":   {-1.2921, 36.8219},
+	"Sydney":    {-33.8688, 151.2093},
+	"Auckland":  {-36.8485, 174.7633},
+	"Ushuaia":   {-54.8019, -68.3030},
+}
+
+// loadGolden returns the rows of testdata/golden.csv keyed by city and date
+func loadGolden(t *testing.T) map[[2]string][]string {
+	t.Helper()
+	f, err := os.Open("testdata/golden.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 433 {
+		t.Fatalf("%d rows, want 433", len(rows))
+	}
+	golden := make(map[[2]string][]string)
+	for _, r := range rows[1:] {
+		golden[[2]string{r[0], r[1]}] = r
+	}
+	return golden
+}
+
+// The package must not drift from the golden almanac by more than the
+// defaults of gengolden -diff, a second in a time and 0.0001 degree in an
+// angle, and no event may appear or vanish.
+func TestGolden(t *testing.T) {
+	for key, g := range loadGolden(t) {
+		c, ok := goldenCities[key[0]]
+		if !ok {
+			t.Fatalf("unknown city %q", key[0])
+		}
+		d, err := time.Parse("2006-01-02", key[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		rise, okRise := Sunrise(d, c[0], c[1])
+		set, okSet := Sunset(d, c[0], c[1])
+		tr := Culminate(d, c[0], c[1])
+		noon := d.Add(12 * time.Hour)
+		for i, want := range []struct {
+			at time.Time
+			ok bool
+		}{{rise, okRise}, {set, okSet}, {tr.Time, true}} {
+			if (g[2+i] != "") != want.ok {
+				t.Errorf("%v column %d: event %q, got %v", key, 2+i, g[2+i], want.ok)
+				continue
+			}
+			if !want.ok {
+				continue
+			}
+			at, err := time.Parse(time.RFC3339, g[2+i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !within(want.at, at, time.Second) {
+				t.Errorf("%v column %d = %v, golden %v", key, 2+i, want.at.UTC(), at)
+			}
+		}
+		for i, want := range []float64{
+			tr.Altitude,
+			AltitudeWith(LowPrecision{}, noon, c[0], c[1]),
+			AzimuthWith(LowPrecision{}, noon, c[0], c[1]),
+		} {
+			v, err := strconv.ParseFloat(g[5+i], 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(between(-180, 180, want-v)) > 0.0001 {
+				t.Errorf("%v column %d = %.6f, golden %v", key, 5+i, want, v)
+			}
+		}
+	}
+}
+
+// Spot checks of the golden file against published sunrise and sunset times,
+// to the minute: London 04:42 and 21:19 BST on 15 June 2024 and 07:59 and
+// 15:51 GMT on 15 December, New York 05:29 and 20:31 EDT on 1 July, Sydney
+// 05:47 and 20:09 AEDT on 1 January and Singapore 07:10 and 19:16 on 15 March.
+// Tromsø has midnight sun in June and polar night in December.
+func TestGoldenAlmanac(t *testing.T) {
+	golden := loadGolden(t)
+	for _, tt := range []struct {
+		city, date      string
+		sunrise, sunset string
+	}{
+		{"London", "2024-06-15", "2024-06-15T03:42:00Z", "2024-06-15T20:19:00Z"},
+		{"London", "2024-12-15", "2024-12-15T07:59:00Z", "2024-12-15T15:51:00Z"},
+		{"New York", "2024-07-01", "2024-07-01T09:29:00Z", "2024-07-02T00:31:00Z"},
+		{"Sydney", "2024-01-01", "2023-12-31T18:47:00Z", "2024-01-01T09:09:00Z"},
+		{"Singapore", "2024-03-15", "2024-03-14T23:10:00Z", "2024-03-15T11:16:00Z"},
+		{"Tromso", "2024-06-15", "", ""},
+		{"Tromso", "2024-12-15", "", ""},
+	} {
+		g := golden[[2]string{tt.city, tt.date}]
+		if g == nil {
+			t.Fatalf("no row for %s %s", tt.city, tt.date)
+		}
+		for i, want := range []string{tt.sunrise, tt.sunset} {
+			if want == "" || g[2+i] == "" {
+				if want != g[2+i] {
+					t.Errorf("%s %s column %d = %q, want %q", tt.city, tt.date, 2+i, g[2+i], want)
+				}
+				continue
+			}
+			a, _ := time.Parse(time.RFC3339, g[2+i])
+			b, _ := time.Parse(time.RFC3339, want)
+			if !within(a, b, time.Minute) {
+				t.Errorf("%s %s column %d = %v, want %v", tt.city, tt.date, 2+i, a, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sun
+
+import (
+	"encoding/csv"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Heatmap is the altitude of the Sun at one location through a year, sampled
+// at the same clock times each day, for the classic daylight poster.
+type Heatmap struct {
+	Year        int
+	Location    *time.Location
+	StepsPerDay int
+	// Altitude[d][s] is the altitude in degrees on day d of the year, counting
+	// from 0, at s/StepsPerDay of the way through the clock day.
+	Altitude [][]float64
+}
+
+// NewHeatmap samples the altitude of the Sun stepsPerDay times a day through
+// the given year, at clock times in loc. 24 gives the hourly 365×24 matrix;
+// 96 or 288 give smoother posters.
+func NewHeatmap(year int, loc *time.Location, latitude float64, longitude float64, stepsPerDay int) *Heatmap {
+	if stepsPerDay < 1 {
+		stepsPerDay = 1
+	}
+	h := &Heatmap{Year: year, Location: loc, StepsPerDay: stepsPerDay}
+	for d := 0; time.Date(year, 1, 1+d, 0, 0, 0, 0, loc).Year() == year; d++ {
+		row := make([]float64, stepsPerDay)
+		for s := range row {
+			t := time.Date(year, 1, 1+d, 0, 0, s*86400/stepsPerDay, 0, loc)
+			row[s] = Altitude(t, latitude, longitude)
+		}
+		h.Altitude = append(h.Altitude, row)
+	}
+	return h
+}
+
+// WriteCSV writes the heatmap with one row per day, headed by the date, and
+// one column per clock time, headed HH:MM.
+func (h *Heatmap) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, h.StepsPerDay+1)
+	record[0] = "date"
+	for s := 0; s < h.StepsPerDay; s++ {
+		record[s+1] = time.Date(0, 1, 1, 0, 0, s*86400/h.StepsPerDay, 0, time.UTC).Format("15:04")
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for d, row := range h.Altitude {
+		record[0] = time.Date(h.Year, 1, 1+d, 0, 0, 0, 0, h.Location).Format("2006-01-02")
+		for s, alt := range row {
+			record[s+1] = strconv.FormatFloat(alt, 'f', 2, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Image returns the heatmap as an image with one pixel per sample, days running
+// left to right and the clock day top to bottom. Night is dark blue, twilight
+// shades lighter towards the horizon, and day runs from orange at the horizon
+// to pale yellow overhead.
+func (h *Heatmap) Image() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, len(h.Altitude), h.StepsPerDay))
+	for d, row := range h.Altitude {
+		for s, alt := range row {
+			img.Set(d, s, AltitudeColor(alt))
+		}
+	}
+	return img
+}
+
+// WritePNG writes the heatmap image as a PNG.
+func (h *Heatmap) WritePNG(w io.Writer) error {
+	return png.Encode(w, h.Image())
+}
+
+// AltitudeColor returns the colour the heatmap uses for a solar altitude.
+func AltitudeColor(alt float64) color.RGBA {
+	night := color.RGBA{12, 14, 40, 255}
+	horizonBlue := color.RGBA{90, 120, 190, 255}
+	horizonSun := color.RGBA{245, 150, 40, 255}
+	zenith := color.RGBA{255, 250, 200, 255}
+	switch {
+	case alt < AstronomicalTwilightAltitude:
+		return night
+	case alt < 0:
+		// graded in the three twilight bands
+		band := float64(int((alt-AstronomicalTwilightAltitude)/6)+1) / 3
+		return lerpColor(night, horizonBlue, band)
+	}
+	return lerpColor(horizonSun, zenith, alt/90)
+}
+
+func lerpColor(a color.RGBA, b color.RGBA, f float64) color.RGBA {
+	if f < 0 {
+		f = 0
+	} else if f > 1 {
+		f = 1
+	}
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*f + 0.5)
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
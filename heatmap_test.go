@@ -0,0 +1,120 @@
+package sun
+
+import (
+	"bytes"
+	"encoding/csv"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	loc := location(t, "Europe/London")
+	for _, tt := range []struct {
+		year, steps, days, wantSteps int
+	}{
+		{2024, 24, 366, 24},
+		{2023, 96, 365, 96},
+		{2023, 0, 365, 1},
+	} {
+		h := NewHeatmap(tt.year, loc, 51.5074, -0.1278, tt.steps)
+		if len(h.Altitude) != tt.days || h.StepsPerDay != tt.wantSteps || len(h.Altitude[0]) != tt.wantSteps {
+			t.Errorf("NewHeatmap(%d, %d) is %d days of %d steps, want %d of %d", tt.year, tt.steps, len(h.Altitude), h.StepsPerDay, tt.days, tt.wantSteps)
+		}
+	}
+
+	h := NewHeatmap(2024, loc, 51.5074, -0.1278, 24)
+	// samples are at clock times, so 13:00 in summer is 12:00 UT
+	for _, tt := range []struct {
+		day, step int
+		t         time.Time
+	}{
+		{0, 0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{172, 13, time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)},
+		{365, 23, time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)},
+	} {
+		if got, want := h.Altitude[tt.day][tt.step], Altitude(tt.t, 51.5074, -0.1278); got != want {
+			t.Errorf("Altitude[%d][%d] = %v, want %v at %v", tt.day, tt.step, got, want, tt.t)
+		}
+	}
+	// the highest sample of the year is at 13:00 BST near midsummer, a few
+	// minutes from noon and so just under the noon altitude of 61.9
+	best, day, step := -90.0, 0, 0
+	for d, row := range h.Altitude {
+		for s, a := range row {
+			if a > best {
+				best, day, step = a, d, s
+			}
+		}
+	}
+	if best < 61.8 || best > 61.94 || step != 13 || day < 165 || day > 180 {
+		t.Errorf("highest sample %v on day %d at step %d, want just under 61.9 at 13:00 in late June", best, day, step)
+	}
+}
+
+func TestHeatmapCSV(t *testing.T) {
+	h := NewHeatmap(2023, time.UTC, 0, 0, 4)
+	var b bytes.Buffer
+	if err := h.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 366 {
+		t.Fatalf("%d rows, want a header and 365 days", len(rows))
+	}
+	if got := rows[0]; len(got) != 5 || got[0] != "date" || got[1] != "00:00" || got[2] != "06:00" || got[4] != "18:00" {
+		t.Errorf("header = %v", got)
+	}
+	// a day after the equinox the Sun passes nearly overhead, but at noon UT
+	// it is still 7 minutes short of the meridian, by the equation of time
+	if got := rows[80]; got[0] != "2023-03-21" || got[3] != "88.17" {
+		t.Errorf("row for 21 March = %v, want 88.17 at 12:00", got)
+	}
+}
+
+func TestHeatmapImage(t *testing.T) {
+	h := NewHeatmap(2023, time.UTC, 51.5, 0, 24)
+	img := h.Image()
+	if b := img.Bounds(); b.Dx() != 365 || b.Dy() != 24 {
+		t.Fatalf("image is %v, want 365×24", b)
+	}
+	if got := color.RGBAModel.Convert(img.At(0, 0)); got != AltitudeColor(h.Altitude[0][0]) {
+		t.Errorf("pixel at midnight on 1 January = %v, want %v", got, AltitudeColor(h.Altitude[0][0]))
+	}
+	var b bytes.Buffer
+	if err := h.WritePNG(&b); err != nil {
+		t.Fatal(err)
+	}
+	dec, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec.Bounds() != img.Bounds() {
+		t.Errorf("PNG is %v, want %v", dec.Bounds(), img.Bounds())
+	}
+}
+
+func TestAltitudeColor(t *testing.T) {
+	for _, tt := range []struct {
+		alt  float64
+		want color.RGBA
+	}{
+		{-40, color.RGBA{12, 14, 40, 255}},
+		{-18.5, color.RGBA{12, 14, 40, 255}},
+		{-15, color.RGBA{38, 49, 90, 255}},
+		{-9, color.RGBA{64, 85, 140, 255}},
+		{-3, color.RGBA{90, 120, 190, 255}},
+		{0, color.RGBA{245, 150, 40, 255}},
+		{45, color.RGBA{250, 200, 120, 255}},
+		{90, color.RGBA{255, 250, 200, 255}},
+		{100, color.RGBA{255, 250, 200, 255}},
+	} {
+		if got := AltitudeColor(tt.alt); got != tt.want {
+			t.Errorf("AltitudeColor(%v) = %v, want %v", tt.alt, got, tt.want)
+		}
+	}
+}
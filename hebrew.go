@@ -0,0 +1,132 @@
+package sun
+
+import (
+	"strconv"
+	"time"
+)
+
+// HebrewDate is a date in the Hebrew calendar. Months are numbered from 1 for
+// Nisan, as in the Bible, so the year, which begins on 1 Tishrei, starts with
+// month 7. Month 12 is Adar, or Adar I in a leap year, and month 13 is Adar II.
+type HebrewDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+var hebrewMonths = [...]string{"Nisan", "Iyar", "Sivan", "Tammuz", "Av", "Elul",
+	"Tishrei", "Cheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II"}
+
+// String returns the date as, for example, "1 Tishrei 5785".
+func (d HebrewDate) String() string {
+	name := "?"
+	if d.Month >= 1 && d.Month <= 13 {
+		name = hebrewMonths[d.Month-1]
+		if d.Month == 12 && hebrewLeap(d.Year) {
+			name = "Adar I"
+		}
+	}
+	return strconv.Itoa(d.Day) + " " + name + " " + strconv.Itoa(d.Year)
+}
+
+// ToHebrew returns the Hebrew date matching the civil date of t in its time
+// zone. As with ToHijri, the Hebrew day begins the evening before, at
+// nightfall.
+func ToHebrew(t time.Time) HebrewDate {
+	n := dayNumber(t)
+	year := (n-hebrewEpoch)*98496/35975351 - 1
+	for n >= hebrewDayNumber(year+1, 7, 1) {
+		year++
+	}
+	month := 1
+	if n < hebrewDayNumber(year, 1, 1) {
+		month = 7
+	}
+	for n > hebrewDayNumber(year, month, hebrewMonthDays(year, month)) {
+		month++
+	}
+	return HebrewDate{year, month, n - hebrewDayNumber(year, month, 1) + 1}
+}
+
+// Date returns midnight at the start of the civil date matching d, in loc.
+func (d HebrewDate) Date(loc *time.Location) time.Time {
+	return dayNumberDate(hebrewDayNumber(d.Year, d.Month, d.Day), loc)
+}
+
+// hebrewEpoch is the julian day number of the day before 1 Tishrei AM 1
+const hebrewEpoch = 347997
+
+// hebrewLeap reports whether year has 13 months
+func hebrewLeap(year int) bool {
+	return (year*7+1)%19 < 7
+}
+
+// hebrewElapsed returns the days from the epoch to the molad of Tishrei of
+// year, put off a day when it falls on a Sunday, Wednesday or Friday
+func hebrewElapsed(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	day := months*29 + parts/25920
+	if (3*(day+1))%7 < 3 {
+		day++
+	}
+	return day
+}
+
+// hebrewDelay returns the further postponement of 1 Tishrei of year that keeps
+// the years next to it to an allowed length
+func hebrewDelay(year int) int {
+	last, present, next := hebrewElapsed(year-1), hebrewElapsed(year), hebrewElapsed(year+1)
+	switch {
+	case next-present == 356:
+		return 2
+	case present-last == 382:
+		return 1
+	}
+	return 0
+}
+
+// hebrewYearDays returns the number of days in year
+func hebrewYearDays(year int) int {
+	return hebrewDayNumber(year+1, 7, 1) - hebrewDayNumber(year, 7, 1)
+}
+
+// hebrewMonthDays returns the number of days in month of year
+func hebrewMonthDays(year int, month int) int {
+	switch {
+	case month == 2 || month == 4 || month == 6 || month == 10 || month == 13:
+		return 29
+	case month == 12 && !hebrewLeap(year):
+		return 29
+	case month == 8 && hebrewYearDays(year)%10 != 5:
+		// Cheshvan is long only in a complete year
+		return 29
+	case month == 9 && hebrewYearDays(year)%10 == 3:
+		// Kislev is short in a deficient year
+		return 29
+	}
+	return 30
+}
+
+// hebrewDayNumber returns the julian day number of a Hebrew date, after the
+// algorithm of the Fourmilab calendar converter
+func hebrewDayNumber(year int, month int, day int) int {
+	n := hebrewEpoch + hebrewElapsed(year) + hebrewDelay(year) + day
+	months := 12
+	if hebrewLeap(year) {
+		months = 13
+	}
+	if month < 7 {
+		for m := 7; m <= months; m++ {
+			n += hebrewMonthDays(year, m)
+		}
+		for m := 1; m < month; m++ {
+			n += hebrewMonthDays(year, m)
+		}
+	} else {
+		for m := 7; m < month; m++ {
+			n += hebrewMonthDays(year, m)
+		}
+	}
+	return n
+}
@@ -0,0 +1,74 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// Rosh Hashanah, Yom Kippur, Passover, Purim and Hanukkah from the Hebrew
+// calendar tables, for the day that begins at nightfall the evening before.
+func TestHebrewDate(t *testing.T) {
+	for _, tt := range []struct {
+		h    HebrewDate
+		want time.Time
+		name string
+	}{
+		{HebrewDate{5760, 7, 1}, date(time.UTC, 1999, time.September, 11), "1 Tishrei 5760"},
+		{HebrewDate{5784, 7, 1}, date(time.UTC, 2023, time.September, 16), "1 Tishrei 5784"},
+		{HebrewDate{5784, 12, 1}, date(time.UTC, 2024, time.February, 10), "1 Adar I 5784"},
+		{HebrewDate{5784, 13, 14}, date(time.UTC, 2024, time.March, 24), "14 Adar II 5784"},
+		{HebrewDate{5784, 1, 15}, date(time.UTC, 2024, time.April, 23), "15 Nisan 5784"},
+		{HebrewDate{5785, 7, 1}, date(time.UTC, 2024, time.October, 3), "1 Tishrei 5785"},
+		{HebrewDate{5785, 7, 10}, date(time.UTC, 2024, time.October, 12), "10 Tishrei 5785"},
+		{HebrewDate{5785, 9, 25}, date(time.UTC, 2024, time.December, 26), "25 Kislev 5785"},
+		{HebrewDate{5785, 12, 14}, date(time.UTC, 2025, time.March, 14), "14 Adar 5785"},
+		{HebrewDate{5786, 7, 1}, date(time.UTC, 2025, time.September, 23), "1 Tishrei 5786"},
+	} {
+		if got := tt.h.Date(time.UTC); !got.Equal(tt.want) {
+			t.Errorf("%v.Date() = %v, want %v", tt.h, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+		if got := ToHebrew(tt.want); got != tt.h {
+			t.Errorf("ToHebrew(%v) = %v, want %v", tt.want.Format("2006-01-02"), got, tt.h)
+		}
+		if got := tt.h.String(); got != tt.name {
+			t.Errorf("String() = %q, want %q", got, tt.name)
+		}
+	}
+	if got := (HebrewDate{5785, 14, 1}).String(); got != "1 ? 5785" {
+		t.Errorf("String() of month 14 = %q", got)
+	}
+}
+
+// 5784 is a deficient leap year of 383 days and 5785 a complete common year of
+// 355, with 30 days in Cheshvan.
+func TestHebrewYear(t *testing.T) {
+	for _, tt := range []struct {
+		year, days, cheshvan, kislev int
+		leap                         bool
+	}{
+		{5783, 355, 30, 30, false},
+		{5784, 383, 29, 29, true},
+		{5785, 355, 30, 30, false},
+		{5786, 354, 29, 30, false},
+	} {
+		if got := hebrewYearDays(tt.year); got != tt.days {
+			t.Errorf("%d has %d days, want %d", tt.year, got, tt.days)
+		}
+		if hebrewLeap(tt.year) != tt.leap {
+			t.Errorf("%d leap = %v", tt.year, !tt.leap)
+		}
+		if c, k := hebrewMonthDays(tt.year, 8), hebrewMonthDays(tt.year, 9); c != tt.cheshvan || k != tt.kislev {
+			t.Errorf("%d Cheshvan %d and Kislev %d days, want %d and %d", tt.year, c, k, tt.cheshvan, tt.kislev)
+		}
+	}
+
+	// each civil day is the day after the one before
+	prev := ToHebrew(date(time.UTC, 1999, time.December, 31))
+	for d := date(time.UTC, 2000, time.January, 1); d.Year() < 2031; d = d.AddDate(0, 0, 1) {
+		h := ToHebrew(d)
+		if !h.Date(time.UTC).Equal(d) || (h.Day != prev.Day+1 && h.Day != 1) {
+			t.Fatalf("ToHebrew(%v) = %v after %v", d.Format("2006-01-02"), h, prev)
+		}
+		prev = h
+	}
+}
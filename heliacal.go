@@ -0,0 +1,106 @@
+package sun
+
+import "time"
+
+// Star is a fixed star, with its J2000 position in degrees.
+type Star struct {
+	RightAscension float64
+	Declination    float64
+	Magnitude      float64
+}
+
+// position returns the altitude and azimuth of s at t, precessed from J2000
+// to the date
+func (s Star) position(t time.Time, latitude float64, longitude float64) (altitude float64, azimuth float64) {
+	jd := timeToJD(t)
+	v := [3]float64{
+		angleCos(s.Declination) * angleCos(s.RightAscension),
+		angleCos(s.Declination) * angleSin(s.RightAscension),
+		angleSin(s.Declination),
+	}
+	v = precessJ2000(v, getJdn(jd)/36525)
+	ra, dec := angleAtan2(v[1], v[0]), angleAsin(v[2])
+	ha := getHourAngle(jd, longitude, ra)
+	altitude = angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	return altitude, getAzimuth(latitude, dec, ha)
+}
+
+// heliacalStep is how often the twilight is sampled for a sighting
+const heliacalStep = 2 * time.Minute
+
+// visibleInTwilight reports whether star s can be seen at any moment of the
+// morning (rising) or evening twilight on the date of t, from when the Sun is
+// 18 degrees down, or its lowest if it gets no lower, to sunrise or sunset.
+func (c SkyConditions) visibleInTwilight(s Star, t time.Time, latitude float64, longitude float64, rising bool) bool {
+	horizon, ok := crossing(t, latitude, longitude, 0, rising, defaultTolerance)
+	if !ok {
+		return false
+	}
+	dark, ok := crossing(t, latitude, longitude, AstronomicalTwilightAltitude, rising, defaultTolerance)
+	if !ok {
+		dark = Culminate(t, latitude, longitude).Time.Add(-12 * time.Hour)
+		if !rising {
+			dark = dark.Add(24 * time.Hour)
+		}
+	}
+	from, to := dark, horizon
+	if !rising {
+		from, to = horizon, dark
+	}
+	for at := from; at.Before(to); at = at.Add(heliacalStep) {
+		alt, az := s.position(at, latitude, longitude)
+		if alt > 0 && s.Magnitude <= c.LimitingMagnitude(at, latitude, longitude, alt, az) {
+			return true
+		}
+	}
+	return false
+}
+
+// HeliacalRising returns the date in the given year of the heliacal rising of
+// star s at the given location: the first morning it can be seen in the dawn
+// twilight after weeks lost in the glare of the Sun. The date is midnight in
+// loc, and ok is false if there is no such morning that year, as for a star
+// that never sets or never rises.
+//
+// The star is seen if at some moment of the twilight it is brighter than
+// the limiting magnitude of DefaultSkyConditions where it stands. Its position
+// is precessed from J2000, so ancient dates can be given, though for them the
+// proleptic Gregorian calendar of time.Time applies.
+func HeliacalRising(year int, loc *time.Location, s Star, latitude float64, longitude float64) (date time.Time, ok bool) {
+	return DefaultSkyConditions.HeliacalRising(year, loc, s, latitude, longitude)
+}
+
+// HeliacalRising returns the heliacal rising as the package function
+// HeliacalRising does, in the conditions c.
+func (c SkyConditions) HeliacalRising(year int, loc *time.Location, s Star, latitude float64, longitude float64) (date time.Time, ok bool) {
+	prev := c.visibleInTwilight(s, time.Date(year, 1, 0, 0, 0, 0, 0, loc), latitude, longitude, true)
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		v := c.visibleInTwilight(s, d, latitude, longitude, true)
+		if v && !prev {
+			return d, true
+		}
+		prev = v
+	}
+	return time.Time{}, false
+}
+
+// HeliacalSetting returns the date in the given year of the heliacal setting of
+// star s: the last evening it can be seen in the dusk twilight before it is
+// lost in the glare of the Sun. It is otherwise as for HeliacalRising.
+func HeliacalSetting(year int, loc *time.Location, s Star, latitude float64, longitude float64) (date time.Time, ok bool) {
+	return DefaultSkyConditions.HeliacalSetting(year, loc, s, latitude, longitude)
+}
+
+// HeliacalSetting returns the heliacal setting as the package function
+// HeliacalSetting does, in the conditions c.
+func (c SkyConditions) HeliacalSetting(year int, loc *time.Location, s Star, latitude float64, longitude float64) (date time.Time, ok bool) {
+	prev := c.visibleInTwilight(s, time.Date(year, 1, 1, 0, 0, 0, 0, loc), latitude, longitude, false)
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		next := c.visibleInTwilight(s, d.AddDate(0, 0, 1), latitude, longitude, false)
+		if prev && !next {
+			return d, true
+		}
+		prev = next
+	}
+	return time.Time{}, false
+}
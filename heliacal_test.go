@@ -0,0 +1,69 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+var sirius = Star{RightAscension: 101.2872, Declination: -16.7161, Magnitude: -1.46}
+
+func TestHeliacalRisingOfSirius(t *testing.T) {
+	// Censorinus dates a heliacal rising of Sirius on the Egyptian new year,
+	// 20 July 139 in the Julian calendar, 19 July in the proleptic Gregorian
+	want := time.Date(139, 7, 19, 0, 0, 0, 0, time.UTC)
+	got, ok := HeliacalRising(139, time.UTC, sirius, 30.04, 31.24) // Memphis
+	if !ok {
+		t.Fatal("no heliacal rising")
+	}
+	if d := got.Sub(want).Hours() / 24; d < -3 || d > 3 {
+		t.Errorf("heliacal rising on %v, want within 3 days of %v", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+
+	set, ok := HeliacalSetting(139, time.UTC, sirius, 30.04, 31.24)
+	if !ok {
+		t.Fatal("no heliacal setting")
+	}
+	// Sirius is lost in the glare for some 70 days
+	if d := got.Sub(set).Hours() / 24; d < 50 || d > 90 {
+		t.Errorf("Sirius invisible for %.0f days from %v, want about 70", d, set.Format("2006-01-02"))
+	}
+}
+
+// Clearer air lets Sirius be seen nearer the Sun, so it rises heliacally
+// earlier and sets later; haze and weaker sight do the reverse.
+func TestHeliacalConditions(t *testing.T) {
+	rise, _ := HeliacalRising(139, time.UTC, sirius, 30.04, 31.24)
+	set, _ := HeliacalSetting(139, time.UTC, sirius, 30.04, 31.24)
+	for _, tt := range []struct {
+		name    string
+		c       SkyConditions
+		clearer bool
+	}{
+		{"mountain", SkyConditions{Elevation: 2400, Temperature: 5, Humidity: 20}, true},
+		{"humid", SkyConditions{Temperature: 30, Humidity: 90}, false},
+		{"poor sight", SkyConditions{Temperature: 20, Humidity: 50, SnellenRatio: 0.5}, false},
+	} {
+		r, ok1 := tt.c.HeliacalRising(139, time.UTC, sirius, 30.04, 31.24)
+		s, ok2 := tt.c.HeliacalSetting(139, time.UTC, sirius, 30.04, 31.24)
+		if !ok1 || !ok2 || r.Before(rise) != tt.clearer || s.After(set) != tt.clearer {
+			t.Errorf("%s: rising %v and setting %v against %v and %v", tt.name, r.Format("2 Jan"), s.Format("2 Jan"), rise.Format("2 Jan"), set.Format("2 Jan"))
+		}
+	}
+}
+
+func TestHeliacalNoEvent(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    Star
+	}{
+		{"Polaris never sets", Star{37.95, 89.26, 1.98}},
+		{"Canopus never rises", Star{95.99, -52.70, -0.74}},
+	} {
+		if d, ok := HeliacalRising(2024, time.UTC, tt.s, 51.5, 0); ok {
+			t.Errorf("%s: heliacal rising on %v", tt.name, d.Format("2006-01-02"))
+		}
+		if d, ok := HeliacalSetting(2024, time.UTC, tt.s, 51.5, 0); ok {
+			t.Errorf("%s: heliacal setting on %v", tt.name, d.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sun
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// HijriDate is a date in the tabular Islamic calendar, with months numbered
+// from 1 for Muharram to 12 for Dhu al-Hijjah.
+type HijriDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+var hijriMonths = [...]string{"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani",
+	"Jumada al-Awwal", "Jumada al-Thani", "Rajab", "Shaban", "Ramadan", "Shawwal",
+	"Dhu al-Qadah", "Dhu al-Hijjah"}
+
+// String returns the date as, for example, "1 Ramadan 1445 AH".
+func (d HijriDate) String() string {
+	name := "?"
+	if d.Month >= 1 && d.Month <= 12 {
+		name = hijriMonths[d.Month-1]
+	}
+	return strconv.Itoa(d.Day) + " " + name + " " + strconv.Itoa(d.Year) + " AH"
+}
+
+// ToHijri returns the tabular Islamic date matching the civil date of t in its
+// time zone. The Islamic day begins at sunset, so from sunset on the civil
+// date it is already the next Hijri day. The tabular calendar is the civil
+// calendar of several countries, and usually within a day or two of calendars
+// set by sighting the crescent, as CrescentVisibility can forecast.
+func ToHijri(t time.Time) HijriDate {
+	n := dayNumber(t)
+	year := floorDiv(30*(n-1948440)+10646, 10631)
+	month := int(math.Ceil(float64(n-29-hijriDayNumber(year, 1, 1))/29.5)) + 1
+	if month > 12 {
+		month = 12
+	}
+	return HijriDate{year, month, n - hijriDayNumber(year, month, 1) + 1}
+}
+
+// Date returns midnight at the start of the civil date matching d, in loc.
+func (d HijriDate) Date(loc *time.Location) time.Time {
+	return dayNumberDate(hijriDayNumber(d.Year, d.Month, d.Day), loc)
+}
+
+// hijriDayNumber returns the julian day number of a date in the tabular
+// Islamic calendar, which has months of 30 and 29 days in turn and 11 leap
+// years in 30, counted from 1 Muharram 1 AH, 16 July 622 in the Julian
+// calendar.
+func hijriDayNumber(year int, month int, day int) int {
+	return day + int(math.Ceil(29.5*float64(month-1))) + (year-1)*354 + floorDiv(3+11*year, 30) + 1948439
+}
+
+// dayNumber returns the julian day number of the civil date of t in its time
+// zone
+func dayNumber(t time.Time) int {
+	y, m, d := t.Date()
+	return int(calendarGregorianToJD(y, int(m), float64(d)) + 0.5)
+}
+
+// dayNumberDate returns midnight at the start of the Gregorian date with julian
+// day number n, in loc
+func dayNumberDate(n int, loc *time.Location) time.Time {
+	// 1 January 2000 is day 2451545
+	return time.Date(2000, 1, 1+n-2451545, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,52 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// The arithmetical calendar of Calendrical Calculations begins on 16 July 622
+// in the Julian calendar, 19 July in the Gregorian. Its months are a day later
+// than the Saudi calendar for Muharram 1446 (7 July 2024) and Dhu al-Hijjah
+// 1445 (Eid al-Adha on 16 June 2024), and agree for Ramadan and Shawwal 1445.
+func TestHijriDate(t *testing.T) {
+	for _, tt := range []struct {
+		h    HijriDate
+		want time.Time
+		name string
+	}{
+		{HijriDate{1, 1, 1}, date(time.UTC, 622, time.July, 19), "1 Muharram 1 AH"},
+		{HijriDate{1445, 9, 1}, date(time.UTC, 2024, time.March, 11), "1 Ramadan 1445 AH"},
+		{HijriDate{1445, 10, 1}, date(time.UTC, 2024, time.April, 10), "1 Shawwal 1445 AH"},
+		{HijriDate{1445, 12, 10}, date(time.UTC, 2024, time.June, 17), "10 Dhu al-Hijjah 1445 AH"},
+		{HijriDate{1446, 1, 1}, date(time.UTC, 2024, time.July, 8), "1 Muharram 1446 AH"},
+		{HijriDate{1447, 1, 1}, date(time.UTC, 2025, time.June, 27), "1 Muharram 1447 AH"},
+	} {
+		if got := tt.h.Date(time.UTC); !got.Equal(tt.want) {
+			t.Errorf("%v.Date() = %v, want %v", tt.h, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+		if got := ToHijri(tt.want); got != tt.h {
+			t.Errorf("ToHijri(%v) = %v, want %v", tt.want.Format("2006-01-02"), got, tt.h)
+		}
+		if got := tt.h.String(); got != tt.name {
+			t.Errorf("String() = %q, want %q", got, tt.name)
+		}
+	}
+	if got := (HijriDate{1445, 13, 1}).String(); got != "1 ? 1445 AH" {
+		t.Errorf("String() of month 13 = %q", got)
+	}
+
+	// 30 year cycles of 10631 days, and each civil day the day after the one
+	// before
+	if n := hijriDayNumber(1471, 1, 1) - hijriDayNumber(1441, 1, 1); n != 10631 {
+		t.Errorf("30 years of %d days, want 10631", n)
+	}
+	prev := ToHijri(date(time.UTC, 1999, time.December, 31))
+	for d := date(time.UTC, 2000, time.January, 1); d.Year() < 2031; d = d.AddDate(0, 0, 1) {
+		h := ToHijri(d)
+		if !h.Date(time.UTC).Equal(d) || (h.Day != prev.Day+1 && h.Day != 1) {
+			t.Fatalf("ToHijri(%v) = %v after %v", d.Format("2006-01-02"), h, prev)
+		}
+		prev = h
+	}
+}
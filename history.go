@@ -0,0 +1,112 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// HistoricalOptions sets the assumptions behind HistoricalSunrise and
+// HistoricalSunset, with their uncertainties, which are taken as one standard
+// deviation.
+type HistoricalOptions struct {
+	// DeltaT is TT - UT, the drift of the Earth's rotation against uniform
+	// time: about three hours two thousand years ago. Zero uses the estimate
+	// of Espenak and Meeus.
+	DeltaT            time.Duration
+	DeltaTUncertainty time.Duration
+	// Refraction is the refraction at the horizon in degrees. Zero uses the
+	// standard 34 arc minutes, but it can vary by a fifth of a degree or more
+	// with the air near the ground.
+	Refraction            float64
+	RefractionUncertainty float64
+}
+
+// EventEstimate is the time of an event and its uncertainty, one standard
+// deviation either way.
+type EventEstimate struct {
+	Time        time.Time
+	Uncertainty time.Duration
+}
+
+// HistoricalSunrise returns the first sunrise on the date of t, with an error
+// bar, for dates far from the present. The Sun's position comes from the
+// model of Meeus in dynamical time, and the Earth's rotation from universal
+// time, so the two are set apart by DeltaT. For sunrise, unlike eclipses, an
+// error in DeltaT matters little, as it moves the Sun by less than the Earth
+// turns; the uncertainty in refraction usually dominates. Times are in the
+// time zone of t, so for dates before 1582, given with JulianCalendarDate,
+// use time.UTC and add the longitude to find local mean time. ok is false if
+// the Sun does not rise.
+func HistoricalSunrise(t time.Time, latitude float64, longitude float64, opts HistoricalOptions) (e EventEstimate, ok bool) {
+	return historicalEvent(t, latitude, longitude, opts, true)
+}
+
+// HistoricalSunset returns the first sunset on the date of t, as for
+// HistoricalSunrise.
+func HistoricalSunset(t time.Time, latitude float64, longitude float64, opts HistoricalOptions) (e EventEstimate, ok bool) {
+	return historicalEvent(t, latitude, longitude, opts, false)
+}
+
+func historicalEvent(t time.Time, latitude float64, longitude float64, opts HistoricalOptions, rising bool) (EventEstimate, bool) {
+	dt := opts.DeltaT.Seconds()
+	if opts.DeltaT == 0 {
+		dt = deltaT(t)
+	}
+	refraction := opts.Refraction
+	if refraction == 0 {
+		refraction = -SunriseAltitude - sunSemiDiameter
+	}
+	at, ok := historicalCrossing(t, latitude, longitude, dt, refraction, rising)
+	if !ok {
+		return EventEstimate{}, false
+	}
+	// half the spread of the time across each plus and minus one sigma
+	spread := func(lo, hi time.Time, okLo, okHi bool) float64 {
+		if !okLo || !okHi {
+			return 0
+		}
+		return hi.Sub(lo).Seconds() / 2
+	}
+	sdt := opts.DeltaTUncertainty.Seconds()
+	lo1, ok1 := historicalCrossing(t, latitude, longitude, dt-sdt, refraction, rising)
+	hi1, ok2 := historicalCrossing(t, latitude, longitude, dt+sdt, refraction, rising)
+	sr := opts.RefractionUncertainty
+	lo2, ok3 := historicalCrossing(t, latitude, longitude, dt, refraction-sr, rising)
+	hi2, ok4 := historicalCrossing(t, latitude, longitude, dt, refraction+sr, rising)
+	s := math.Hypot(spread(lo1, hi1, ok1, ok2), spread(lo2, hi2, ok3, ok4))
+	return EventEstimate{at, secondsToDuration(s)}, true
+}
+
+// historicalCrossing returns the first time on the date of t that the upper
+// limb of the Sun crosses the horizon, with refraction there in degrees and
+// dt the seconds of TT - UT
+func historicalCrossing(t time.Time, latitude float64, longitude float64, dt float64, refraction float64, rising bool) (time.Time, bool) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	h0 := -refraction - sunSemiDiameter
+	f := func(s float64) float64 {
+		at := start.Add(secondsToDuration(s))
+		c := Meeus{}.Sun(at.Add(secondsToDuration(dt)))
+		ha := getHourAngle(timeToJD(at), longitude, c.RightAscension)
+		alt := angleAsin(angleSin(latitude)*angleSin(c.Declination) + angleCos(latitude)*angleCos(c.Declination)*angleCos(ha))
+		return alt - h0
+	}
+	for _, c := range crossings(start, end, f, math.Inf(1), defaultTolerance) {
+		if c.Increasing == rising {
+			return c.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// JulianCalendarDate returns midnight at the start of the given date in the
+// Julian calendar, in loc, for dates before the Gregorian reform of 1582 as
+// historical sources give them. Years are astronomical, so 1 BC is year 0.
+func JulianCalendarDate(year int, month time.Month, day int, loc *time.Location) time.Time {
+	a := floorDiv(14-int(month), 12)
+	y := year + 4800 - a
+	m := int(month) + 12*a - 3
+	n := day + floorDiv(153*m+2, 5) + 365*y + floorDiv(y, 4) - 32083
+	return dayNumberDate(n, loc)
+}
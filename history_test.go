@@ -0,0 +1,65 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// Meeus example 7.b puts 333 January 27 in the Julian calendar on JD 1842713,
+// and julian day 0 is 24 November 4714 BC in the Gregorian calendar.
+func TestJulianCalendarDate(t *testing.T) {
+	for _, tt := range []struct {
+		year  int
+		month time.Month
+		day   int
+		want  time.Time
+	}{
+		{1582, time.October, 4, date(time.UTC, 1582, time.October, 14)},
+		{622, time.July, 16, date(time.UTC, 622, time.July, 19)},
+		{333, time.January, 27, date(time.UTC, 333, time.January, 28)},
+		{-4712, time.January, 1, date(time.UTC, -4713, time.November, 24)},
+	} {
+		if got := JulianCalendarDate(tt.year, tt.month, tt.day, time.UTC); !got.Equal(tt.want) {
+			t.Errorf("JulianCalendarDate(%d, %v, %d) = %v, want %v", tt.year, tt.month, tt.day, got, tt.want)
+		}
+	}
+	if n := dayNumber(JulianCalendarDate(333, time.January, 27, time.UTC)); n != 1842713 {
+		t.Errorf("day number %d, want 1842713", n)
+	}
+}
+
+// At London on the June solstice the Sun climbs 15 × 0.5711 × sin 124.8 =
+// 7.04 degrees an hour at sunrise, so 0.2 degree of refraction is 102
+// seconds. An hour of DeltaT moves its right ascension by 1.074 degrees a day
+// over 24, 10.7 seconds.
+func TestHistoricalSunrise(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	rise, _ := Sunrise(d, 51.5074, -0.1278)
+	set, _ := Sunset(d, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		opts    HistoricalOptions
+		rising  bool
+		want    time.Time
+		sigma   time.Duration
+		timeTol time.Duration
+	}{
+		{HistoricalOptions{}, true, rise, 0, time.Second},
+		{HistoricalOptions{}, false, set, 0, time.Second},
+		{HistoricalOptions{RefractionUncertainty: 0.2}, true, rise, 102 * time.Second, time.Second},
+		{HistoricalOptions{DeltaTUncertainty: time.Hour}, true, rise, 10 * time.Second, time.Second},
+		{HistoricalOptions{DeltaT: 3 * time.Hour}, true, rise, 0, 35 * time.Second},
+	} {
+		f := HistoricalSunrise
+		if !tt.rising {
+			f = HistoricalSunset
+		}
+		e, ok := f(d, 51.5074, -0.1278, tt.opts)
+		if !ok || !within(e.Time, tt.want, tt.timeTol) || (e.Uncertainty-tt.sigma).Abs() > time.Second {
+			t.Errorf("%+v: %v ± %v, want %v ± %v", tt.opts, e.Time, e.Uncertainty, tt.want, tt.sigma)
+		}
+	}
+
+	if e, ok := HistoricalSunrise(date(time.UTC, 2024, time.December, 21), 69.6492, 18.9553, HistoricalOptions{}); ok {
+		t.Errorf("sunrise at Tromsø in the polar night = %v", e)
+	}
+}
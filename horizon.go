@@ -0,0 +1,154 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Horizon is the skyline around an observer: the apparent elevation in degrees
+// of the top of the terrain at azimuths Step degrees apart, starting from
+// north. Elevation[i] is at azimuth i·Step.
+type Horizon struct {
+	Step      float64
+	Elevation []float64
+}
+
+// NewHorizon returns a horizon from n elevations at equal steps of azimuth
+// starting from north, as from a survey of the skyline.
+func NewHorizon(elevation []float64) Horizon {
+	return Horizon{360 / float64(len(elevation)), elevation}
+}
+
+// At returns the elevation of the horizon at azimuth az, interpolating linearly
+// between the surveyed azimuths. An empty Horizon is flat, with elevation 0.
+func (h Horizon) At(az float64) float64 {
+	n := len(h.Elevation)
+	if n == 0 {
+		return 0
+	}
+	x := between(0, 360, az) / h.Step
+	i := int(x)
+	f := x - float64(i)
+	return h.Elevation[i%n]*(1-f) + h.Elevation[(i+1)%n]*f
+}
+
+// Horizon returns the skyline seen from p, at height metres above the ground,
+// at n equal steps of azimuth. Terrain beyond the edge of the DEM is ignored,
+// so the grid should reach well beyond the mountains that matter, and where
+// the view reaches the edge with nothing above eye level the elevation is
+// taken as 0. ok is false if p is off the grid.
+func (d *DEM) Horizon(p Point, height float64, n int) (h Horizon, ok bool) {
+	r, c, ok := d.Cell(p)
+	if !ok || n <= 0 {
+		return Horizon{}, false
+	}
+	z0 := d.At(r, c) + height
+	top := d.maxElevation()
+	h = Horizon{Step: 360 / float64(n), Elevation: make([]float64, n)}
+	for i := range h.Elevation {
+		h.Elevation[i] = math.Max(0, d.horizonAngle(r, c, z0, float64(i)*h.Step, -89, top))
+	}
+	return h, true
+}
+
+// horizonClearance returns the geometric altitude of the Sun's centre at t less
+// the altitude at which its upper limb appears on horizon h, allowing for
+// refraction scaled by air, so it is positive when the Sun is at least partly
+// clear
+func horizonClearance(t time.Time, latitude float64, longitude float64, h Horizon, air float64) float64 {
+	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	ha := getHourAngle(jd, longitude, rAsc)
+	alt := angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	e := h.At(getAzimuth(latitude, dec, ha))
+	return alt - (e - air*Refraction(e) - sunSemiDiameter)
+}
+
+// SunriseOver returns the first time on the date of t that the upper limb of
+// the Sun appears over horizon h, as seen from the given location, in the time
+// zone of t and to within a second. ok is false if the Sun does not clear the
+// horizon that day. Over a flat horizon it agrees with Sunrise to within a
+// minute or so, the refraction models differing slightly.
+func SunriseOver(t time.Time, latitude float64, longitude float64, h Horizon) (sunrise time.Time, ok bool) {
+	return firstRising(horizonCrossings(t, latitude, longitude, h, 1))
+}
+
+// SunsetOver returns the last time on the date of t that the upper limb of the
+// Sun disappears behind horizon h, as for SunriseOver.
+func SunsetOver(t time.Time, latitude float64, longitude float64, h Horizon) (sunset time.Time, ok bool) {
+	return lastSetting(horizonCrossings(t, latitude, longitude, h, 1))
+}
+
+// firstRising returns the time of the first increasing crossing in cs
+func firstRising(cs []Crossing) (time.Time, bool) {
+	for _, c := range cs {
+		if c.Increasing {
+			return c.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// lastSetting returns the time of the last decreasing crossing in cs
+func lastSetting(cs []Crossing) (time.Time, bool) {
+	for i := len(cs) - 1; i >= 0; i-- {
+		if !cs[i].Increasing {
+			return cs[i].Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// horizonCrossings returns every time on the date of t that the upper limb of
+// the Sun crosses horizon h, with the refraction scaled by air
+func horizonCrossings(t time.Time, latitude float64, longitude float64, h Horizon, air float64) []Crossing {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := start.AddDate(0, 0, 1)
+	f := func(s float64) float64 {
+		return horizonClearance(start.Add(secondsToDuration(s)), latitude, longitude, h, air)
+	}
+	return crossings(start, end, f, math.Inf(1), defaultTolerance)
+}
+
+// TerrainDay is the direct sunshine on one date at a point in rough terrain.
+type TerrainDay struct {
+	// Sunrise and Sunset are the astronomical events over a flat horizon,
+	// zero if there are none that day.
+	Sunrise time.Time
+	Sunset  time.Time
+	// FirstLight and LastLight are when the Sun first clears the terrain and
+	// last drops behind it, zero if it never clears it that day.
+	FirstLight time.Time
+	LastLight  time.Time
+	// Sunlit holds the periods between, broken where the Sun passes behind a
+	// peak.
+	Sunlit []Period
+}
+
+// terrainHorizonSteps is the number of azimuths at which TerrainDay surveys the
+// skyline
+const terrainHorizonSteps = 360
+
+// TerrainDay returns the direct sunshine on the date of t at ground level at p,
+// with times in the time zone of t. In alpine valleys first and last light can
+// be hours from sunrise and sunset, and in winter some villages see none at
+// all. The skyline is taken from the DEM at one degree steps of azimuth, as for
+// Horizon. ok is false if p is off the grid.
+func (d *DEM) TerrainDay(t time.Time, p Point) (day TerrainDay, ok bool) {
+	h, ok := d.Horizon(p, 0, terrainHorizonSteps)
+	if !ok {
+		return TerrainDay{}, false
+	}
+	day.Sunrise, _ = Sunrise(t, p.Latitude, p.Longitude)
+	day.Sunset, _ = Sunset(t, p.Latitude, p.Longitude)
+	y, m, dd := t.Date()
+	start := time.Date(y, m, dd, 0, 0, 0, 0, t.Location())
+	day.Sunlit = periodsWhere(start, start.AddDate(0, 0, 1), func(at time.Time) float64 {
+		return horizonClearance(at, p.Latitude, p.Longitude, h, 1)
+	})
+	if n := len(day.Sunlit); n > 0 {
+		day.FirstLight, day.LastLight = day.Sunlit[0].Start, day.Sunlit[n-1].End
+	}
+	return day, true
+}
@@ -0,0 +1,177 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHorizonAt(t *testing.T) {
+	h := NewHorizon([]float64{10, 20, 0, 4})
+	if h.Step != 90 {
+		t.Errorf("Step = %v, want 90", h.Step)
+	}
+	for _, tt := range []struct{ az, want float64 }{
+		{0, 10}, {45, 15}, {90, 20}, {135, 10}, {270, 4}, {315, 7}, {360, 10}, {-45, 7}, {405, 15},
+	} {
+		if got := h.At(tt.az); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("At(%v) = %v, want %v", tt.az, got, tt.want)
+		}
+	}
+	if got := (Horizon{}).At(123); got != 0 {
+		t.Errorf("empty horizon At = %v, want 0", got)
+	}
+}
+
+// valleyDEM returns a 10 km square DEM of 50 m cells near Zermatt and the point
+// at its centre
+func valleyDEM(t *testing.T) (*DEM, Point) {
+	d, err := NewDEM(201, 201, 50, Point{46.045, 7.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	metres := toRadians(earthRadius)
+	return d, Point{d.Origin.Latitude - 100*50/metres, d.Origin.Longitude + 100*50/(metres*angleCos(d.Origin.Latitude))}
+}
+
+// A wall 350 m high from 2 km east of the observer rises atan(350/2000) = 9.93
+// degrees due east, and atan(350 sin 60/2000) = 8.62 degrees at azimuths 60
+// and 120, where the trace a cell at a time first meets it at 2350 m and
+// makes it 8.47. Nothing stands to the west.
+func TestDEMHorizon(t *testing.T) {
+	d, p := valleyDEM(t)
+	for r := 0; r < d.Rows; r++ {
+		for c := 140; c < d.Cols; c++ {
+			d.Elevation[r*d.Cols+c] = 350
+		}
+	}
+	h, ok := d.Horizon(p, 0, 360)
+	if !ok || len(h.Elevation) != 360 || h.Step != 1 {
+		t.Fatalf("Horizon = %v, %v", h, ok)
+	}
+	for _, tt := range []struct{ az, want, tol float64 }{
+		{90, 9.93, 0.01},
+		{60, 8.62, 0.2},
+		{120, 8.62, 0.2},
+		{270, 0, 0},
+		{0, 0, 0},
+	} {
+		if got := h.At(tt.az); math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("horizon at %v = %.3f, want %v", tt.az, got, tt.want)
+		}
+	}
+	// standing 350 m up the view east is flat
+	if h, _ := d.Horizon(p, 350, 4); h.At(90) != 0 {
+		t.Errorf("horizon from 350 m at 90 = %v, want 0", h.At(90))
+	}
+	if _, ok := d.Horizon(Point{0, 0}, 0, 360); ok {
+		t.Error("Horizon off the grid is ok")
+	}
+	if _, ok := d.Horizon(p, 0, 0); ok {
+		t.Error("Horizon at no azimuths is ok")
+	}
+}
+
+// At London on the June solstice, with noon at 12:02.3 UT, the upper limb
+// clears a horizon 5 degrees high with the centre at 5 - 0.165 refraction -
+// 0.267 semi-diameter = 4.569 degrees; sin h = 0.3113 + 0.5711 cos H gives an
+// hour angle of 113.93 degrees, 7h35.7m, so it rises at 04:26.6 UT and sets at
+// 19:38.0 UT, 43 minutes after and before the flat horizon times.
+func TestSunriseOver(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	flat := NewHorizon(make([]float64, 36))
+	five := NewHorizon([]float64{5, 5, 5, 5})
+	rise, _ := Sunrise(d, 51.5074, -0.1278)
+	set, _ := Sunset(d, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		name      string
+		h         Horizon
+		rise, set time.Time
+		tol       time.Duration
+	}{
+		{"flat", flat, rise, set, time.Minute},
+		{"empty", Horizon{}, rise, set, time.Minute},
+		{"five degrees", five, clock(d, 4, 26).Add(36 * time.Second), clock(d, 19, 38), time.Minute},
+	} {
+		if got, ok := SunriseOver(d, 51.5074, -0.1278, tt.h); !ok || !within(got, tt.rise, tt.tol) {
+			t.Errorf("%s: SunriseOver = %v, %v, want %v", tt.name, got, ok, tt.rise)
+		}
+		if got, ok := SunsetOver(d, 51.5074, -0.1278, tt.h); !ok || !within(got, tt.set, tt.tol) {
+			t.Errorf("%s: SunsetOver = %v, %v, want %v", tt.name, got, ok, tt.set)
+		}
+	}
+
+	// in December the Sun gets no higher than 15 degrees, below a wall of 20
+	d = date(time.UTC, 2024, time.December, 21)
+	wall := NewHorizon([]float64{20, 20, 20, 20})
+	if got, ok := SunriseOver(d, 51.5074, -0.1278, wall); ok {
+		t.Errorf("SunriseOver a 20 degree wall in December = %v", got)
+	}
+	if got, ok := SunsetOver(d, 51.5074, -0.1278, wall); ok {
+		t.Errorf("SunsetOver a 20 degree wall in December = %v", got)
+	}
+}
+
+// Over a valley floor with a 350 m wall 2 km to the east, first light in June
+// comes an hour after sunrise, when the Sun clears the wall, and last light
+// is at sunset over the open west. In December a peak 1500 m high and 250 m
+// wide 2 km due south, standing 36.9 degrees up, hides the Sun at noon, when
+// it is 20.6 degrees up and moving at 15 cos δ / cos h = 14.7 degrees of
+// azimuth an hour; the peak is about seven degrees wide there, so the Sun is
+// hidden for about half an hour around noon.
+func TestTerrainDay(t *testing.T) {
+	d, p := valleyDEM(t)
+	for r := 0; r < d.Rows; r++ {
+		for c := 140; c < d.Cols; c++ {
+			d.Elevation[r*d.Cols+c] = 350
+		}
+	}
+	june := date(time.UTC, 2024, time.June, 21)
+	day, ok := d.TerrainDay(june, p)
+	if !ok {
+		t.Fatal("TerrainDay is not ok")
+	}
+	rise, _ := Sunrise(june, p.Latitude, p.Longitude)
+	set, _ := Sunset(june, p.Latitude, p.Longitude)
+	h, _ := d.Horizon(p, 0, terrainHorizonSteps)
+	first, _ := SunriseOver(june, p.Latitude, p.Longitude, h)
+	if !day.Sunrise.Equal(rise) || !day.Sunset.Equal(set) {
+		t.Errorf("sunrise and sunset = %v, %v, want %v, %v", day.Sunrise, day.Sunset, rise, set)
+	}
+	if !within(day.FirstLight, first, time.Second) || day.FirstLight.Sub(rise) < 50*time.Minute || day.FirstLight.Sub(rise) > 70*time.Minute {
+		t.Errorf("first light at %v, want %v, an hour after sunrise at %v", day.FirstLight, first, rise)
+	}
+	if !within(day.LastLight, set, time.Minute) {
+		t.Errorf("last light at %v, want sunset at %v", day.LastLight, set)
+	}
+	if len(day.Sunlit) != 1 || day.Sunlit[0] != (Period{day.FirstLight, day.LastLight}) {
+		t.Errorf("sunlit %v, want one period", day.Sunlit)
+	}
+
+	d, p = valleyDEM(t)
+	for r := 140; r <= 145; r++ {
+		for c := 98; c <= 102; c++ {
+			d.Elevation[r*d.Cols+c] = 1500
+		}
+	}
+	december := date(time.UTC, 2024, time.December, 21)
+	day, _ = d.TerrainDay(december, p)
+	noon := Culminate(december, p.Latitude, p.Longitude).Time
+	if len(day.Sunlit) != 2 {
+		t.Fatalf("sunlit %v, want two periods", day.Sunlit)
+	}
+	hidden, back := day.Sunlit[0].End, day.Sunlit[1].Start
+	if gap := back.Sub(hidden); gap < 20*time.Minute || gap > 35*time.Minute {
+		t.Errorf("hidden from %v to %v, want about half an hour", hidden, back)
+	}
+	if d := noon.Sub(hidden) - back.Sub(noon); d.Abs() > 5*time.Second {
+		t.Errorf("hidden from %v to %v, not centred on noon at %v", hidden, back, noon)
+	}
+	if !within(day.FirstLight, day.Sunrise, time.Minute) || !within(day.LastLight, day.Sunset, time.Minute) {
+		t.Errorf("light from %v to %v, want sunrise %v to sunset %v", day.FirstLight, day.LastLight, day.Sunrise, day.Sunset)
+	}
+
+	if _, ok := d.TerrainDay(december, Point{0, 0}); ok {
+		t.Error("TerrainDay off the grid is ok")
+	}
+}
@@ -0,0 +1,76 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// StarlightIlluminance is the illuminance in lux of a clear moonless night sky
+// once twilight is over, from the stars and airglow.
+const StarlightIlluminance float64 = 0.002
+
+// sunIlluminanceTable gives the common logarithm of the illuminance in lux on
+// level ground from the Sun and sky on a clear day, at a range of geometric
+// altitudes of the Sun in degrees
+var sunIlluminanceTable = [...][2]float64{
+	{-18, -3.3},
+	{-12, -2.0},
+	{-6, 0.53},
+	{SunriseAltitude, 2.75},
+	{0, 2.9},
+	{5, 3.85},
+	{10, 4.2},
+	{20, 4.55},
+	{30, 4.74},
+	{60, 5.01},
+	{90, 5.09},
+}
+
+// SunIlluminance returns the illuminance in lux on level ground from the Sun
+// and the sky it lights, on a clear day, from about 120000 lux with the Sun
+// overhead through some 600 at sunset to nothing at the end of astronomical
+// twilight. It is interpolated from typical measured values and good to a
+// factor of about two; cloud can cut it ten times or more.
+func SunIlluminance(t time.Time, latitude float64, longitude float64) float64 {
+	alt := Altitude(t, latitude, longitude)
+	tab := sunIlluminanceTable
+	if alt < tab[0][0] {
+		return 0
+	}
+	for i := 1; i < len(tab); i++ {
+		if alt <= tab[i][0] {
+			f := (alt - tab[i-1][0]) / (tab[i][0] - tab[i-1][0])
+			return math.Pow(10, tab[i-1][1]+f*(tab[i][1]-tab[i-1][1]))
+		}
+	}
+	return math.Pow(10, tab[len(tab)-1][1])
+}
+
+// MoonIlluminance returns the illuminance in lux on level ground from the Moon,
+// about 0.3 lux at most from a full moon overhead and nothing with the Moon
+// below the horizon. The brightness of the Moon outside the atmosphere follows
+// its phase by the magnitude law of Krisciunas and Schaefer, and its distance;
+// it is then dimmed by 0.2 magnitude per air mass, as on a clear night at sea
+// level. Earthshine on the dark part of the Moon adds too little to count.
+func MoonIlluminance(t time.Time, latitude float64, longitude float64) float64 {
+	alt, _, parallax := moonHorizontal(t, latitude, longitude)
+	if alt <= 0 {
+		return 0
+	}
+	a := moonPhaseAngle(t)
+	mag := -12.73 + 0.026*a + 4e-9*math.Pow(a, 4)
+	d := earthEquatorialKm / angleSin(parallax) / 384400
+	return magnitudeLux(mag) / (d * d) * angleSin(alt) * math.Pow(10, -0.4*0.2*AirMass(alt))
+}
+
+// Illuminance returns the total illuminance in lux on level ground under a
+// clear sky: the Sun and twilight, the Moon and the starlit sky together.
+func Illuminance(t time.Time, latitude float64, longitude float64) float64 {
+	return SunIlluminance(t, latitude, longitude) + MoonIlluminance(t, latitude, longitude) + StarlightIlluminance
+}
+
+// magnitudeLux returns the illuminance in lux, square on to the light outside
+// the atmosphere, from a source of the given visual magnitude
+func magnitudeLux(mag float64) float64 {
+	return 2.54e-6 * math.Pow(10, -0.4*mag)
+}
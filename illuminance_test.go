@@ -0,0 +1,90 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The Sun at magnitude -26.74 gives 126000 lux outside the atmosphere, close
+// to the 128000 usually quoted, and the full moon at -12.73 gives 0.314 lux.
+func TestMagnitudeLux(t *testing.T) {
+	for _, tt := range []struct{ mag, want, tol float64 }{
+		{-26.74, 126100, 200},
+		{-12.73, 0.3139, 1e-4},
+		{0, 2.54e-6, 1e-12},
+		{-2.5, 2.54e-5, 1e-10},
+	} {
+		if got := magnitudeLux(tt.mag); math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("magnitudeLux(%v) = %v, want %v", tt.mag, got, tt.want)
+		}
+	}
+}
+
+// From the table: 10^5.09 = 123000 lux with the Sun overhead, 10^2.75 = 562 at
+// sunset and 10^-3.3 = 0.0005 at the end of astronomical twilight.
+func TestSunIlluminance(t *testing.T) {
+	d := date(time.UTC, 2024, time.March, 20)
+	overhead := clock(d, 12, 7)
+	lat, lon := SubsolarPoint(overhead)
+	if got := SunIlluminance(overhead, lat, lon); math.Abs(got-123027) > 200 {
+		t.Errorf("SunIlluminance overhead = %.0f, want 123027", got)
+	}
+	set, _ := Sunset(d, 51.5074, -0.1278)
+	if got := SunIlluminance(set, 51.5074, -0.1278); math.Abs(got-562) > 10 {
+		t.Errorf("SunIlluminance at sunset = %.1f, want 562", got)
+	}
+	dusk, _ := AltitudeSegments(d, 51.5074, -0.1278)[2].TimeAt(-18, 51.5074, -0.1278)
+	if got := SunIlluminance(dusk.Add(-5*time.Second), 51.5074, -0.1278); math.Abs(got-0.0005) > 0.0001 {
+		t.Errorf("SunIlluminance at astronomical dusk = %v, want 0.0005", got)
+	}
+	if got := SunIlluminance(dusk.Add(time.Hour), 51.5074, -0.1278); got != 0 {
+		t.Errorf("SunIlluminance after dusk = %v, want 0", got)
+	}
+	// it only falls through the evening
+	last := math.Inf(1)
+	for at := clock(d, 12, 10); at.Before(dusk); at = at.Add(5 * time.Minute) {
+		v := SunIlluminance(at, 51.5074, -0.1278)
+		if v > last {
+			t.Errorf("SunIlluminance rises to %v at %v", v, at)
+		}
+		last = v
+	}
+}
+
+// At the penumbral eclipse of 25 March 2024 the Moon was at a phase angle of
+// 0.96 degrees and 405405 km away, 1.0547 times its mean distance. Overhead,
+// 0.314 lux dimmed by 0.026 × 0.96 magnitude, divided by 1.0547² and by 0.2
+// magnitude for one air mass, gives 0.2294 lux.
+func TestMoonIlluminance(t *testing.T) {
+	at := time.Date(2024, time.March, 25, 7, 0, 0, 0, time.UTC)
+	m := MoonPosition(at)
+	lat, lon := m.Declination, -getHourAngle(timeToJD(at), 0, m.RightAscension)
+	if got := MoonIlluminance(at, lat, lon); math.Abs(got-0.2294) > 0.0005 {
+		t.Errorf("MoonIlluminance overhead = %.4f, want 0.2294", got)
+	}
+	// and nothing on the far side of the Earth
+	if got := MoonIlluminance(at, -lat, lon+180); got != 0 {
+		t.Errorf("MoonIlluminance with the Moon down = %v, want 0", got)
+	}
+	// the new moon, at a phase angle near 180 degrees, is some nine magnitudes
+	// fainter and gives under a thousandth of a lux
+	nm := time.Date(2024, time.April, 8, 18, 21, 0, 0, time.UTC)
+	m = MoonPosition(nm)
+	if got := MoonIlluminance(nm, m.Declination, -getHourAngle(timeToJD(nm), 0, m.RightAscension)); got > 1e-3 {
+		t.Errorf("MoonIlluminance at new moon = %v", got)
+	}
+}
+
+func TestIlluminance(t *testing.T) {
+	// a moonless night at London is starlight alone
+	at := time.Date(2024, time.April, 8, 23, 0, 0, 0, time.UTC)
+	if got := Illuminance(at, 51.5074, -0.1278); got != StarlightIlluminance {
+		t.Errorf("Illuminance on a moonless night = %v, want %v", got, StarlightIlluminance)
+	}
+	at = time.Date(2024, time.March, 25, 1, 0, 0, 0, time.UTC)
+	want := SunIlluminance(at, 51.5074, -0.1278) + MoonIlluminance(at, 51.5074, -0.1278) + StarlightIlluminance
+	if got := Illuminance(at, 51.5074, -0.1278); got != want || got < 0.1 {
+		t.Errorf("Illuminance under a full moon = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package sun
+
+import "time"
+
+// SolarAngles is the direction of the Sun in the form image metadata gives it.
+type SolarAngles struct {
+	Zenith  float64 // degrees from the zenith, 90 less the altitude
+	Azimuth float64 // degrees clockwise from north
+}
+
+// BoundingBox is the extent of a scene in decimal degrees.
+type BoundingBox struct {
+	South, West, North, East float64
+}
+
+// EarthSunDistance returns the distance from the Earth to the Sun at t in
+// astronomical units, as in the EARTH_SUN_DISTANCE field of Landsat metadata.
+func EarthSunDistance(t time.Time) float64 {
+	return LowPrecision{}.Sun(t).Distance
+}
+
+// SceneAngles returns the solar zenith and azimuth at time t for every point of
+// the grid formed by latitudes and longitudes, in row order as for
+// AltitudeGrid. The result is appended to out[:0]. Zenith and azimuth follow
+// the Landsat and Sentinel-2 conventions, so for a scene with one acquisition
+// time they match the published angle grids to the accuracy of the model,
+// about 0.01 degree.
+func SceneAngles(t time.Time, latitudes []float64, longitudes []float64, out []SolarAngles) []SolarAngles {
+	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	ha := make([]float64, len(longitudes))
+	for j, lon := range longitudes {
+		ha[j] = getHourAngle(jd, lon, rAsc)
+	}
+	out = out[:0]
+	for _, lat := range latitudes {
+		for _, h := range ha {
+			alt := angleAsin(angleSin(lat)*angleSin(dec) + angleCos(lat)*angleCos(dec)*angleCos(h))
+			out = append(out, SolarAngles{90 - alt, getAzimuth(lat, dec, h)})
+		}
+	}
+	return out
+}
+
+// BoxAngles returns SceneAngles at the centres of a grid of rows by cols equal
+// cells covering box, with the first row along the northern edge as in an
+// image. Longitudes run east from West, across the antimeridian if East is
+// less than West. A single cell gives the angles at the centre of the scene.
+func BoxAngles(t time.Time, box BoundingBox, rows int, cols int, out []SolarAngles) []SolarAngles {
+	lats, lons := boxCentres(box, rows, cols)
+	return SceneAngles(t, lats, lons, out)
+}
+
+// boxCentres returns the latitudes of the rows, north first, and the
+// longitudes of the columns of a grid of cells covering box
+func boxCentres(box BoundingBox, rows int, cols int) (latitudes []float64, longitudes []float64) {
+	width := box.East - box.West
+	if width < 0 {
+		width += 360
+	}
+	for i := 0; i < rows; i++ {
+		latitudes = append(latitudes, box.North-(box.North-box.South)*(float64(i)+0.5)/float64(rows))
+	}
+	for j := 0; j < cols; j++ {
+		longitudes = append(longitudes, between(-180, 180, box.West+width*(float64(j)+0.5)/float64(cols)))
+	}
+	return latitudes, longitudes
+}
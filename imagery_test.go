@@ -0,0 +1,105 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Perihelion and aphelion in 2024, from the USNO: 0.983307 AU at 00:38 UT on
+// 3 January and 1.016725 AU at 05:06 UT on 5 July. The Landsat table of
+// Earth-Sun distance by day of year gives 1.00000 in early April and October.
+func TestEarthSunDistance(t *testing.T) {
+	for _, tt := range []struct {
+		t         time.Time
+		want, tol float64
+	}{
+		{time.Date(2024, time.January, 3, 0, 38, 0, 0, time.UTC), 0.983307, 0.0001},
+		{time.Date(2024, time.July, 5, 5, 6, 0, 0, time.UTC), 1.016725, 0.0001},
+		{time.Date(2024, time.April, 4, 0, 0, 0, 0, time.UTC), 1.0000, 0.001},
+		{time.Date(2024, time.October, 4, 0, 0, 0, 0, time.UTC), 1.0000, 0.001},
+	} {
+		if got := EarthSunDistance(tt.t); math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("EarthSunDistance(%v) = %.6f, want %v", tt.t.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestSceneAngles(t *testing.T) {
+	// a Landsat 8 overpass of London at about 10:55 UT
+	at := time.Date(2024, time.June, 21, 10, 55, 0, 0, time.UTC)
+	lats := []float64{52, 51.5, 51}
+	lons := []float64{-1, -0.1278, 0.5}
+	out := SceneAngles(at, lats, lons, make([]SolarAngles, 1, 9))
+	if len(out) != 9 {
+		t.Fatalf("%d angles, want 9", len(out))
+	}
+	for i, lat := range lats {
+		for j, lon := range lons {
+			a := out[i*len(lons)+j]
+			if z := 90 - Altitude(at, lat, lon); math.Abs(a.Zenith-z) > 1e-9 {
+				t.Errorf("zenith at %v, %v = %v, want %v", lat, lon, a.Zenith, z)
+			}
+			if az := Azimuth(at, lat, lon); math.Abs(a.Azimuth-az) > 1e-9 {
+				t.Errorf("azimuth at %v, %v = %v, want %v", lat, lon, a.Azimuth, az)
+			}
+		}
+	}
+	// the Sun stands higher to the south and further round to the west
+	if !(out[6].Zenith < out[0].Zenith) || !(out[2].Azimuth > out[0].Azimuth) {
+		t.Errorf("angles %v do not vary as they should across the scene", out)
+	}
+
+	// directly under the Sun the zenith angle is nought
+	lat, lon := SubsolarPoint(at)
+	if a := SceneAngles(at, []float64{lat}, []float64{lon}, nil)[0]; a.Zenith > 0.01 {
+		t.Errorf("zenith at the subsolar point = %v", a.Zenith)
+	}
+}
+
+func TestBoxAngles(t *testing.T) {
+	for _, tt := range []struct {
+		box        BoundingBox
+		rows, cols int
+		lats, lons []float64
+	}{
+		{BoundingBox{50, -2, 52, 2}, 2, 4, []float64{51.5, 50.5}, []float64{-1.5, -0.5, 0.5, 1.5}},
+		{BoundingBox{-20, 170, -10, -170}, 1, 2, []float64{-15}, []float64{175, -175}},
+		{BoundingBox{0, 0, 10, 10}, 1, 1, []float64{5}, []float64{5}},
+	} {
+		lats, lons := boxCentres(tt.box, tt.rows, tt.cols)
+		if !floatsNear(lats, tt.lats) || !floatsNear(lons, tt.lons) {
+			t.Errorf("boxCentres(%v) = %v, %v, want %v, %v", tt.box, lats, lons, tt.lats, tt.lons)
+		}
+		at := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+		got := BoxAngles(at, tt.box, tt.rows, tt.cols, nil)
+		want := SceneAngles(at, tt.lats, tt.lons, nil)
+		if len(got) != tt.rows*tt.cols || !anglesNear(got, want) {
+			t.Errorf("BoxAngles(%v) = %v, want %v", tt.box, got, want)
+		}
+	}
+}
+
+func floatsNear(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func anglesNear(a, b []SolarAngles) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i].Zenith-b[i].Zenith) > 1e-9 || math.Abs(a[i].Azimuth-b[i].Azimuth) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package sun
+
+import (
+	"sort"
+	"time"
+)
+
+// ImagingNight is one night's opportunity for deep sky imaging.
+type ImagingNight struct {
+	Date             time.Time     // midnight at the start of the evening's date
+	Darkness         Period        // astronomical darkness, zero if none
+	Windows          []Period      // parts of Darkness free of moonlight
+	Usable           time.Duration // total length of Windows
+	MoonIllumination float64       // illuminated fraction at the middle of Darkness
+}
+
+// PlanImaging returns, for each night from the date of start to the date of end
+// inclusive, the windows that are both astronomically dark and free of
+// moonlight, ranked with the most usable dark time first. Nights with equal
+// usable time stay in date order.
+//
+// The Moon counts as absent when it is below the horizon, or whenever its
+// illuminated fraction is at most maxIllumination; pass 0 to require the Moon
+// to be down, or around 0.1 to accept a thin crescent.
+func PlanImaging(start time.Time, end time.Time, latitude float64, longitude float64, maxIllumination float64) []ImagingNight {
+	var nights []ImagingNight
+	y, m, d := start.Date()
+	for i := 0; ; i++ {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, start.Location())
+		if afterDate(date, end) {
+			break
+		}
+		n := ImagingNight{Date: date}
+		dark, ok := AstronomicalDarkness(date, latitude, longitude)
+		if ok {
+			n.Darkness = dark
+			n.MoonIllumination = MoonIllumination(dark.Start.Add(dark.Duration() / 2))
+			if n.MoonIllumination <= maxIllumination {
+				n.Windows = []Period{dark}
+			} else {
+				n.Windows = periodsWhere(dark.Start, dark.End, func(t time.Time) float64 {
+					return MoonriseAltitude - MoonAltitude(t, latitude, longitude)
+				})
+			}
+			for _, w := range n.Windows {
+				n.Usable += w.Duration()
+			}
+		}
+		nights = append(nights, n)
+	}
+	sort.SliceStable(nights, func(i, j int) bool {
+		return nights[i].Usable > nights[j].Usable
+	})
+	return nights
+}
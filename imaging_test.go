@@ -0,0 +1,133 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Example 47.a of Meeus, Astronomical Algorithms: the Moon at 0h TD on 12
+// April 1992. The right ascension and declination there are apparent, with
+// nutation, which shifts them by a few thousandths of a degree; the latitude
+// is out by as much again for the terms MoonPosition leaves out. Example 48.a
+// gives the illuminated fraction.
+func TestMoonPosition(t *testing.T) {
+	at := time.Date(1992, time.April, 12, 0, 0, 0, 0, time.UTC)
+	at = at.Add(-secondsToDuration(deltaT(at)))
+	m := MoonPosition(at)
+	_, _, parallax := moonEcliptic(getJdn(tdbJD(at)) / 36525)
+	for _, tt := range []struct {
+		name           string
+		got, want, tol float64
+	}{
+		{"longitude", m.EclipticLongitude, 133.162655, 0.005},
+		{"latitude", m.EclipticLatitude, -3.229126, 0.006},
+		{"right ascension", m.RightAscension, 134.688470, 0.01},
+		{"declination", m.Declination, 13.768368, 0.005},
+		{"parallax", parallax, 0.991990, 0.0005},
+		{"distance", m.Distance * auKm, 368409.7, 20},
+		{"illumination", MoonIllumination(at), 0.6786, 0.001},
+	} {
+		if math.Abs(tt.got-tt.want) > tt.tol {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// New moon fell at 11:57 UT on 11 January 2024 and full moon at 17:54 UT on
+// 25 January; the Moon was then nearly 5 degrees off the ecliptic, so not quite
+// fully lit.
+func TestMoonIllumination(t *testing.T) {
+	for _, tt := range []struct {
+		at       time.Time
+		min, max float64
+	}{
+		{time.Date(2024, time.January, 11, 11, 57, 0, 0, time.UTC), 0, 0.005},
+		{time.Date(2024, time.January, 18, 3, 53, 0, 0, time.UTC), 0.45, 0.55},
+		{time.Date(2024, time.January, 25, 17, 54, 0, 0, time.UTC), 0.995, 1},
+	} {
+		if got := MoonIllumination(tt.at); got < tt.min || got > tt.max {
+			t.Errorf("MoonIllumination(%v) = %v, want %v to %v", tt.at, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestMoonriseMoonset(t *testing.T) {
+	// the full moon rises around sunset
+	d := date(time.UTC, 2024, time.January, 25)
+	rise, ok := Moonrise(d, 51.5, 0)
+	set, _ := Sunset(d, 51.5, 0)
+	if !ok || !within(rise, set, time.Hour) {
+		t.Errorf("Moonrise at full moon = %v, %v, want near sunset at %v", rise, ok, set)
+	}
+	for _, f := range []func(time.Time, float64, float64) (time.Time, bool){Moonrise, Moonset} {
+		at, ok := f(d, 51.5, 0)
+		if !ok {
+			t.Fatal("no moonrise or moonset")
+		}
+		if a := MoonAltitude(at, 51.5, 0); math.Abs(a-MoonriseAltitude) > 0.001 {
+			t.Errorf("Moon altitude at the event = %v, want %v", a, MoonriseAltitude)
+		}
+		if y, m, dd := at.Date(); y != 2024 || m != time.January || dd != 25 {
+			t.Errorf("event on %v, want 25 January", at)
+		}
+	}
+}
+
+// At latitude 45 in January the Sun is 18 degrees down for 11h29m a night,
+// from the hour angle of 93.86 degrees at a declination of -22 degrees.
+func TestPlanImaging(t *testing.T) {
+	start, end := date(time.UTC, 2024, time.January, 8), date(time.UTC, 2024, time.January, 28)
+	nights := PlanImaging(start, end, 45, 0, 0)
+	if len(nights) != 21 {
+		t.Fatalf("%d nights, want 21", len(nights))
+	}
+	for i, n := range nights {
+		if i > 0 && n.Usable > nights[i-1].Usable {
+			t.Errorf("night %d has %v usable, more than %v before it", i, n.Usable, nights[i-1].Usable)
+		}
+		var sum time.Duration
+		for _, w := range n.Windows {
+			sum += w.Duration()
+			if w.Start.Before(n.Darkness.Start) || w.End.After(n.Darkness.End) {
+				t.Errorf("%s: window %v outside the darkness %v", n.Date.Format("Jan 2"), w, n.Darkness)
+			}
+			if mid := w.Start.Add(w.Duration() / 2); MoonAltitude(mid, 45, 0) > MoonriseAltitude && n.MoonIllumination > 0 {
+				t.Errorf("%s: the Moon is up in the middle of %v", n.Date.Format("Jan 2"), w)
+			}
+		}
+		if sum != n.Usable {
+			t.Errorf("%s: Usable = %v, want %v", n.Date.Format("Jan 2"), n.Usable, sum)
+		}
+		if d := n.Darkness.Duration(); d < 10*time.Hour+55*time.Minute || d > 11*time.Hour+35*time.Minute {
+			t.Errorf("%s: %v of darkness", n.Date.Format("Jan 2"), d)
+		}
+	}
+
+	// the nights either side of new moon are dark all night, in date order,
+	// and those around full moon have no usable time
+	for i, day := range []int{8, 9, 10, 11, 12} {
+		if n := nights[i]; n.Date.Day() != day || n.Usable != n.Darkness.Duration() {
+			t.Errorf("night %d is %s with %v of %v usable, want January %d all night", i, n.Date.Format("Jan 2"), n.Usable, n.Darkness.Duration(), day)
+		}
+	}
+	for _, n := range nights[len(nights)-5:] {
+		if d := n.Date.Day(); d < 22 || d > 26 || n.Usable != 0 || len(n.Windows) != 0 {
+			t.Errorf("%s has %v usable, want nothing around full moon", n.Date.Format("Jan 2"), n.Usable)
+		}
+	}
+
+	// a thin crescent is accepted when asked
+	for _, n := range PlanImaging(date(time.UTC, 2024, time.January, 13), date(time.UTC, 2024, time.January, 13), 45, 0, 0.1) {
+		if n.Usable != n.Darkness.Duration() {
+			t.Errorf("with a 9%% crescent allowed, %v of %v usable", n.Usable, n.Darkness.Duration())
+		}
+	}
+
+	// at midsummer in London there is no astronomical darkness
+	for _, n := range PlanImaging(date(time.UTC, 2024, time.June, 20), date(time.UTC, 2024, time.June, 22), 51.5, 0, 1) {
+		if n.Darkness != (Period{}) || n.Usable != 0 {
+			t.Errorf("%s: darkness %v, usable %v, want none", n.Date.Format("Jan 2"), n.Darkness, n.Usable)
+		}
+	}
+}
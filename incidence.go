@@ -0,0 +1,17 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// IncidenceAngle returns the angle in degrees between the direct beam of the
+// Sun at t and the normal to a plane surface tilted slope degrees from
+// horizontal and facing aspect degrees clockwise from north: a hillside, a
+// roof, a wall (slope 90) or a solar panel. Below 90 the Sun lights the face,
+// with a share of the beam equal to the cosine of the angle; above 90 it is
+// behind it. The Sun may still be below the horizon, so check Altitude too.
+func IncidenceAngle(t time.Time, latitude float64, longitude float64, slope float64, aspect float64) float64 {
+	c := cosIncidence(Altitude(t, latitude, longitude), Azimuth(t, latitude, longitude), slope, aspect)
+	return angleAcos(math.Max(-1, math.Min(1, c)))
+}
@@ -0,0 +1,45 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At a London noon on the June solstice the Sun is due south and 61.93
+// degrees up, so it meets level ground at the zenith angle 28.07, a south
+// wall at 61.93, a north wall at 118.07 from behind, an east wall edge on at
+// 90, and a roof pitched 28.07 degrees to the south square on.
+func TestIncidenceAngle(t *testing.T) {
+	noon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278)
+	z := 90 - noon.Altitude
+	for _, tt := range []struct {
+		name          string
+		slope, aspect float64
+		want          float64
+	}{
+		{"level", 0, 0, 28.07},
+		{"south wall", 90, 180, 61.93},
+		{"north wall", 90, 0, 118.07},
+		{"east wall", 90, 90, 90},
+		{"west wall", 90, 270, 90},
+		{"south roof", z, 180, 0},
+		{"north roof", z, 0, 2 * 28.07},
+	} {
+		got := IncidenceAngle(noon.Time, 51.5074, -0.1278, tt.slope, tt.aspect)
+		if math.Abs(got-tt.want) > 0.02 {
+			t.Errorf("%s: IncidenceAngle = %.3f, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// a panel turned to face the Sun at any time takes it square on
+	at := time.Date(2024, time.June, 21, 9, 30, 0, 0, time.UTC)
+	alt, az := Altitude(at, 51.5074, -0.1278), Azimuth(at, 51.5074, -0.1278)
+	if got := IncidenceAngle(at, 51.5074, -0.1278, 90-alt, az); got > 0.01 {
+		t.Errorf("IncidenceAngle facing the Sun = %v, want 0", got)
+	}
+	// and level ground at the zenith angle
+	if got := IncidenceAngle(at, 51.5074, -0.1278, 0, 0); math.Abs(got-(90-alt)) > 1e-9 {
+		t.Errorf("IncidenceAngle on level ground = %v, want %v", got, 90-alt)
+	}
+}
@@ -0,0 +1,99 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// SolarConstant is the mean solar irradiance outside the atmosphere at one
+// astronomical unit, in watts per square metre.
+const SolarConstant = 1361.0
+
+// clearSkyTransmissivity is the fraction of the direct beam passing straight
+// down through a clean, dry atmosphere, as commonly taken in melt models
+const clearSkyTransmissivity = 0.75
+
+// insolationStep is the time step of the daily insolation integrals
+const insolationStep = 10 * time.Minute
+
+// ClearSkyBeam returns the direct beam irradiance of the Sun at t, in watts per
+// square metre on a surface facing it, under a clear sky. It is the solar
+// constant, corrected for the Earth-Sun distance, attenuated by a
+// transmissivity of 0.75 per air mass, and zero with the Sun below the horizon.
+// Real skies vary by tens of percent either way.
+func ClearSkyBeam(t time.Time, latitude float64, longitude float64) float64 {
+	alt := Altitude(t, latitude, longitude)
+	if alt <= 0 {
+		return 0
+	}
+	d := EarthSunDistance(t)
+	return SolarConstant / (d * d) * math.Pow(clearSkyTransmissivity, AirMass(alt))
+}
+
+// ClearSkyGHI returns the global horizontal irradiance at t, the direct beam
+// and diffuse sky light together on level ground, in watts per square metre
+// under a clear sky, by the model of Haurwitz. It is some 1000 with the Sun
+// overhead and zero with the Sun below the horizon.
+func ClearSkyGHI(t time.Time, latitude float64, longitude float64) float64 {
+	cosZ := angleSin(Altitude(t, latitude, longitude))
+	if cosZ <= 0 {
+		return 0
+	}
+	return 1098 * cosZ * math.Exp(-0.057/cosZ)
+}
+
+// AirMass returns the relative optical path through the atmosphere towards a
+// body at geometric altitude alt degrees, 1 at the zenith, using the formula of
+// Kasten and Young. It is +Inf below the horizon.
+func AirMass(alt float64) float64 {
+	if alt < -1 {
+		return math.Inf(1)
+	}
+	return 1 / (angleSin(alt) + 0.50572*math.Pow(alt+6.07995, -1.6364))
+}
+
+// MeltInsolation returns the potential direct insolation on the date of t, in
+// watt hours per square metre, on ground tilted by slope degrees and facing
+// aspect degrees clockwise from north, with the skyline h: the clear sky beam
+// integrated over the day wherever the Sun is in front of the slope and clear
+// of the terrain. It is the radiation index of temperature index melt models
+// and of avalanche hazard maps, where it separates sunny from shady slopes.
+func MeltInsolation(t time.Time, latitude float64, longitude float64, slope float64, aspect float64, h Horizon) float64 {
+	var sum float64
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	for at := start.Add(insolationStep / 2); at.Before(start.AddDate(0, 0, 1)); at = at.Add(insolationStep) {
+		if horizonClearance(at, latitude, longitude, h, 1) <= 0 {
+			continue
+		}
+		c := cosIncidence(Altitude(at, latitude, longitude), Azimuth(at, latitude, longitude), slope, aspect)
+		if c > 0 {
+			sum += ClearSkyBeam(at, latitude, longitude) * c * insolationStep.Hours()
+		}
+	}
+	return sum
+}
+
+// MeltInsolation returns the potential direct insolation of MeltInsolation for
+// every cell of the DEM on the date of t, in row order, with slope and aspect
+// from SlopeAspect and shading from Shadow.
+func (d *DEM) MeltInsolation(t time.Time) []float64 {
+	lat, lon := d.Origin.Latitude, d.Origin.Longitude
+	sum := make([]float64, d.Rows*d.Cols)
+	slope, aspect := d.SlopeAspect()
+	y, m, dd := t.Date()
+	start := time.Date(y, m, dd, 0, 0, 0, 0, t.Location())
+	for at := start.Add(insolationStep / 2); at.Before(start.AddDate(0, 0, 1)); at = at.Add(insolationStep) {
+		beam := ClearSkyBeam(at, lat, lon)
+		if beam == 0 {
+			continue
+		}
+		alt, az := Altitude(at, lat, lon), Azimuth(at, lat, lon)
+		for i, s := range d.Shadow(at) {
+			if !s {
+				sum[i] += beam * cosIncidence(alt, az, slope[i], aspect[i]) * insolationStep.Hours()
+			}
+		}
+	}
+	return sum
+}
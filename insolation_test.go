@@ -0,0 +1,99 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Kasten and Young tabulate an air mass of 1.0 at the zenith, 1.994 at 30
+// degrees and 37.92 at the horizon.
+func TestAirMass(t *testing.T) {
+	for _, tt := range []struct{ alt, want, tol float64 }{
+		{90, 0.9997, 1e-4},
+		{30, 1.9943, 1e-4},
+		{0, 37.92, 0.01},
+	} {
+		if got := AirMass(tt.alt); math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("AirMass(%v) = %.4f, want %v", tt.alt, got, tt.want)
+		}
+	}
+	if got := AirMass(-2); !math.IsInf(got, 1) {
+		t.Errorf("AirMass(-2) = %v, want +Inf", got)
+	}
+}
+
+// At the subsolar point at perihelion the beam is 1361 / 0.983307² × 0.75 to the
+// power 0.9997 = 1055.8 W/m², and Haurwitz gives 1098 e^-0.057 = 1037.2 W/m²;
+// with the Sun 30 degrees up Haurwitz gives 549 e^-0.114 = 489.9 W/m².
+func TestClearSky(t *testing.T) {
+	at := time.Date(2024, time.January, 3, 0, 38, 0, 0, time.UTC)
+	lat, lon := SubsolarPoint(at)
+	if got := ClearSkyBeam(at, lat, lon); math.Abs(got-1055.8) > 0.5 {
+		t.Errorf("ClearSkyBeam overhead = %.1f, want 1055.8", got)
+	}
+	if got := ClearSkyGHI(at, lat, lon); math.Abs(got-1037.2) > 0.5 {
+		t.Errorf("ClearSkyGHI overhead = %.1f, want 1037.2", got)
+	}
+	// 60 degrees from the subsolar point the Sun is 30 degrees up
+	p := Destination(Point{lat, lon}, 0, toRadians(60)*earthRadius)
+	if got := ClearSkyGHI(at, p.Latitude, p.Longitude); math.Abs(got-489.9) > 1 {
+		t.Errorf("ClearSkyGHI at 30 degrees = %.1f, want 489.9", got)
+	}
+	// the antisolar point is in darkness
+	if b, g := ClearSkyBeam(at, -lat, lon+180), ClearSkyGHI(at, -lat, lon+180); b != 0 || g != 0 {
+		t.Errorf("clear sky at night = %v, %v, want 0", b, g)
+	}
+}
+
+// Without an atmosphere level ground at the equator on the equinox gets the
+// solar constant for 24/π = 7.64 hours, some 10.4 kWh/m²; the clear sky takes
+// a third of that. In December at 46 degrees north the Sun rises and sets
+// south of east and west, so a north facing wall gets none, and a skyline of
+// 30 degrees hides it all day.
+func TestMeltInsolation(t *testing.T) {
+	eq := date(time.UTC, 2024, time.March, 20)
+	if got := MeltInsolation(eq, 0, 0, 0, 0, Horizon{}); got < 6500 || got > 7500 {
+		t.Errorf("MeltInsolation at the equator = %.0f, want about 7000", got)
+	}
+	flat := MeltInsolation(eq, 46, 0, 0, 0, Horizon{})
+	south := MeltInsolation(eq, 46, 0, 46, 180, Horizon{})
+	north := MeltInsolation(eq, 46, 0, 30, 0, Horizon{})
+	if !(south > flat && flat > north && north > 0) {
+		t.Errorf("south %.0f, flat %.0f and north %.0f slopes in the wrong order", south, flat, north)
+	}
+	// the day is symmetric about noon, so east and west slopes get the same
+	east := MeltInsolation(eq, 46, 0, 30, 90, Horizon{})
+	west := MeltInsolation(eq, 46, 0, 30, 270, Horizon{})
+	if math.Abs(east-west) > 0.005*east {
+		t.Errorf("east %.0f and west %.0f slopes differ", east, west)
+	}
+
+	dec := date(time.UTC, 2024, time.December, 21)
+	if got := MeltInsolation(dec, 46, 0, 90, 0, Horizon{}); got != 0 {
+		t.Errorf("north wall in December = %v, want 0", got)
+	}
+	if got := MeltInsolation(dec, 46, 0, 0, 0, NewHorizon([]float64{30, 30})); got != 0 {
+		t.Errorf("behind a 30 degree skyline in December = %v, want 0", got)
+	}
+}
+
+func TestDEMMeltInsolation(t *testing.T) {
+	dec := date(time.UTC, 2024, time.December, 21)
+	d, err := NewDEM(5, 5, 50, Point{46, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MeltInsolation(dec, 46, 0, 0, 0, Horizon{})
+	for i, got := range d.MeltInsolation(dec) {
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("flat cell %d = %v, want %v", i, got, want)
+		}
+	}
+	// on ground rising northwards the southern face gets more
+	d = slopeDEM(t, 5, 10)
+	m := d.MeltInsolation(dec)
+	if m[12] <= want {
+		t.Errorf("south facing cell = %v, want more than flat %v", m[12], want)
+	}
+}
@@ -0,0 +1,53 @@
+// Package ical writes the content lines of iCalendar (RFC 5545) feeds for the
+// sun package and its HTTP handlers.
+package ical
+
+import (
+	"io"
+	"strings"
+)
+
+// Writer writes content lines ending in CRLF and folded at 75 octets,
+// remembering the first error.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Line writes the content line s, folding it without splitting a character.
+// After an error it does nothing.
+func (lw *Writer) Line(s string) {
+	if lw.err != nil {
+		return
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		l := len(string(r))
+		if n+l > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += l
+	}
+	b.WriteString("\r\n")
+	_, lw.err = io.WriteString(lw.w, b.String())
+}
+
+// Err returns the first error from writing, if any.
+func (lw *Writer) Err() error {
+	return lw.err
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// Text escapes a TEXT value.
+func Text(s string) string {
+	return textEscaper.Replace(s)
+}
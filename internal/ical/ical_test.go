@@ -0,0 +1,50 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	var b bytes.Buffer
+	lw := NewWriter(&b)
+	lw.Line("SUMMARY:" + strings.Repeat("é", 40))
+	// 75 octets to a line, not splitting the two octets of an é
+	want := "SUMMARY:" + strings.Repeat("é", 33) + "\r\n " + strings.Repeat("é", 7) + "\r\n"
+	if b.String() != want {
+		t.Errorf("folded line = %q, want %q", b.String(), want)
+	}
+
+	// a continuation line holds 74 octets after its space
+	b.Reset()
+	lw.Line(strings.Repeat("a", 75+74+1))
+	want = strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n"
+	if b.String() != want {
+		t.Errorf("folded line = %q, want %q", b.String(), want)
+	}
+
+	lw = NewWriter(failWriter{})
+	lw.Line("BEGIN:VCALENDAR")
+	lw.Line("END:VCALENDAR")
+	if lw.Err() == nil {
+		t.Error("no error from a failing writer")
+	}
+}
+
+func TestText(t *testing.T) {
+	for _, tt := range []struct{ s, want string }{
+		{`Kew; Richmond, Surrey`, `Kew\; Richmond\, Surrey`},
+		{`a\b`, `a\\b`},
+		{"two\nlines", `two\nlines`},
+	} {
+		if got := Text(tt.s); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("closed") }
@@ -0,0 +1,114 @@
+// Package invariants holds physical invariants of the sun package's results,
+// checked across random dates and locations to guard refactors of the solar
+// model such as changes to the obliquity or to how angles are resolved. The
+// tests of package sun run them on every build, and the suninvariants command
+// on as many cases as wanted.
+package invariants
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// maxDeclination is the greatest declination the Sun can reach, the obliquity
+// of the ecliptic with a little to spare for its slow change
+const maxDeclination = 23.45
+
+// Invariant is a property that must hold at a time and place. Check returns a
+// description of the failure if it does not.
+type Invariant struct {
+	Name  string
+	Check func(t time.Time, lat float64, lon float64) error
+}
+
+// All is every invariant.
+var All = []Invariant{
+	{"declination bounded by obliquity", func(t time.Time, lat float64, lon float64) error {
+		dec := sun.LowPrecision{}.Sun(t).Declination
+		if math.Abs(dec) > maxDeclination {
+			return fmt.Errorf("declination %.4f", dec)
+		}
+		return nil
+	}},
+	{"altitude symmetric about transit", func(t time.Time, lat float64, lon float64) error {
+		c := sun.Culminate(t, lat, lon)
+		before := sun.Altitude(c.Time.Add(-2*time.Hour), lat, lon)
+		after := sun.Altitude(c.Time.Add(2*time.Hour), lat, lon)
+		// the declination changes by up to 0.04 degree in four hours
+		if d := math.Abs(before - after); d > 0.05 {
+			return fmt.Errorf("altitudes two hours either side of %s differ by %.4f", c.Time.Format(time.RFC3339), d)
+		}
+		return nil
+	}},
+	{"sunrise before transit before sunset", func(t time.Time, lat float64, lon float64) error {
+		rise, ok1 := sun.Sunrise(t, lat, lon)
+		set, ok2 := sun.Sunset(t, lat, lon)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		noon := sun.Culminate(t, lat, lon).Time
+		if !rise.Before(noon) || !noon.Before(set) {
+			return fmt.Errorf("sunrise %s, transit %s, sunset %s", rise.Format(time.RFC3339),
+				noon.Format(time.RFC3339), set.Format(time.RFC3339))
+		}
+		return nil
+	}},
+	{"azimuth monotonic in the morning", func(t time.Time, lat float64, lon float64) error {
+		// outside the tropics the Sun never crosses the meridian on the
+		// observer's own side, so its azimuth turns one way all morning:
+		// clockwise in the north and anticlockwise in the south
+		if math.Abs(lat) < 30 || math.Abs(lat) > 60 {
+			return nil
+		}
+		rise, ok := sun.Sunrise(t, lat, lon)
+		if !ok {
+			return nil
+		}
+		noon := sun.Culminate(t, lat, lon).Time
+		prev := sun.Azimuth(rise, lat, lon)
+		for at := rise.Add(10 * time.Minute); at.Before(noon); at = at.Add(10 * time.Minute) {
+			az := sun.Azimuth(at, lat, lon)
+			turn := math.Mod(az-prev+540, 360) - 180
+			if turn*lat <= 0 {
+				return fmt.Errorf("azimuth went from %.4f to %.4f at %s", prev, az, at.Format(time.RFC3339))
+			}
+			prev = az
+		}
+		return nil
+	}},
+}
+
+// Failure is an invariant that did not hold in a scenario.
+type Failure struct {
+	Invariant string
+	Scenario  sun.Scenario
+	Err       error
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s at %.4f, %.4f: %v", f.Invariant, f.Scenario.Time.Format(time.RFC3339),
+		f.Scenario.Latitude, f.Scenario.Longitude, f.Err)
+}
+
+// Run checks every invariant in n scenarios from a generator seeded with seed,
+// for times from 1950 to 2050, and returns the failures. The same seed always
+// checks the same cases, so a failure can be replayed.
+func Run(n int, seed int64) ([]Failure, error) {
+	gen, err := sun.NewScenarioGenerator(seed, time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+	var failures []Failure
+	for i := 0; i < n; i++ {
+		s := gen.Next()
+		for _, inv := range All {
+			if err := inv.Check(s.Time, s.Latitude, s.Longitude); err != nil {
+				failures = append(failures, Failure{inv.Name, s, err})
+			}
+		}
+	}
+	return failures, nil
+}
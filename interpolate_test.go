@@ -0,0 +1,94 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestInterpolationError(t *testing.T) {
+	tests := []struct {
+		step time.Duration
+		want float64
+	}{
+		{time.Hour, 0.00121},
+		{2 * time.Hour, 0.0194},
+		{30 * time.Minute, 0.0000758},
+	}
+	for _, tt := range tests {
+		if got := InterpolationError(tt.step); math.Abs(got-tt.want) > tt.want*0.01 {
+			t.Errorf("InterpolationError(%v) = %.6f, want %.6f", tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestInterpolatorWithinBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		step     time.Duration
+		start    time.Time
+	}{
+		{"London", 51.5, -0.13, time.Hour, time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)},
+		{"Singapore near zenith", 1.35, 103.8, time.Hour, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{"Tromsø", 69.65, 18.96, 2 * time.Hour, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		ip, err := NewInterpolator(tt.start, tt.start.Add(24*time.Hour), tt.step, tt.lat, tt.lon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bound := InterpolationError(tt.step)
+		for at := tt.start; at.Before(tt.start.Add(24 * time.Hour)); at = at.Add(7 * time.Minute) {
+			alt, az := ip.Position(at)
+			a, b := altAzVector(alt, az), enuAt(at, tt.lat, tt.lon)
+			if d := vectorAngle(a, b); d > bound {
+				t.Errorf("%s at %v: error %.6f exceeds bound %.6f", tt.name, at, d, bound)
+			}
+		}
+	}
+}
+
+func TestInterpolatorOutsideRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ip, err := NewInterpolator(start, start.Add(time.Hour), time.Hour, 40, -74)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := start.Add(-time.Hour)
+	alt, az := ip.Position(at)
+	if alt != Altitude(at, 40, -74) || az != Azimuth(at, 40, -74) {
+		t.Errorf("Position outside the samples should be computed directly")
+	}
+}
+
+func TestNewInterpolatorStep(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, step := range []time.Duration{0, -time.Minute} {
+		if _, err := NewInterpolator(start, start.Add(time.Hour), step, 0, 0); err != errNonPositiveStep {
+			t.Errorf("NewInterpolator with step %v: err = %v, want %v", step, err, errNonPositiveStep)
+		}
+	}
+}
+
+func TestNewInterpolatorSpan(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, end := range []time.Time{start.Add(-time.Minute), start.Add(-time.Hour), start.Add(-24 * time.Hour)} {
+		if _, err := NewInterpolator(start, end, time.Minute, 0, 0); err != errEmptySpan {
+			t.Errorf("NewInterpolator to %v before the start: err = %v, want %v", start.Sub(end), err, errEmptySpan)
+		}
+	}
+	ip, err := NewInterpolator(start, start, time.Minute, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alt, _ := ip.Position(start); alt != Altitude(start, 0, 0) {
+		t.Errorf("Position at the only sample = %v, want %v", alt, Altitude(start, 0, 0))
+	}
+}
+
+// altAzVector returns the unit vector in the east, north, up frame of an
+// altitude and azimuth
+func altAzVector(alt float64, az float64) [3]float64 {
+	return [3]float64{angleCos(alt) * angleSin(az), angleCos(alt) * angleCos(az), angleSin(alt)}
+}
@@ -0,0 +1,87 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Rates at which the body clock is taken to shift with well timed light, in
+// hours per day. Delays come more easily than advances.
+const (
+	advancePerDay = 1.0
+	delayPerDay   = 1.5
+)
+
+// LightPlanDay is one day of a plan for shifting the body clock with light.
+type LightPlanDay struct {
+	Date time.Time // midnight at the start of the day at the destination
+	// Minimum is the estimated time of the body temperature minimum, the
+	// pivot of the body clock: light after it advances the clock and light
+	// before it delays it.
+	Minimum time.Time
+	Seek    Period // bright light in this window shifts the clock the right way
+	Avoid   Period // light in this window shifts it the wrong way
+	// SeekDaylight and AvoidDaylight are the parts of Seek and Avoid with the
+	// Sun above the horizon at the destination: time to be outdoors, or to
+	// wear dark glasses. A Seek window in darkness calls for a light box.
+	SeekDaylight  []Period
+	AvoidDaylight []Period
+}
+
+// LightPlan returns a day by day plan of when to seek and when to avoid bright
+// light to move the body clock from the origin's time zone to the
+// destination's, starting on the date of travel, with times in the
+// destination's zone. wake is the usual waking time by the clock at home.
+//
+// The temperature minimum is put two hours before waking. Travel east calls for
+// an advance, with light sought for three hours after the minimum, and travel
+// west a delay, with light sought for three hours before it, the minimum moving
+// by about an hour and an hour and a half a day respectively. Shifts of more
+// than 12 hours are made the other way round. The plan is empty if the two
+// zones keep the same time.
+func LightPlan(origin Place, destination Place, travel time.Time, wake time.Duration) []LightPlanDay {
+	_, from := travel.In(origin.Location).Zone()
+	_, to := travel.In(destination.Location).Zone()
+	shift := math.Mod(float64(to-from)/3600, 24)
+	if shift > 12 {
+		shift -= 24
+	} else if shift < -12 {
+		shift += 24
+	}
+	rate := advancePerDay
+	if shift < 0 {
+		rate = delayPerDay
+	}
+	y, m, d := travel.In(origin.Location).Date()
+	minimum := time.Date(y, m, d, 0, 0, 0, 0, origin.Location).Add(wake - 2*time.Hour)
+	y, m, d = travel.In(destination.Location).Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, destination.Location)
+
+	daylight := func(p Period) []Period {
+		return periodsWhere(p.Start, p.End, func(t time.Time) float64 {
+			return Altitude(t, destination.Latitude, destination.Longitude) - SunriseAltitude
+		})
+	}
+	var plan []LightPlanDay
+	for remaining := math.Abs(shift); remaining > 0; remaining -= rate {
+		day := LightPlanDay{Date: date, Minimum: minimum.In(destination.Location)}
+		before := Period{day.Minimum.Add(-3 * time.Hour), day.Minimum}
+		after := Period{day.Minimum, day.Minimum.Add(3 * time.Hour)}
+		if shift > 0 {
+			day.Seek, day.Avoid = after, before
+		} else {
+			day.Seek, day.Avoid = before, after
+		}
+		day.SeekDaylight = daylight(day.Seek)
+		day.AvoidDaylight = daylight(day.Avoid)
+		plan = append(plan, day)
+
+		step := math.Min(rate, remaining)
+		if shift > 0 {
+			step = -step
+		}
+		minimum = minimum.Add(24*time.Hour + secondsToDuration(step*3600))
+		date = date.AddDate(0, 0, 1)
+	}
+	return plan
+}
@@ -0,0 +1,93 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLightPlan(t *testing.T) {
+	london := Place{"London", Point{51.5074, -0.1278}, location(t, "Europe/London")}
+	newYork := Place{"New York", Point{40.7128, -74.0060}, location(t, "America/New_York")}
+	travel := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+
+	// flying east five hours needs five days of advances of an hour; the
+	// minimum, 05:00 in New York, is 10:00 in London on arrival
+	plan := LightPlan(newYork, london, travel, 7*time.Hour)
+	if len(plan) != 5 {
+		t.Fatalf("eastward plan has %d days, want 5", len(plan))
+	}
+	for i, day := range plan {
+		minimum := time.Date(2024, time.June, 21+i, 10-i, 0, 0, 0, london.Location)
+		if !day.Minimum.Equal(minimum) || day.Minimum.Location() != london.Location {
+			t.Errorf("day %d minimum %v, want %v", i, day.Minimum, minimum)
+		}
+		if !day.Date.Equal(date(london.Location, 2024, time.June, 21+i)) {
+			t.Errorf("day %d date %v", i, day.Date)
+		}
+		if day.Seek != (Period{minimum, minimum.Add(3 * time.Hour)}) || day.Avoid != (Period{minimum.Add(-3 * time.Hour), minimum}) {
+			t.Errorf("day %d seek %v, avoid %v, want light after the minimum", i, day.Seek, day.Avoid)
+		}
+		// the Sun rises at 04:44 in London, before every seek window, and the
+		// avoid windows start before it from the fourth day
+		if len(day.SeekDaylight) != 1 || day.SeekDaylight[0] != day.Seek {
+			t.Errorf("day %d seek daylight %v, want the whole window", i, day.SeekDaylight)
+		}
+		avoid := day.Avoid
+		if i >= 3 {
+			avoid.Start = time.Date(2024, time.June, 21+i, 4, 44, 0, 0, london.Location)
+		}
+		if ds := day.AvoidDaylight; len(ds) != 1 || !within(ds[0].Start, avoid.Start, time.Minute) || ds[0].End != avoid.End {
+			t.Errorf("day %d avoid daylight %v, want %v", i, ds, avoid)
+		}
+	}
+
+	// flying west five hours needs delays of an hour and a half a day; the
+	// minimum, 05:00 in London, is midnight in New York, where the Sun set at
+	// 20:31 and rises at 05:25
+	plan = LightPlan(london, newYork, travel, 7*time.Hour)
+	if len(plan) != 4 {
+		t.Fatalf("westward plan has %d days, want 4", len(plan))
+	}
+	for i, want := range []time.Time{
+		time.Date(2024, time.June, 21, 0, 0, 0, 0, newYork.Location),
+		time.Date(2024, time.June, 22, 1, 30, 0, 0, newYork.Location),
+		time.Date(2024, time.June, 23, 3, 0, 0, 0, newYork.Location),
+		time.Date(2024, time.June, 24, 4, 30, 0, 0, newYork.Location),
+	} {
+		day := plan[i]
+		if !day.Minimum.Equal(want) {
+			t.Errorf("day %d minimum %v, want %v", i, day.Minimum, want)
+		}
+		if day.Seek != (Period{want.Add(-3 * time.Hour), want}) || day.Avoid != (Period{want, want.Add(3 * time.Hour)}) {
+			t.Errorf("day %d seek %v, avoid %v, want light before the minimum", i, day.Seek, day.Avoid)
+		}
+	}
+	if len(plan[0].SeekDaylight) != 0 || len(plan[0].AvoidDaylight) != 0 {
+		t.Errorf("day 0 daylight %v and %v, want none in the night", plan[0].SeekDaylight, plan[0].AvoidDaylight)
+	}
+	// on the last day the avoid window runs to 07:30, two hours after sunrise
+	if ds := plan[3].AvoidDaylight; len(ds) != 1 || !within(ds[0].Start, time.Date(2024, time.June, 24, 5, 26, 0, 0, newYork.Location), 2*time.Minute) || ds[0].End != plan[3].Avoid.End {
+		t.Errorf("day 3 avoid daylight %v, want from sunrise at 05:26", ds)
+	}
+
+	if plan := LightPlan(london, Place{"Lisbon", Point{38.7223, -9.1393}, location(t, "Europe/Lisbon")}, travel, 7*time.Hour); len(plan) != 0 {
+		t.Errorf("plan between zones keeping the same time has %d days", len(plan))
+	}
+}
+
+func TestLightPlanShortWay(t *testing.T) {
+	// Auckland is 19 hours ahead of Los Angeles in June, so the clock is
+	// delayed 5 hours rather than advanced 19
+	la := Place{"Los Angeles", Point{34.0522, -118.2437}, location(t, "America/Los_Angeles")}
+	auckland := Place{"Auckland", Point{-36.8485, 174.7633}, location(t, "Pacific/Auckland")}
+	plan := LightPlan(la, auckland, time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), 7*time.Hour)
+	if len(plan) != 4 {
+		t.Fatalf("plan has %d days, want 4", len(plan))
+	}
+	if plan[0].Seek.End != plan[0].Minimum {
+		t.Errorf("seek %v ends at %v, want the minimum %v for a delay", plan[0].Seek, plan[0].Seek.End, plan[0].Minimum)
+	}
+	if got := plan[1].Minimum.Sub(plan[0].Minimum); got != 25*time.Hour+30*time.Minute {
+		t.Errorf("minimum moves %v a day, want 25h30m", got)
+	}
+}
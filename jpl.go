@@ -0,0 +1,449 @@
+package sun
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Indexes of bodies in the JPL pointer table (group 1050).
+const (
+	jplEMB       = 2
+	jplMoon      = 9
+	jplSun       = 10
+	jplNutations = 11
+)
+
+// lightKmPerDay is the speed of light in km per day
+const lightKmPerDay = 299792.458 * 86400
+
+// JPL is an Ephemeris read from a JPL Development Ephemeris, such as DE440, in
+// the ASCII distribution format. The positions it gives are apparent, allowing
+// for light time, aberration, precession and nutation, and are good to well
+// under an arcsecond, limited mainly by the estimate of ΔT used to turn UT
+// into the ephemeris time scale.
+//
+// Only the records loaded are available. Sun returns NaN coordinates for times
+// outside them; use Covers to check first.
+type JPL struct {
+	ncoeff  int
+	ptr     [][3]int // offset (1 based), coefficients per component, sub-intervals
+	emrat   float64
+	records [][]float64 // each starts with its first and last julian day
+}
+
+// LoadJPL reads the header file of an ASCII JPL ephemeris, such as header.440,
+// and any number of its data files, such as ascp01950.440.
+func LoadJPL(header io.Reader, data ...io.Reader) (*JPL, error) {
+	j := &JPL{}
+	if err := j.readHeader(header); err != nil {
+		return nil, err
+	}
+	for _, r := range data {
+		if err := j.readData(r); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+func (j *JPL) readHeader(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	var words []string
+	for sc.Scan() {
+		words = append(words, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	groups := map[string][]string{}
+	for i := 0; i < len(words); i++ {
+		switch {
+		case strings.HasPrefix(words[i], "NCOEFF="):
+			s := strings.TrimPrefix(words[i], "NCOEFF=")
+			if s == "" && i+1 < len(words) {
+				s = words[i+1]
+			}
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("sun: bad NCOEFF in JPL header: %v", err)
+			}
+			j.ncoeff = n
+		case words[i] == "GROUP" && i+1 < len(words):
+			k := i + 2
+			for k < len(words) && words[k] != "GROUP" {
+				k++
+			}
+			groups[words[i+1]] = words[i+2 : k]
+			i = k - 1
+		}
+	}
+
+	names, values := groups["1040"], groups["1041"]
+	if len(names) < 1 || len(values) < 1 {
+		return errors.New("sun: JPL header has no constants")
+	}
+	for i, name := range names[1:] {
+		if i+1 >= len(values) {
+			break
+		}
+		if name == "EMRAT" {
+			v, err := parseJPLFloat(values[i+1])
+			if err != nil {
+				return err
+			}
+			j.emrat = v
+		}
+	}
+	if j.emrat == 0 {
+		return errors.New("sun: JPL header has no EMRAT")
+	}
+
+	p := groups["1050"]
+	if len(p) == 0 || len(p)%3 != 0 {
+		return errors.New("sun: bad pointer table in JPL header")
+	}
+	n := len(p) / 3
+	if n <= jplNutations {
+		return errors.New("sun: JPL pointer table is too short")
+	}
+	j.ptr = make([][3]int, n)
+	for row := 0; row < 3; row++ {
+		for col := 0; col < n; col++ {
+			v, err := strconv.Atoi(p[row*n+col])
+			if err != nil {
+				return fmt.Errorf("sun: bad pointer table in JPL header: %v", err)
+			}
+			j.ptr[col][row] = v
+		}
+	}
+	if j.ncoeff == 0 {
+		return errors.New("sun: JPL header has no NCOEFF")
+	}
+	return nil
+}
+
+func (j *JPL) readData(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		// each record starts with its number and the coefficient count
+		if !sc.Scan() {
+			break
+		}
+		n, err := strconv.Atoi(sc.Text())
+		if err != nil {
+			return fmt.Errorf("sun: bad JPL record header: %v", err)
+		}
+		if n != j.ncoeff {
+			return fmt.Errorf("sun: JPL record has %d coefficients, want %d", n, j.ncoeff)
+		}
+		rec := make([]float64, n)
+		// coefficients come three to a line, padded with zeros
+		for i := 0; i < (n+2)/3*3; i++ {
+			if !sc.Scan() {
+				return io.ErrUnexpectedEOF
+			}
+			v, err := parseJPLFloat(sc.Text())
+			if err != nil {
+				return err
+			}
+			if i < n {
+				rec[i] = v
+			}
+		}
+		j.records = append(j.records, rec)
+	}
+	return sc.Err()
+}
+
+// parseJPLFloat parses a Fortran number such as 0.2287184500D+07
+func parseJPLFloat(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.Replace(s, "D", "E", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("sun: bad number in JPL file: %v", err)
+	}
+	return v, nil
+}
+
+// Covers reports whether the loaded records include time t.
+func (j *JPL) Covers(t time.Time) bool {
+	return j.record(tdbJD(t)) != nil
+}
+
+func (j *JPL) record(jd float64) []float64 {
+	for _, rec := range j.records {
+		if jd >= rec[0] && jd <= rec[1] {
+			return rec
+		}
+	}
+	return nil
+}
+
+// state returns the position in km and velocity in km per day of body at the
+// ephemeris julian day jd, relative to the solar system barycentre except for
+// the Moon which is geocentric. For nutations only the first two components
+// are set, in radians.
+func (j *JPL) state(body int, jd float64) (pos [3]float64, vel [3]float64, ok bool) {
+	rec := j.record(jd)
+	if rec == nil || body >= len(j.ptr) || j.ptr[body][1] == 0 {
+		return pos, vel, false
+	}
+	off, ncf, nsub := j.ptr[body][0]-1, j.ptr[body][1], j.ptr[body][2]
+	comps := 3
+	if body == jplNutations {
+		comps = 2
+	}
+	span := (rec[1] - rec[0]) / float64(nsub)
+	sub := int((jd - rec[0]) / span)
+	if sub >= nsub {
+		sub = nsub - 1
+	}
+	x := 2*(jd-rec[0]-float64(sub)*span)/span - 1
+
+	// Chebyshev polynomials and their derivatives at x
+	t := make([]float64, ncf)
+	d := make([]float64, ncf)
+	t[0] = 1
+	if ncf > 1 {
+		t[1], d[1] = x, 1
+	}
+	for k := 2; k < ncf; k++ {
+		t[k] = 2*x*t[k-1] - t[k-2]
+		d[k] = 2*t[k-1] + 2*x*d[k-1] - d[k-2]
+	}
+	base := off + sub*ncf*comps
+	for c := 0; c < comps; c++ {
+		cf := rec[base+c*ncf : base+(c+1)*ncf]
+		for k := ncf - 1; k >= 0; k-- {
+			pos[c] += cf[k] * t[k]
+			vel[c] += cf[k] * d[k]
+		}
+		vel[c] *= 2 / span
+	}
+	return pos, vel, true
+}
+
+// earth returns the barycentric position and velocity of the Earth
+func (j *JPL) earth(jd float64) (pos [3]float64, vel [3]float64, ok bool) {
+	emb, embVel, ok1 := j.state(jplEMB, jd)
+	moon, moonVel, ok2 := j.state(jplMoon, jd)
+	if !ok1 || !ok2 {
+		return pos, vel, false
+	}
+	for i := range pos {
+		pos[i] = emb[i] - moon[i]/(1+j.emrat)
+		vel[i] = embVel[i] - moonVel[i]/(1+j.emrat)
+	}
+	return pos, vel, true
+}
+
+// Sun returns the apparent geocentric position of the Sun at t.
+func (j *JPL) Sun(t time.Time) Coords {
+	return j.apparentBody(jplSun, t)
+}
+
+// Planet is a planet, or Pluto, numbered as in the JPL ephemerides.
+type Planet int
+
+const (
+	Mercury Planet = 0
+	Venus   Planet = 1
+	Mars    Planet = 3
+	Jupiter Planet = 4
+	Saturn  Planet = 5
+	Uranus  Planet = 6
+	Neptune Planet = 7
+	Pluto   Planet = 8
+)
+
+// Planet returns the apparent geocentric position of planet p at t, with the
+// distance in astronomical units. The giant planets are given by the
+// barycentres of their systems, which is the way DE files carry them.
+func (j *JPL) Planet(p Planet, t time.Time) Coords {
+	if p < Mercury || p > Pluto || p == jplEMB {
+		return nanCoords()
+	}
+	return j.apparentBody(int(p), t)
+}
+
+// apparentBody returns the apparent geocentric position of a body given
+// relative to the solar system barycentre, allowing for light time and
+// annual aberration
+func (j *JPL) apparentBody(body int, t time.Time) Coords {
+	jd := tdbJD(t)
+	earth, earthVel, ok := j.earth(jd)
+	if !ok {
+		return nanCoords()
+	}
+	// light time iteration
+	var g [3]float64
+	tau := 0.0
+	for i := 0; i < 3; i++ {
+		s, _, ok := j.state(body, jd-tau)
+		if !ok {
+			return nanCoords()
+		}
+		for k := range g {
+			g[k] = s[k] - earth[k]
+		}
+		tau = vecLen(g) / lightKmPerDay
+	}
+	dist := vecLen(g)
+	// annual aberration, to first order
+	for k := range g {
+		g[k] += earthVel[k] * dist / lightKmPerDay
+	}
+	c := j.apparent(jd, g)
+	c.Distance = dist / auKm
+	return c
+}
+
+// Moon returns the apparent geocentric position of the Moon at t, with the
+// distance in astronomical units.
+func (j *JPL) Moon(t time.Time) Coords {
+	jd := tdbJD(t)
+	var g [3]float64
+	tau := 0.0
+	for i := 0; i < 2; i++ {
+		m, _, ok := j.state(jplMoon, jd-tau)
+		if !ok {
+			return nanCoords()
+		}
+		g = m
+		tau = vecLen(g) / lightKmPerDay
+	}
+	c := j.apparent(jd, g)
+	c.Distance = vecLen(g) / auKm
+	return c
+}
+
+// apparent precesses and nutates a geocentric J2000 equatorial vector to the
+// true equator and equinox of jd, and returns its coordinates
+func (j *JPL) apparent(jd float64, v [3]float64) Coords {
+	T := getJdn(jd) / 36525
+	v = precessJ2000(v, T)
+
+	eps0 := meanObliquity(T)
+	var dpsi, deps float64
+	if n, _, ok := j.state(jplNutations, jd); ok {
+		dpsi, deps = toAngle(n[0]), toAngle(n[1])
+	} else {
+		dpsi, deps = nutation(T)
+	}
+	// to ecliptic of date, add nutation in longitude, back to the true equator
+	r := vecLen(v)
+	ra, dec := angleAtan2(v[1], v[0]), angleAsin(v[2]/r)
+	lon, lat := equatorialToEcliptic(ra, dec, eps0)
+	lon += dpsi
+	eps := eps0 + deps
+	ra, dec = eclipticToEquatorial(lon, lat, eps)
+	return Coords{
+		EclipticLongitude: between(0, 360, lon),
+		EclipticLatitude:  lat,
+		RightAscension:    between(0, 360, ra),
+		Declination:       dec,
+	}
+}
+
+// auKm is the astronomical unit in km
+const auKm = 149597870.7
+
+// tdbJD returns the julian day of t in the ephemeris time scale, which differs
+// from TT by under 2 ms and is taken as equal to it here
+func tdbJD(t time.Time) float64 {
+	return timeToJD(t) + deltaT(t)/86400
+}
+
+// deltaT returns an estimate of TT - UT in seconds, from the polynomials of
+// Espenak and Meeus
+func deltaT(t time.Time) float64 {
+	y := float64(t.Year()) + (float64(t.YearDay())-0.5)/365.25
+	switch {
+	case y >= 1986 && y < 2005:
+		u := y - 2000
+		return 63.86 + 0.3345*u - 0.060374*u*u + 0.0017275*u*u*u + 0.000651814*u*u*u*u + 0.00002373599*u*u*u*u*u
+	case y >= 2005 && y < 2050:
+		u := y - 2000
+		return 62.92 + 0.32217*u + 0.005589*u*u
+	case y >= 2050 && y < 2150:
+		return -20 + 32*((y-1820)/100)*((y-1820)/100) - 0.5628*(2150-y)
+	case y >= 1961 && y < 1986:
+		u := y - 1975
+		return 45.45 + 1.067*u - u*u/260 - u*u*u/718
+	case y >= 1941 && y < 1961:
+		u := y - 1950
+		return 29.07 + 0.407*u - u*u/233 + u*u*u/2547
+	}
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// meanObliquity returns the mean obliquity of the ecliptic in degrees, T
+// julian centuries after J2000
+func meanObliquity(T float64) float64 {
+	return 23 + (26+(21.448-T*(46.8150+T*(0.00059-T*0.001813)))/60)/60
+}
+
+// nutation returns the nutation in longitude and obliquity in degrees from the
+// main terms of the IAU 1980 theory, good to about 0.5 arcsecond
+func nutation(T float64) (dpsi float64, deps float64) {
+	omega := 125.04452 - 1934.136261*T
+	l := 280.4665 + 36000.7698*T
+	lm := 218.3165 + 481267.8813*T
+	dpsi = -17.20*angleSin(omega) - 1.32*angleSin(2*l) - 0.23*angleSin(2*lm) + 0.21*angleSin(2*omega)
+	deps = 9.20*angleCos(omega) + 0.57*angleCos(2*l) + 0.10*angleCos(2*lm) - 0.09*angleCos(2*omega)
+	return dpsi / 3600, deps / 3600
+}
+
+// precessJ2000 precesses an equatorial vector from J2000 to T julian centuries
+// later, with the IAU 1976 angles (Meeus 21.3)
+func precessJ2000(v [3]float64, T float64) [3]float64 {
+	zeta := (2306.2181*T + 0.30188*T*T + 0.017998*T*T*T) / 3600
+	z := (2306.2181*T + 1.09468*T*T + 0.018203*T*T*T) / 3600
+	theta := (2004.3109*T - 0.42665*T*T - 0.041833*T*T*T) / 3600
+	cz, sz := angleCos(zeta), angleSin(zeta)
+	cZ, sZ := angleCos(z), angleSin(z)
+	ct, st := angleCos(theta), angleSin(theta)
+	p := [3][3]float64{
+		{cz*ct*cZ - sz*sZ, -sz*ct*cZ - cz*sZ, -st * cZ},
+		{cz*ct*sZ + sz*cZ, -sz*ct*sZ + cz*cZ, -st * sZ},
+		{cz * st, -sz * st, ct},
+	}
+	var r [3]float64
+	for i := range r {
+		r[i] = p[i][0]*v[0] + p[i][1]*v[1] + p[i][2]*v[2]
+	}
+	return r
+}
+
+// equatorialToEcliptic converts right ascension and declination to ecliptic
+// longitude and latitude, all in degrees, for obliquity eps
+func equatorialToEcliptic(ra float64, dec float64, eps float64) (lon float64, lat float64) {
+	lon = angleAtan2(angleSin(ra)*angleCos(eps)+angleTan(dec)*angleSin(eps), angleCos(ra))
+	lat = angleAsin(angleSin(dec)*angleCos(eps) - angleCos(dec)*angleSin(eps)*angleSin(ra))
+	return lon, lat
+}
+
+// eclipticToEquatorial converts ecliptic longitude and latitude to right
+// ascension and declination, all in degrees, for obliquity eps
+func eclipticToEquatorial(lon float64, lat float64, eps float64) (ra float64, dec float64) {
+	ra = angleAtan2(angleSin(lon)*angleCos(eps)-angleTan(lat)*angleSin(eps), angleCos(lon))
+	dec = angleAsin(angleSin(lat)*angleCos(eps) + angleCos(lat)*angleSin(eps)*angleSin(lon))
+	return ra, dec
+}
+
+func vecLen(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
+
+func nanCoords() Coords {
+	n := math.NaN()
+	return Coords{n, n, n, n, n}
+}
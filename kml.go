@@ -0,0 +1,116 @@
+package sun
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// KML builds a KML document of sun paths, sunrise and sunset bearings and
+// shadows for viewing in Google Earth and similar tools.
+type KML struct {
+	name       string
+	placemarks bytes.Buffer
+}
+
+// NewKML returns an empty KML document with the given name.
+func NewKML(name string) *KML {
+	return &KML{name: name}
+}
+
+// AddSunPath adds the path of the Sun on the date of t drawn on the ground
+// around p like a polar sun chart: the horizon is a circle of the given radius
+// in metres and the zenith is p itself.
+func (k *KML) AddSunPath(t time.Time, p Point, radius float64) {
+	k.addLine(fmt.Sprintf("Sun path %s", t.Format("2006-01-02")), "sunPath", groundPath(t, p, radius))
+}
+
+// AddEventRays adds lines of the given length in metres from p along the
+// bearings of sunrise and sunset on the date of t, when the Sun rises and
+// sets that day.
+func (k *KML) AddEventRays(t time.Time, p Point, length float64) {
+	if rise, ok := Sunrise(t, p.Latitude, p.Longitude); ok {
+		az := Azimuth(rise, p.Latitude, p.Longitude)
+		k.addLine("Sunrise "+rise.Format("15:04"), "sunrise", []Point{p, Destination(p, az, length)})
+	}
+	if set, ok := Sunset(t, p.Latitude, p.Longitude); ok {
+		az := Azimuth(set, p.Latitude, p.Longitude)
+		k.addLine("Sunset "+set.Format("15:04"), "sunset", []Point{p, Destination(p, az, length)})
+	}
+}
+
+// AddShadow adds the shadow at time t of a block of the given height in metres
+// on footprint, as returned by ShadowPolygon. Nothing is added when the Sun is
+// below the horizon.
+func (k *KML) AddShadow(t time.Time, footprint []Point, height float64) {
+	shadow := ShadowPolygon(t, footprint, height)
+	if shadow == nil {
+		return
+	}
+	k.placemarks.WriteString("<Placemark><name>")
+	xml.EscapeText(&k.placemarks, []byte("Shadow "+t.Format("2006-01-02 15:04")))
+	k.placemarks.WriteString("</name><styleUrl>#shadow</styleUrl><Polygon><outerBoundaryIs><LinearRing><coordinates>")
+	k.writeCoords(append(shadow, shadow[0]))
+	k.placemarks.WriteString("</coordinates></LinearRing></outerBoundaryIs></Polygon></Placemark>\n")
+}
+
+func (k *KML) addLine(name string, style string, pts []Point) {
+	if len(pts) < 2 {
+		return
+	}
+	k.placemarks.WriteString("<Placemark><name>")
+	xml.EscapeText(&k.placemarks, []byte(name))
+	k.placemarks.WriteString("</name><styleUrl>#" + style + "</styleUrl><LineString><tessellate>1</tessellate><coordinates>")
+	k.writeCoords(pts)
+	k.placemarks.WriteString("</coordinates></LineString></Placemark>\n")
+}
+
+func (k *KML) writeCoords(pts []Point) {
+	for i, p := range pts {
+		if i > 0 {
+			k.placemarks.WriteByte(' ')
+		}
+		k.placemarks.WriteString(strconv.FormatFloat(p.Longitude, 'f', 7, 64))
+		k.placemarks.WriteByte(',')
+		k.placemarks.WriteString(strconv.FormatFloat(p.Latitude, 'f', 7, 64))
+		k.placemarks.WriteString(",0")
+	}
+}
+
+// kmlStyles colours the sun path orange, sunrise yellow, sunset red and
+// shadows translucent grey (KML colours are aabbggrr)
+const kmlStyles = `<Style id="sunPath"><LineStyle><color>ff1e96f0</color><width>3</width></LineStyle></Style>
+<Style id="sunrise"><LineStyle><color>ff00d7ff</color><width>2</width></LineStyle></Style>
+<Style id="sunset"><LineStyle><color>ff1e28dc</color><width>2</width></LineStyle></Style>
+<Style id="shadow"><LineStyle><color>ff404040</color></LineStyle><PolyStyle><color>80404040</color></PolyStyle></Style>
+`
+
+// WriteTo writes the document as KML.
+func (k *KML) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>`)
+	xml.EscapeText(&b, []byte(k.name))
+	b.WriteString("</name>\n")
+	b.WriteString(kmlStyles)
+	b.Write(k.placemarks.Bytes())
+	b.WriteString("</Document></kml>\n")
+	return b.WriteTo(w)
+}
+
+// WriteKMZ writes the document as KMZ, a zip archive holding doc.kml.
+func (k *KML) WriteKMZ(w io.Writer) error {
+	z := zip.NewWriter(w)
+	f, err := z.Create("doc.kml")
+	if err != nil {
+		return err
+	}
+	if _, err := k.WriteTo(f); err != nil {
+		return err
+	}
+	return z.Close()
+}
@@ -0,0 +1,137 @@
+package sun
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// kmlDoc is the part of a KML document the tests read back
+type kmlDoc struct {
+	Name   string `xml:"Document>name"`
+	Styles []struct {
+		ID string `xml:"id,attr"`
+	} `xml:"Document>Style"`
+	Placemarks []struct {
+		Name    string `xml:"name"`
+		Style   string `xml:"styleUrl"`
+		Line    string `xml:"LineString>coordinates"`
+		Polygon string `xml:"Polygon>outerBoundaryIs>LinearRing>coordinates"`
+	} `xml:"Document>Placemark"`
+}
+
+// kmlPoints parses a KML coordinates string
+func kmlPoints(t *testing.T, s string) []Point {
+	t.Helper()
+	var pts []Point
+	for _, c := range strings.Fields(s) {
+		f := strings.Split(c, ",")
+		if len(f) != 3 || f[2] != "0" {
+			t.Fatalf("bad coordinate %q", c)
+		}
+		lon, err1 := strconv.ParseFloat(f[0], 64)
+		lat, err2 := strconv.ParseFloat(f[1], 64)
+		if err1 != nil || err2 != nil {
+			t.Fatalf("bad coordinate %q", c)
+		}
+		pts = append(pts, Point{lat, lon})
+	}
+	return pts
+}
+
+func TestKML(t *testing.T) {
+	d := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	k := NewKML("Sun & shadows <London>")
+	k.AddSunPath(d, london, 100)
+	k.AddEventRays(d, london, 500)
+	footprint := []Point{london, {london.Latitude, london.Longitude + 0.0001}, {london.Latitude + 0.0001, london.Longitude}}
+	k.AddShadow(d.Add(12*time.Hour), footprint, 20)
+	k.AddShadow(d, footprint, 20) // midnight, no shadow
+	k.AddEventRays(date(time.UTC, 2024, time.June, 21), Point{75, 0}, 500)
+
+	var b bytes.Buffer
+	if _, err := k.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	var doc kmlDoc
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("KML does not parse: %v", err)
+	}
+	if doc.Name != "Sun & shadows <London>" {
+		t.Errorf("name = %q", doc.Name)
+	}
+	if len(doc.Styles) != 4 {
+		t.Errorf("%d styles, want 4", len(doc.Styles))
+	}
+	if len(doc.Placemarks) != 4 {
+		t.Fatalf("%d placemarks, want a path, two rays and a shadow", len(doc.Placemarks))
+	}
+	rise, _ := Sunrise(d, london.Latitude, london.Longitude)
+	for i, want := range []struct{ name, style string }{
+		{"Sun path 2024-06-21", "#sunPath"},
+		{"Sunrise " + rise.Format("15:04"), "#sunrise"},
+		{"Sunset 20:21", "#sunset"},
+		{"Shadow 2024-06-21 12:00", "#shadow"},
+	} {
+		if p := doc.Placemarks[i]; p.Name != want.name || p.Style != want.style {
+			t.Errorf("placemark %d is %q styled %q, want %q styled %q", i, p.Name, p.Style, want.name, want.style)
+		}
+	}
+
+	// the sunrise ray points along the sunrise azimuth, about 49 degrees
+	ray := kmlPoints(t, doc.Placemarks[1].Line)
+	if len(ray) != 2 || ray[0] != london {
+		t.Fatalf("sunrise ray = %v", ray)
+	}
+	if d := Distance(ray[0], ray[1]); d < 499.9 || d > 500.1 {
+		t.Errorf("sunrise ray is %v m long, want 500", d)
+	}
+	if b := Bearing(ray[0], ray[1]); b < 48 || b > 50 {
+		t.Errorf("sunrise ray bears %v, want about 49", b)
+	}
+	ring := kmlPoints(t, doc.Placemarks[3].Polygon)
+	if ring[0] != ring[len(ring)-1] {
+		t.Error("shadow ring is not closed")
+	}
+	for _, p := range kmlPoints(t, doc.Placemarks[0].Line) {
+		if Distance(london, p) > 100.01 {
+			t.Errorf("sun path point %v outside the horizon circle", p)
+		}
+	}
+}
+
+func TestKMZ(t *testing.T) {
+	k := NewKML("test")
+	k.AddEventRays(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), Point{0, 0}, 1000)
+	var kml, kmz bytes.Buffer
+	if _, err := k.WriteTo(&kml); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.WriteKMZ(&kmz); err != nil {
+		t.Fatal(err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(kmz.Bytes()), int64(kmz.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(z.File) != 1 || z.File[0].Name != "doc.kml" {
+		t.Fatalf("KMZ holds %v, want doc.kml alone", z.File)
+	}
+	f, err := z.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, kml.Bytes()) {
+		t.Error("doc.kml differs from the KML")
+	}
+}
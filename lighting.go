@@ -0,0 +1,100 @@
+package sun
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// LightingRule sets when street lights switch on and off.
+type LightingRule struct {
+	OnAltitude  float64       // the setting Sun's altitude at switch on, degrees
+	OffAltitude float64       // the rising Sun's altitude at switch off, degrees
+	OnOffset    time.Duration // added to the switch on time
+	OffOffset   time.Duration // added to the switch off time
+	// MinimumBurn is the shortest time the lights are left on once lit,
+	// which spares lamps short runs on bright summer nights. The switch off
+	// is put back to meet it.
+	MinimumBurn time.Duration
+}
+
+// LightingSwitch is one night's switching.
+type LightingSwitch struct {
+	Date time.Time // midnight at the start of the evening's date
+	On   time.Time
+	Off  time.Time
+}
+
+// Burn returns how long the lights are on.
+func (s LightingSwitch) Burn() time.Duration {
+	return s.Off.Sub(s.On)
+}
+
+// LightingSchedule returns the switching for each night of the year in the
+// location loc, from the evening of 1 January to the evening of 31 December,
+// under rule. Nights the Sun does not get below OnAltitude and OffAltitude, or
+// the offsets leave no time on, are left out. In polar night the lights are
+// switched at noon, so burn all day. Times are to within a second and in loc.
+func LightingSchedule(year int, loc *time.Location, latitude float64, longitude float64, rule LightingRule) []LightingSwitch {
+	var ss []LightingSwitch
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		night, ok := nightBetween(d, latitude, longitude, rule.OnAltitude, rule.OffAltitude)
+		if !ok {
+			continue
+		}
+		s := LightingSwitch{d, night.Start.Add(rule.OnOffset), night.End.Add(rule.OffOffset)}
+		if !s.Off.After(s.On) {
+			continue
+		}
+		if s.Burn() < rule.MinimumBurn {
+			s.Off = s.On.Add(rule.MinimumBurn)
+		}
+		ss = append(ss, s)
+	}
+	return ss
+}
+
+// WriteLightingCSV writes a schedule with one row per night, times to the
+// second with their offset from UTC and the burn in minutes.
+func WriteLightingCSV(w io.Writer, ss []LightingSwitch) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "on", "off", "burn_minutes"}); err != nil {
+		return err
+	}
+	for _, s := range ss {
+		err := cw.Write([]string{
+			s.Date.Format("2006-01-02"),
+			s.On.Format(time.RFC3339),
+			s.Off.Format(time.RFC3339),
+			strconv.FormatFloat(s.Burn().Minutes(), 'f', 1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteLightingJSON writes a schedule as a JSON array with the same fields as
+// WriteLightingCSV.
+func WriteLightingJSON(w io.Writer, ss []LightingSwitch) error {
+	type row struct {
+		Date        string  `json:"date"`
+		On          string  `json:"on"`
+		Off         string  `json:"off"`
+		BurnMinutes float64 `json:"burn_minutes"`
+	}
+	rows := make([]row, len(ss))
+	for i, s := range ss {
+		rows[i] = row{
+			s.Date.Format("2006-01-02"),
+			s.On.Format(time.RFC3339),
+			s.Off.Format(time.RFC3339),
+			s.Burn().Minutes(),
+		}
+	}
+	return json.NewEncoder(w).Encode(rows)
+}
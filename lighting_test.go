@@ -0,0 +1,123 @@
+package sun
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Switched at sunset and sunrise, London's lights go on at 21:21 BST on the
+// June solstice and off at 04:43 the next morning, and on at 15:53 GMT on the
+// December one and off at 08:04 or 08:05, by the almanac. Civil dusk in June
+// is 9h06.9m after the 12:02.3 UT noon, when sin(-6) = 0.3113 + 0.5711 cos H,
+// at 22:09 BST, and civil dawn at 03:55 BST, less than six hours later.
+func TestLightingSchedule(t *testing.T) {
+	london := location(t, "Europe/London")
+	ss := LightingSchedule(2024, london, 51.5074, -0.1278, LightingRule{OnAltitude: SunriseAltitude, OffAltitude: SunriseAltitude})
+	if len(ss) != 366 {
+		t.Fatalf("%d nights, want 366", len(ss))
+	}
+	june, december := date(london, 2024, time.June, 21), date(london, 2024, time.December, 21)
+	for _, tt := range []struct {
+		s       LightingSwitch
+		date    time.Time
+		on, off time.Time
+	}{
+		{ss[172], june, clock(june, 21, 21), clock(june, 28, 43)},
+		{ss[355], december, clock(december, 15, 53), clock(december, 32, 4)},
+	} {
+		if !tt.s.Date.Equal(tt.date) || !within(tt.s.On, tt.on, time.Minute) || !within(tt.s.Off, tt.off, time.Minute) {
+			t.Errorf("%v: on %v, off %v, want %v, %v", tt.date.Format("Jan 2"), tt.s.On, tt.s.Off, tt.on, tt.off)
+		}
+		if tt.s.On.Location() != london {
+			t.Errorf("%v: times in %v, want %v", tt.date.Format("Jan 2"), tt.s.On.Location(), london)
+		}
+	}
+	if last := ss[365]; last.Off.Year() != 2025 {
+		t.Errorf("last night ends %v, want on New Year's Day", last.Off)
+	}
+
+	// with offsets
+	ss = LightingSchedule(2024, london, 51.5074, -0.1278, LightingRule{
+		OnAltitude: SunriseAltitude, OffAltitude: SunriseAltitude, OnOffset: 15 * time.Minute, OffOffset: -15 * time.Minute,
+	})
+	if s := ss[172]; !within(s.On, clock(june, 21, 36), time.Minute) || !within(s.Off, clock(june, 28, 28), time.Minute) {
+		t.Errorf("with offsets: on %v, off %v, want 21:36, 04:28", s.On, s.Off)
+	}
+	// offsets that take ten hours from the June nights, of seven, leave no
+	// time on
+	ss = LightingSchedule(2024, london, 51.5074, -0.1278, LightingRule{
+		OnAltitude: SunriseAltitude, OffAltitude: SunriseAltitude, OnOffset: 5 * time.Hour, OffOffset: -5 * time.Hour,
+	})
+	for _, s := range ss {
+		if s.Date.Month() == time.June || !s.Off.After(s.On) {
+			t.Errorf("night of %v left in with no time on: %v", s.Date.Format("Jan 2"), s)
+			break
+		}
+	}
+
+	// a minimum burn puts back the switch off
+	ss = LightingSchedule(2024, london, 51.5074, -0.1278, LightingRule{OnAltitude: -6, OffAltitude: -6, MinimumBurn: 8 * time.Hour})
+	if s := ss[172]; !within(s.On, clock(june, 22, 9), time.Minute) || s.Burn() != 8*time.Hour {
+		t.Errorf("minimum burn: on %v for %v, want 22:09 for 8h", s.On, s.Burn())
+	}
+	if s := ss[355]; s.Burn() < 14*time.Hour {
+		t.Errorf("December burn %v, want the night's full length", s.Burn())
+	}
+}
+
+// In Tromsø the Sun does not set from 18 May to 24 July, so those nights are
+// left out, and from 27 November it does not rise, so the lights burn from
+// noon to noon.
+func TestLightingSchedulePolar(t *testing.T) {
+	oslo := location(t, "Europe/Oslo")
+	ss := LightingSchedule(2024, oslo, 69.6492, 18.9553, LightingRule{OnAltitude: SunriseAltitude, OffAltitude: SunriseAltitude})
+	byDate := map[string]LightingSwitch{}
+	for _, s := range ss {
+		byDate[s.Date.Format("01-02")] = s
+	}
+	for _, d := range []string{"05-20", "06-21", "07-20"} {
+		if s, ok := byDate[d]; ok {
+			t.Errorf("lights switched in the midnight sun: %v", s)
+		}
+	}
+	d := date(oslo, 2024, time.December, 21)
+	s, ok := byDate["12-21"]
+	if !ok {
+		t.Fatal("no switching in the polar night")
+	}
+	if on, off := Culminate(d, 69.6492, 18.9553).Time, Culminate(d.AddDate(0, 0, 1), 69.6492, 18.9553).Time; !s.On.Equal(on) || !s.Off.Equal(off) {
+		t.Errorf("polar night: on %v, off %v, want noon %v to noon %v", s.On, s.Off, on, off)
+	}
+}
+
+func TestWriteLighting(t *testing.T) {
+	d := date(time.UTC, 2024, time.December, 21)
+	ss := []LightingSwitch{{d, clock(d, 15, 53).Add(38 * time.Second), clock(d, 32, 4).Add(28 * time.Second)}}
+	var b bytes.Buffer
+	if err := WriteLightingCSV(&b, ss); err != nil {
+		t.Fatal(err)
+	}
+	want := "date,on,off,burn_minutes\n2024-12-21,2024-12-21T15:53:38Z,2024-12-22T08:04:28Z,970.8\n"
+	if b.String() != want {
+		t.Errorf("WriteLightingCSV =\n%s\nwant\n%s", b.String(), want)
+	}
+	b.Reset()
+	if err := WriteLightingJSON(&b, ss); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0]["on"] != "2024-12-21T15:53:38Z" || rows[0]["burn_minutes"] != 970.8333333333334 {
+		t.Errorf("WriteLightingJSON = %s", b.String())
+	}
+	if err := WriteLightingCSV(failWriter{}, ss); err == nil {
+		t.Error("WriteLightingCSV to a failing writer succeeded")
+	}
+	if err := WriteLightingJSON(failWriter{}, ss); err == nil {
+		t.Error("WriteLightingJSON to a failing writer succeeded")
+	}
+}
@@ -0,0 +1,159 @@
+package sun
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Season is a meteorological season of three whole months.
+type Season int
+
+const (
+	Winter Season = iota
+	Spring
+	Summer
+	Autumn
+)
+
+var seasonNames = [...]string{"winter", "spring", "summer", "autumn"}
+
+func (s Season) String() string {
+	if s < 0 || int(s) >= len(seasonNames) {
+		return "unknown"
+	}
+	return seasonNames[s]
+}
+
+// seasonDates returns dates spread through the months of season s in the
+// given year and location, on the 1st, 8th, 15th and 22nd of each month.
+// Winter is December to February in the northern hemisphere and June to
+// August in the southern, taking its December or January from the same year.
+func seasonDates(year int, loc *time.Location, s Season, latitude float64) []time.Time {
+	first := time.Month(int(s)*3 + 12)
+	if latitude < 0 {
+		first += 6
+	}
+	var ds []time.Time
+	for i := 0; i < 3; i++ {
+		m := (first+time.Month(i)-1)%12 + 1
+		for _, d := range []int{1, 8, 15, 22} {
+			ds = append(ds, time.Date(year, m, d, 0, 0, 0, 0, loc))
+		}
+	}
+	return ds
+}
+
+// Facade is a wall or window facing Azimuth degrees clockwise from north.
+type Facade struct {
+	Name    string
+	Azimuth float64
+}
+
+// facadeSun returns the time direct sun falls on a vertical facade facing
+// azimuth on the date of t, before and after the Sun culminates, ignoring
+// anything in the way
+func facadeSun(t time.Time, latitude float64, longitude float64, azimuth float64) (morning time.Duration, evening time.Duration) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	noon := Culminate(t, latitude, longitude).Time
+	ps := periodsWhere(start, end, func(at time.Time) float64 {
+		alt := Altitude(at, latitude, longitude)
+		return math.Min(alt, cosIncidence(alt, Azimuth(at, latitude, longitude), 90, azimuth))
+	})
+	for _, p := range ps {
+		switch {
+		case !p.End.After(noon):
+			morning += p.Duration()
+		case !p.Start.Before(noon):
+			evening += p.Duration()
+		default:
+			morning += noon.Sub(p.Start)
+			evening += p.End.Sub(noon)
+		}
+	}
+	return morning, evening
+}
+
+// SeasonExposure is the average daily direct sun on a facade over a season.
+type SeasonExposure struct {
+	Season  Season
+	Morning time.Duration // before the Sun culminates
+	Evening time.Duration // after it culminates
+}
+
+// FacadeReport is the seasonal sun on one facade.
+type FacadeReport struct {
+	Facade
+	Seasons [4]SeasonExposure
+}
+
+// DaylightReport summarises the sun on the facades of a property through the
+// seasons of a year, as for a listing.
+type DaylightReport struct {
+	Year     int
+	Location *time.Location
+	Point
+	Facades []FacadeReport
+}
+
+// NewDaylightReport returns the average daily hours of direct sun on each
+// vertical facade at p, morning and evening, in each season of the given
+// year. Buildings, trees and terrain in the way are not allowed for, so these
+// are the most sun each facade can get.
+func NewDaylightReport(year int, loc *time.Location, p Point, facades []Facade) *DaylightReport {
+	r := &DaylightReport{Year: year, Location: loc, Point: p}
+	for _, f := range facades {
+		fr := FacadeReport{Facade: f}
+		for s := Winter; s <= Autumn; s++ {
+			dates := seasonDates(year, loc, s, p.Latitude)
+			var morning, evening time.Duration
+			for _, d := range dates {
+				am, pm := facadeSun(d, p.Latitude, p.Longitude, f.Azimuth)
+				morning += am
+				evening += pm
+			}
+			n := time.Duration(len(dates))
+			fr.Seasons[s] = SeasonExposure{s, morning / n, evening / n}
+		}
+		r.Facades = append(r.Facades, fr)
+	}
+	return r
+}
+
+// String returns the report as plain English text, a paragraph per facade.
+func (r *DaylightReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sunlight at %.4f, %.4f in %d\n", r.Latitude, r.Longitude, r.Year)
+	for _, f := range r.Facades {
+		fmt.Fprintf(&b, "\n%s, facing %s (%.0f°):\n", f.Name, compassPoint(f.Azimuth), f.Azimuth)
+		for _, s := range f.Seasons {
+			fmt.Fprintf(&b, "  %-7s %s\n", s.Season.String()+":", describeExposure(s))
+		}
+	}
+	return b.String()
+}
+
+// describeExposure puts a season's sun on a facade into words
+func describeExposure(s SeasonExposure) string {
+	const little = 15 * time.Minute
+	am, pm := FormatDuration(s.Morning, "en"), FormatDuration(s.Evening, "en")
+	switch {
+	case s.Morning < little && s.Evening < little:
+		return "no direct sun"
+	case s.Evening < little:
+		return "morning sun only, about " + am + " a day"
+	case s.Morning < little:
+		return "afternoon and evening sun only, about " + pm + " a day"
+	}
+	return "about " + am + " of morning sun and " + pm + " of afternoon sun a day"
+}
+
+// compassPoint returns the nearest of the eight principal points of the compass
+// to azimuth az
+func compassPoint(az float64) string {
+	points := [...]string{"north", "north-east", "east", "south-east", "south", "south-west", "west", "north-west"}
+	return points[int(math.Floor(between(0, 360, az)/45+0.5))%8]
+}
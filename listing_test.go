@@ -0,0 +1,139 @@
+package sun
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// A facade is lit while the Sun is up, with its centre above the horizon, and
+// in front of it. At the equinox, with δ = 0, the Sun rises due east 6h before
+// noon and sets due west 6h after, so an east wall has the whole morning, a
+// south wall the whole day and a north wall nothing. At London on the June
+// solstice the Sun rises, sin h = 0.3113 + 0.5711 cos H = 0, 8h12.1m before
+// noon at azimuth 49, and crosses the prime vertical, cos H = tan δ / tan φ,
+// 4h39.3m before noon: the north wall gets 3h32.8m each side of noon and the
+// south 4h39.3m.
+func TestFacadeSun(t *testing.T) {
+	hm := func(h, m int, s int) time.Duration {
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+	march, june := date(time.UTC, 2024, time.March, 20), date(time.UTC, 2024, time.June, 21)
+	for _, tt := range []struct {
+		d                time.Time
+		azimuth          float64
+		morning, evening time.Duration
+	}{
+		{march, 0, 0, 0},
+		{march, 90, hm(6, 0, 0), 0},
+		{march, 180, hm(6, 0, 0), hm(6, 0, 0)},
+		{march, 270, 0, hm(6, 0, 0)},
+		{june, 0, hm(3, 32, 48), hm(3, 32, 48)},
+		{june, 90, hm(8, 12, 6), 0},
+		{june, 180, hm(4, 39, 18), hm(4, 39, 18)},
+		{june, 270, 0, hm(8, 12, 6)},
+	} {
+		am, pm := facadeSun(tt.d, 51.5074, -0.1278, tt.azimuth)
+		if (am-tt.morning).Abs() > 2*time.Minute || (pm-tt.evening).Abs() > 2*time.Minute {
+			t.Errorf("%v facing %v: %v and %v, want %v and %v", tt.d.Format("Jan 2"), tt.azimuth, am, pm, tt.morning, tt.evening)
+		}
+	}
+}
+
+func TestSeasonDates(t *testing.T) {
+	for _, tt := range []struct {
+		s      Season
+		lat    float64
+		months []time.Month
+	}{
+		{Winter, 51, []time.Month{time.December, time.January, time.February}},
+		{Spring, 51, []time.Month{time.March, time.April, time.May}},
+		{Summer, 51, []time.Month{time.June, time.July, time.August}},
+		{Autumn, 51, []time.Month{time.September, time.October, time.November}},
+		{Winter, -33, []time.Month{time.June, time.July, time.August}},
+		{Summer, -33, []time.Month{time.December, time.January, time.February}},
+	} {
+		ds := seasonDates(2024, time.UTC, tt.s, tt.lat)
+		if len(ds) != 12 {
+			t.Fatalf("%v at %v: %d dates, want 12", tt.s, tt.lat, len(ds))
+		}
+		for i, d := range ds {
+			if d.Year() != 2024 || d.Month() != tt.months[i/4] || d.Day() != []int{1, 8, 15, 22}[i%4] {
+				t.Errorf("%v at %v: date %d is %v", tt.s, tt.lat, i, d.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+func TestDaylightReport(t *testing.T) {
+	r := NewDaylightReport(2024, time.UTC, Point{51.5074, -0.1278}, []Facade{{"Front", 180}, {"Back", 0}})
+	if len(r.Facades) != 2 {
+		t.Fatalf("%d facades, want 2", len(r.Facades))
+	}
+	front, back := r.Facades[0], r.Facades[1]
+	// the north wall is dark through the winter and autumn, when the Sun
+	// rises south of east, and gets most in summer
+	if s := back.Seasons[Winter]; s.Morning != 0 || s.Evening != 0 {
+		t.Errorf("north wall in winter = %+v", s)
+	}
+	if back.Seasons[Summer].Morning < 2*time.Hour || back.Seasons[Summer].Morning < back.Seasons[Spring].Morning {
+		t.Errorf("north wall in summer = %+v", back.Seasons[Summer])
+	}
+	// the south wall's sun is symmetric about noon
+	for _, s := range front.Seasons {
+		if (s.Morning - s.Evening).Abs() > 2*time.Minute {
+			t.Errorf("south wall in %v = %+v", s.Season, s)
+		}
+	}
+
+	text := r.String()
+	for _, want := range []string{
+		"Sunlight at 51.5074, -0.1278 in 2024\n",
+		"\nFront, facing south (180°):\n",
+		"\nBack, facing north (0°):\n",
+		"  winter: no direct sun\n",
+		"  summer: about 2h56m of morning sun and 2h56m of afternoon sun a day\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report lacks %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestDescribeExposure(t *testing.T) {
+	for _, tt := range []struct {
+		am, pm time.Duration
+		want   string
+	}{
+		{0, 14 * time.Minute, "no direct sun"},
+		{3 * time.Hour, 0, "morning sun only, about 3h00m a day"},
+		{0, 2*time.Hour + 30*time.Minute, "afternoon and evening sun only, about 2h30m a day"},
+		{time.Hour, 2 * time.Hour, "about 1h00m of morning sun and 2h00m of afternoon sun a day"},
+	} {
+		if got := describeExposure(SeasonExposure{Summer, tt.am, tt.pm}); got != tt.want {
+			t.Errorf("describeExposure(%v, %v) = %q, want %q", tt.am, tt.pm, got, tt.want)
+		}
+	}
+}
+
+func TestCompassPoint(t *testing.T) {
+	for _, tt := range []struct {
+		az   float64
+		want string
+	}{
+		{0, "north"}, {22.4, "north"}, {22.6, "north-east"}, {90, "east"}, {180, "south"},
+		{225, "south-west"}, {350, "north"}, {-45, "north-west"}, {405, "north-east"},
+	} {
+		if got := compassPoint(tt.az); got != tt.want {
+			t.Errorf("compassPoint(%v) = %q, want %q", tt.az, got, tt.want)
+		}
+	}
+}
+
+func TestSeasonString(t *testing.T) {
+	for s, want := range map[Season]string{Winter: "winter", Spring: "spring", Summer: "summer", Autumn: "autumn", Season(4): "unknown"} {
+		if got := s.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(s), got, want)
+		}
+	}
+}
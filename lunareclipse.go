@@ -0,0 +1,139 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// LunarEclipseKind is the deepest phase a lunar eclipse reaches.
+type LunarEclipseKind int
+
+const (
+	PenumbralEclipse LunarEclipseKind = iota // the Moon only enters the penumbra
+	PartialEclipse                           // part of the Moon enters the umbra
+	TotalEclipse                             // all of the Moon is in the umbra
+)
+
+var lunarEclipseKindNames = [...]string{"penumbral", "partial", "total"}
+
+func (k LunarEclipseKind) String() string {
+	if k < 0 || int(k) >= len(lunarEclipseKindNames) {
+		return "unknown"
+	}
+	return lunarEclipseKindNames[k]
+}
+
+// Contact is one of the contacts of a lunar eclipse: P1 and P4 when the Moon
+// enters and leaves the penumbra, U1 and U4 the umbra, U2 and U3 when totality
+// begins and ends, and "greatest" for greatest eclipse.
+type Contact struct {
+	Name string
+	Time time.Time
+	// MoonAltitude is the altitude of the Moon at the time, as seen from the
+	// location, and Visible whether it is above the horizon.
+	MoonAltitude float64
+	Visible      bool
+}
+
+// LunarEclipse is an eclipse of the Moon and how it appears from a location.
+type LunarEclipse struct {
+	Kind LunarEclipseKind
+	// Magnitude is the umbral magnitude, the fraction of the Moon's diameter
+	// inside the umbra at greatest eclipse: negative for a penumbral eclipse
+	// and greater than 1 for a total one.
+	Magnitude float64
+	Contacts  []Contact // in time order
+	// Visible holds the parts of the eclipse, from P1 to P4, with the Moon
+	// above the horizon of the location.
+	Visible []Period
+}
+
+// LunarEclipses returns the eclipses of the Moon between start and end, with
+// times in the time zone of start, and their visibility from the given
+// location. The Earth's shadow is enlarged by a fiftieth for the atmosphere,
+// as is the custom, and contact times are good to a minute or two.
+func LunarEclipses(start time.Time, end time.Time, latitude float64, longitude float64) []LunarEclipse {
+	var es []LunarEclipse
+	for _, fm := range FullMoons(start, end) {
+		if math.Abs(MoonPosition(fm).EclipticLatitude) > 1.7 {
+			continue
+		}
+		g := goldenMax(func(s float64) float64 {
+			sep, _, _, _ := shadowGeometry(fm.Add(secondsToDuration(s)))
+			return -sep
+		}, -4*3600, 4*3600, defaultTolerance)
+		greatest := fm.Add(secondsToDuration(g))
+		sep, pen, umb, sd := shadowGeometry(greatest)
+		if sep >= pen+sd {
+			continue
+		}
+		e := LunarEclipse{Kind: PenumbralEclipse, Magnitude: (umb + sd - sep) / (2 * sd)}
+		if sep < umb+sd {
+			e.Kind = PartialEclipse
+		}
+		if sep < umb-sd {
+			e.Kind = TotalEclipse
+		}
+		contact := func(name string, at time.Time) {
+			alt := MoonAltitude(at, latitude, longitude)
+			e.Contacts = append(e.Contacts, Contact{name, at, alt, alt > MoonriseAltitude})
+		}
+		// contacts are where the separation equals the radius of the shadow
+		// plus or minus the Moon's
+		find := func(radius func(pen, umb, sd float64) float64, before bool) time.Time {
+			f := func(s float64) float64 {
+				sep, pen, umb, sd := shadowGeometry(greatest.Add(secondsToDuration(s)))
+				return sep - radius(pen, umb, sd)
+			}
+			a, b := -5*3600.0, 0.0
+			if !before {
+				a, b = 0, 5*3600
+			}
+			return greatest.Add(secondsToDuration(brent(f, a, b, f(a), f(b), defaultTolerance)))
+		}
+		penumbra := func(pen, umb, sd float64) float64 { return pen + sd }
+		umbra := func(pen, umb, sd float64) float64 { return umb + sd }
+		total := func(pen, umb, sd float64) float64 { return umb - sd }
+
+		p1 := find(penumbra, true)
+		contact("P1", p1)
+		if e.Kind >= PartialEclipse {
+			contact("U1", find(umbra, true))
+		}
+		if e.Kind == TotalEclipse {
+			contact("U2", find(total, true))
+		}
+		contact("greatest", greatest)
+		if e.Kind == TotalEclipse {
+			contact("U3", find(total, false))
+		}
+		if e.Kind >= PartialEclipse {
+			contact("U4", find(umbra, false))
+		}
+		p4 := find(penumbra, false)
+		contact("P4", p4)
+		e.Visible = periodsWhere(p1, p4, func(t time.Time) float64 {
+			return MoonAltitude(t, latitude, longitude) - MoonriseAltitude
+		})
+		for i := range e.Contacts {
+			e.Contacts[i].Time = e.Contacts[i].Time.In(start.Location())
+		}
+		es = append(es, e)
+	}
+	return es
+}
+
+// shadowGeometry returns, in degrees as seen from the centre of the Earth, the
+// distance of the Moon from the centre of the Earth's shadow, the radii of the
+// penumbra and umbra at the Moon's distance and the Moon's semi-diameter
+func shadowGeometry(t time.Time) (sep float64, penumbra float64, umbra float64, semiDiameter float64) {
+	m := MoonPosition(t)
+	s := LowPrecision{}.Sun(t)
+	sep = angularDistance(m.Declination, m.RightAscension, -s.Declination, s.RightAscension+180)
+	moonParallax := angleAsin(earthEquatorialKm / (m.Distance * auKm))
+	sunParallax := 8.794 / 3600 / s.Distance
+	sunSD := 959.63 / 3600 / s.Distance
+	penumbra = 1.02 * (moonParallax + sunParallax + sunSD)
+	umbra = 1.02 * (moonParallax + sunParallax - sunSD)
+	return sep, penumbra, umbra, 0.2725 * moonParallax
+}
@@ -0,0 +1,119 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Contacts and umbral magnitudes from Espenak's NASA eclipse pages. The
+// penumbral contacts are faint and depend on the shadow model, so they are
+// allowed three minutes, the umbral ones two and greatest eclipse ninety
+// seconds.
+func TestLunarEclipses(t *testing.T) {
+	type contact struct {
+		name string
+		at   [3]int
+	}
+	for _, tt := range []struct {
+		d         time.Time
+		kind      LunarEclipseKind
+		magnitude float64
+		contacts  []contact
+	}{
+		{date(time.UTC, 2022, time.November, 8), TotalEclipse, 1.359, []contact{
+			{"P1", [3]int{8, 2, 16}}, {"U1", [3]int{9, 9, 12}}, {"U2", [3]int{10, 16, 39}}, {"greatest", [3]int{10, 59, 11}},
+			{"U3", [3]int{11, 41, 35}}, {"U4", [3]int{12, 49, 3}}, {"P4", [3]int{13, 56, 9}},
+		}},
+		{date(time.UTC, 2024, time.March, 25), PenumbralEclipse, -0.132, []contact{
+			{"P1", [3]int{4, 53, 14}}, {"greatest", [3]int{7, 12, 51}}, {"P4", [3]int{9, 32, 33}},
+		}},
+		{date(time.UTC, 2024, time.September, 18), PartialEclipse, 0.085, []contact{
+			{"P1", [3]int{0, 41, 7}}, {"U1", [3]int{2, 12, 51}}, {"greatest", [3]int{2, 44, 18}},
+			{"U4", [3]int{3, 15, 48}}, {"P4", [3]int{4, 47, 29}},
+		}},
+		{date(time.UTC, 2025, time.March, 14), TotalEclipse, 1.178, []contact{
+			{"U2", [3]int{6, 25, 58}}, {"greatest", [3]int{6, 58, 43}}, {"U3", [3]int{7, 31, 30}},
+		}},
+	} {
+		es := LunarEclipses(tt.d, tt.d.AddDate(0, 0, 1), 51.5074, -0.1278)
+		if len(es) != 1 {
+			t.Fatalf("%v: %d eclipses, want 1", tt.d.Format("2006-01-02"), len(es))
+		}
+		e := es[0]
+		if e.Kind != tt.kind || math.Abs(e.Magnitude-tt.magnitude) > 0.015 {
+			t.Errorf("%v: %v of magnitude %.3f, want %v of %v", tt.d.Format("2006-01-02"), e.Kind, e.Magnitude, tt.kind, tt.magnitude)
+		}
+		at := map[string]time.Time{}
+		for i, c := range e.Contacts {
+			at[c.Name] = c.Time
+			if i > 0 && !c.Time.After(e.Contacts[i-1].Time) {
+				t.Errorf("%v: %s at %v is not after %s", tt.d.Format("2006-01-02"), c.Name, c.Time, e.Contacts[i-1].Name)
+			}
+		}
+		for _, c := range tt.contacts {
+			tol := 2 * time.Minute
+			switch c.name {
+			case "P1", "P4":
+				tol = 3 * time.Minute
+			case "greatest":
+				tol = 90 * time.Second
+			}
+			want := clock(tt.d, c.at[0], c.at[1]).Add(time.Duration(c.at[2]) * time.Second)
+			if got, ok := at[c.name]; !ok || !within(got, want, tol) {
+				t.Errorf("%v: %s at %v, want %v", tt.d.Format("2006-01-02"), c.name, got, want)
+			}
+		}
+	}
+}
+
+// The total eclipse of 7 September 2025 was under way as the Moon rose at
+// London during totality, some minutes before 18:30 UT, and was seen through
+// to the end, so only the last part, from the rising to P4, is visible.
+func TestLunarEclipseVisibility(t *testing.T) {
+	d := date(time.UTC, 2025, time.September, 7)
+	es := LunarEclipses(d, d.AddDate(0, 0, 1), 51.5074, -0.1278)
+	if len(es) != 1 || es[0].Kind != TotalEclipse || math.Abs(es[0].Magnitude-1.362) > 0.015 {
+		t.Fatalf("LunarEclipses = %+v, want a total eclipse of magnitude 1.362", es)
+	}
+	e := es[0]
+	at := map[string]Contact{}
+	for _, c := range e.Contacts {
+		at[c.Name] = c
+	}
+	if len(e.Visible) != 1 || !e.Visible[0].Start.After(at["U2"].Time) || !e.Visible[0].Start.Before(at["U3"].Time) || !e.Visible[0].End.Equal(at["P4"].Time) {
+		t.Errorf("visible %v, want from moonrise during totality to P4", e.Visible)
+	}
+	if at["U2"].Visible || !at["U4"].Visible {
+		t.Errorf("U2 visible %v and U4 visible %v, want false and true", at["U2"].Visible, at["U4"].Visible)
+	}
+	for _, c := range e.Contacts {
+		if c.Visible != (c.MoonAltitude > MoonriseAltitude) {
+			t.Errorf("%s visible %v with the Moon at %.2f", c.Name, c.Visible, c.MoonAltitude)
+		}
+	}
+
+	// the times are in the zone of start
+	bst := location(t, "Europe/London")
+	if es := LunarEclipses(date(bst, 2025, time.September, 7), date(bst, 2025, time.September, 8), 51.5074, -0.1278); len(es) != 1 || es[0].Contacts[0].Time.Location() != bst {
+		t.Errorf("LunarEclipses in BST = %+v", es)
+	}
+
+	// no eclipse at the full moon of June 2024
+	if es := LunarEclipses(date(time.UTC, 2024, time.June, 21), date(time.UTC, 2024, time.June, 23), 51.5074, -0.1278); len(es) != 0 {
+		t.Errorf("LunarEclipses in June 2024 = %+v", es)
+	}
+}
+
+func TestLunarEclipseKind(t *testing.T) {
+	for _, tt := range []struct {
+		k    LunarEclipseKind
+		want string
+	}{
+		{PenumbralEclipse, "penumbral"}, {PartialEclipse, "partial"}, {TotalEclipse, "total"}, {LunarEclipseKind(3), "unknown"},
+	} {
+		if got := tt.k.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.k), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// MoonPosition returns the geocentric position of the Moon at t, from the
+// main terms of the lunar theory in chapter 47 of Meeus, Astronomical
+// Algorithms. It is good to about 0.005 degree, plenty for rise and set times,
+// visibility and eclipse timings to a minute or two, but not for
+// occultations of stars.
+func MoonPosition(t time.Time) Coords {
+	T := getJdn(tdbJD(t)) / 36525
+	lon, lat, parallax := moonEcliptic(T)
+	ra, dec := eclipticToEquatorial(lon, lat, meanObliquity(T))
+	return Coords{
+		EclipticLongitude: between(0, 360, lon),
+		EclipticLatitude:  lat,
+		RightAscension:    between(0, 360, ra),
+		Declination:       dec,
+		Distance:          earthEquatorialKm / angleSin(parallax) / auKm,
+	}
+}
+
+// moonTerms are the periodic terms of the Moon's longitude and distance
+// (Meeus table 47.A) and latitude (table 47.B) of more than about 0.002
+// degree or 2 km: the multiples of D, M, M' and F, then the coefficients in
+// millionths of a degree and, for distance, in metres.
+var (
+	moonLonDistTerms = [...][6]float64{
+		{0, 0, 1, 0, 6288774, -20905355},
+		{2, 0, -1, 0, 1274027, -3699111},
+		{2, 0, 0, 0, 658314, -2955968},
+		{0, 0, 2, 0, 213618, -569925},
+		{0, 1, 0, 0, -185116, 48888},
+		{0, 0, 0, 2, -114332, -3149},
+		{2, 0, -2, 0, 58793, 246158},
+		{2, -1, -1, 0, 57066, -152138},
+		{2, 0, 1, 0, 53322, -170733},
+		{2, -1, 0, 0, 45758, -204586},
+		{0, 1, -1, 0, -40923, -129620},
+		{1, 0, 0, 0, -34720, 108743},
+		{0, 1, 1, 0, -30383, 104755},
+		{2, 0, 0, -2, 15327, 10321},
+		{0, 0, 1, 2, -12528, 0},
+		{0, 0, 1, -2, 10980, 79661},
+		{4, 0, -1, 0, 10675, -34782},
+		{0, 0, 3, 0, 10034, -23210},
+		{4, 0, -2, 0, 8548, -21636},
+		{2, 1, -1, 0, -7888, 24208},
+		{2, 1, 0, 0, -6766, 30824},
+		{1, 0, -1, 0, -5163, -8379},
+		{1, 1, 0, 0, 4987, -16675},
+		{2, -1, 1, 0, 4036, -12831},
+		{2, 0, 2, 0, 3994, -10445},
+		{4, 0, 0, 0, 3861, -11650},
+		{2, 0, -3, 0, 3665, 14403},
+		{0, 1, -2, 0, -2689, -7003},
+		{2, 0, -1, 2, -2602, 0},
+		{2, -1, -2, 0, 2390, 10056},
+		{1, 0, 1, 0, -2348, 6322},
+		{2, -2, 0, 0, 2236, -9884},
+		{0, 1, 2, 0, -2120, 5751},
+		{0, 2, 0, 0, -2069, 0},
+	}
+	moonLatTerms = [...][5]float64{
+		{0, 0, 0, 1, 5128122},
+		{0, 0, 1, 1, 280602},
+		{0, 0, 1, -1, 277693},
+		{2, 0, 0, -1, 173237},
+		{2, 0, -1, 1, 55413},
+		{2, 0, -1, -1, 46271},
+		{2, 0, 0, 1, 32573},
+		{0, 0, 2, 1, 17198},
+		{2, 0, 1, -1, 9266},
+		{0, 0, 2, -1, 8822},
+		{2, -1, 0, -1, 8216},
+		{2, 0, -2, -1, 4324},
+		{2, 0, 1, 1, 4200},
+		{2, 1, 0, -1, -3359},
+		{2, -1, -1, 1, 2463},
+		{2, -1, 0, 1, 2211},
+		{2, -1, -1, -1, 2065},
+		{0, 1, -1, -1, -1870},
+		{4, 0, -1, -1, 1828},
+		{0, 1, 0, 1, -1794},
+	}
+)
+
+// moonEcliptic returns the geocentric ecliptic longitude and latitude and the
+// equatorial horizontal parallax of the Moon in degrees, T julian centuries of
+// dynamical time after J2000
+func moonEcliptic(T float64) (lon float64, lat float64, parallax float64) {
+	lp := 218.3164477 + 481267.88123421*T - 0.0015786*T*T
+	d := 297.8501921 + 445267.1114034*T - 0.0018819*T*T
+	m := 357.5291092 + 35999.0502909*T - 0.0001536*T*T
+	mp := 134.9633964 + 477198.8675055*T + 0.0087414*T*T
+	f := 93.2720950 + 483202.0175233*T - 0.0036539*T*T
+	// the eccentricity of the Earth's orbit is shrinking, which weakens the
+	// terms in M
+	e := 1 - 0.002516*T - 0.0000074*T*T
+	a1 := 119.75 + 131.849*T
+	a2 := 53.09 + 479264.290*T
+	a3 := 313.45 + 481266.484*T
+
+	var sl, sr, sb float64
+	for _, c := range moonLonDistTerms {
+		arg := c[0]*d + c[1]*m + c[2]*mp + c[3]*f
+		k := math.Pow(e, math.Abs(c[1]))
+		sl += c[4] * k * angleSin(arg)
+		sr += c[5] * k * angleCos(arg)
+	}
+	for _, c := range moonLatTerms {
+		arg := c[0]*d + c[1]*m + c[2]*mp + c[3]*f
+		sb += c[4] * math.Pow(e, math.Abs(c[1])) * angleSin(arg)
+	}
+	sl += 3958*angleSin(a1) + 1962*angleSin(lp-f) + 318*angleSin(a2)
+	sb += -2235*angleSin(lp) + 382*angleSin(a3) + 175*angleSin(a1-f) + 175*angleSin(a1+f) +
+		127*angleSin(lp-mp) - 115*angleSin(lp+mp)
+
+	distance := 385000.56 + sr/1000
+	return lp + sl/1e6, sb / 1e6, angleAsin(earthEquatorialKm / distance)
+}
+
+// moonHorizontal returns the topocentric altitude and azimuth of the Moon and
+// its horizontal parallax, in degrees
+func moonHorizontal(t time.Time, latitude float64, longitude float64) (alt float64, az float64, parallax float64) {
+	jd := timeToJD(t)
+	T := getJdn(tdbJD(t)) / 36525
+	lon, lat, parallax := moonEcliptic(T)
+	ra, dec := eclipticToEquatorial(lon, lat, meanObliquity(T))
+	ha := getHourAngle(jd, longitude, ra)
+	alt = angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	// parallax lowers the Moon by up to a degree as seen from the surface
+	alt -= toAngle(math.Asin(angleSin(parallax) * angleCos(alt)))
+	return alt, getAzimuth(latitude, dec, ha), parallax
+}
+
+// MoonAltitude returns the altitude of the centre of the Moon in degrees, as
+// seen from the surface of the Earth at the given location (that is, allowing
+// for parallax), without refraction.
+func MoonAltitude(t time.Time, latitude float64, longitude float64) float64 {
+	alt, _, _ := moonHorizontal(t, latitude, longitude)
+	return alt
+}
+
+// MoonAzimuth returns the azimuth of the Moon in degrees east of north.
+func MoonAzimuth(t time.Time, latitude float64, longitude float64) float64 {
+	_, az, _ := moonHorizontal(t, latitude, longitude)
+	return az
+}
+
+// MoonriseAltitude is the topocentric altitude of the centre of the Moon, in
+// degrees, when its upper limb is on the horizon: mean refraction less the
+// semi-diameter.
+const MoonriseAltitude float64 = -0.5667 - 0.2725*0.9508
+
+// MoonIllumination returns the illuminated fraction of the Moon's disc at t,
+// from 0 at new moon to 1 at full.
+func MoonIllumination(t time.Time) float64 {
+	return (1 + angleCos(moonPhaseAngle(t))) / 2
+}
+
+// moonPhaseAngle returns the Sun–Moon–Earth angle in degrees, 0 at full moon
+func moonPhaseAngle(t time.Time) float64 {
+	m := MoonPosition(t)
+	s := LowPrecision{}.Sun(t)
+	elong := angleAcos(angleCos(m.EclipticLatitude) * angleCos(m.EclipticLongitude-s.EclipticLongitude))
+	// Meeus 48.3, with the Moon's distance in the same units as the Sun's
+	return angleAtan2(s.Distance*angleSin(elong), m.Distance-s.Distance*angleCos(elong))
+}
+
+// Moonrise returns the first moonrise on the date of t, as seen from the given
+// location, in the time zone of t. The Moon rises about 50 minutes later each
+// day, so roughly once a month there is no moonrise on a date, and ok is
+// false.
+func Moonrise(t time.Time, latitude float64, longitude float64) (moonrise time.Time, ok bool) {
+	return moonEvent(t, latitude, longitude, true)
+}
+
+// Moonset returns the first moonset on the date of t, as for Moonrise.
+func Moonset(t time.Time, latitude float64, longitude float64) (moonset time.Time, ok bool) {
+	return moonEvent(t, latitude, longitude, false)
+}
+
+func moonEvent(t time.Time, latitude float64, longitude float64, rising bool) (time.Time, bool) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	f := func(s float64) float64 {
+		return MoonAltitude(start.Add(secondsToDuration(s)), latitude, longitude) - MoonriseAltitude
+	}
+	for _, c := range crossings(start, end, f, math.Inf(1), defaultTolerance) {
+		if c.Increasing == rising {
+			return c.Time, true
+		}
+	}
+	return time.Time{}, false
+}
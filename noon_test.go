@@ -0,0 +1,102 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Meeus, example 28.b, gives an equation of time of +13m42.7s on 1992 October
+// 13.0. It is least, -14m12s, about 11 February and greatest, +16m26s, about
+// 3 November, and passes through nought about 15 April, 13 June, 1 September
+// and 25 December.
+func TestEquationOfTime(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want time.Duration
+		tol  time.Duration
+	}{
+		{time.Date(1992, time.October, 13, 0, 0, 0, 0, time.UTC), 13*time.Minute + 42700*time.Millisecond, 2 * time.Second},
+		{time.Date(2024, time.February, 11, 12, 0, 0, 0, time.UTC), -(14*time.Minute + 12*time.Second), 5 * time.Second},
+		{time.Date(2024, time.November, 3, 12, 0, 0, 0, time.UTC), 16*time.Minute + 26*time.Second, 5 * time.Second},
+		{time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC), 0, 20 * time.Second},
+		{time.Date(2024, time.June, 13, 12, 0, 0, 0, time.UTC), 0, 20 * time.Second},
+		{time.Date(2024, time.September, 1, 12, 0, 0, 0, time.UTC), 0, 20 * time.Second},
+		{time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC), 0, 20 * time.Second},
+	} {
+		if got := EquationOfTime(tt.t); (got - tt.want).Abs() > tt.tol {
+			t.Errorf("EquationOfTime(%v) = %v, want %v", tt.t.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+// At Greenwich the transit is 12:00 UT less the equation of time: 12:14:12 on
+// 11 February and 11:43:34 on 3 November, with the Sun 90 - 51.48 - 14.09 =
+// 24.43 and 90 - 51.48 - 15.30 = 23.22 degrees up.
+func TestMaxAltitude(t *testing.T) {
+	for _, tt := range []struct {
+		d        time.Time
+		lat, lon float64
+		want     time.Time
+		altitude float64
+	}{
+		{date(time.UTC, 2024, time.February, 11), 51.4779, 0, time.Date(2024, time.February, 11, 12, 14, 12, 0, time.UTC), 24.43},
+		{date(time.UTC, 2024, time.November, 3), 51.4779, 0, time.Date(2024, time.November, 3, 11, 43, 34, 0, time.UTC), 23.22},
+		{date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278, time.Date(2024, time.June, 21, 12, 2, 18, 0, time.UTC), 61.93},
+		{date(time.UTC, 2024, time.December, 21), -33.8688, 151.2093, time.Date(2024, time.December, 21, 1, 53, 0, 0, time.UTC), 79.57},
+	} {
+		got := MaxAltitude(tt.d, tt.lat, tt.lon)
+		if !within(got.Time, tt.want, 30*time.Second) || math.Abs(got.Altitude-tt.altitude) > 0.01 {
+			t.Errorf("MaxAltitude(%v, %v, %v) = %v, want %v at %v", tt.d.Format("Jan 2"), tt.lat, tt.lon, got, tt.altitude, tt.want)
+		}
+		c := Culminate(tt.d, tt.lat, tt.lon)
+		if !within(got.Time, c.Time, 20*time.Second) || math.Abs(got.Altitude-c.Altitude) > 1e-4 {
+			t.Errorf("MaxAltitude = %v, Culminate = %v", got, c)
+		}
+	}
+
+	// the time is in the zone of t
+	bst := location(t, "Europe/London")
+	if got := MaxAltitude(date(bst, 2024, time.June, 21), 51.5074, -0.1278); got.Time.Location() != bst || got.Time.Hour() != 13 {
+		t.Errorf("MaxAltitude in BST = %v, want 13:02", got.Time)
+	}
+}
+
+// The almanac gives London sunrise and sunset as 04:43 and 21:21 BST on the
+// June solstice and 08:04 and 15:53 GMT on the December one. Tromsø, at 69.65
+// north, has polar night in December and midnight sun in June.
+func TestFastSunrise(t *testing.T) {
+	london := location(t, "Europe/London")
+	for _, tt := range []struct {
+		d         time.Time
+		rise, set [2]int
+	}{
+		{date(london, 2024, time.June, 21), [2]int{4, 43}, [2]int{21, 21}},
+		{date(london, 2024, time.December, 21), [2]int{8, 4}, [2]int{15, 53}},
+	} {
+		rise, ok := FastSunrise(tt.d, 51.5074, -0.1278)
+		if want := clock(tt.d, tt.rise[0], tt.rise[1]); !ok || !within(rise, want, time.Minute) {
+			t.Errorf("FastSunrise on %v = %v, %v, want %v", tt.d.Format("Jan 2"), rise, ok, want)
+		}
+		set, ok := FastSunset(tt.d, 51.5074, -0.1278)
+		if want := clock(tt.d, tt.set[0], tt.set[1]); !ok || !within(set, want, time.Minute) {
+			t.Errorf("FastSunset on %v = %v, %v, want %v", tt.d.Format("Jan 2"), set, ok, want)
+		}
+		if r, _ := Sunrise(tt.d, 51.5074, -0.1278); !within(rise, r, time.Minute) {
+			t.Errorf("FastSunrise = %v, Sunrise = %v", rise, r)
+		}
+		if s, _ := Sunset(tt.d, 51.5074, -0.1278); !within(set, s, time.Minute) {
+			t.Errorf("FastSunset = %v, Sunset = %v", set, s)
+		}
+	}
+
+	for _, m := range []time.Month{time.June, time.December} {
+		d := date(time.UTC, 2024, m, 21)
+		if got, ok := FastSunrise(d, 69.6492, 18.9553); ok {
+			t.Errorf("FastSunrise at Tromsø in %v = %v", m, got)
+		}
+		if got, ok := FastSunset(d, 69.6492, 18.9553); ok {
+			t.Errorf("FastSunset at Tromsø in %v = %v", m, got)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package sun
+
+import "time"
+
+// NoonSight reduces a noon sight: the greatest altitude of the Sun's centre,
+// corrected as for a Sight, observed at local apparent noon at time t.
+// sunSouth is true if the Sun bore south at the time, as it does for an
+// observer north of its declination.
+//
+// Latitude follows from the declination and the zenith distance, and longitude
+// from the Greenwich hour angle of the Sun at the time of noon, where the local
+// hour angle is zero. The time of the highest altitude is hard to judge, the
+// Sun hanging for minutes, and every 4 minutes of error in it is a degree of
+// longitude, so navigators usually time equal altitudes either side of noon and
+// take the mean.
+func NoonSight(t time.Time, altitude float64, sunSouth bool) Point {
+	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	lat := dec - (90 - altitude)
+	if sunSouth {
+		lat = dec + (90 - altitude)
+	}
+	return Point{lat, between(-180, 180, rAsc-getGst(jd))}
+}
@@ -0,0 +1,59 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The noon altitude is 90 - |φ - δ|: 61.93 at London on the June solstice
+// with δ = 23.44, 79.57 at Sydney on the December one with the Sun to the
+// north, and 38.67 at Greenwich on the day of the March equinox, with δ =
+// 0.148 nine hours after it and noon at 12:07 UT by the equation of time.
+// Culminate finds the time to within seconds at the solstices, and at the
+// equinox, when the declination changes fastest, to within half a minute, an
+// eighth of a degree of longitude; the highest altitude is then a little off
+// the meridian, which shows in the latitude.
+func TestNoonSight(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		d         time.Time
+		lat, lon  float64
+		altitude  float64
+		sunSouth  bool
+		latTol    float64
+		lonTol    float64
+		wantClock [2]int
+	}{
+		{"London", date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278, 90 - 51.5074 + obliquity, true, 0.02, 0.05, [2]int{12, 2}},
+		{"Sydney", date(time.UTC, 2024, time.December, 21), -33.8688, 151.2093, 90 - 33.8688 + obliquity, false, 0.02, 0.05, [2]int{1, 53}},
+		{"Greenwich", date(time.UTC, 2024, time.March, 20), 51.4779, -0.0015, 90 - 51.4779 + 0.148, true, 0.02, 0.13, [2]int{12, 7}},
+	} {
+		noon := Culminate(tt.d, tt.lat, tt.lon)
+		if want := clock(tt.d, tt.wantClock[0], tt.wantClock[1]); !within(noon.Time, want, time.Minute) {
+			t.Errorf("%s: noon at %v, want %v", tt.name, noon.Time, want)
+		}
+		// the reduction with the almanac altitude
+		p := NoonSight(noon.Time, tt.altitude, tt.sunSouth)
+		if math.Abs(p.Latitude-tt.lat) > tt.latTol || math.Abs(p.Longitude-tt.lon) > tt.lonTol {
+			t.Errorf("%s: NoonSight = %v, want %v, %v", tt.name, p, tt.lat, tt.lon)
+		}
+		// and with the altitude actually reached, which gives back the latitude
+		// to a ten-thousandth of a degree
+		p = NoonSight(noon.Time, noon.Altitude, tt.sunSouth)
+		if math.Abs(p.Latitude-tt.lat) > 1e-4 {
+			t.Errorf("%s: NoonSight latitude = %v, want %v", tt.name, p.Latitude, tt.lat)
+		}
+	}
+}
+
+func TestNoonSightTiming(t *testing.T) {
+	// the Sun's Greenwich hour angle turns 15 degrees an hour, so every four
+	// minutes of error in the time is a degree of longitude
+	noon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278)
+	early := NoonSight(noon.Time, noon.Altitude, true)
+	late := NoonSight(noon.Time.Add(4*time.Minute), noon.Altitude, true)
+	if d := early.Longitude - late.Longitude; math.Abs(d-1) > 0.002 {
+		t.Errorf("four minutes late moves the longitude %.4f degrees west, want 1", d)
+	}
+}
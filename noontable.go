@@ -0,0 +1,71 @@
+package sun
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// NoonOffset is the difference between clock noon and solar noon on one date.
+type NoonOffset struct {
+	Date      time.Time // midnight at the start of the date
+	SolarNoon time.Time // local apparent noon
+	// Longitude is how much later mean noon comes than clock noon because
+	// the place lies west of the meridian its clocks keep, including an hour
+	// in summer time. EquationOfTime is how far the Sun runs ahead of mean
+	// time. Offset is Longitude less EquationOfTime: how long after clock
+	// noon the Sun crosses the meridian, and what to add to a sundial's
+	// reading to get the clock time.
+	Longitude      time.Duration
+	EquationOfTime time.Duration
+	Offset         time.Duration
+}
+
+// NoonOffsets returns the noon offset for every date of the year in the time
+// zone loc, for sundial correction tables and classroom noon observations.
+// Solar noon is as for MaxAltitude, within seconds of Culminate.
+func NoonOffsets(year int, loc *time.Location, longitude float64) []NoonOffset {
+	var ns []NoonOffset
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		clockNoon := time.Date(year, d.Month(), d.Day(), 12, 0, 0, 0, loc)
+		mean := meanNoon(d, longitude)
+		eot := secondsToDuration(equationOfTime(timeToJD(mean)))
+		n := NoonOffset{
+			Date:           d,
+			Longitude:      mean.Sub(clockNoon),
+			EquationOfTime: eot,
+		}
+		n.Offset = n.Longitude - eot
+		n.SolarNoon = clockNoon.Add(n.Offset)
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+// WriteNoonOffsetCSV writes noon offsets with one row per date: solar noon to
+// the second with its offset from UTC, then the longitude correction, the
+// equation of time and the total offset in signed seconds.
+func WriteNoonOffsetCSV(w io.Writer, ns []NoonOffset) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "solar_noon", "longitude_seconds", "equation_of_time_seconds", "offset_seconds"}); err != nil {
+		return err
+	}
+	seconds := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Seconds(), 'f', 0, 64)
+	}
+	for _, n := range ns {
+		err := cw.Write([]string{
+			n.Date.Format("2006-01-02"),
+			n.SolarNoon.Truncate(time.Second).Format(time.RFC3339),
+			seconds(n.Longitude),
+			seconds(n.EquationOfTime),
+			seconds(n.Offset),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
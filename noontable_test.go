@@ -0,0 +1,73 @@
+package sun
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Madrid, at 3.7038 W, keeps the time of 15 E, so mean noon comes 18.7038 × 4
+// = 74.8 minutes after clock noon in winter and an hour more in summer. With
+// the equation of time of TestEquationOfTime the Sun crosses the meridian at
+// 13:29:01 CET on 11 February and 12:58:22 CET on 3 November.
+func TestNoonOffsets(t *testing.T) {
+	madrid := location(t, "Europe/Madrid")
+	ns := NoonOffsets(2024, madrid, -3.7038)
+	if len(ns) != 366 {
+		t.Fatalf("%d dates, want 366", len(ns))
+	}
+	longitude := 74*time.Minute + 48912*time.Millisecond
+	for _, tt := range []struct {
+		day            int
+		longitude, eot time.Duration
+		noon           time.Time
+	}{
+		{41, longitude, -(14*time.Minute + 12*time.Second), time.Date(2024, time.February, 11, 13, 29, 1, 0, madrid)},
+		{182, longitude + time.Hour, -(4 * time.Minute), time.Date(2024, time.July, 1, 14, 18, 49, 0, madrid)},
+		{307, longitude, 16*time.Minute + 26*time.Second, time.Date(2024, time.November, 3, 12, 58, 22, 0, madrid)},
+	} {
+		n := ns[tt.day]
+		if (n.Longitude-tt.longitude).Abs() > 10*time.Millisecond || (n.EquationOfTime-tt.eot).Abs() > 2*time.Second ||
+			n.Offset != n.Longitude-n.EquationOfTime || !within(n.SolarNoon, tt.noon, 2*time.Second) {
+			t.Errorf("%v: %+v, want %v, %v, noon at %v", n.Date.Format("2 January"), n, tt.longitude, tt.eot, tt.noon)
+		}
+		if c := Culminate(n.Date, 40.4168, -3.7038).Time; !within(n.SolarNoon, c, 20*time.Second) {
+			t.Errorf("%v: solar noon %v, Culminate %v", n.Date.Format("2 January"), n.SolarNoon, c)
+		}
+	}
+
+	// Tongatapu, at 175.2018 W, keeps UTC+13, the time of 165 W, so mean noon
+	// comes 10.2018 × 4 = 40.8 minutes after clock noon on the same date
+	tonga := location(t, "Pacific/Tongatapu")
+	for _, n := range NoonOffsets(2024, tonga, -175.2018) {
+		if (n.Longitude-(40*time.Minute+48432*time.Millisecond)).Abs() > 10*time.Millisecond || n.SolarNoon.YearDay() != n.Date.YearDay() {
+			t.Fatalf("Tongatapu on %v: %+v", n.Date.Format("2 January"), n)
+		}
+	}
+	if n := NoonOffsets(2024, tonga, -175.2018)[41]; !within(n.SolarNoon, time.Date(2024, time.February, 11, 12, 55, 0, 0, tonga), 2*time.Second) {
+		t.Errorf("Tongatapu on 11 February: %+v", n)
+	}
+
+	// at Greenwich there is no longitude correction
+	if n := NoonOffsets(2024, time.UTC, 0)[41]; n.Longitude != 0 || !within(n.SolarNoon, time.Date(2024, time.February, 11, 12, 14, 12, 0, time.UTC), 2*time.Second) {
+		t.Errorf("Greenwich on 11 February: %+v", n)
+	}
+}
+
+func TestWriteNoonOffsetCSV(t *testing.T) {
+	madrid := location(t, "Europe/Madrid")
+	ns := NoonOffsets(2024, madrid, -3.7038)[41:42]
+	var b bytes.Buffer
+	if err := WriteNoonOffsetCSV(&b, ns); err != nil {
+		t.Fatal(err)
+	}
+	want := "date,solar_noon,longitude_seconds,equation_of_time_seconds,offset_seconds\n" +
+		"2024-02-11,2024-02-11T13:29:01+01:00,4489,-852,5341\n"
+	if b.String() != want {
+		t.Errorf("CSV:\n%s\nwant:\n%s", b.String(), want)
+	}
+	if err := WriteNoonOffsetCSV(failWriter{}, ns); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("error %v from a failing writer", err)
+	}
+}
@@ -0,0 +1,124 @@
+package sun
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Parquet physical types, encodings and the other enumerations used by the
+// Parquet writer, numbered as in parquet.thrift.
+const (
+	parquetInt64           = 2
+	parquetDouble          = 5
+	parquetRequired        = 0
+	parquetTimestampMillis = 9
+	parquetPlain           = 0
+	parquetRLE             = 3
+	parquetUncompressed    = 0
+	parquetDataPage        = 0
+)
+
+// Thrift compact protocol type codes.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes Thrift structures in the compact protocol, in which the
+// Parquet page headers and footer are written. Only the types Parquet needs are
+// supported. The field ids of the open structures are kept in last, since each
+// field id is written as the difference from the previous one.
+type thriftWriter struct {
+	b    []byte
+	last []int16
+}
+
+func newThriftWriter(b []byte) *thriftWriter {
+	return &thriftWriter{b: b[:0], last: []int16{0}}
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	t.b = binary.AppendUvarint(t.b, v)
+}
+
+func (t *thriftWriter) zigzag(v int64) {
+	t.varint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.last[len(t.last)-1]
+	if d := id - *last; d > 0 && d <= 15 {
+		t.b = append(t.b, byte(d)<<4|typ)
+	} else {
+		t.b = append(t.b, typ)
+		t.zigzag(int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.zigzag(v)
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.str(s)
+}
+
+func (t *thriftWriter) str(s string) {
+	t.varint(uint64(len(s)))
+	t.b = append(t.b, s...)
+}
+
+// beginStruct starts a structure held in field id, or an element of a list of
+// structures if id is 0.
+func (t *thriftWriter) beginStruct(id int16) {
+	if id != 0 {
+		t.field(id, thriftStruct)
+	}
+	t.last = append(t.last, 0)
+}
+
+// endStruct writes the stop byte that ends the innermost structure, or the
+// whole message.
+func (t *thriftWriter) endStruct() {
+	t.b = append(t.b, 0)
+	t.last = t.last[:len(t.last)-1]
+}
+
+// list writes the header of a list of n elements of type elem held in field
+// id. The elements follow.
+func (t *thriftWriter) list(id int16, elem byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.b = append(t.b, byte(n)<<4|elem)
+	} else {
+		t.b = append(t.b, 0xf0|elem)
+		t.varint(uint64(n))
+	}
+}
+
+// appendPlainInt64 and appendPlainDouble append values in the Parquet PLAIN
+// encoding, little-endian and unpadded.
+func appendPlainInt64(b []byte, v []int64) []byte {
+	for _, x := range v {
+		b = binary.LittleEndian.AppendUint64(b, uint64(x))
+	}
+	return b
+}
+
+func appendPlainDouble(b []byte, v []float64) []byte {
+	for _, x := range v {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	}
+	return b
+}
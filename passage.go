@@ -0,0 +1,110 @@
+package sun
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Knot is a speed of one nautical mile an hour, in metres per second.
+const Knot = 1852.0 / 3600
+
+// PassageEventKind is the kind of a PassageEvent.
+type PassageEventKind int
+
+const (
+	NauticalDawn PassageEventKind = iota // Sun rising through -12 degrees
+	CivilDawn                            // Sun rising through -6 degrees
+	SunriseEvent                         // upper limb rising over the horizon
+	SunsetEvent                          // upper limb setting below the horizon
+	CivilDusk                            // Sun setting through -6 degrees
+	NauticalDusk                         // Sun setting through -12 degrees
+)
+
+var passageEventNames = [...]string{"nautical dawn", "civil dawn", "sunrise", "sunset", "civil dusk", "nautical dusk"}
+
+func (k PassageEventKind) String() string {
+	if k < 0 || int(k) >= len(passageEventNames) {
+		return "unknown"
+	}
+	return passageEventNames[k]
+}
+
+// PassageEvent is a twilight or sunrise or sunset met on passage.
+type PassageEvent struct {
+	Kind     PassageEventKind
+	Time     time.Time
+	Position Point // where the vessel is at the time
+}
+
+// Passage is the timetable of the Sun for a voyage.
+type Passage struct {
+	Departure time.Time
+	Arrival   time.Time
+	Events    []PassageEvent // in time order
+}
+
+// PlanPassage follows a vessel along great circle legs joining the waypoints,
+// leaving the first at departure and making good speed metres per second
+// throughout, and returns the twilights, sunrises and sunsets it meets on the
+// way, as seen from its position at the time.
+//
+// Star sights are taken in the twilight between civil and nautical, when the
+// horizon is still sharp and the brighter stars are out: from nautical to civil
+// dawn and from civil to nautical dusk. Times are in the time zone of departure
+// and to within a second. It returns an error if there are fewer than two
+// waypoints or speed is not positive.
+func PlanPassage(waypoints []Point, departure time.Time, speed float64) (Passage, error) {
+	if len(waypoints) < 2 {
+		return Passage{}, errTooFewWaypoints
+	}
+	if speed <= 0 {
+		return Passage{}, errNonPositiveSpeed
+	}
+	var legs []float64
+	var total float64
+	for i := 1; i < len(waypoints); i++ {
+		d := Distance(waypoints[i-1], waypoints[i])
+		legs = append(legs, d)
+		total += d
+	}
+	at := func(s float64) Point {
+		d := s * speed
+		for i, l := range legs {
+			if d <= l || i == len(legs)-1 {
+				if l == 0 {
+					return waypoints[i]
+				}
+				return Intermediate(waypoints[i], waypoints[i+1], math.Min(d/l, 1))
+			}
+			d -= l
+		}
+		return waypoints[len(waypoints)-1]
+	}
+
+	p := Passage{Departure: departure, Arrival: departure.Add(secondsToDuration(total / speed))}
+	for _, e := range []struct {
+		alt           float64
+		rising, falls PassageEventKind
+	}{
+		{NauticalTwilightAltitude, NauticalDawn, NauticalDusk},
+		{CivilTwilightAltitude, CivilDawn, CivilDusk},
+		{SunriseAltitude, SunriseEvent, SunsetEvent},
+	} {
+		f := func(s float64) float64 {
+			pos := at(s)
+			return Altitude(departure.Add(secondsToDuration(s)), pos.Latitude, pos.Longitude) - e.alt
+		}
+		for _, c := range crossings(departure, p.Arrival, f, math.Inf(1), defaultTolerance) {
+			kind := e.falls
+			if c.Increasing {
+				kind = e.rising
+			}
+			p.Events = append(p.Events, PassageEvent{kind, c.Time, at(c.Time.Sub(departure).Seconds())})
+		}
+	}
+	sort.SliceStable(p.Events, func(i, j int) bool {
+		return p.Events[i].Time.Before(p.Events[j].Time)
+	})
+	return p, nil
+}
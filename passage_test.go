@@ -0,0 +1,93 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// A night passage from Falmouth past the Scillies at 6 knots on the summer
+// solstice. At latitude 50 the hour angle of the Sun at -0.833, -6 and -12
+// degrees is 122.8, 133.9 and 150.4 degrees, and local noon is 12:01.6 UT
+// plus four minutes a degree west, so the Sun sets at 20:35 UT off the
+// Lizard at 5.46 west and nautical dawn comes at 02:26 near 6.33 west. The
+// Sun gets no lower than -16.6 degrees, so there is no astronomical night.
+func TestPlanPassage(t *testing.T) {
+	waypoints := []Point{{50.15, -5.07}, {49.91, -6.32}, {49.0, -8.0}}
+	dep := time.Date(2024, time.June, 21, 18, 0, 0, 0, time.UTC)
+	p, err := PlanPassage(waypoints, dep, 6*Knot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	distance := Distance(waypoints[0], waypoints[1]) + Distance(waypoints[1], waypoints[2])
+	if want := dep.Add(secondsToDuration(distance / (6 * Knot))); !p.Arrival.Equal(want) || !p.Departure.Equal(dep) {
+		t.Errorf("passage %v to %v, want %v to %v", p.Departure, p.Arrival, dep, want)
+	}
+	for i, tt := range []struct {
+		kind PassageEventKind
+		at   time.Time
+		alt  float64
+	}{
+		{SunsetEvent, time.Date(2024, time.June, 21, 20, 35, 0, 0, time.UTC), SunriseAltitude},
+		{CivilDusk, time.Date(2024, time.June, 21, 21, 20, 0, 0, time.UTC), CivilTwilightAltitude},
+		{NauticalDusk, time.Date(2024, time.June, 21, 22, 27, 0, 0, time.UTC), NauticalTwilightAltitude},
+		{NauticalDawn, time.Date(2024, time.June, 22, 2, 26, 0, 0, time.UTC), NauticalTwilightAltitude},
+		{CivilDawn, time.Date(2024, time.June, 22, 3, 32, 0, 0, time.UTC), CivilTwilightAltitude},
+		{SunriseEvent, time.Date(2024, time.June, 22, 4, 17, 0, 0, time.UTC), SunriseAltitude},
+	} {
+		if i >= len(p.Events) {
+			t.Fatalf("%d events, want 6", len(p.Events))
+		}
+		e := p.Events[i]
+		if e.Kind != tt.kind || !within(e.Time, tt.at, 3*time.Minute) {
+			t.Errorf("event %d = %v at %v, want %v at %v", i, e.Kind, e.Time, tt.kind, tt.at)
+		}
+		if a := Altitude(e.Time, e.Position.Latitude, e.Position.Longitude); math.Abs(a-tt.alt) > 0.001 {
+			t.Errorf("%v at %v: Sun at %.4f, want %v", e.Kind, e.Position, a, tt.alt)
+		}
+		// the vessel has gone six knots times the time since departure
+		if i == 0 {
+			if d := Distance(waypoints[0], e.Position); math.Abs(d-e.Time.Sub(dep).Seconds()*6*Knot) > 1 {
+				t.Errorf("%v is %.0f m out, want %.0f", e.Kind, d, e.Time.Sub(dep).Seconds()*6*Knot)
+			}
+		}
+	}
+	if len(p.Events) != 6 {
+		t.Errorf("%d events, want 6", len(p.Events))
+	}
+}
+
+func TestPlanPassageErrors(t *testing.T) {
+	dep := time.Date(2024, time.June, 21, 18, 0, 0, 0, time.UTC)
+	if _, err := PlanPassage([]Point{{50, -5}}, dep, 6*Knot); err != errTooFewWaypoints {
+		t.Errorf("one waypoint: err = %v, want %v", err, errTooFewWaypoints)
+	}
+	if _, err := PlanPassage([]Point{{50, -5}, {49, -6}}, dep, 0); err != errNonPositiveSpeed {
+		t.Errorf("zero speed: err = %v, want %v", err, errNonPositiveSpeed)
+	}
+	// a repeated waypoint is a leg of no length
+	p, err := PlanPassage([]Point{{50, -5}, {50, -5}, {50, -5}}, dep, 6*Knot)
+	if err != nil || !p.Arrival.Equal(dep) || len(p.Events) != 0 {
+		t.Errorf("passage going nowhere = %+v, %v", p, err)
+	}
+}
+
+func TestPassageEventKind(t *testing.T) {
+	for _, tt := range []struct {
+		k    PassageEventKind
+		want string
+	}{
+		{NauticalDawn, "nautical dawn"},
+		{SunriseEvent, "sunrise"},
+		{NauticalDusk, "nautical dusk"},
+		{PassageEventKind(-1), "unknown"},
+		{PassageEventKind(6), "unknown"},
+	} {
+		if got := tt.k.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.k), got, tt.want)
+		}
+	}
+	if math.Abs(Knot-0.514444) > 1e-6 {
+		t.Errorf("Knot = %v m/s, want 0.514444", Knot)
+	}
+}
@@ -0,0 +1,58 @@
+package sun
+
+import "time"
+
+// Altitudes of the centre of the Sun, in degrees, that bound the phases of
+// twilight and the golden hour.
+const (
+	CivilTwilightAltitude        float64 = -6
+	NauticalTwilightAltitude     float64 = -12
+	AstronomicalTwilightAltitude float64 = -18
+	GoldenHourLow                float64 = -4
+	GoldenHourHigh               float64 = 6
+)
+
+// DayPhase is the part of the day given by the altitude of the Sun.
+type DayPhase int
+
+// The phases, from darkest to lightest.
+const (
+	Night                DayPhase = iota // Sun below -18 degrees
+	AstronomicalTwilight                 // -18 to -12 degrees
+	NauticalTwilight                     // -12 to -6 degrees
+	CivilTwilight                        // -6 degrees to sunrise or sunset
+	Day                                  // Sun above the horizon
+)
+
+var dayPhaseNames = [...]string{"Night", "AstronomicalTwilight", "NauticalTwilight", "CivilTwilight", "Day"}
+
+func (p DayPhase) String() string {
+	if p < 0 || int(p) >= len(dayPhaseNames) {
+		return "DayPhase(?)"
+	}
+	return dayPhaseNames[p]
+}
+
+// Phase returns the phase of the day at time t at the given location, and
+// whether it is the golden hour, when the Sun is between 4 degrees below and 6
+// degrees above the horizon. The golden hour straddles sunrise and sunset, so
+// it overlaps both Day and CivilTwilight.
+func Phase(t time.Time, latitude float64, longitude float64) (phase DayPhase, goldenHour bool) {
+	alt := Altitude(t, latitude, longitude)
+	return phaseOf(alt), alt >= GoldenHourLow && alt <= GoldenHourHigh
+}
+
+// phaseOf returns the phase of the day for a solar altitude in degrees
+func phaseOf(alt float64) DayPhase {
+	switch {
+	case alt >= SunriseAltitude:
+		return Day
+	case alt >= CivilTwilightAltitude:
+		return CivilTwilight
+	case alt >= NauticalTwilightAltitude:
+		return NauticalTwilight
+	case alt >= AstronomicalTwilightAltitude:
+		return AstronomicalTwilight
+	}
+	return Night
+}
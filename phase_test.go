@@ -0,0 +1,73 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhaseOf(t *testing.T) {
+	for _, tt := range []struct {
+		alt  float64
+		want DayPhase
+	}{
+		{45, Day},
+		{SunriseAltitude, Day},
+		{SunriseAltitude - 0.001, CivilTwilight},
+		{-6, CivilTwilight},
+		{-6.001, NauticalTwilight},
+		{-12, NauticalTwilight},
+		{-12.001, AstronomicalTwilight},
+		{-18, AstronomicalTwilight},
+		{-18.001, Night},
+		{-90, Night},
+	} {
+		if got := phaseOf(tt.alt); got != tt.want {
+			t.Errorf("phaseOf(%v) = %v, want %v", tt.alt, got, tt.want)
+		}
+	}
+}
+
+func TestDayPhaseString(t *testing.T) {
+	for p, want := range map[DayPhase]string{
+		Night:                "Night",
+		AstronomicalTwilight: "AstronomicalTwilight",
+		NauticalTwilight:     "NauticalTwilight",
+		CivilTwilight:        "CivilTwilight",
+		Day:                  "Day",
+		Day + 1:              "DayPhase(?)",
+		-1:                   "DayPhase(?)",
+	} {
+		if got := p.String(); got != want {
+			t.Errorf("DayPhase(%d).String() = %q, want %q", int(p), got, want)
+		}
+	}
+}
+
+// At the solstices the Sun is lowest at midnight, 90 - latitude - obliquity
+// degrees below the horizon: about 15 degrees in London in June, so it never
+// gets darker than astronomical twilight there, and about 62 in December.
+func TestPhase(t *testing.T) {
+	loc := location(t, "Europe/London")
+	june := date(loc, 2024, time.June, 21)
+	rise, _ := Sunrise(june, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		name   string
+		t      time.Time
+		want   DayPhase
+		golden bool
+	}{
+		{"midsummer midnight", Culminate(june, 51.5074, -0.1278).Time.Add(-12 * time.Hour), AstronomicalTwilight, false},
+		{"midwinter midnight", time.Date(2024, 12, 21, 0, 0, 0, 0, loc), Night, false},
+		{"midsummer noon", time.Date(2024, 6, 21, 13, 0, 0, 0, loc), Day, false},
+		{"just after sunrise", rise.Add(time.Minute), Day, true},
+		{"just before sunrise", rise.Add(-time.Minute), CivilTwilight, true},
+		{"half an hour before sunrise", rise.Add(-30 * time.Minute), CivilTwilight, false},
+		{"40 minutes after sunrise", rise.Add(40 * time.Minute), Day, true},
+		{"two hours after sunrise", rise.Add(2 * time.Hour), Day, false},
+	} {
+		phase, golden := Phase(tt.t, 51.5074, -0.1278)
+		if phase != tt.want || golden != tt.golden {
+			t.Errorf("%s: Phase = %v, %v, want %v, %v", tt.name, phase, golden, tt.want, tt.golden)
+		}
+	}
+}
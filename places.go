@@ -0,0 +1,72 @@
+package sun
+
+import "time"
+
+// Place is a named location with the time zone its clocks keep.
+type Place struct {
+	Name string
+	Point
+	Location *time.Location
+}
+
+// DayComparison sets the solar events of one date at two places side by side.
+type DayComparison struct {
+	Date time.Time // midnight at the start of the date, in UTC
+	A    DayInfo   // with times in the time zone of place a
+	B    DayInfo   // with times in the time zone of place b
+	// DayLength is how much longer the day is at b than at a.
+	DayLength time.Duration
+	// Sunrise and Sunset are how much later by the local clock the Sun rises
+	// and sets at b than at a. They are zero if either place has no such event
+	// that day.
+	Sunrise time.Duration
+	Sunset  time.Duration
+}
+
+// CompareDays returns a comparison of places a and b for every date from
+// start to end inclusive, as for a move or a long stay. Events are those of
+// DayEvents on the same calendar date at each place, and sunrise and sunset are
+// compared by the time shown on the local clocks, so a place that keeps
+// summer time sees its sunrise an hour later than one that does not. A place
+// with no Location is taken to keep UTC.
+func CompareDays(start time.Time, end time.Time, a Place, b Place) []DayComparison {
+	aLoc, bLoc := a.zone(), b.zone()
+	var cs []DayComparison
+	for d := start; !afterDate(d, end); d = d.AddDate(0, 0, 1) {
+		y, m, day := d.Date()
+		c := DayComparison{
+			Date: time.Date(y, m, day, 0, 0, 0, 0, time.UTC),
+			A:    DayEvents(time.Date(y, m, day, 12, 0, 0, 0, aLoc), a.Latitude, a.Longitude),
+			B:    DayEvents(time.Date(y, m, day, 12, 0, 0, 0, bLoc), b.Latitude, b.Longitude),
+		}
+		c.DayLength = c.B.Length - c.A.Length
+		c.Sunrise = clockDifference(c.A.Sunrise, c.B.Sunrise)
+		c.Sunset = clockDifference(c.A.Sunset, c.B.Sunset)
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+// zone returns the time zone of p, UTC if it has none
+func (p Place) zone() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+	return p.Location
+}
+
+// clockDifference returns how much later the time of day shown by b is than
+// that of a, each in its own time zone, or zero if either is zero
+func clockDifference(a time.Time, b time.Time) time.Duration {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	return clockTime(b) - clockTime(a)
+}
+
+// clockTime returns the time since midnight shown on the clock at t
+func clockTime(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
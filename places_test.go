@@ -0,0 +1,94 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// Sunrise and sunset by the local clock from the timeanddate.com almanac:
+// London 04:43 to 21:21 and New York 05:25 to 20:31 at the June solstice,
+// London 08:03 to 15:53 and New York 07:17 to 16:32 at the December one.
+// Each is to the minute, so the differences are allowed two.
+func TestCompareDays(t *testing.T) {
+	london := Place{"London", Point{51.5074, -0.1278}, location(t, "Europe/London")}
+	newYork := Place{"New York", Point{40.7128, -74.0060}, location(t, "America/New_York")}
+	hm := func(h, m int) time.Duration { return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute }
+	cs := CompareDays(date(time.UTC, 2024, time.June, 20), date(time.UTC, 2024, time.June, 21), london, newYork)
+	if len(cs) != 2 {
+		t.Fatalf("%d days, want 2", len(cs))
+	}
+	cs = append(cs, CompareDays(date(time.UTC, 2024, time.December, 21), date(time.UTC, 2024, time.December, 21), london, newYork)...)
+	for i, tt := range []struct {
+		date                      time.Time
+		sunrise, sunset, daylight time.Duration
+	}{
+		{date(time.UTC, 2024, time.June, 20), hm(5, 25) - hm(4, 43), hm(20, 31) - hm(21, 21), hm(15, 6) - hm(16, 38)},
+		{date(time.UTC, 2024, time.June, 21), hm(5, 25) - hm(4, 43), hm(20, 31) - hm(21, 21), hm(15, 6) - hm(16, 38)},
+		{date(time.UTC, 2024, time.December, 21), hm(7, 17) - hm(8, 3), hm(16, 32) - hm(15, 53), hm(9, 15) - hm(7, 50)},
+	} {
+		c := cs[i]
+		if !c.Date.Equal(tt.date) || c.Date.Location() != time.UTC {
+			t.Errorf("comparison %d is for %v, want %v", i, c.Date, tt.date)
+		}
+		for _, d := range []struct {
+			name      string
+			got, want time.Duration
+		}{
+			{"sunrise", c.Sunrise, tt.sunrise},
+			{"sunset", c.Sunset, tt.sunset},
+			{"day length", c.DayLength, tt.daylight},
+		} {
+			if diff := d.got - d.want; diff < -2*time.Minute || diff > 2*time.Minute {
+				t.Errorf("%s: %s difference %v, want %v", tt.date.Format("Jan 2"), d.name, d.got, d.want)
+			}
+		}
+		if c.A.Sunrise.Location() != london.Location || c.B.Sunset.Location() != newYork.Location {
+			t.Errorf("%s: events in %v and %v", tt.date.Format("Jan 2"), c.A.Sunrise.Location(), c.B.Sunset.Location())
+		}
+		if c.DayLength != c.B.Length-c.A.Length {
+			t.Errorf("%s: DayLength %v, want %v", tt.date.Format("Jan 2"), c.DayLength, c.B.Length-c.A.Length)
+		}
+	}
+
+	// a place against itself differs by nothing, and where one has no sunset
+	// the clock differences are zero
+	for _, c := range CompareDays(date(time.UTC, 2024, time.March, 1), date(time.UTC, 2024, time.March, 3), london, london) {
+		if c.Sunrise != 0 || c.Sunset != 0 || c.DayLength != 0 {
+			t.Errorf("London against itself on %s: %+v", c.Date.Format("Jan 2"), c)
+		}
+	}
+	tromso := Place{"Tromsø", Point{69.6492, 18.9553}, location(t, "Europe/Oslo")}
+	c := CompareDays(date(time.UTC, 2024, time.June, 21), date(time.UTC, 2024, time.June, 21), london, tromso)[0]
+	if c.Sunrise != 0 || c.Sunset != 0 || c.DayLength != 24*time.Hour-c.A.Length {
+		t.Errorf("London against the midnight sun: %+v", c)
+	}
+	if cs := CompareDays(date(time.UTC, 2024, time.March, 2), date(time.UTC, 2024, time.March, 1), london, london); len(cs) != 0 {
+		t.Errorf("CompareDays with end before start = %d days", len(cs))
+	}
+
+	// a place with no zone keeps UTC, an hour behind London's summer clocks
+	greenwich := Place{Name: "Greenwich", Point: london.Point}
+	c = CompareDays(date(time.UTC, 2024, time.June, 21), date(time.UTC, 2024, time.June, 21), london, greenwich)[0]
+	if c.Sunrise != -time.Hour || c.Sunset != -time.Hour || c.DayLength != 0 || c.B.Sunrise.Location() != time.UTC {
+		t.Errorf("London against itself in UTC: %+v", c)
+	}
+}
+
+func TestClockDifference(t *testing.T) {
+	bst := time.FixedZone("BST", 3600)
+	edt := time.FixedZone("EDT", -4*3600)
+	for _, tt := range []struct {
+		a, b time.Time
+		want time.Duration
+	}{
+		// the same instant shows five hours earlier in New York
+		{time.Date(2024, 6, 21, 10, 0, 0, 0, bst), time.Date(2024, 6, 21, 5, 0, 0, 0, edt), -5 * time.Hour},
+		{time.Date(2024, 6, 21, 4, 43, 10, 0, bst), time.Date(2024, 6, 20, 5, 25, 40, 500, edt), 42*time.Minute + 30*time.Second + 500},
+		{time.Time{}, time.Date(2024, 6, 21, 5, 0, 0, 0, edt), 0},
+		{time.Date(2024, 6, 21, 5, 0, 0, 0, edt), time.Time{}, 0},
+	} {
+		if got := clockDifference(tt.a, tt.b); got != tt.want {
+			t.Errorf("clockDifference(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
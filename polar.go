@@ -0,0 +1,82 @@
+package sun
+
+import "time"
+
+// MidnightSun returns the spells of midnight sun that overlap the given year:
+// runs of dates in loc on which the upper limb of the Sun stays above the
+// horizon all day, allowing for refraction as for Sunrise. Each period runs
+// from midnight at the start of its first date to midnight at the end of its
+// last, and a spell that spans New Year is returned whole, so in Antarctica
+// one period starts in the year before.
+//
+// The result is empty outside the polar circles.
+func MidnightSun(year int, loc *time.Location, latitude float64, longitude float64) []Period {
+	return datePeriods(year, loc, func(d time.Time) bool {
+		return sunAllDay(d, latitude, longitude, true)
+	})
+}
+
+// PolarNight returns the spells of polar night that overlap the given year:
+// runs of dates in loc on which the upper limb of the Sun never rises. It is
+// otherwise as for MidnightSun.
+func PolarNight(year int, loc *time.Location, latitude float64, longitude float64) []Period {
+	return datePeriods(year, loc, func(d time.Time) bool {
+		return sunAllDay(d, latitude, longitude, false)
+	})
+}
+
+// WhiteNights returns the spells of white nights that overlap the given year:
+// runs of dates in loc whose following night the Sun never sinks more than
+// depression degrees below the horizon. The usual definition takes 6 degrees,
+// the end of civil twilight, so the sky never grows fully dark. Nights of
+// midnight sun count too. Periods run from the start of the first date to the
+// end of the last, as for MidnightSun.
+func WhiteNights(year int, loc *time.Location, latitude float64, longitude float64, depression float64) []Period {
+	return datePeriods(year, loc, func(d time.Time) bool {
+		return lowestAltitude(d, latitude, longitude) > -depression
+	})
+}
+
+// sunAllDay reports whether the Sun neither rises nor sets on the date of t
+// and is up all day if up is true, or down all day if it is false. DayLength
+// is not enough: on the last day of the midnight sun the Sun may set just
+// before midnight and rise again on the next date.
+func sunAllDay(t time.Time, latitude float64, longitude float64, up bool) bool {
+	if _, ok := Sunrise(t, latitude, longitude); ok {
+		return false
+	}
+	if _, ok := Sunset(t, latitude, longitude); ok {
+		return false
+	}
+	return (Culminate(t, latitude, longitude).Altitude > SunriseAltitude) == up
+}
+
+// lowestAltitude returns the altitude of the Sun at its lower culmination in
+// the night after the date of t, searched for within an hour of local mean
+// midnight as Culminate does for noon
+func lowestAltitude(t time.Time, latitude float64, longitude float64) float64 {
+	return Altitude(lowerCulmination(meanNoon(t, longitude).Add(12*time.Hour), latitude, longitude), latitude, longitude)
+}
+
+// datePeriods returns the runs of dates overlapping year for which is holds,
+// each extended into the neighbouring years as far as it goes
+func datePeriods(year int, loc *time.Location, is func(time.Time) bool) []Period {
+	var ps []Period
+	first := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	for d := first; d.Year() == year; d = d.AddDate(0, 0, 1) {
+		if !is(d) {
+			continue
+		}
+		start := d
+		if d.Equal(first) {
+			for i := 0; i < searchDays && is(start.AddDate(0, 0, -1)); i++ {
+				start = start.AddDate(0, 0, -1)
+			}
+		}
+		for i := 0; i < 2*searchDays && is(d.AddDate(0, 0, 1)); i++ {
+			d = d.AddDate(0, 0, 1)
+		}
+		ps = append(ps, Period{start, d.AddDate(0, 0, 1)})
+	}
+	return ps
+}
@@ -0,0 +1,105 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// spell is a run of dates, first to last inclusive
+type spell struct {
+	first, last time.Time
+}
+
+// checkSpells compares periods from MidnightSun and the like with the
+// expected runs of dates, allowing a day either way at each end
+func checkSpells(t *testing.T, name string, got []Period, want []spell) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s = %v, want %d spells", name, got, len(want))
+		return
+	}
+	for i, w := range want {
+		if !within(got[i].Start, w.first, 24*time.Hour) || !within(got[i].End, w.last.AddDate(0, 0, 1), 24*time.Hour) {
+			t.Errorf("%s spell %d = %s to %s, want %s to %s", name, i, got[i].Start.Format("2006-01-02"),
+				got[i].End.AddDate(0, 0, -1).Format("2006-01-02"), w.first.Format("2006-01-02"), w.last.Format("2006-01-02"))
+		}
+		if got[i].Start.Location() != w.first.Location() || got[i].Start.Hour() != 0 {
+			t.Errorf("%s spell %d starts at %v, want midnight in %v", name, i, got[i].Start, w.first.Location())
+		}
+	}
+}
+
+// The dates of the midnight sun and polar night in Tromsø and Longyearbyen
+// from the timeanddate.com almanac, which also takes the upper limb with
+// standard refraction. The midnight sun lasts while the Sun's declination at
+// lower culmination is at least 90 - φ - 0.833 degrees, 19.52 at Tromsø; it
+// falls below that late on 25 July UT, when the Sun sets just before
+// midnight and rises again after it.
+func TestMidnightSunPolarNight(t *testing.T) {
+	oslo := location(t, "Europe/Oslo")
+	d := func(y int, m time.Month, day int) time.Time { return date(oslo, y, m, day) }
+	for _, tt := range []struct {
+		name     string
+		f        func(int, *time.Location, float64, float64) []Period
+		lat, lon float64
+		want     []spell
+	}{
+		{"Tromsø midnight sun", MidnightSun, 69.6492, 18.9553, []spell{{d(2024, 5, 18), d(2024, 7, 24)}}},
+		{"Tromsø polar night", PolarNight, 69.6492, 18.9553, []spell{{d(2023, 11, 27), d(2024, 1, 14)}, {d(2024, 11, 27), d(2025, 1, 14)}}},
+		{"Longyearbyen midnight sun", MidnightSun, 78.2232, 15.6267, []spell{{d(2024, 4, 19), d(2024, 8, 23)}}},
+		{"Longyearbyen polar night", PolarNight, 78.2232, 15.6267, []spell{{d(2023, 10, 26), d(2024, 2, 14)}, {d(2024, 10, 26), d(2025, 2, 14)}}},
+		{"London midnight sun", MidnightSun, 51.5074, -0.1278, nil},
+		{"London polar night", PolarNight, 51.5074, -0.1278, nil},
+	} {
+		checkSpells(t, tt.name, tt.f(2024, oslo, tt.lat, tt.lon), tt.want)
+	}
+
+	// in Antarctica the midnight sun spans New Year and the polar night
+	// falls in the middle of the year
+	light := MidnightSun(2024, time.UTC, -77.8463, 166.6683)
+	if len(light) != 2 || light[0].Start.Year() != 2023 || light[1].End.Year() != 2025 {
+		t.Errorf("McMurdo midnight sun = %v, want spells from 2023 and into 2025", light)
+	}
+	night := PolarNight(2024, time.UTC, -77.8463, 166.6683)
+	if len(night) != 1 || night[0].Start.Month() != time.April || night[0].End.Month() != time.August {
+		t.Errorf("McMurdo polar night = %v, want April to August", night)
+	}
+}
+
+func TestSunAllDay(t *testing.T) {
+	const lat, lon = 69.6492, 18.9553
+	// on 25 July the Sun sets at 22:23 UT and rises after midnight, so the
+	// day is not wholly sunlit although DayLength gives 24 hours
+	d := date(time.UTC, 2024, time.July, 25)
+	if sunAllDay(d, lat, lon, true) {
+		t.Error("sunAllDay on 25 July = true, want false")
+	}
+	if !sunAllDay(d.AddDate(0, 0, -1), lat, lon, true) {
+		t.Error("sunAllDay on 24 July = false, want true")
+	}
+	if !sunAllDay(date(time.UTC, 2024, time.December, 21), lat, lon, false) {
+		t.Error("sunAllDay down on 21 December = false, want true")
+	}
+}
+
+// White nights last while the Sun at lower culmination, at φ + δ - 90, stays
+// above -6 degrees: for declinations above 20.57 at Trondheim, from 22 May to
+// 19 July, and above 14.35 at Tromsø, from 28 April to 12 August. St
+// Petersburg, at 59.94, would need 24.06, which the Sun never reaches. London
+// has no astronomical darkness while the declination is above 20.51.
+func TestWhiteNights(t *testing.T) {
+	d := func(m time.Month, day int) time.Time { return date(time.UTC, 2024, m, day) }
+	for _, tt := range []struct {
+		name       string
+		lat, lon   float64
+		depression float64
+		want       []spell
+	}{
+		{"Trondheim", 63.4305, 10.3951, 6, []spell{{d(5, 22), d(7, 19)}}},
+		{"St Petersburg", 59.9343, 30.3351, 6, nil},
+		{"London", 51.5074, -0.1278, 18, []spell{{d(5, 22), d(7, 19)}}},
+		{"Tromsø", 69.6492, 18.9553, 6, []spell{{d(4, 28), d(8, 12)}}},
+	} {
+		checkSpells(t, tt.name, WhiteNights(2024, time.UTC, tt.lat, tt.lon, tt.depression), tt.want)
+	}
+}
@@ -0,0 +1,226 @@
+package sun
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// standardPressure and standardTemperature are the conditions, in millibars
+// and degrees Celsius, for which the mean refraction at the horizon is given
+const (
+	standardPressure    = 1010
+	standardTemperature = 10
+)
+
+// horizonRefraction is the mean refraction at the horizon in degrees
+const horizonRefraction = 0.5667
+
+// Profile is a named location with the settings that decide when the Sun
+// rises and sets there: the observer's height, the skyline around them and
+// the state of the air.
+type Profile struct {
+	Place
+	// Elevation is the observer's height in metres above the level horizon
+	// they look out over, such as the sea from a cliff top, which lowers the
+	// horizon and brings sunrise earlier.
+	Elevation float64
+	// Horizon is the skyline, empty for a level one. When it is set it is
+	// used in place of Elevation, while Pressure and Temperature still scale
+	// the refraction over it.
+	Horizon Horizon
+	// Pressure in millibars and Temperature in degrees Celsius scale the
+	// refraction at the horizon. If Pressure is zero the standard 1010 mb and
+	// 10°C are used and Temperature is ignored.
+	Pressure    float64
+	Temperature float64
+}
+
+// RiseAltitude returns the geometric altitude of the centre of the Sun, in
+// degrees, when its upper limb is on a level horizon for the profile: the
+// same as SunriseAltitude for standard air at sea level.
+func (p Profile) RiseAltitude() float64 {
+	refraction := horizonRefraction * p.air()
+	// the dip of the sea horizon, 1.76 arcminutes times the square root of
+	// the height in metres, which allows for refraction along the line of sight
+	dip := 1.76 / 60 * math.Sqrt(math.Max(p.Elevation, 0))
+	return -refraction - sunSemiDiameter - dip
+}
+
+// air returns the refraction for the profile's pressure and temperature as a
+// fraction of that in standard air
+func (p Profile) air() float64 {
+	if p.Pressure == 0 {
+		return 1
+	}
+	return p.Pressure / standardPressure * (273 + standardTemperature) / (273 + p.Temperature)
+}
+
+// Sunrise returns the time of sunrise on the date of t for the profile, as
+// for the Sunrise function, over its skyline if it has one.
+func (p Profile) Sunrise(t time.Time) (sunrise time.Time, ok bool) {
+	if len(p.Horizon.Elevation) > 0 {
+		return firstRising(horizonCrossings(t, p.Latitude, p.Longitude, p.Horizon, p.air()))
+	}
+	return crossing(t, p.Latitude, p.Longitude, p.RiseAltitude(), true, defaultTolerance)
+}
+
+// Sunset returns the time of sunset on the date of t for the profile, as for
+// Sunrise.
+func (p Profile) Sunset(t time.Time) (sunset time.Time, ok bool) {
+	if len(p.Horizon.Elevation) > 0 {
+		return lastSetting(horizonCrossings(t, p.Latitude, p.Longitude, p.Horizon, p.air()))
+	}
+	return crossing(t, p.Latitude, p.Longitude, p.RiseAltitude(), false, defaultTolerance)
+}
+
+// ProfileConfig is a set of named location profiles, such as home, office and
+// observatory, for tools to choose between by name.
+type ProfileConfig struct {
+	Profiles map[string]Profile
+}
+
+// profileFile is the JSON form of a profile in a configuration file
+type profileFile struct {
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	TimeZone    string   `json:"timezone"`
+	Elevation   float64  `json:"elevation"`
+	HorizonFile string   `json:"horizonFile"`
+	Refraction  *struct {
+		Pressure    float64 `json:"pressure"`
+		Temperature float64 `json:"temperature"`
+	} `json:"refraction"`
+}
+
+// LoadProfiles reads a profile configuration from the JSON file at path, as
+// for ParseProfiles, with horizon files relative to the directory of path.
+func LoadProfiles(path string) (*ProfileConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseProfiles(f, filepath.Dir(path))
+}
+
+// ParseProfiles reads a profile configuration in JSON, a map of profile names
+// to their settings:
+//
+//	{
+//	  "home": {"latitude": 51.48, "longitude": 0, "timezone": "Europe/London"},
+//	  "observatory": {
+//	    "latitude": 28.76, "longitude": -17.89, "timezone": "Atlantic/Canary",
+//	    "elevation": 2400, "horizonFile": "roque.txt",
+//	    "refraction": {"pressure": 770, "temperature": 5}
+//	  }
+//	}
+//
+// latitude and longitude are required; timezone defaults to UTC. A relative
+// horizonFile is taken from dir and read with ReadHorizon.
+func ParseProfiles(r io.Reader, dir string) (*ProfileConfig, error) {
+	var files map[string]profileFile
+	if err := json.NewDecoder(r).Decode(&files); err != nil {
+		return nil, fmt.Errorf("sun: bad profile configuration: %v", err)
+	}
+	c := &ProfileConfig{Profiles: make(map[string]Profile, len(files))}
+	for name, f := range files {
+		if f.Latitude == nil || f.Longitude == nil {
+			return nil, fmt.Errorf("sun: profile %q needs a latitude and longitude", name)
+		}
+		if math.Abs(*f.Latitude) > 90 || math.Abs(*f.Longitude) > 180 {
+			return nil, fmt.Errorf("sun: profile %q is off the globe", name)
+		}
+		p := Profile{
+			Place:     Place{Name: name, Point: Point{*f.Latitude, *f.Longitude}, Location: time.UTC},
+			Elevation: f.Elevation,
+		}
+		if f.TimeZone != "" {
+			loc, err := time.LoadLocation(f.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("sun: profile %q: %v", name, err)
+			}
+			p.Location = loc
+		}
+		if f.Refraction != nil {
+			p.Pressure, p.Temperature = f.Refraction.Pressure, f.Refraction.Temperature
+		}
+		if f.HorizonFile != "" {
+			path := f.HorizonFile
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			h, err := readHorizonFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("sun: profile %q: %v", name, err)
+			}
+			p.Horizon = h
+		}
+		c.Profiles[name] = p
+	}
+	return c, nil
+}
+
+// Profile returns the profile with the given name.
+func (c *ProfileConfig) Profile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("sun: no profile %q", name)
+	}
+	return p, nil
+}
+
+// Names returns the names of the profiles in alphabetical order.
+func (c *ProfileConfig) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readHorizonFile(path string) (Horizon, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Horizon{}, err
+	}
+	defer f.Close()
+	return ReadHorizon(f)
+}
+
+// ReadHorizon reads a skyline survey for NewHorizon: elevations in degrees at
+// equal steps of azimuth starting from north, separated by spaces, commas or
+// new lines. Text from a # to the end of a line is a comment.
+func ReadHorizon(r io.Reader) (Horizon, error) {
+	var elevation []float64
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		for _, field := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			e, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return Horizon{}, fmt.Errorf("sun: bad horizon elevation: %v", err)
+			}
+			elevation = append(elevation, e)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return Horizon{}, err
+	}
+	if len(elevation) == 0 {
+		return Horizon{}, errEmptyHorizon
+	}
+	return NewHorizon(elevation), nil
+}
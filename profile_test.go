@@ -0,0 +1,139 @@
+package sun
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Refraction of 0.5667 and a semi-diameter of 0.2666 degree put the Sun's
+// centre 0.8333 down at sunrise. From 100 m the sea horizon dips 1.76 × 10
+// arcminutes, 0.2933 degree, and at 770 mb and 5°C refraction is 0.5667 ×
+// 770/1010 × 283/278 = 0.4398.
+func TestRiseAltitude(t *testing.T) {
+	for _, tt := range []struct {
+		p    Profile
+		want float64
+	}{
+		{Profile{}, -0.8333},
+		{Profile{Elevation: 100}, -1.1266},
+		{Profile{Elevation: -5}, -0.8333},
+		{Profile{Pressure: 1010, Temperature: 10}, -0.8333},
+		{Profile{Pressure: 770, Temperature: 5}, -0.7064},
+	} {
+		if got := tt.p.RiseAltitude(); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("%+v.RiseAltitude() = %.4f, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+// At London on the June solstice the Sun climbs 7.04 degrees an hour at
+// sunrise, so the 0.2933 degree dip from 100 m brings sunrise 2.5 minutes
+// earlier and sunset as much later.
+func TestProfileSunrise(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	place := Place{Name: "London", Point: Point{51.5074, -0.1278}, Location: time.UTC}
+	rise, _ := Sunrise(d, place.Latitude, place.Longitude)
+	set, _ := Sunset(d, place.Latitude, place.Longitude)
+
+	p := Profile{Place: place}
+	if r, ok := p.Sunrise(d); !ok || !within(r, rise, time.Second) {
+		t.Errorf("Sunrise at sea level = %v, want %v", r, rise)
+	}
+	p.Elevation = 100
+	if r, ok := p.Sunrise(d); !ok || !within(r, rise.Add(-150*time.Second), 10*time.Second) {
+		t.Errorf("Sunrise from 100 m = %v, want %v", r, rise.Add(-150*time.Second))
+	}
+	if s, ok := p.Sunset(d); !ok || !within(s, set.Add(150*time.Second), 10*time.Second) {
+		t.Errorf("Sunset from 100 m = %v, want %v", s, set.Add(150*time.Second))
+	}
+
+	// a skyline takes over from the elevation
+	p.Horizon = NewHorizon([]float64{5})
+	want, _ := SunriseOver(d, place.Latitude, place.Longitude, p.Horizon)
+	if r, ok := p.Sunrise(d); !ok || !r.Equal(want) {
+		t.Errorf("Sunrise over a skyline = %v, want %v", r, want)
+	}
+
+	// over a 5 degree skyline the refraction is 0.1647 degree, and at 770 mb
+	// and 5°C it is 0.7761 of that, so the Sun, climbing 7.84 degrees an hour
+	// there, rises 17 seconds later and sets as much earlier
+	wantSet, _ := SunsetOver(d, place.Latitude, place.Longitude, p.Horizon)
+	p.Pressure, p.Temperature = 770, 5
+	if r, ok := p.Sunrise(d); !ok || !within(r, want.Add(17*time.Second), time.Second) {
+		t.Errorf("Sunrise over a skyline at 770 mb = %v, want %v", r, want.Add(17*time.Second))
+	}
+	if s, ok := p.Sunset(d); !ok || !within(s, wantSet.Add(-17*time.Second), time.Second) {
+		t.Errorf("Sunset over a skyline at 770 mb = %v, want %v", s, wantSet.Add(-17*time.Second))
+	}
+}
+
+func TestParseProfiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "roque.txt"), []byte("# skyline\n1, 2\n3 4 # east\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := `{
+	  "home": {"latitude": 51.48, "longitude": 0, "timezone": "Europe/London"},
+	  "observatory": {
+	    "latitude": 28.76, "longitude": -17.89, "elevation": 2400, "horizonFile": "roque.txt",
+	    "refraction": {"pressure": 770, "temperature": 5}
+	  }
+	}`
+	path := filepath.Join(dir, "profiles.json")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := strings.Join(c.Names(), ","); names != "home,observatory" {
+		t.Errorf("Names() = %s", names)
+	}
+	home, err := c.Profile("home")
+	if err != nil || home.Name != "home" || home.Latitude != 51.48 || home.Location.String() != "Europe/London" {
+		t.Errorf("home = %+v, %v", home, err)
+	}
+	obs, _ := c.Profile("observatory")
+	if obs.Location != time.UTC || obs.Elevation != 2400 || obs.Pressure != 770 || obs.Temperature != 5 {
+		t.Errorf("observatory = %+v", obs)
+	}
+	if e := obs.Horizon.Elevation; len(e) != 4 || e[0] != 1 || e[3] != 4 {
+		t.Errorf("observatory skyline %v, want 1 2 3 4", e)
+	}
+	if _, err := c.Profile("office"); err == nil {
+		t.Error("no error for a missing profile")
+	}
+
+	for _, bad := range []string{
+		`{"home": {"longitude": 0}}`,
+		`{"home": {"latitude": 91, "longitude": 0}}`,
+		`{"home": {"latitude": 0, "longitude": 0, "timezone": "Mars/Olympus"}}`,
+		`{"home": {"latitude": 0, "longitude": 0, "horizonFile": "missing.txt"}}`,
+		`{"home": `,
+	} {
+		if _, err := ParseProfiles(strings.NewReader(bad), dir); err == nil {
+			t.Errorf("no error from %s", bad)
+		}
+	}
+	if _, err := LoadProfiles(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("no error for a missing file")
+	}
+}
+
+func TestReadHorizon(t *testing.T) {
+	h, err := ReadHorizon(strings.NewReader("0,10\t20 # comment 99\n\n30\n"))
+	if err != nil || len(h.Elevation) != 4 || h.Elevation[2] != 20 || h.Elevation[3] != 30 {
+		t.Errorf("ReadHorizon = %v, %v", h.Elevation, err)
+	}
+	if _, err := ReadHorizon(strings.NewReader("# nothing\n")); err != errEmptyHorizon {
+		t.Errorf("empty horizon error %v", err)
+	}
+	if _, err := ReadHorizon(strings.NewReader("1, high\n")); err == nil {
+		t.Error("no error for a bad elevation")
+	}
+}
@@ -0,0 +1,33 @@
+package sun
+
+import "time"
+
+// DayProgress returns how far through the daylight period of its date time t
+// is, from 0 at sunrise to 1 at sunset. It is negative before sunrise and
+// greater than 1 after sunset, so a sun arc gauge can clamp it or not as it
+// likes.
+//
+// On a day when the Sun never sets the daylight period is taken to run from
+// the lower culmination before noon to the one after, so progress is 0.5 at
+// noon. On a day when the Sun never rises ok is false.
+func DayProgress(t time.Time, latitude float64, longitude float64) (progress float64, ok bool) {
+	rise, ok1 := Sunrise(t, latitude, longitude)
+	set, ok2 := Sunset(t, latitude, longitude)
+	if ok1 && ok2 && set.After(rise) {
+		return float64(t.Sub(rise)) / float64(set.Sub(rise)), true
+	}
+	noon := Culminate(t, latitude, longitude)
+	if noon.Altitude < SunriseAltitude {
+		return 0, false
+	}
+	return 0.5 + float64(t.Sub(noon.Time))/float64(24*time.Hour), true
+}
+
+// SolarTime returns the local apparent solar time at t in hours, in [0, 24):
+// the time a sundial would show, with 12 at solar noon whatever the clock says.
+// It depends only on longitude.
+func SolarTime(t time.Time, longitude float64) float64 {
+	jd := timeToJD(t)
+	_, rAsc, _ := getSunCoords(jd)
+	return between(0, 24, 12+getHourAngle(jd, longitude, rAsc)/15)
+}
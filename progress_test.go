@@ -0,0 +1,85 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDayProgress(t *testing.T) {
+	d := date(time.UTC, 2024, time.March, 20)
+	rise, _ := Sunrise(d, 51.48, 0)
+	set, _ := Sunset(d, 51.48, 0)
+	for _, tt := range []struct {
+		name string
+		t    time.Time
+		want float64
+	}{
+		{"sunrise", rise, 0},
+		{"sunset", set, 1},
+		{"midway", rise.Add(set.Sub(rise) / 2), 0.5},
+		{"a quarter", rise.Add(set.Sub(rise) / 4), 0.25},
+		{"before sunrise", rise.Add(-set.Sub(rise) / 10), -0.1},
+		{"after sunset", set.Add(set.Sub(rise) / 5), 1.2},
+	} {
+		got, ok := DayProgress(tt.t, 51.48, 0)
+		if !ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("DayProgress at %s = %v, %v, want %v", tt.name, got, ok, tt.want)
+		}
+	}
+
+	// in the midnight sun the day runs from one lower culmination to the next
+	june := date(time.UTC, 2024, time.June, 21)
+	noon := Culminate(june, 75, 0).Time
+	for _, tt := range []struct {
+		t    time.Time
+		want float64
+	}{
+		{noon, 0.5},
+		{noon.Add(-6 * time.Hour), 0.25},
+		{noon.Add(9 * time.Hour), 0.875},
+	} {
+		if got, ok := DayProgress(tt.t, 75, 0); !ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("DayProgress in the midnight sun at %v = %v, %v, want %v", tt.t, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := DayProgress(date(time.UTC, 2024, time.December, 21), 75, 0); ok {
+		t.Error("DayProgress in the polar night: ok")
+	}
+}
+
+// The equation of time is +16.4 minutes on 3 November and -14.2 minutes on 11
+// February, so a sundial at Greenwich is that far ahead of the clock at noon
+// UT. Each 15 degrees of longitude adds an hour.
+func TestSolarTime(t *testing.T) {
+	for _, tt := range []struct {
+		t         time.Time
+		longitude float64
+		want      float64
+	}{
+		{time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC), 0, 12 + 16.4/60},
+		{time.Date(2024, 2, 11, 12, 0, 0, 0, time.UTC), 0, 12 - 14.2/60},
+		{time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC), 15, 13 + 16.4/60},
+		{time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC), -150, 2 + 16.4/60},
+		{time.Date(2024, 11, 3, 23, 0, 0, 0, time.UTC), 30, 1 + 16.4/60},
+	} {
+		if got := SolarTime(tt.t, tt.longitude); math.Abs(got-tt.want) > 0.5/60 {
+			t.Errorf("SolarTime(%v, %v) = %.4f, want %.4f", tt.t, tt.longitude, got, tt.want)
+		}
+	}
+
+	// it reads 12 at the meridian transit, wherever and whenever
+	for _, lon := range []float64{-120, 0, 77.2} {
+		at := AzimuthCrossings(date(time.UTC, 2024, time.May, 5).Add(secondsToDuration(-lon*240)), date(time.UTC, 2024, time.May, 6).Add(secondsToDuration(-lon*240)), 40, lon, 180)
+		if len(at) != 1 {
+			t.Fatalf("%d meridian crossings at longitude %v", len(at), lon)
+		}
+		if got := SolarTime(at[0].Time, lon); math.Abs(got-12) > 2.0/3600 {
+			t.Errorf("SolarTime at the meridian at longitude %v = %.5f, want 12", lon, got)
+		}
+	}
+	if got := SolarTime(time.Date(2024, 11, 3, 0, 0, 0, 0, time.UTC), -179); got < 0 || got >= 24 {
+		t.Errorf("SolarTime = %v, outside [0, 24)", got)
+	}
+}
@@ -0,0 +1,150 @@
+package sun
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exploded/sun/internal/ical"
+)
+
+// Common depressions of the Sun at Fajr, in degrees, under the conventions of
+// the main calculation authorities.
+const (
+	FajrMuslimWorldLeague float64 = 18
+	FajrISNA              float64 = 15
+	FajrEgypt             float64 = 19.5
+	FajrUmmAlQura         float64 = 18.5
+)
+
+// FastingDay is one day of a fast from dawn to sunset.
+type FastingDay struct {
+	Date time.Time // midnight at the start of the date
+	Day  int       // day of the month, from 1
+	// Fajr is dawn, the end of suhoor and start of the fast. It is zero where
+	// the Sun does not get far enough below the horizon, as in summer at high
+	// latitudes, when a local convention must be followed instead.
+	Fajr time.Time
+	// Maghrib is sunset, iftar and the end of the fast.
+	Maghrib time.Time
+}
+
+// Fast returns the length of the fast, or zero if Fajr or Maghrib is missing.
+func (d FastingDay) Fast() time.Duration {
+	if d.Fajr.IsZero() || d.Maghrib.IsZero() {
+		return 0
+	}
+	return d.Maghrib.Sub(d.Fajr)
+}
+
+// FastingTimetable is a month of fasting times at one place.
+type FastingTimetable struct {
+	Place Place
+	Days  []FastingDay
+}
+
+// RamadanStart returns 1 Ramadan of the given Hijri year in the tabular
+// Islamic calendar, as midnight in loc. Where the month is begun by sighting
+// the crescent, or by Umm al-Qura, it may start a day or so either side.
+func RamadanStart(hijriYear int, loc *time.Location) time.Time {
+	return HijriDate{hijriYear, 9, 1}.Date(loc)
+}
+
+// FastingTimes returns Fajr and Maghrib at place for each of days dates from
+// the date of start, with Fajr when the Sun is fajrAngle degrees below the
+// horizon, such as FajrMuslimWorldLeague. Times are to within a second and in
+// the place's time zone.
+func FastingTimes(start time.Time, days int, place Place, fajrAngle float64) FastingTimetable {
+	tt := FastingTimetable{Place: place}
+	y, m, d := start.Date()
+	for i := 0; i < days; i++ {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, place.Location)
+		fd := FastingDay{Date: date, Day: i + 1}
+		fd.Fajr, _ = crossing(date, place.Latitude, place.Longitude, -fajrAngle, true, defaultTolerance)
+		fd.Maghrib, _ = Sunset(date, place.Latitude, place.Longitude)
+		tt.Days = append(tt.Days, fd)
+	}
+	return tt
+}
+
+// RamadanTimetables returns the fasting times for the 30 days of Ramadan in the
+// given Hijri year at each place, starting from RamadanStart. Drop the last
+// day when the month is seen to end after 29.
+func RamadanTimetables(hijriYear int, places []Place, fajrAngle float64) []FastingTimetable {
+	var tts []FastingTimetable
+	for _, p := range places {
+		tts = append(tts, FastingTimes(RamadanStart(hijriYear, p.Location), 30, p, fajrAngle))
+	}
+	return tts
+}
+
+// WriteFastingCSV writes timetables with one row per place and day, times to
+// the minute in local time.
+func WriteFastingCSV(w io.Writer, tts []FastingTimetable) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"place", "date", "day", "fajr", "maghrib", "fast_hours"}); err != nil {
+		return err
+	}
+	clock := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Round(time.Minute).Format("15:04")
+	}
+	for _, tt := range tts {
+		for _, d := range tt.Days {
+			err := cw.Write([]string{
+				tt.Place.Name,
+				d.Date.Format("2006-01-02"),
+				strconv.Itoa(d.Day),
+				clock(d.Fajr),
+				clock(d.Maghrib),
+				strconv.FormatFloat(d.Fast().Hours(), 'f', 2, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteFastingICS writes timetables as an iCalendar feed with a suhoor event
+// at each Fajr and an iftar event at each Maghrib. Times are given in UTC,
+// which calendar clients show in the user's own zone.
+func WriteFastingICS(w io.Writer, tts []FastingTimetable) error {
+	lw := ical.NewWriter(w)
+	utc := func(t time.Time) string {
+		return t.Round(time.Minute).UTC().Format("20060102T150405Z")
+	}
+	lw.Line("BEGIN:VCALENDAR")
+	lw.Line("VERSION:2.0")
+	lw.Line("PRODID:-//exploded//sun//EN")
+	lw.Line("CALSCALE:GREGORIAN")
+	for i, tt := range tts {
+		for _, d := range tt.Days {
+			for _, e := range []struct {
+				name string
+				at   time.Time
+			}{{"Suhoor ends", d.Fajr}, {"Iftar", d.Maghrib}} {
+				if e.at.IsZero() {
+					continue
+				}
+				lw.Line("BEGIN:VEVENT")
+				lw.Line(fmt.Sprintf("UID:%s-%d-%d-%s@sun", d.Date.Format("20060102"), i, d.Day, strings.Fields(e.name)[0]))
+				lw.Line("DTSTAMP:" + utc(tt.Days[0].Date))
+				lw.Line("DTSTART:" + utc(e.at))
+				lw.Line("DTEND:" + utc(e.at))
+				lw.Line("SUMMARY:" + ical.Text(e.name+" "+tt.Place.Name+" "+e.at.Round(time.Minute).Format("15:04")))
+				lw.Line("TRANSP:TRANSPARENT")
+				lw.Line("END:VEVENT")
+			}
+		}
+	}
+	lw.Line("END:VCALENDAR")
+	return lw.Err()
+}
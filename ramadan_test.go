@@ -0,0 +1,101 @@
+package sun
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Ramadan began in Saudi Arabia on 23 March 2023, 11 March 2024 and 1 March
+// 2025, the same days as the tabular calendar.
+func TestRamadanStart(t *testing.T) {
+	for _, tt := range []struct {
+		year int
+		want time.Time
+	}{
+		{1444, date(time.UTC, 2023, time.March, 23)},
+		{1445, date(time.UTC, 2024, time.March, 11)},
+		{1446, date(time.UTC, 2025, time.March, 1)},
+	} {
+		if got := RamadanStart(tt.year, time.UTC); !got.Equal(tt.want) {
+			t.Errorf("RamadanStart(%d) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+}
+
+// At Mecca on 11 March 2024, with declination -3.9 degrees and noon at 12:30.9
+// local time, cos H = (sin h - 0.0248) / 0.9288 gives Fajr at 18 degrees 7h11m
+// before noon, 05:19, and Maghrib 5h57.5m after, 18:28.
+func TestFastingTimes(t *testing.T) {
+	mecca := Place{"Mecca", Point{21.4225, 39.8262}, time.FixedZone("AST", 3*3600)}
+	london := Place{"London", Point{51.5074, -0.1278}, location(t, "Europe/London")}
+	tts := RamadanTimetables(1445, []Place{mecca, london}, FajrMuslimWorldLeague)
+	if len(tts) != 2 || len(tts[0].Days) != 30 {
+		t.Fatalf("%d timetables", len(tts))
+	}
+	for _, tt := range []struct {
+		tt            FastingTimetable
+		day           int
+		fajr, maghrib time.Time
+	}{
+		{tts[0], 1, clock(date(mecca.Location, 2024, time.March, 11), 5, 19), clock(date(mecca.Location, 2024, time.March, 11), 18, 29)},
+		{tts[0], 30, clock(date(mecca.Location, 2024, time.April, 9), 4, 51), clock(date(mecca.Location, 2024, time.April, 9), 18, 38)},
+		{tts[1], 1, clock(date(london.Location, 2024, time.March, 11), 4, 31), clock(date(london.Location, 2024, time.March, 11), 17, 59)},
+		{tts[1], 30, clock(date(london.Location, 2024, time.April, 9), 4, 13), clock(date(london.Location, 2024, time.April, 9), 19, 48)},
+	} {
+		d := tt.tt.Days[tt.day-1]
+		if d.Day != tt.day || !within(d.Fajr, tt.fajr, time.Minute) || !within(d.Maghrib, tt.maghrib, time.Minute) {
+			t.Errorf("%s day %d: %v to %v, want %v to %v", tt.tt.Place.Name, d.Day, d.Fajr, d.Maghrib, tt.fajr, tt.maghrib)
+		}
+		if d.Fast() != d.Maghrib.Sub(d.Fajr) {
+			t.Errorf("%s day %d: fast %v", tt.tt.Place.Name, d.Day, d.Fast())
+		}
+	}
+
+	// London never gets 18 degrees dark at midsummer
+	d := FastingTimes(date(london.Location, 2024, time.June, 21), 1, london, FajrMuslimWorldLeague).Days[0]
+	if !d.Fajr.IsZero() || d.Fast() != 0 {
+		t.Errorf("London Fajr at midsummer = %v, fast %v", d.Fajr, d.Fast())
+	}
+}
+
+func TestWriteFasting(t *testing.T) {
+	mecca := Place{"Mecca", Point{21.4225, 39.8262}, time.FixedZone("AST", 3*3600)}
+	tts := []FastingTimetable{FastingTimes(date(mecca.Location, 2024, time.March, 11), 2, mecca, FajrMuslimWorldLeague)}
+
+	var b bytes.Buffer
+	if err := WriteFastingCSV(&b, tts); err != nil {
+		t.Fatal(err)
+	}
+	want := "place,date,day,fajr,maghrib,fast_hours\n" +
+		"Mecca,2024-03-11,1,05:19,18:29,13.17\n" +
+		"Mecca,2024-03-12,2,05:18,18:29,13.19\n"
+	if b.String() != want {
+		t.Errorf("CSV:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := WriteFastingICS(&b, tts); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20240311T021900Z\r\n",
+		"SUMMARY:Suhoor ends Mecca 05:19\r\n",
+		"DTSTART:20240311T152900Z\r\n",
+		"SUMMARY:Iftar Mecca 18:29\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(b.String(), s) {
+			t.Errorf("iCalendar is missing %q", s)
+		}
+	}
+	if n := strings.Count(b.String(), "BEGIN:VEVENT"); n != 4 {
+		t.Errorf("%d events, want 4", n)
+	}
+
+	if WriteFastingCSV(failWriter{}, tts) == nil || WriteFastingICS(failWriter{}, tts) == nil {
+		t.Error("no error from a failing writer")
+	}
+}
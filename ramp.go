@@ -0,0 +1,77 @@
+package sun
+
+import "time"
+
+// RampSample is the clear sky irradiance over a region at one time.
+type RampSample struct {
+	Time time.Time
+	GHI  float64 // mean global horizontal irradiance, watts per square metre
+	Rate float64 // rate of change of GHI, watts per square metre per minute
+}
+
+// RampWindow is the steepest ramp of a day, and its mean rate in watts per
+// square metre per minute: positive for the morning rise and negative for the
+// evening fall.
+type RampWindow struct {
+	Period
+	Rate float64
+}
+
+// SolarRamp is the clear sky generation ramp over a region for one day.
+type SolarRamp struct {
+	Samples []RampSample
+	Up      RampWindow
+	Down    RampWindow
+}
+
+// NewSolarRamp returns the clear sky ramp over the date of t for a region
+// given as one or more points, such as a grid over a balancing area: the mean
+// ClearSkyGHI of the points at every step through the day, its rate of change,
+// and the windows of length window over which it rises and falls fastest.
+// Solar generation follows GHI closely enough for these to mark when the
+// fleet ramps hardest around sunrise and sunset, before cloud is allowed for.
+//
+// It returns an error if step is not positive or there are no points. window
+// is rounded down to whole steps, and at least one.
+func NewSolarRamp(t time.Time, points []Point, step time.Duration, window time.Duration) (SolarRamp, error) {
+	if step <= 0 {
+		return SolarRamp{}, errNonPositiveStep
+	}
+	if len(points) == 0 {
+		return SolarRamp{}, errNoSites
+	}
+	ghi := func(at time.Time) float64 {
+		var sum float64
+		for _, p := range points {
+			sum += ClearSkyGHI(at, p.Latitude, p.Longitude)
+		}
+		return sum / float64(len(points))
+	}
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	var r SolarRamp
+	for at := start; at.Before(end); at = at.Add(step) {
+		g := ghi(at)
+		// over the minute centred on at
+		rate := ghi(at.Add(30*time.Second)) - ghi(at.Add(-30*time.Second))
+		r.Samples = append(r.Samples, RampSample{at, g, rate})
+	}
+
+	k := int(window / step)
+	if k < 1 {
+		k = 1
+	}
+	minutes := (time.Duration(k) * step).Minutes()
+	for i := 0; i+k < len(r.Samples); i++ {
+		a, b := r.Samples[i], r.Samples[i+k]
+		rate := (b.GHI - a.GHI) / minutes
+		if rate > r.Up.Rate {
+			r.Up = RampWindow{Period{a.Time, b.Time}, rate}
+		}
+		if rate < r.Down.Rate {
+			r.Down = RampWindow{Period{a.Time, b.Time}, rate}
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,72 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At the equator on the March equinox noon is at 12:07 UT, and with δ = 0 the
+// cosine of the zenith angle is cos H. Haurwitz's 1098 c e^(-0.057/c) is 890.3
+// at 10:07, two hours out, and rises at 1098 e^(-0.057/c) (1 + 0.057/c) sin H
+// dH/dt, with H turning 0.25 degrees a minute: 2.39 W/m² a minute at 10:07,
+// 4.53 at 07:07, five hours out, and at most 4.54 some fifty minutes after sunrise. An hour's
+// steepest ramp is more than the 262 W/m² of the second hour after sunrise,
+// 4.36 a minute, and less than that peak.
+func TestNewSolarRamp(t *testing.T) {
+	d := date(time.UTC, 2024, time.March, 20)
+	r, err := NewSolarRamp(d, []Point{{0, 0}}, time.Minute, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Samples) != 1440 {
+		t.Fatalf("%d samples, want 1440", len(r.Samples))
+	}
+	for _, tt := range []struct {
+		at        time.Time
+		ghi, rate float64
+	}{
+		{clock(d, 7, 7), 228.0, 4.53},
+		{clock(d, 10, 7), 890.3, 2.39},
+		{clock(d, 12, 7), 1037.2, 0},
+		{clock(d, 17, 7), 228.0, -4.53},
+		{clock(d, 2, 0), 0, 0},
+	} {
+		s := r.Samples[int(tt.at.Sub(d).Minutes())]
+		if !s.Time.Equal(tt.at) || math.Abs(s.GHI-tt.ghi) > 3 || math.Abs(s.Rate-tt.rate) > 0.05 {
+			t.Errorf("sample at %v = %+v, want GHI %v rising %v", tt.at.Format("15:04"), s, tt.ghi, tt.rate)
+		}
+	}
+	if r.Up.Rate < 4.36 || r.Up.Rate > 4.54 || r.Up.Duration() != time.Hour || r.Up.Start.Hour() != 6 {
+		t.Errorf("Up = %+v, want 4.36 to 4.54 an hour after 06:07", r.Up)
+	}
+	if math.Abs(r.Down.Rate+r.Up.Rate) > 0.02 || r.Down.End.Hour() != 17 {
+		t.Errorf("Down = %+v, want the mirror of Up", r.Down)
+	}
+
+	// the region's GHI is the mean of its points
+	pts := []Point{{0, 0}, {0, 30}, {40, -3}}
+	r, _ = NewSolarRamp(d, pts, time.Hour, time.Hour)
+	s := r.Samples[10]
+	var sum float64
+	for _, p := range pts {
+		sum += ClearSkyGHI(s.Time, p.Latitude, p.Longitude)
+	}
+	if math.Abs(s.GHI-sum/3) > 1e-9 {
+		t.Errorf("GHI at 10:00 = %v, want %v", s.GHI, sum/3)
+	}
+	// a window shorter than the step is one step
+	if r.Up.Duration() != time.Hour {
+		t.Errorf("Up over hourly samples = %v, want an hour", r.Up.Duration())
+	}
+	if r, _ := NewSolarRamp(d, pts, time.Hour, time.Minute); r.Up.Duration() != time.Hour {
+		t.Errorf("Up with a one minute window = %v, want an hour", r.Up.Duration())
+	}
+
+	if _, err := NewSolarRamp(d, pts, 0, time.Hour); err != errNonPositiveStep {
+		t.Errorf("zero step: err = %v, want %v", err, errNonPositiveStep)
+	}
+	if _, err := NewSolarRamp(d, nil, time.Minute, time.Hour); err != errNoSites {
+		t.Errorf("no points: err = %v, want %v", err, errNoSites)
+	}
+}
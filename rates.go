@@ -0,0 +1,22 @@
+package sun
+
+import "time"
+
+// rateStep is half the interval used for the centred differences in Rates
+const rateStep = 10 * time.Second
+
+// Rates returns how fast the altitude and azimuth of the Sun are changing at
+// time t, in degrees per second. Altitude rate is positive while the Sun is
+// climbing and azimuth rate is positive while it moves clockwise.
+//
+// The rates are centred differences over 20 seconds, which is far shorter than
+// any change in the rates themselves. Near the zenith the azimuth rate becomes
+// very large, as it must.
+func Rates(t time.Time, latitude float64, longitude float64) (altitudeRate float64, azimuthRate float64) {
+	before, after := t.Add(-rateStep), t.Add(rateStep)
+	span := 2 * rateStep.Seconds()
+	altitudeRate = (Altitude(after, latitude, longitude) - Altitude(before, latitude, longitude)) / span
+	dAz := between(-180, 180, Azimuth(after, latitude, longitude)-Azimuth(before, latitude, longitude))
+	azimuthRate = dAz / span
+	return altitudeRate, azimuthRate
+}
@@ -0,0 +1,58 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// omega is the rate the Sun moves round the sky, in degrees per second
+const omega = 360.0 / 86400
+
+// The rates follow from the hour angle turning at omega: at sunrise or sunset
+// on an equinox the altitude changes at omega·cos(latitude), and at the
+// meridian the azimuth changes at omega·cos(declination)/cos(altitude).
+func TestRates(t *testing.T) {
+	equinox := date(time.UTC, 2024, time.March, 20)
+	solstice := date(time.UTC, 2024, time.June, 20)
+	for _, tt := range []struct {
+		name     string
+		at       time.Time
+		lat, lon float64
+		alt, az  float64
+		checkAz  bool
+	}{
+		{"equinox sunrise at the equator", mustEvent(Sunrise(equinox, 0, 0)), 0, 0, omega, 0, false},
+		{"equinox sunset at 60 N", mustEvent(Sunset(equinox, 60, 0)), 60, 0, -omega * 0.5, 0, false},
+		{"solstice noon at Greenwich", Culminate(solstice, 51.4779, 0).Time, 51.4779, 0,
+			0, omega * math.Cos(obliquity*math.Pi/180) / math.Cos((90-51.4779+obliquity)*math.Pi/180), true},
+		{"solstice noon at 60 S", Culminate(solstice, -60, 0).Time, -60, 0,
+			0, -omega * math.Cos(obliquity*math.Pi/180) / math.Cos((30-obliquity)*math.Pi/180), true},
+	} {
+		alt, az := Rates(tt.at, tt.lat, tt.lon)
+		if math.Abs(alt-tt.alt) > 0.01*omega {
+			t.Errorf("%s: altitude rate = %.6f, want %.6f", tt.name, alt, tt.alt)
+		}
+		if tt.checkAz && math.Abs(az-tt.az) > 0.01*math.Abs(tt.az) {
+			t.Errorf("%s: azimuth rate = %.6f, want %.6f", tt.name, az, tt.az)
+		}
+	}
+}
+
+func TestRatesAcrossNorth(t *testing.T) {
+	// at the lower culmination in the midnight sun the azimuth passes through
+	// north, where it wraps from 360 to 0; the rate must not jump
+	midnight := Culminate(date(time.UTC, 2024, time.June, 20), 70, 0).Time.Add(-12 * time.Hour)
+	_, az := Rates(midnight, 70, 0)
+	if az <= 0 || az > 2*omega {
+		t.Errorf("azimuth rate at the lower culmination = %v, want small and positive", az)
+	}
+}
+
+// mustEvent returns the time of an event that is known to happen
+func mustEvent(t time.Time, ok bool) time.Time {
+	if !ok {
+		panic("sun: no event")
+	}
+	return t
+}
@@ -0,0 +1,53 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Reflectance holds the solar terms of top of atmosphere reflectance,
+//
+//	ρ = π · L · d² / (ESUN · cos θs)
+//
+// where L is the radiance a sensor measured, ESUN the mean solar irradiance in
+// its band, d the Earth-Sun distance in astronomical units and θs the solar
+// zenith angle.
+type Reflectance struct {
+	CosZenith       float64
+	DistanceSquared float64 // d² in square astronomical units
+}
+
+// Factor returns d²/cos θs, so reflectance is π·L·Factor/ESUN. It is +Inf with
+// the Sun on or below the horizon, where reflectance has no meaning.
+func (r Reflectance) Factor() float64 {
+	if r.CosZenith <= 0 {
+		return math.Inf(1)
+	}
+	return r.DistanceSquared / r.CosZenith
+}
+
+// ReflectanceAt returns the reflectance terms at time t and the given location.
+func ReflectanceAt(t time.Time, latitude float64, longitude float64) Reflectance {
+	d := EarthSunDistance(t)
+	return Reflectance{angleSin(Altitude(t, latitude, longitude)), d * d}
+}
+
+// ReflectanceGrid returns the reflectance terms at time t for every point of
+// the grid formed by latitudes and longitudes, in row order as for
+// AltitudeGrid, appended to out[:0]. The distance is computed once for the
+// whole scene.
+func ReflectanceGrid(t time.Time, latitudes []float64, longitudes []float64, out []Reflectance) []Reflectance {
+	d := EarthSunDistance(t)
+	out = out[:0]
+	for _, alt := range AltitudeGrid(t, latitudes, longitudes, nil) {
+		out = append(out, Reflectance{angleSin(alt), d * d})
+	}
+	return out
+}
+
+// ReflectanceBox returns ReflectanceGrid at the centres of a grid of rows by
+// cols cells covering box, laid out as for BoxAngles.
+func ReflectanceBox(t time.Time, box BoundingBox, rows int, cols int, out []Reflectance) []Reflectance {
+	lats, lons := boxCentres(box, rows, cols)
+	return ReflectanceGrid(t, lats, lons, out)
+}
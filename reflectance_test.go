@@ -0,0 +1,75 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At perihelion, d = 0.983307 AU and d² = 0.966893; at aphelion, d = 1.016725
+// AU and d² = 1.033730. Directly under the Sun cos θs is one, so the factor is
+// d² itself. With the Sun 30 degrees up, cos θs is a half and the factor twice
+// d².
+func TestReflectanceFactor(t *testing.T) {
+	for _, tt := range []struct {
+		r    Reflectance
+		want float64
+	}{
+		{Reflectance{1, 1}, 1},
+		{Reflectance{0.5, 1}, 2},
+		{Reflectance{0.5, 0.966893}, 1.933786},
+		{Reflectance{0, 1}, math.Inf(1)},
+		{Reflectance{-0.2, 1}, math.Inf(1)},
+	} {
+		if got := tt.r.Factor(); !(got == tt.want || math.Abs(got-tt.want) < 1e-9) {
+			t.Errorf("%v.Factor() = %v, want %v", tt.r, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(2024, time.January, 3, 0, 38, 0, 0, time.UTC), 0.966893},
+		{time.Date(2024, time.July, 5, 5, 6, 0, 0, time.UTC), 1.033730},
+	} {
+		lat, lon := SubsolarPoint(tt.t)
+		r := ReflectanceAt(tt.t, lat, lon)
+		if math.Abs(r.CosZenith-1) > 1e-6 || math.Abs(r.DistanceSquared-tt.want) > 2e-4 || math.Abs(r.Factor()-tt.want) > 2e-4 {
+			t.Errorf("ReflectanceAt the subsolar point on %v = %+v, want factor %v", tt.t.Format("Jan 2"), r, tt.want)
+		}
+	}
+}
+
+func TestReflectanceGrid(t *testing.T) {
+	at := time.Date(2024, time.June, 21, 10, 55, 0, 0, time.UTC)
+	lats := []float64{52, 51}
+	lons := []float64{-1, 0, 1}
+	out := ReflectanceGrid(at, lats, lons, make([]Reflectance, 4))
+	if len(out) != 6 {
+		t.Fatalf("%d terms, want 6", len(out))
+	}
+	for i, lat := range lats {
+		for j, lon := range lons {
+			want := ReflectanceAt(at, lat, lon)
+			if got := out[i*len(lons)+j]; math.Abs(got.CosZenith-want.CosZenith) > 1e-9 || got.DistanceSquared != want.DistanceSquared {
+				t.Errorf("at %v, %v = %+v, want %+v", lat, lon, got, want)
+			}
+		}
+	}
+	// the cosine of the zenith angle is the sine of the altitude
+	a := SceneAngles(at, lats, lons, nil)
+	for i := range a {
+		if c := math.Cos(a[i].Zenith * math.Pi / 180); math.Abs(out[i].CosZenith-c) > 1e-9 {
+			t.Errorf("cell %d: cos θs = %v, want %v", i, out[i].CosZenith, c)
+		}
+	}
+
+	box := ReflectanceBox(at, BoundingBox{51, -1.5, 52, 1.5}, 2, 3, nil)
+	grid := ReflectanceGrid(at, []float64{51.75, 51.25}, []float64{-1, 0, 1}, nil)
+	for i := range grid {
+		if math.Abs(box[i].CosZenith-grid[i].CosZenith) > 1e-9 {
+			t.Errorf("ReflectanceBox cell %d = %+v, want %+v", i, box[i], grid[i])
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package sun
+
+// Refraction returns the atmospheric refraction, in degrees, for a body seen
+// at the given apparent altitude, using Bennett's formula for standard
+// pressure and temperature. The geometric altitude is the apparent altitude
+// less the refraction. Near the horizon real refraction varies by several
+// arcminutes with the weather.
+func Refraction(apparent float64) float64 {
+	if apparent < -1 {
+		apparent = -1
+	}
+	return 1 / angleTan(apparent+7.31/(apparent+4.4)) / 60
+}
+
+// sunSemiDiameter is the mean angular radius of the Sun in degrees
+const sunSemiDiameter = 0.2666
@@ -0,0 +1,169 @@
+package sun
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"time"
+)
+
+var (
+	chartBackground = color.RGBA{255, 255, 255, 255}
+	chartGrid       = color.RGBA{215, 215, 215, 255}
+	chartHorizon    = color.RGBA{120, 120, 120, 255}
+	chartSummer     = color.RGBA{220, 60, 30, 255}
+	chartWinter     = color.RGBA{40, 80, 200, 255}
+	chartOther      = color.RGBA{240, 160, 40, 255}
+)
+
+// SunPathImage draws a sun-path diagram for the given year and location:
+// altitude against azimuth for the 21st of every month, from the June
+// solstice in red through orange to the December solstice in blue. Azimuth
+// runs across the image centred on south in the northern hemisphere and on
+// north in the southern, with grid lines every 30 degrees of azimuth and 10 of
+// altitude.
+func SunPathImage(year int, latitude float64, longitude float64, width int, height int) image.Image {
+	centre := 180.0
+	if latitude < 0 {
+		centre = 0
+	}
+	p := newPlot(width, height, -180, 180, 0, 90)
+	p.grid(30, 10)
+	for m := time.January; m <= time.December; m++ {
+		c := chartOther
+		switch m {
+		case time.June:
+			c = chartSummer
+		case time.December:
+			c = chartWinter
+		}
+		day := time.Date(year, m, 21, 0, 0, 0, 0, time.UTC)
+		noon := Culminate(day, latitude, longitude).Time
+		var px, py float64
+		have := false
+		for t := noon.Add(-12 * time.Hour); !t.After(noon.Add(12 * time.Hour)); t = t.Add(5 * time.Minute) {
+			alt := Altitude(t, latitude, longitude)
+			x := between(-180, 180, Azimuth(t, latitude, longitude)-centre)
+			if alt >= 0 && have && math.Abs(x-px) < 90 {
+				p.line(px, py, x, alt, c)
+			}
+			px, py, have = x, alt, alt >= 0
+		}
+	}
+	return p.img
+}
+
+// AnalemmaImage draws the analemma: the position of the Sun at the same clock
+// time, given as a duration after midnight in loc, on every day of the year,
+// as a dot at its azimuth and altitude. The image spans the azimuths and
+// altitudes the figure eight covers, with a margin.
+func AnalemmaImage(year int, loc *time.Location, clock time.Duration, latitude float64, longitude float64, width int, height int) image.Image {
+	var az, alt []float64
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		t := d.Add(clock)
+		alt = append(alt, Altitude(t, latitude, longitude))
+		az = append(az, Azimuth(t, latitude, longitude))
+	}
+	// unwrap azimuth around the first day so the figure is not split at north
+	for i := range az {
+		az[i] = az[0] + between(-180, 180, az[i]-az[0])
+	}
+	minAz, maxAz := minMax(az)
+	minAlt, maxAlt := minMax(alt)
+	mx, my := (maxAz-minAz)*0.1+1, (maxAlt-minAlt)*0.1+1
+	p := newPlot(width, height, minAz-mx, maxAz+mx, minAlt-my, maxAlt+my)
+	p.grid(5, 5)
+	if minAlt-my < 0 && maxAlt+my > 0 {
+		p.line(minAz-mx, 0, maxAz+mx, 0, chartHorizon)
+	}
+	for i := range az {
+		c := lerpColor(chartWinter, chartSummer, (1-angleCos(float64(i)*360/float64(len(az))))/2)
+		p.dot(az[i], alt[i], c)
+	}
+	return p.img
+}
+
+func minMax(v []float64) (lo float64, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, x := range v {
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	return lo, hi
+}
+
+// plot maps a rectangle of data coordinates onto an image, with y upwards
+type plot struct {
+	img                    *image.RGBA
+	minX, maxX, minY, maxY float64
+}
+
+func newPlot(width int, height int, minX float64, maxX float64, minY float64, maxY float64) *plot {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(chartBackground), image.Point{}, draw.Src)
+	return &plot{img, minX, maxX, minY, maxY}
+}
+
+func (p *plot) pixel(x float64, y float64) (int, int) {
+	w, h := p.img.Bounds().Dx()-1, p.img.Bounds().Dy()-1
+	px := (x - p.minX) / (p.maxX - p.minX) * float64(w)
+	py := (p.maxY - y) / (p.maxY - p.minY) * float64(h)
+	return int(math.Round(px)), int(math.Round(py))
+}
+
+// grid draws lines at multiples of dx and dy
+func (p *plot) grid(dx float64, dy float64) {
+	for x := math.Ceil(p.minX/dx) * dx; x <= p.maxX; x += dx {
+		p.line(x, p.minY, x, p.maxY, chartGrid)
+	}
+	for y := math.Ceil(p.minY/dy) * dy; y <= p.maxY; y += dy {
+		p.line(p.minX, y, p.maxX, y, chartGrid)
+	}
+}
+
+// line draws a line with Bresenham's algorithm
+func (p *plot) line(x0 float64, y0 float64, x1 float64, y1 float64, c color.Color) {
+	ax, ay := p.pixel(x0, y0)
+	bx, by := p.pixel(x1, y1)
+	dx, dy := abs(bx-ax), -abs(by-ay)
+	sx, sy := 1, 1
+	if ax > bx {
+		sx = -1
+	}
+	if ay > by {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		p.img.Set(ax, ay, c)
+		if ax == bx && ay == by {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			ax += sx
+		}
+		if e2 <= dx {
+			e += dx
+			ay += sy
+		}
+	}
+}
+
+// dot draws a 3×3 pixel dot
+func (p *plot) dot(x float64, y float64, c color.Color) {
+	px, py := p.pixel(x, y)
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			p.img.Set(px+i, py+j, c)
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,122 @@
+package sun
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+	"time"
+)
+
+// column returns the rows of column x of img that have colour c
+func column(img image.Image, x int, c color.RGBA) []int {
+	var ys []int
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		if color.RGBAModel.Convert(img.At(x, y)) == c {
+			ys = append(ys, y)
+		}
+	}
+	return ys
+}
+
+// near reports whether any of ys is within d of y
+func near(ys []int, y int, d int) bool {
+	for _, v := range ys {
+		if abs(v-y) <= d {
+			return true
+		}
+	}
+	return false
+}
+
+// With one pixel per degree, the centre column is the meridian and the row is
+// 90 less the altitude, so each solstice curve crosses the centre at its noon
+// altitude: 61.9 and 15.1 degrees in London, 79.6 in Sydney at midsummer in
+// December, seen facing north.
+func TestSunPathImage(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		lat, lon float64
+		c        color.RGBA
+		altitude float64
+	}{
+		{"London in June", 51.5074, -0.1278, chartSummer, 61.9},
+		{"London in December", 51.5074, -0.1278, chartWinter, 15.1},
+		{"Sydney in December", -33.8688, 151.2093, chartWinter, 79.6},
+	} {
+		img := SunPathImage(2024, tt.lat, tt.lon, 361, 91)
+		if b := img.Bounds(); b.Dx() != 361 || b.Dy() != 91 {
+			t.Fatalf("%s: image is %v, want 361×91", tt.name, b)
+		}
+		if ys := column(img, 180, tt.c); !near(ys, int(math.Round(90-tt.altitude)), 1) {
+			t.Errorf("%s: curve crosses the centre at rows %v, want %v", tt.name, ys, math.Round(90-tt.altitude))
+		}
+	}
+	img := SunPathImage(2024, 51.5074, -0.1278, 361, 91)
+	if c := color.RGBAModel.Convert(img.At(30, 5)); c != chartGrid {
+		t.Errorf("pixel on the 30 degree grid line = %v, want %v", c, chartGrid)
+	}
+	if c := color.RGBAModel.Convert(img.At(15, 5)); c != chartBackground {
+		t.Errorf("pixel off the grid = %v, want %v", c, chartBackground)
+	}
+}
+
+func TestAnalemmaImage(t *testing.T) {
+	img := AnalemmaImage(2024, time.UTC, 12*time.Hour, 51.48, 0, 200, 400)
+	if b := img.Bounds(); b.Dx() != 200 || b.Dy() != 400 {
+		t.Fatalf("image is %v, want 200×400", b)
+	}
+	// the figure runs from the winter to the summer solstice altitudes, 15.1
+	// to 62 degrees, and is drawn inside a tenth of a margin plus a degree
+	top, bottom := img.Bounds().Max.Y, -1
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			if c != chartBackground && c != chartGrid && c != chartHorizon {
+				if y < top {
+					top = y
+				}
+				bottom = y
+			}
+		}
+	}
+	span := 62.0 - 15.1
+	margin := span*0.1 + 1
+	scale := 399 / (span + 2*margin)
+	if want := margin * scale; math.Abs(float64(top)-want) > 3 {
+		t.Errorf("figure starts at row %d, want about %.0f", top, want)
+	}
+	if want := 399 - margin*scale; math.Abs(float64(bottom)-want) > 3 {
+		t.Errorf("figure ends at row %d, want about %.0f", bottom, want)
+	}
+}
+
+func TestPlot(t *testing.T) {
+	p := newPlot(11, 21, 0, 10, -10, 10)
+	for _, tt := range []struct {
+		x, y   float64
+		px, py int
+	}{
+		{0, 10, 0, 0},
+		{10, -10, 10, 20},
+		{5, 0, 5, 10},
+		{2.4, 2.6, 2, 7},
+	} {
+		if px, py := p.pixel(tt.x, tt.y); px != tt.px || py != tt.py {
+			t.Errorf("pixel(%v, %v) = %d, %d, want %d, %d", tt.x, tt.y, px, py, tt.px, tt.py)
+		}
+	}
+	red := color.RGBA{255, 0, 0, 255}
+	p.line(0, 10, 10, -10, red)
+	for _, pt := range [][2]int{{0, 0}, {5, 10}, {10, 20}} {
+		if c := p.img.RGBAAt(pt[0], pt[1]); c != red {
+			t.Errorf("line misses (%d, %d)", pt[0], pt[1])
+		}
+	}
+	if c := p.img.RGBAAt(10, 0); c != chartBackground {
+		t.Errorf("background = %v, want %v", c, chartBackground)
+	}
+	if lo, hi := minMax([]float64{3, -1, 7, 2}); lo != -1 || hi != 7 {
+		t.Errorf("minMax = %v, %v, want -1, 7", lo, hi)
+	}
+}
@@ -0,0 +1,61 @@
+package sun
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Scenario is an observer and a time to test or demonstrate with.
+type Scenario struct {
+	Point
+	Time time.Time // in UTC
+}
+
+// populationBands is the approximate share of the world's people living in
+// each 10 degree band of latitude, from 60 south to 80 north
+var populationBands = [...]float64{0.1, 0.3, 2, 4, 4.5, 6.5, 9.5, 12.5, 19.5, 23, 11, 6.5, 0.5, 0.05}
+
+// ScenarioGenerator produces random scenarios from a seed, so the same seed
+// always gives the same sequence and a failing case can be replayed. Most
+// latitudes are drawn in proportion to where people live, and a tenth evenly
+// from pole to pole, which brings in the polar day and night and the poles
+// themselves. Times are spread evenly from start to end.
+//
+// A ScenarioGenerator is not safe for concurrent use.
+type ScenarioGenerator struct {
+	rng   *rand.Rand
+	start time.Time
+	span  time.Duration
+}
+
+// NewScenarioGenerator returns a generator seeded with seed for times from
+// start up to end. An end that is not after start is an error.
+func NewScenarioGenerator(seed int64, start time.Time, end time.Time) (*ScenarioGenerator, error) {
+	if !end.After(start) {
+		return nil, errEmptySpan
+	}
+	return &ScenarioGenerator{rand.New(rand.NewSource(seed)), start.UTC(), end.Sub(start)}, nil
+}
+
+// Next returns the next scenario.
+func (g *ScenarioGenerator) Next() Scenario {
+	var s Scenario
+	if g.rng.Float64() < 0.1 {
+		s.Latitude = g.rng.Float64()*180 - 90
+	} else {
+		var total float64
+		for _, w := range populationBands {
+			total += w
+		}
+		x := g.rng.Float64() * total
+		band := 0
+		for band < len(populationBands)-1 && x >= populationBands[band] {
+			x -= populationBands[band]
+			band++
+		}
+		s.Latitude = -60 + 10*float64(band) + g.rng.Float64()*10
+	}
+	s.Longitude = g.rng.Float64()*360 - 180
+	s.Time = g.start.Add(time.Duration(g.rng.Int63n(int64(g.span))))
+	return s
+}
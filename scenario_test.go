@@ -0,0 +1,45 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScenarioGenerator(t *testing.T) {
+	start := time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC)
+	a, err := NewScenarioGenerator(7, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewScenarioGenerator(7, start, end)
+	polar := 0
+	for i := 0; i < 2000; i++ {
+		s, s2 := a.Next(), b.Next()
+		if s != s2 {
+			t.Fatalf("case %d differs with the same seed: %+v, %+v", i, s, s2)
+		}
+		if s.Latitude < -90 || s.Latitude > 90 || s.Longitude < -180 || s.Longitude >= 180 {
+			t.Errorf("case %d off the globe: %+v", i, s.Point)
+		}
+		if s.Time.Before(start) || !s.Time.Before(end) {
+			t.Errorf("case %d at %v, outside the span", i, s.Time)
+		}
+		if s.Latitude > 66.56 || s.Latitude < -66.56 {
+			polar++
+		}
+	}
+	// a tenth of latitudes are drawn evenly, of which a quarter are polar
+	if polar < 20 || polar > 120 {
+		t.Errorf("%d polar cases in 2000, want about 50", polar)
+	}
+}
+
+func TestNewScenarioGeneratorSpan(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, end := range []time.Time{at, at.Add(-time.Hour)} {
+		if _, err := NewScenarioGenerator(1, at, end); err != errEmptySpan {
+			t.Errorf("end %v: err = %v, want %v", end, err, errEmptySpan)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package sun
+
+import "time"
+
+// searchDays is how far ahead the calendar searches look before giving up. A
+// full year plus a day covers every value a location will ever see.
+const searchDays = 367
+
+// NextDayLengthAtLeast returns the first date, starting with the date of after,
+// on which the day length at the given location is at least d. The result is
+// midnight at the start of that date in the time zone of after.
+//
+// ok is false if no such day occurs within a year, as for a 20 hour day at the
+// equator.
+func NextDayLengthAtLeast(after time.Time, latitude float64, longitude float64, d time.Duration) (date time.Time, ok bool) {
+	y, m, day := after.Date()
+	for i := 0; i < searchDays; i++ {
+		date = time.Date(y, m, day+i, 0, 0, 0, 0, after.Location())
+		if DayLength(date, latitude, longitude) >= d {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextSunsetAfterClockTime returns the first sunset later than after which
+// happens at or after hour:minute on the clock in the time zone of after.
+// Daylight saving changes are allowed for, as the clock time is read in that
+// time zone on each date.
+//
+// ok is false if no such sunset occurs within a year.
+func NextSunsetAfterClockTime(after time.Time, latitude float64, longitude float64, hour int, minute int) (sunset time.Time, ok bool) {
+	y, m, day := after.Date()
+	for i := 0; i < searchDays; i++ {
+		date := time.Date(y, m, day+i, 0, 0, 0, 0, after.Location())
+		set, ok := Sunset(date, latitude, longitude)
+		if !ok || !set.After(after) {
+			continue
+		}
+		clock := time.Date(y, m, day+i, hour, minute, 0, 0, after.Location())
+		if !set.Before(clock) {
+			return set, true
+		}
+	}
+	return time.Time{}, false
+}
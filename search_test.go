@@ -0,0 +1,72 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// London reaches 16 hours of daylight on 23 May, and 8 hours again on 5
+// January after the winter solstice; sunset first comes at 18:00 GMT on 12
+// March, and at 21:00 BST on 25 May.
+func TestNextDayLengthAtLeast(t *testing.T) {
+	loc := location(t, "Europe/London")
+	start := date(loc, 2024, time.January, 1)
+	for _, tt := range []struct {
+		d    time.Duration
+		want time.Time
+		ok   bool
+	}{
+		{16 * time.Hour, date(loc, 2024, time.May, 23), true},
+		{8 * time.Hour, date(loc, 2024, time.January, 5), true},
+		{7 * time.Hour, start, true},
+		{17 * time.Hour, time.Time{}, false},
+	} {
+		got, ok := NextDayLengthAtLeast(start, 51.5074, -0.1278, tt.d)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("NextDayLengthAtLeast(%v) = %v, %v, want %v, %v", tt.d, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.Location() != loc {
+			t.Errorf("NextDayLengthAtLeast(%v) is in %v, want %v", tt.d, got.Location(), loc)
+		}
+	}
+	if _, ok := NextDayLengthAtLeast(start, 0, 0, 20*time.Hour); ok {
+		t.Error("NextDayLengthAtLeast found a 20 hour day at the equator")
+	}
+}
+
+func TestNextSunsetAfterClockTime(t *testing.T) {
+	loc := location(t, "Europe/London")
+	start := date(loc, 2024, time.January, 1)
+	for _, tt := range []struct {
+		hour int
+		want time.Time
+	}{
+		{18, date(loc, 2024, time.March, 12)},
+		{21, date(loc, 2024, time.May, 25)},
+	} {
+		got, ok := NextSunsetAfterClockTime(start, 51.5074, -0.1278, tt.hour, 0)
+		if !ok {
+			t.Fatalf("NextSunsetAfterClockTime(%d:00): no sunset", tt.hour)
+		}
+		if y, m, d := got.Date(); y != tt.want.Year() || m != tt.want.Month() || d != tt.want.Day() {
+			t.Errorf("NextSunsetAfterClockTime(%d:00) = %v, want on %s", tt.hour, got, tt.want.Format("2006-01-02"))
+		}
+		if got.Before(clock(got, tt.hour, 0)) {
+			t.Errorf("NextSunsetAfterClockTime(%d:00) = %v, before the clock time", tt.hour, got)
+		}
+		prev, _ := Sunset(got.AddDate(0, 0, -1), 51.5074, -0.1278)
+		if !prev.Before(clock(prev, tt.hour, 0)) {
+			t.Errorf("sunset the day before %v was already at %v", got, prev)
+		}
+	}
+
+	// a sunset earlier on the day of after is passed over
+	after := time.Date(2024, time.June, 21, 22, 0, 0, 0, loc)
+	got, ok := NextSunsetAfterClockTime(after, 51.5074, -0.1278, 0, 0)
+	if !ok || got.Day() != 22 {
+		t.Errorf("NextSunsetAfterClockTime after sunset = %v, %v, want on the next day", got, ok)
+	}
+	if _, ok := NextSunsetAfterClockTime(start, 0, 0, 20, 0); ok {
+		t.Error("NextSunsetAfterClockTime found a sunset after 20:00 at the equator")
+	}
+}
@@ -0,0 +1,72 @@
+package sun
+
+import (
+	"sort"
+	"time"
+)
+
+// AltitudeSegment is a stretch of time over which the altitude of the Sun only
+// rises or only falls.
+type AltitudeSegment struct {
+	Start, End                 time.Time
+	StartAltitude, EndAltitude float64
+	Rising                     bool
+}
+
+// AltitudeSegments splits the altitude curve over the date of t, from midnight
+// to midnight in the time zone of t, into monotonic segments at the upper
+// and lower culminations. A typical day gives three: falling from midnight to
+// the lower culmination, rising to noon and falling to the next midnight,
+// though the first is often only minutes long. Within a segment a search for
+// an altitude by bisection is safe, and a curve drawn through its endpoints and
+// a few points between has no false wiggles.
+func AltitudeSegments(t time.Time, latitude float64, longitude float64) []AltitudeSegment {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := start.AddDate(0, 0, 1)
+	noon := meanNoon(t, longitude)
+	cuts := []time.Time{start, end}
+	for _, c := range []time.Time{
+		Culminate(t, latitude, longitude).Time,
+		lowerCulmination(noon.Add(-12*time.Hour), latitude, longitude),
+		lowerCulmination(noon.Add(12*time.Hour), latitude, longitude),
+	} {
+		if c.After(start) && c.Before(end) {
+			cuts = append(cuts, c)
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	var segs []AltitudeSegment
+	for i := 1; i < len(cuts); i++ {
+		a, b := Altitude(cuts[i-1], latitude, longitude), Altitude(cuts[i], latitude, longitude)
+		segs = append(segs, AltitudeSegment{cuts[i-1].In(t.Location()), cuts[i].In(t.Location()), a, b, b > a})
+	}
+	return segs
+}
+
+// TimeAt returns the time within the segment that the Sun is at altitude alt,
+// to within a second, and false if the segment does not reach it.
+func (s AltitudeSegment) TimeAt(alt float64, latitude float64, longitude float64) (time.Time, bool) {
+	fa, fb := s.StartAltitude-alt, s.EndAltitude-alt
+	if fa == 0 {
+		return s.Start, true
+	}
+	if fa*fb > 0 {
+		return time.Time{}, false
+	}
+	f := func(x float64) float64 {
+		return Altitude(s.Start.Add(secondsToDuration(x)), latitude, longitude) - alt
+	}
+	x := brent(f, 0, s.End.Sub(s.Start).Seconds(), fa, fb, defaultTolerance)
+	return s.Start.Add(secondsToDuration(x)), true
+}
+
+// lowerCulmination returns the time the Sun is lowest within an hour of mean
+// midnight, given in UTC
+func lowerCulmination(midnight time.Time, latitude float64, longitude float64) time.Time {
+	alt := func(s float64) float64 {
+		return -Altitude(midnight.Add(secondsToDuration(s)), latitude, longitude)
+	}
+	return midnight.Add(secondsToDuration(goldenMax(alt, -3600, 3600, defaultTolerance)))
+}
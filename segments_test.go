@@ -0,0 +1,83 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At London on the June solstice the Sun culminates at 12:02.3 UT, 61.93
+// degrees up, and is lowest twelve hours either side, at φ + δ - 90 = -15.05
+// degrees. At Sydney on the December one the transit is at 01:53 UT and the
+// lower culmination at 13:53 UT, 90 - 33.87 - 23.44 = 32.69 degrees below the
+// horizon.
+func TestAltitudeSegments(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		d        time.Time
+		lat, lon float64
+		cuts     [][2]int
+		rising   []bool
+		alts     []float64
+	}{
+		{"London", date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278,
+			[][2]int{{0, 0}, {0, 2}, {12, 2}, {24, 0}}, []bool{false, true, false}, []float64{-15.05, 61.93}},
+		{"Sydney", date(time.UTC, 2024, time.December, 21), -33.8688, 151.2093,
+			[][2]int{{0, 0}, {1, 53}, {13, 53}, {24, 0}}, []bool{true, false, true}, []float64{79.57, -32.69}},
+	} {
+		segs := AltitudeSegments(tt.d, tt.lat, tt.lon)
+		if len(segs) != len(tt.rising) {
+			t.Fatalf("%s: %d segments, want %d", tt.name, len(segs), len(tt.rising))
+		}
+		for i, s := range segs {
+			start, end := clock(tt.d, tt.cuts[i][0], tt.cuts[i][1]), clock(tt.d, tt.cuts[i+1][0], tt.cuts[i+1][1])
+			if !within(s.Start, start, time.Minute) || !within(s.End, end, time.Minute) || s.Rising != tt.rising[i] {
+				t.Errorf("%s: segment %d = %v to %v rising %v, want %v to %v rising %v", tt.name, i, s.Start, s.End, s.Rising, start, end, tt.rising[i])
+			}
+			if i > 0 {
+				if !s.Start.Equal(segs[i-1].End) {
+					t.Errorf("%s: segment %d starts at %v, not at %v", tt.name, i, s.Start, segs[i-1].End)
+				}
+				if a := tt.alts[i-1]; math.Abs(s.StartAltitude-a) > 0.02 {
+					t.Errorf("%s: segment %d starts at %.3f degrees, want %v", tt.name, i, s.StartAltitude, a)
+				}
+			}
+		}
+	}
+
+	// in BST the day runs from 23:00 UT, so the cuts fall an hour later
+	bst := location(t, "Europe/London")
+	segs := AltitudeSegments(date(bst, 2024, time.June, 21), 51.5074, -0.1278)
+	if len(segs) != 3 || segs[0].Start.Location() != bst || segs[1].Start.Hour() != 1 || segs[2].Start.Hour() != 13 {
+		t.Errorf("AltitudeSegments in BST = %v", segs)
+	}
+}
+
+// The Sun reaches 30 degrees at London on the June solstice at 07:19 and 16:45
+// UT, from the hour angle as in the band tests.
+func TestAltitudeSegmentTimeAt(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	segs := AltitudeSegments(d, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		seg  int
+		alt  float64
+		want time.Time
+		ok   bool
+	}{
+		{1, 30, clock(d, 7, 19), true},
+		{2, 30, clock(d, 16, 45), true},
+		{1, -0.833, clock(d, 3, 43), true},
+		{0, 30, time.Time{}, false},
+		{1, 70, time.Time{}, false},
+	} {
+		got, ok := segs[tt.seg].TimeAt(tt.alt, 51.5074, -0.1278)
+		if ok != tt.ok || (ok && !within(got, tt.want, time.Minute)) {
+			t.Errorf("segment %d TimeAt(%v) = %v, %v, want %v, %v", tt.seg, tt.alt, got, ok, tt.want, tt.ok)
+		}
+	}
+	// the start altitude is found at the start
+	s := segs[1]
+	if got, ok := s.TimeAt(s.StartAltitude, 51.5074, -0.1278); !ok || !got.Equal(s.Start) {
+		t.Errorf("TimeAt the start altitude = %v, %v, want %v", got, ok, s.Start)
+	}
+}
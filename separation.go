@@ -0,0 +1,52 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// AngularSeparation returns the angle in degrees between two bodies as seen
+// from the centre of the Earth, from their right ascensions and declinations.
+// Positions from MoonPosition, an Ephemeris or (*JPL).Planet can be mixed.
+func AngularSeparation(a Coords, b Coords) float64 {
+	return angularDistance(a.Declination, a.RightAscension, b.Declination, b.RightAscension)
+}
+
+// Appulse is a moment two bodies are closest together in the sky.
+type Appulse struct {
+	Time       time.Time
+	Separation float64 // degrees
+}
+
+// Appulses returns the times between start and end when bodies a and b, given
+// as functions of time such as MoonPosition or LowPrecision{}.Sun, pass closest
+// to each other, keeping those closer than maxSeparation degrees: the
+// conjunctions, or the evenings with the Moon near Venus for an observing
+// alert. Times are in the time zone of start and to within a second.
+// Separations are geocentric; the Moon can appear a degree away from there as
+// seen from the surface.
+//
+// The separation is sampled every ten minutes, which suits the Moon and the
+// planets, whose closest approaches are hours or weeks apart.
+func Appulses(start time.Time, end time.Time, a func(time.Time) Coords, b func(time.Time) Coords, maxSeparation float64) []Appulse {
+	sep := func(s float64) float64 {
+		t := start.Add(secondsToDuration(s))
+		return AngularSeparation(a(t), b(t))
+	}
+	// the separation is least where its rate of change turns from falling to
+	// rising
+	rate := func(s float64) float64 {
+		return sep(s+30) - sep(s-30)
+	}
+	var as []Appulse
+	for _, s := range findRoots(rate, 0, end.Sub(start).Seconds(), defaultTolerance, math.Inf(1)) {
+		if rate(s+scanStep/2) < 0 {
+			continue
+		}
+		s = goldenMax(func(x float64) float64 { return -sep(x) }, s-scanStep, s+scanStep, defaultTolerance)
+		if v := sep(s); v <= maxSeparation && s >= 0 && s <= end.Sub(start).Seconds() {
+			as = append(as, Appulse{start.Add(secondsToDuration(s)), v})
+		}
+	}
+	return as
+}
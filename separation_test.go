@@ -0,0 +1,65 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Meeus, example 17.a: Arcturus and Spica are 32.7930 degrees apart.
+func TestAngularSeparation(t *testing.T) {
+	arcturus := Coords{RightAscension: 213.9154, Declination: 19.1825}
+	spica := Coords{RightAscension: 201.2983, Declination: -11.1614}
+	for _, tt := range []struct {
+		a, b Coords
+		want float64
+	}{
+		{arcturus, spica, 32.7930},
+		{spica, arcturus, 32.7930},
+		{arcturus, arcturus, 0},
+		{Coords{RightAscension: 0, Declination: 90}, Coords{RightAscension: 123, Declination: -90}, 180},
+		{Coords{RightAscension: 359.5, Declination: 0}, Coords{RightAscension: 0.5, Declination: 0}, 1},
+	} {
+		if got := AngularSeparation(tt.a, tt.b); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("AngularSeparation(%v, %v) = %.4f, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// Greatest eclipse on 8 April 2024 was at 18:16 UT with γ = 0.3431: the shadow
+// axis passed that many Earth radii from the centre of the Earth, which with
+// the Moon 359790 km away, at a horizontal parallax of 1.016 degrees, puts
+// the centres 0.348 degrees apart as seen from there.
+func TestAppulses(t *testing.T) {
+	start := date(time.UTC, 2024, time.April, 1)
+	as := Appulses(start, start.AddDate(0, 1, 0), MoonPosition, LowPrecision{}.Sun, 5)
+	if len(as) != 1 {
+		t.Fatalf("Appulses = %v, want one in April 2024", as)
+	}
+	if a := as[0]; !within(a.Time, time.Date(2024, time.April, 8, 18, 16, 0, 0, time.UTC), 2*time.Minute) || math.Abs(a.Separation-0.348) > 0.01 {
+		t.Errorf("Appulse = %v, want 0.348 degrees at 18:16", a)
+	}
+	if as := Appulses(start, start.AddDate(0, 1, 0), MoonPosition, LowPrecision{}.Sun, 0.3); len(as) != 0 {
+		t.Errorf("Appulses within 0.3 degrees = %v, want none", as)
+	}
+	// over a year the Moon passes the Sun at each of the thirteen new moons
+	// of 2024
+	start = date(time.UTC, 2024, time.January, 1)
+	if as := Appulses(start, start.AddDate(1, 0, 0), MoonPosition, LowPrecision{}.Sun, 6); len(as) != 13 {
+		t.Errorf("%d appulses in 2024, want 13", len(as))
+	}
+}
+
+// The fixture carries no planets, leaving them at the barycentre with the Sun,
+// so every planet appears where the Sun does.
+func TestJPLPlanet(t *testing.T) {
+	j := loadJPLFixture(t)
+	at := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	sun := j.Sun(at)
+	for _, p := range []Planet{Mercury, Venus, Mars, Jupiter, Pluto} {
+		c := j.Planet(p, at)
+		if d := AngularSeparation(c, sun); d > 1e-9 || math.Abs(c.Distance-sun.Distance) > 1e-12 {
+			t.Errorf("Planet(%d) = %v, want %v", p, c, sun)
+		}
+	}
+}
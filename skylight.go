@@ -0,0 +1,63 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Skylight is a roof window lying in the plane of a roof pitched Pitch degrees
+// from horizontal, whose slope faces Azimuth degrees clockwise from north. A
+// flat roof light has a pitch of zero.
+type Skylight struct {
+	Pitch   float64
+	Azimuth float64
+}
+
+// SkylightBeam is the direct sunlight coming through a skylight.
+type SkylightBeam struct {
+	// Incidence is the angle in degrees between the beam and the normal to
+	// the glazing: 0 when the Sun is square on and 90 when it grazes it.
+	// Glass passes less and less light beyond about 60 degrees.
+	Incidence float64
+	// Depression is the angle in degrees below horizontal at which the beam
+	// travels into the room, the altitude of the Sun, and Heading the
+	// direction it travels in, opposite the Sun's azimuth. Together they give
+	// the patch of sun on the floor and walls.
+	Depression float64
+	Heading    float64
+}
+
+// Beam returns the direct sunlight through the skylight at t, and false if the
+// Sun is down or behind the plane of the roof.
+func (s Skylight) Beam(t time.Time, latitude float64, longitude float64) (SkylightBeam, bool) {
+	alt, az := Altitude(t, latitude, longitude), Azimuth(t, latitude, longitude)
+	c := cosIncidence(alt, az, s.Pitch, s.Azimuth)
+	if alt <= 0 || c <= 0 {
+		return SkylightBeam{}, false
+	}
+	return SkylightBeam{
+		Incidence:  angleAcos(math.Min(c, 1)),
+		Depression: alt,
+		Heading:    between(0, 360, az+180),
+	}, true
+}
+
+// SunHours returns how long direct sun comes through the skylight on the date
+// of t, ignoring anything round it that casts a shadow.
+func (s Skylight) SunHours(t time.Time, latitude float64, longitude float64) time.Duration {
+	var sum time.Duration
+	for _, p := range FaceSun(t, latitude, longitude, s.Pitch, s.Azimuth, Horizon{}).Sunlit {
+		sum += p.Duration()
+	}
+	return sum
+}
+
+// AnnualSunHours returns the total of SunHours over every date of the year in
+// the time zone loc.
+func (s Skylight) AnnualSunHours(year int, loc *time.Location, latitude float64, longitude float64) time.Duration {
+	var sum time.Duration
+	for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		sum += s.SunHours(d, latitude, longitude)
+	}
+	return sum
+}
@@ -0,0 +1,63 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At a London noon on the June solstice the Sun is 61.93 degrees up due
+// south. The normal of a roof pitched p degrees points 90 - p degrees up, so
+// the beam meets glazing on a south slope of 30 at 61.93 - 60 = 1.93 degrees
+// from square on, on a north slope of 30 at 180 - 60 - 61.93 = 58.07 and on
+// a north slope of 60 at 88.07. In December the Sun is 15.06 up and behind
+// the north slope.
+func TestSkylightBeam(t *testing.T) {
+	june := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278).Time
+	december := Culminate(date(time.UTC, 2024, time.December, 21), 51.5074, -0.1278).Time
+	for _, tt := range []struct {
+		s         Skylight
+		t         time.Time
+		incidence float64
+		ok        bool
+	}{
+		{Skylight{30, 180}, june, 1.93, true},
+		{Skylight{0, 0}, june, 28.07, true},
+		{Skylight{30, 0}, june, 58.07, true},
+		{Skylight{60, 0}, june, 88.07, true},
+		{Skylight{60, 0}, december, 0, false},
+		{Skylight{0, 0}, june.Add(12 * time.Hour), 0, false},
+	} {
+		b, ok := tt.s.Beam(tt.t, 51.5074, -0.1278)
+		if ok != tt.ok || math.Abs(b.Incidence-tt.incidence) > 0.01 {
+			t.Errorf("%+v at %v: incidence %.2f, %v, want %v, %v", tt.s, tt.t, b.Incidence, ok, tt.incidence, tt.ok)
+		}
+		if ok && (math.Abs(b.Depression-61.93) > 0.01 || math.Abs(between(-180, 180, b.Heading)) > 0.01) {
+			t.Errorf("%+v: beam %+v, want 61.93 degrees down heading north", tt.s, b)
+		}
+	}
+}
+
+// As in TestFaceSun, flat glazing in London on the June solstice gets sun
+// for H = 123.03 degrees either side of noon, 16h24m25s, and glazing facing
+// the celestial equator for six hours either side, 12h00m07s. On the equator
+// the Sun's centre is up for twelve hours every day, 4392 hours in 2024.
+func TestSkylightSunHours(t *testing.T) {
+	june := date(time.UTC, 2024, time.June, 21)
+	for _, tt := range []struct {
+		s    Skylight
+		d    time.Time
+		want time.Duration
+	}{
+		{Skylight{0, 0}, june, 16*time.Hour + 24*time.Minute + 25*time.Second},
+		{Skylight{51.5074, 180}, june, 12*time.Hour + 7*time.Second},
+		{Skylight{38.4926, 0}, date(time.UTC, 2024, time.December, 21), 0},
+	} {
+		if got := tt.s.SunHours(tt.d, 51.5074, -0.1278); (got - tt.want).Abs() > time.Minute {
+			t.Errorf("%+v on %v: %v, want %v", tt.s, tt.d.Format("2 Jan"), got, tt.want)
+		}
+	}
+	if got := (Skylight{}).AnnualSunHours(2024, time.UTC, 0, 0); (got - 4392*time.Hour).Abs() > time.Hour {
+		t.Errorf("AnnualSunHours on the equator = %v, want 4392h", got)
+	}
+}
@@ -0,0 +1,39 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// SolarLongitude returns the apparent ecliptic longitude of the Sun at t in
+// degrees from 0 to 360, measured from the March equinox: 90 at the June
+// solstice, 180 at the September equinox and 270 at the December solstice. It
+// is from the solar model of Meeus, reckoned in dynamical time, and good to
+// about 0.005 degree, some ten minutes of the Sun's motion.
+func SolarLongitude(t time.Time) float64 {
+	return Meeus{}.Sun(t.Add(secondsToDuration(deltaT(t)))).EclipticLongitude
+}
+
+// SolarLongitudeCrossing returns the first time after after that SolarLongitude
+// reaches target degrees, to within a second, in the time zone of after. It is
+// the engine behind SolarTerms, SeasonalPoints and Ingresses, and finds any
+// other such moment, as SolarLongitudeCrossing(90, t) finds the next June
+// solstice.
+func SolarLongitudeCrossing(target float64, after time.Time) time.Time {
+	f := func(s float64) float64 {
+		return between(-180, 180, SolarLongitude(after.Add(secondsToDuration(s)))-target)
+	}
+	// the equation of centre swings the longitude 1.915 degrees either side
+	// of the mean, so the estimate at the mean rate of 0.9856 degree a day can
+	// be out by nearly four days
+	est := between(0, 360, target-SolarLongitude(after)) / 0.9856 * 86400
+	a, b := math.Max(est-5*86400, 0), est+5*86400
+	fa, fb := f(a), f(b)
+	for fa*fb > 0 {
+		a, fa = b, fb
+		b += 86400
+		fb = f(b)
+	}
+	s := brent(f, a, b, fa, fb, defaultTolerance)
+	return after.Add(secondsToDuration(s))
+}
@@ -0,0 +1,73 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Meeus example 25.a gives 199.90895 degrees at 1992 October 13.0 TD, which
+// is 58.96 seconds earlier in UT.
+func TestSolarLongitude(t *testing.T) {
+	at := time.Date(1992, time.October, 12, 23, 59, 1, 43e6, time.UTC)
+	if got := SolarLongitude(at); math.Abs(got-199.90895) > 1e-4 {
+		t.Errorf("SolarLongitude(%v) = %.6f, want 199.90895", at, got)
+	}
+	for _, at := range []time.Time{
+		time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 20, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 20, 4, 0, 0, 0, time.UTC),
+	} {
+		if got := SolarLongitude(at); got < 0 || got >= 360 {
+			t.Errorf("SolarLongitude(%v) = %v, out of range", at, got)
+		}
+	}
+}
+
+// The USNO gives the June solstices of 2024 and 2025 at 20:51 on 20 June and
+// 02:42 on 21 June UT, and the March equinox of 2024 at 03:06 on 20 March.
+func TestSolarLongitudeCrossing(t *testing.T) {
+	sydney := location(t, "Australia/Sydney")
+	for _, tt := range []struct {
+		target float64
+		after  time.Time
+		want   time.Time
+	}{
+		{90, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.June, 20, 20, 51, 0, 0, time.UTC)},
+		{90, time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC), time.Date(2025, time.June, 21, 2, 42, 0, 0, time.UTC)},
+		{0, time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC)},
+		{0, time.Date(2024, time.January, 1, 0, 0, 0, 0, sydney), time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC)},
+	} {
+		got := SolarLongitudeCrossing(tt.target, tt.after)
+		if !within(got, tt.want, 10*time.Minute) || got.Location() != tt.after.Location() {
+			t.Errorf("SolarLongitudeCrossing(%v, %v) = %v, want %v", tt.target, tt.after, got, tt.want)
+		}
+		if lon := between(-180, 180, SolarLongitude(got)-tt.target); math.Abs(lon) > 1e-4 {
+			t.Errorf("longitude at %v is %v off", got, lon)
+		}
+	}
+}
+
+// Every five degrees from every day of 2024 the crossing must land on the
+// target, after the start and within a year of it. From 5 October 2024 the
+// next 15 degrees is the spring of 2025, 4 April at about 13:00 UT.
+func TestSolarLongitudeCrossingSweep(t *testing.T) {
+	if testing.Short() {
+		t.Skip("sweeps a year")
+	}
+	after := time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)
+	if got, want := SolarLongitudeCrossing(15, after), time.Date(2025, time.April, 4, 13, 0, 0, 0, time.UTC); !within(got, want, time.Hour) {
+		t.Errorf("SolarLongitudeCrossing(15, %v) = %v, want %v", after, got, want)
+	}
+	for d := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == 2024; d = d.AddDate(0, 0, 1) {
+		for target := 0.0; target < 360; target += 5 {
+			got := SolarLongitudeCrossing(target, d)
+			if lon := between(-180, 180, SolarLongitude(got)-target); math.Abs(lon) > 1e-3 {
+				t.Errorf("SolarLongitudeCrossing(%v, %v) = %v, %v degree off", target, d.Format("2006-01-02"), got, lon)
+			}
+			if got.Before(d) || got.Sub(d) > 366*24*time.Hour {
+				t.Errorf("SolarLongitudeCrossing(%v, %v) = %v, not within the year after", target, d.Format("2006-01-02"), got)
+			}
+		}
+	}
+}
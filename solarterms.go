@@ -0,0 +1,86 @@
+package sun
+
+import "time"
+
+// SolarTerm is one of the 24 solar terms (jieqi) of the Chinese calendar, the
+// moments the apparent ecliptic longitude of the Sun reaches a multiple of 15
+// degrees.
+type SolarTerm struct {
+	Longitude float64 // degrees, 0 at the March equinox
+	Name      string  // in pinyin
+	English   string
+	Time      time.Time
+}
+
+// String returns the term as, for example, "Lichun (Start of Spring)
+// 2024-02-04 16:27 CST", with the time in its own zone.
+func (s SolarTerm) String() string {
+	return s.Name + " (" + s.English + ") " + s.Time.Format("2006-01-02 15:04 MST")
+}
+
+// solarTermNames are the names of the terms in order of longitude from 0
+var solarTermNames = [24][2]string{
+	{"Chunfen", "Spring Equinox"},
+	{"Qingming", "Clear and Bright"},
+	{"Guyu", "Grain Rain"},
+	{"Lixia", "Start of Summer"},
+	{"Xiaoman", "Grain Buds"},
+	{"Mangzhong", "Grain in Ear"},
+	{"Xiazhi", "Summer Solstice"},
+	{"Xiaoshu", "Minor Heat"},
+	{"Dashu", "Major Heat"},
+	{"Liqiu", "Start of Autumn"},
+	{"Chushu", "End of Heat"},
+	{"Bailu", "White Dew"},
+	{"Qiufen", "Autumn Equinox"},
+	{"Hanlu", "Cold Dew"},
+	{"Shuangjiang", "Frost's Descent"},
+	{"Lidong", "Start of Winter"},
+	{"Xiaoxue", "Minor Snow"},
+	{"Daxue", "Major Snow"},
+	{"Dongzhi", "Winter Solstice"},
+	{"Xiaohan", "Minor Cold"},
+	{"Dahan", "Major Cold"},
+	{"Lichun", "Start of Spring"},
+	{"Yushui", "Rain Water"},
+	{"Jingzhe", "Awakening of Insects"},
+}
+
+// SolarTerms returns the solar terms falling in the given year in loc, in time
+// order, with times in loc: time.UTC for the instants in UTC, or Asia/Shanghai
+// for the dates of the Chinese calendar. Times are from the solar model of
+// Meeus and good to about ten minutes, so a term within that of midnight may
+// fall on the wrong date.
+func SolarTerms(year int, loc *time.Location) []SolarTerm {
+	var ts []SolarTerm
+	for _, c := range longitudeCrossings(year, loc, 15) {
+		names := solarTermNames[int(c.longitude)/15]
+		ts = append(ts, SolarTerm{c.longitude, names[0], names[1], c.time})
+	}
+	return ts
+}
+
+// longitudeCrossing is a moment the Sun reaches a longitude
+type longitudeCrossing struct {
+	longitude float64
+	time      time.Time
+}
+
+// longitudeCrossings returns the times in the given year in loc that the
+// apparent longitude of the Sun reaches a multiple of step degrees, which must
+// divide 360, in time order and in loc
+func longitudeCrossings(year int, loc *time.Location, step float64) []longitudeCrossing {
+	var cs []longitudeCrossing
+	t := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	n := int(360 / step)
+	k := int(SolarLongitude(t)/step) + 1
+	for {
+		lon := float64(k%n) * step
+		t = SolarLongitudeCrossing(lon, t).In(loc)
+		if t.Year() != year {
+			return cs
+		}
+		cs = append(cs, longitudeCrossing{lon, t})
+		k++
+	}
+}
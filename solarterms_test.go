@@ -0,0 +1,60 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// The solar terms of 2024 in Beijing time, from the Hong Kong Observatory, to
+// the ten minutes SolarTerms promises.
+func TestSolarTerms(t *testing.T) {
+	loc := location(t, "Asia/Shanghai")
+	want := []struct {
+		name     string
+		month    time.Month
+		day      int
+		hour, mn int
+	}{
+		{"Xiaohan", time.January, 6, 4, 49},
+		{"Dahan", time.January, 20, 22, 7},
+		{"Lichun", time.February, 4, 16, 27},
+		{"Yushui", time.February, 19, 12, 13},
+		{"Jingzhe", time.March, 5, 10, 23},
+		{"Chunfen", time.March, 20, 11, 6},
+		{"Qingming", time.April, 4, 15, 2},
+		{"Guyu", time.April, 19, 21, 59},
+		{"Lixia", time.May, 5, 8, 10},
+		{"Xiaoman", time.May, 20, 20, 59},
+		{"Mangzhong", time.June, 5, 12, 10},
+		{"Xiazhi", time.June, 21, 4, 51},
+		{"Xiaoshu", time.July, 6, 22, 20},
+		{"Dashu", time.July, 22, 15, 44},
+		{"Liqiu", time.August, 7, 8, 9},
+		{"Chushu", time.August, 22, 22, 55},
+		{"Bailu", time.September, 7, 11, 11},
+		{"Qiufen", time.September, 22, 20, 44},
+		{"Hanlu", time.October, 8, 3, 0},
+		{"Shuangjiang", time.October, 23, 6, 15},
+		{"Lidong", time.November, 7, 6, 20},
+		{"Xiaoxue", time.November, 22, 3, 56},
+		{"Daxue", time.December, 6, 23, 17},
+		{"Dongzhi", time.December, 21, 17, 21},
+	}
+	got := SolarTerms(2024, loc)
+	if len(got) != len(want) {
+		t.Fatalf("%d terms, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		at := time.Date(2024, w.month, w.day, w.hour, w.mn, 0, 0, loc)
+		g := got[i]
+		if g.Name != w.name || !within(g.Time, at, 10*time.Minute) || g.Time.Location() != loc {
+			t.Errorf("term %d = %v, want %s at %v", i, g, w.name, at.Format("2006-01-02 15:04"))
+		}
+		if lon := float64((19+i)%24) * 15; g.Longitude != lon {
+			t.Errorf("%s at longitude %v, want %v", g.Name, g.Longitude, lon)
+		}
+	}
+	if s := got[2].String(); s != "Lichun (Start of Spring) 2024-02-04 16:20 CST" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,126 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// invPhi is the reciprocal of the golden ratio
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// defaultTolerance is the precision of event times, in seconds, unless an
+// EventOptions gives another
+const defaultTolerance = 1.0
+
+// EventOptions sets how its methods, which otherwise match the package
+// functions of the same names, find event times. The zero value gives what the
+// package functions do.
+type EventOptions struct {
+	// Tolerance is how precisely event times such as sunrise, sunset,
+	// culmination and crossings are found. Zero means a second. A finer
+	// tolerance costs a few more evaluations per event; coarser ones save
+	// little, as the root finders converge fast. Tolerances below a
+	// millisecond are raised to a millisecond, below which the solar model
+	// has no meaning.
+	Tolerance time.Duration
+}
+
+// tolerance returns the Tolerance of o in seconds
+func (o EventOptions) tolerance() float64 {
+	switch {
+	case o.Tolerance == 0:
+		return defaultTolerance
+	case o.Tolerance < time.Millisecond:
+		return time.Millisecond.Seconds()
+	}
+	return o.Tolerance.Seconds()
+}
+
+// goldenMax returns the x in [a, b] at which the unimodal function f is
+// largest, to within tol, using a golden-section search.
+func goldenMax(f func(float64) float64, a float64, b float64, tol float64) float64 {
+	c := b - invPhi*(b-a)
+	d := a + invPhi*(b-a)
+	fc, fd := f(c), f(d)
+	for b-a > tol {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
+
+// scanStep is the interval, in seconds, at which findRoots samples a function
+// looking for changes of sign. Two roots closer together than this may be
+// missed.
+const scanStep = 600
+
+// findRoots returns the x in [a, b] at which f changes sign, to within tol.
+// f is sampled every scanStep, and each change of sign is then narrowed down
+// with brent. A change of sign where f jumps by more than jump is taken as a
+// wrap-around rather than a root and skipped.
+func findRoots(f func(float64) float64, a float64, b float64, tol float64, jump float64) []float64 {
+	var roots []float64
+	x0, f0 := a, f(a)
+	for x0 < b {
+		x1 := math.Min(x0+scanStep, b)
+		f1 := f(x1)
+		if f0 == 0 {
+			roots = append(roots, x0)
+		} else if f0*f1 < 0 && math.Abs(f1-f0) < jump {
+			roots = append(roots, brent(f, x0, x1, f0, f1, tol))
+		}
+		x0, f0 = x1, f1
+	}
+	return roots
+}
+
+// brent returns the root of f in [a, b], where f(a) = fa and f(b) = fb have
+// opposite signs, to within tol, by Brent's method. It mixes inverse quadratic
+// interpolation and the secant method, which converge in a few steps on
+// smooth functions like the altitude, with bisection whenever they stall, so
+// it always converges, and in no more steps than bisection would take.
+func brent(f func(float64) float64, a float64, b float64, fa float64, fb float64, tol float64) float64 {
+	if math.Abs(fa) < math.Abs(fb) {
+		a, b, fa, fb = b, a, fb, fa
+	}
+	c, fc := a, fa
+	d := b - a
+	bisected := true
+	for i := 0; i < 200 && fb != 0 && math.Abs(b-a) > tol; i++ {
+		var s float64
+		if fa != fc && fb != fc {
+			s = a*fb*fc/((fa-fb)*(fa-fc)) + b*fa*fc/((fb-fa)*(fb-fc)) + c*fa*fb/((fc-fa)*(fc-fb))
+		} else {
+			s = b - fb*(b-a)/(fb-fa)
+		}
+		m := (3*a + b) / 4
+		if (s-m)*(s-b) > 0 ||
+			(bisected && math.Abs(s-b) >= math.Abs(b-c)/2) ||
+			(!bisected && math.Abs(s-b) >= math.Abs(c-d)/2) ||
+			(bisected && math.Abs(b-c) < tol) ||
+			(!bisected && math.Abs(c-d) < tol) {
+			s = (a + b) / 2
+			bisected = true
+		} else {
+			bisected = false
+		}
+		fs := f(s)
+		d, c, fc = c, b, fb
+		if fa*fs < 0 {
+			b, fb = s, fs
+		} else {
+			a, fa = s, fs
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a, b, fa, fb = b, a, fb, fa
+		}
+	}
+	return b
+}
@@ -0,0 +1,117 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBrent(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		f    func(float64) float64
+		a, b float64
+		want float64
+	}{
+		{"x² - 2", func(x float64) float64 { return x*x - 2 }, 0, 2, math.Sqrt2},
+		{"cos x - x", func(x float64) float64 { return math.Cos(x) - x }, 0, 1, 0.7390851332151607},
+		{"x³", func(x float64) float64 { return x * x * x }, -1, 0.5, 0},
+		{"step", func(x float64) float64 { return math.Copysign(1, x-0.3) }, 0, 1, 0.3},
+	} {
+		got := brent(tt.f, tt.a, tt.b, tt.f(tt.a), tt.f(tt.b), 1e-9)
+		if math.Abs(got-tt.want) > 1e-8 {
+			t.Errorf("%s: root %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGoldenMax(t *testing.T) {
+	got := goldenMax(func(x float64) float64 { return -(x - 1.25) * (x - 1.25) }, -10, 10, 1e-6)
+	if math.Abs(got-1.25) > 1e-6 {
+		t.Errorf("maximum at %v, want 1.25", got)
+	}
+}
+
+func TestFindRoots(t *testing.T) {
+	// sin with a period of an hour has roots every half hour
+	f := func(s float64) float64 { return math.Sin(2 * math.Pi * (s + 100) / 3600) }
+	roots := findRoots(f, 0, 3*3600, 1e-6, math.Inf(1))
+	if len(roots) != 6 {
+		t.Fatalf("%d roots, want 6", len(roots))
+	}
+	for i, r := range roots {
+		if want := float64(1800*(i+1) - 100); math.Abs(r-want) > 1e-5 {
+			t.Errorf("root %d at %v, want %v", i, r, want)
+		}
+	}
+	// a sawtooth wrapping from +1 to -1 has no roots where it wraps
+	saw := func(s float64) float64 { return math.Mod(s/1000, 2) - 1 }
+	if roots := findRoots(saw, 500, 3500, 1e-6, 1); len(roots) != 2 {
+		t.Errorf("sawtooth: roots %v, want 1000 and 3000 only", roots)
+	}
+}
+
+func TestEventOptionsTolerance(t *testing.T) {
+	for _, tt := range []struct {
+		tolerance time.Duration
+		want      float64
+	}{
+		{0, 1},
+		{time.Microsecond, 0.001},
+		{time.Millisecond, 0.001},
+		{10 * time.Second, 10},
+	} {
+		if got := (EventOptions{Tolerance: tt.tolerance}).tolerance(); got != tt.want {
+			t.Errorf("tolerance of %v = %v s, want %v s", tt.tolerance, got, tt.want)
+		}
+	}
+}
+
+func TestEventOptions(t *testing.T) {
+	day := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	fine := EventOptions{Tolerance: time.Millisecond}
+
+	// the zero options are the package functions
+	rise, _ := Sunrise(day, 51.5, -0.13)
+	if got, _ := (EventOptions{}).Sunrise(day, 51.5, -0.13); !got.Equal(rise) {
+		t.Errorf("EventOptions{}.Sunrise = %v, Sunrise = %v", got, rise)
+	}
+
+	fineRise, _ := fine.Sunrise(day, 51.5, -0.13)
+	if d := fineRise.Sub(rise); d < -time.Second || d > time.Second {
+		t.Errorf("sunrise to a millisecond %v is %v from the default", fineRise, d)
+	}
+	set, _ := Sunset(day, 51.5, -0.13)
+	fineSet, _ := fine.Sunset(day, 51.5, -0.13)
+	if d := fineSet.Sub(set); d < -time.Second || d > time.Second {
+		t.Errorf("sunset to a millisecond %v is %v from the default", fineSet, d)
+	}
+
+	// at the fine tolerance the altitude at sunrise is SunriseAltitude to
+	// within the 0.01 degree a minute the Sun climbs
+	if a := Altitude(fineRise, 51.5, -0.13); math.Abs(a-SunriseAltitude) > 0.01/60 {
+		t.Errorf("altitude at sunrise %v, want %v", a, SunriseAltitude)
+	}
+
+	noon, fineNoon := Culminate(day, 51.5, -0.13), fine.Culminate(day, 51.5, -0.13)
+	if d := fineNoon.Time.Sub(noon.Time); d < -time.Second || d > time.Second {
+		t.Errorf("culmination to a millisecond is %v from the default", d)
+	}
+
+	cs := fine.AltitudeCrossings(day, day.Add(24*time.Hour), 51.5, -0.13, SunriseAltitude)
+	if len(cs) != 2 || !cs[0].Increasing || cs[1].Increasing {
+		t.Fatalf("crossings %+v, want a rising and a setting", cs)
+	}
+	if d := cs[0].Time.Sub(fineRise); d < -10*time.Millisecond || d > 10*time.Millisecond {
+		t.Errorf("rising crossing is %v from sunrise", d)
+	}
+	az := fine.AzimuthCrossings(day, day.Add(24*time.Hour), 51.5, -0.13, 180)
+	if len(az) != 1 {
+		t.Fatalf("%d crossings of the meridian, want 1", len(az))
+	}
+	// at the equinox the climbing declination puts the highest point some 20
+	// seconds after the meridian in London
+	if d := az[0].Time.Sub(fineNoon.Time); d < -30*time.Second || d > 0 {
+		t.Errorf("meridian crossing is %v from culmination", d)
+	}
+}
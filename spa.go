@@ -0,0 +1,169 @@
+package sun
+
+import "time"
+
+// SPA is the position of the Sun by the Solar Position Algorithm of Reda and
+// Andreas (NREL, 2004): the VSOP87 Earth of VSOP87 with the full 63 term IAU
+// 1980 nutation and the apparent sidereal time. It is good to 0.0003 degree
+// from the year -2000 to 6000, given DeltaT. Sun gives the geocentric
+// position; Topocentric adds parallax and refraction for an observer.
+type SPA struct{}
+
+// Sun returns the position of the Sun at t.
+func (SPA) Sun(t time.Time) Coords {
+	c, _, _ := spaGeocentric(t)
+	return c
+}
+
+// Topocentric returns the zenith angle and azimuth of the Sun at t for an
+// observer at the given latitude, longitude and elevation above sea level in
+// metres, allowing for parallax, and for refraction in air at the given
+// pressure in millibars and temperature in °C while the Sun is up. Both are in
+// degrees, the azimuth east of north.
+func (SPA) Topocentric(t time.Time, latitude float64, longitude float64, elevation float64, pressure float64, temperature float64) (zenith float64, azimuth float64) {
+	c, dpsi, eps := spaGeocentric(t)
+	jd := timeToJD(t)
+	T := getJdn(jd) / 36525
+	nu := 280.46061837 + 360.98564736629*getJdn(jd) + 0.000387933*T*T - T*T*T/38710000
+	nu += dpsi * angleCos(eps)
+	h := between(0, 360, nu+longitude-c.RightAscension)
+
+	// parallax in right ascension and declination
+	xi := 8.794 / 3600 / c.Distance
+	u := angleAtan(0.99664719 * angleTan(latitude))
+	x := angleCos(u) + elevation/6378140*angleCos(latitude)
+	y := 0.99664719*angleSin(u) + elevation/6378140*angleSin(latitude)
+	dra := angleAtan2(-x*angleSin(xi)*angleSin(h), angleCos(c.Declination)-x*angleSin(xi)*angleCos(h))
+	dec := angleAtan2((angleSin(c.Declination)-y*angleSin(xi))*angleCos(dra), angleCos(c.Declination)-x*angleSin(xi)*angleCos(h))
+	h -= dra
+
+	e := angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(h))
+	if e >= -(0.26667 + 0.5667) {
+		e += pressure / 1010 * 283 / (273 + temperature) * 1.02 / (60 * angleTan(e+10.3/(e+5.11)))
+	}
+	azimuth = between(0, 360, angleAtan2(angleSin(h), angleCos(h)*angleSin(latitude)-angleTan(dec)*angleCos(latitude))+180)
+	return 90 - e, azimuth
+}
+
+// spaGeocentric returns the apparent geocentric position of the Sun at t by the
+// SPA, with the nutation in longitude and the true obliquity of the ecliptic
+// in degrees
+func spaGeocentric(t time.Time) (c Coords, dpsi float64, eps float64) {
+	jde := timeToJD(t) + deltaT(t)/86400
+	tau := getJdn(jde) / 365250
+	l, b, r := vsop87Earth(tau)
+	T := tau * 10
+
+	dpsi, deps := spaNutation(T)
+	u := tau / 10
+	eps0 := 84381.448 + u*(-4680.93+u*(-1.55+u*(1999.25+u*(-51.38+u*(-249.67+
+		u*(-39.05+u*(7.12+u*(27.87+u*(5.79+u*2.45)))))))))
+	eps = eps0/3600 + deps
+
+	lon := l + 180 + dpsi - 20.4898/3600/r
+	lat := -b
+	ra, dec := eclipticToEquatorial(lon, lat, eps)
+	return Coords{
+		EclipticLongitude: between(0, 360, lon),
+		EclipticLatitude:  lat,
+		RightAscension:    between(0, 360, ra),
+		Declination:       dec,
+		Distance:          r,
+	}, dpsi, eps
+}
+
+// spaNutation returns the nutation in longitude and obliquity in degrees from
+// the 63 terms of the IAU 1980 theory, T julian centuries of dynamical time
+// after J2000
+func spaNutation(T float64) (dpsi float64, deps float64) {
+	x := [5]float64{
+		297.85036 + T*(445267.111480+T*(-0.0019142+T/189474)),
+		357.52772 + T*(35999.050340+T*(-0.0001603-T/300000)),
+		134.96298 + T*(477198.867398+T*(0.0086972+T/56250)),
+		93.27191 + T*(483202.017538+T*(-0.0036825+T/327270)),
+		125.04452 + T*(-1934.136261+T*(0.0020708+T/450000)),
+	}
+	for _, n := range spaNutationTerms {
+		var arg float64
+		for j, y := range n.y {
+			arg += float64(y) * x[j]
+		}
+		dpsi += (n.a + n.b*T) * angleSin(arg)
+		deps += (n.c + n.d*T) * angleCos(arg)
+	}
+	// the coefficients are in units of 0.0001 arcsecond
+	return dpsi / 36000000, deps / 36000000
+}
+
+// spaNutationTerms are the terms of the IAU 1980 nutation: the multiples of
+// the mean elongation of the Moon, the mean anomalies of the Sun and Moon, the
+// Moon's argument of latitude and the longitude of its node, and the sine and
+// cosine coefficients (Meeus table 22.A)
+var spaNutationTerms = []struct {
+	y          [5]int8
+	a, b, c, d float64
+}{
+	{[5]int8{0, 0, 0, 0, 1}, -171996, -174.2, 92025, 8.9},
+	{[5]int8{-2, 0, 0, 2, 2}, -13187, -1.6, 5736, -3.1},
+	{[5]int8{0, 0, 0, 2, 2}, -2274, -0.2, 977, -0.5},
+	{[5]int8{0, 0, 0, 0, 2}, 2062, 0.2, -895, 0.5},
+	{[5]int8{0, 1, 0, 0, 0}, 1426, -3.4, 54, -0.1},
+	{[5]int8{0, 0, 1, 0, 0}, 712, 0.1, -7, 0},
+	{[5]int8{-2, 1, 0, 2, 2}, -517, 1.2, 224, -0.6},
+	{[5]int8{0, 0, 0, 2, 1}, -386, -0.4, 200, 0},
+	{[5]int8{0, 0, 1, 2, 2}, -301, 0, 129, -0.1},
+	{[5]int8{-2, -1, 0, 2, 2}, 217, -0.5, -95, 0.3},
+	{[5]int8{-2, 0, 1, 0, 0}, -158, 0, 0, 0},
+	{[5]int8{-2, 0, 0, 2, 1}, 129, 0.1, -70, 0},
+	{[5]int8{0, 0, -1, 2, 2}, 123, 0, -53, 0},
+	{[5]int8{2, 0, 0, 0, 0}, 63, 0, 0, 0},
+	{[5]int8{0, 0, 1, 0, 1}, 63, 0.1, -33, 0},
+	{[5]int8{2, 0, -1, 2, 2}, -59, 0, 26, 0},
+	{[5]int8{0, 0, -1, 0, 1}, -58, -0.1, 32, 0},
+	{[5]int8{0, 0, 1, 2, 1}, -51, 0, 27, 0},
+	{[5]int8{-2, 0, 2, 0, 0}, 48, 0, 0, 0},
+	{[5]int8{0, 0, -2, 2, 1}, 46, 0, -24, 0},
+	{[5]int8{2, 0, 0, 2, 2}, -38, 0, 16, 0},
+	{[5]int8{0, 0, 2, 2, 2}, -31, 0, 13, 0},
+	{[5]int8{0, 0, 2, 0, 0}, 29, 0, 0, 0},
+	{[5]int8{-2, 0, 1, 2, 2}, 29, 0, -12, 0},
+	{[5]int8{0, 0, 0, 2, 0}, 26, 0, 0, 0},
+	{[5]int8{-2, 0, 0, 2, 0}, -22, 0, 0, 0},
+	{[5]int8{0, 0, -1, 2, 1}, 21, 0, -10, 0},
+	{[5]int8{0, 2, 0, 0, 0}, 17, -0.1, 0, 0},
+	{[5]int8{2, 0, -1, 0, 1}, 16, 0, -8, 0},
+	{[5]int8{-2, 2, 0, 2, 2}, -16, 0.1, 7, 0},
+	{[5]int8{0, 1, 0, 0, 1}, -15, 0, 9, 0},
+	{[5]int8{-2, 0, 1, 0, 1}, -13, 0, 7, 0},
+	{[5]int8{0, -1, 0, 0, 1}, -12, 0, 6, 0},
+	{[5]int8{0, 0, 2, -2, 0}, 11, 0, 0, 0},
+	{[5]int8{2, 0, -1, 2, 1}, -10, 0, 5, 0},
+	{[5]int8{2, 0, 1, 2, 2}, -8, 0, 3, 0},
+	{[5]int8{0, 1, 0, 2, 2}, 7, 0, -3, 0},
+	{[5]int8{-2, 1, 1, 0, 0}, -7, 0, 0, 0},
+	{[5]int8{0, -1, 0, 2, 2}, -7, 0, 3, 0},
+	{[5]int8{2, 0, 0, 2, 1}, -7, 0, 3, 0},
+	{[5]int8{2, 0, 1, 0, 0}, 6, 0, 0, 0},
+	{[5]int8{-2, 0, 2, 2, 2}, 6, 0, -3, 0},
+	{[5]int8{-2, 0, 1, 2, 1}, 6, 0, -3, 0},
+	{[5]int8{2, 0, -2, 0, 1}, -6, 0, 3, 0},
+	{[5]int8{2, 0, 0, 0, 1}, -6, 0, 3, 0},
+	{[5]int8{0, -1, 1, 0, 0}, 5, 0, 0, 0},
+	{[5]int8{-2, -1, 0, 2, 1}, -5, 0, 3, 0},
+	{[5]int8{-2, 0, 0, 0, 1}, -5, 0, 3, 0},
+	{[5]int8{0, 0, 2, 2, 1}, -5, 0, 3, 0},
+	{[5]int8{-2, 0, 2, 0, 1}, 4, 0, 0, 0},
+	{[5]int8{-2, 1, 0, 2, 1}, 4, 0, 0, 0},
+	{[5]int8{0, 0, 1, -2, 0}, 4, 0, 0, 0},
+	{[5]int8{-1, 0, 1, 0, 0}, -4, 0, 0, 0},
+	{[5]int8{-2, 1, 0, 0, 0}, -4, 0, 0, 0},
+	{[5]int8{1, 0, 0, 0, 0}, -4, 0, 0, 0},
+	{[5]int8{0, 0, 1, 2, 0}, 3, 0, 0, 0},
+	{[5]int8{0, 0, -2, 2, 2}, -3, 0, 0, 0},
+	{[5]int8{-1, -1, 1, 0, 0}, -3, 0, 0, 0},
+	{[5]int8{0, 1, 1, 0, 0}, -3, 0, 0, 0},
+	{[5]int8{0, -1, 1, 2, 2}, -3, 0, 0, 0},
+	{[5]int8{2, -1, -1, 2, 2}, -3, 0, 0, 0},
+	{[5]int8{0, 0, 3, 2, 2}, -3, 0, 0, 0},
+	{[5]int8{2, -1, 0, 2, 2}, -3, 0, 0, 0},
+}
@@ -0,0 +1,69 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The example of Reda and Andreas, Solar Position Algorithm for Solar
+// Radiation Applications, NREL/TP-560-34302, table A5.1: 17 October 2003
+// 12:30:30 at UTC-7, with ΔT = 67 s.
+var spaExample = time.Date(2003, 10, 17, 19, 30, 30, 0, time.UTC)
+
+func TestSPAHeliocentric(t *testing.T) {
+	jde := timeToJD(spaExample) + 67.0/86400
+	tau := getJdn(jde) / 365250
+	l, b, r := vsop87Earth(tau)
+	dpsi, deps := spaNutation(tau * 10)
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+		tolerance float64
+	}{
+		{"L", l, 24.0182616917, 1e-9},
+		{"B", b, -0.0001011219, 1e-9},
+		{"R", r, 0.9965422974, 1e-9},
+		{"Δψ", dpsi, -0.00399840, 1e-8},
+		{"Δε", deps, 0.00166657, 1e-8},
+	} {
+		if math.Abs(tt.got-tt.want) > tt.tolerance {
+			t.Errorf("%s = %.10f, want %.10f", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSPATopocentric(t *testing.T) {
+	// our ΔT for the date is 64.5 s, 2.5 s short of the example's, which moves
+	// the Sun by 0.0001 degree at most
+	c := SPA{}.Sun(spaExample)
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"right ascension", c.RightAscension, 202.22741},
+		{"declination", c.Declination, -9.31434},
+		{"ecliptic longitude", c.EclipticLongitude, 204.0085519},
+	} {
+		if math.Abs(tt.got-tt.want) > 1e-4 {
+			t.Errorf("%s = %.6f, want %.6f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	zenith, azimuth := SPA{}.Topocentric(spaExample, 39.742476, -105.1786, 1830.14, 820, 11)
+	if math.Abs(zenith-50.11162) > 1e-4 {
+		t.Errorf("zenith = %.6f, want 50.11162", zenith)
+	}
+	if math.Abs(azimuth-194.34024) > 1e-4 {
+		t.Errorf("azimuth = %.6f, want 194.34024", azimuth)
+	}
+}
+
+func TestSPAAgreesWithMeeus(t *testing.T) {
+	for y := 1900; y <= 2100; y += 20 {
+		at := time.Date(y, 3, 1, 6, 0, 0, 0, time.UTC)
+		if d := math.Abs(AltitudeWith(SPA{}, at, 40, -3) - AltitudeWith(Meeus{}, at, 40, -3)); d > 0.02 {
+			t.Errorf("%d: SPA and Meeus altitudes differ by %.4f", y, d)
+		}
+	}
+}
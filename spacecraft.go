@@ -0,0 +1,96 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Radii used for shadow geometry, in kilometres.
+const (
+	earthEquatorialKm = 6378.137
+	sunRadiusKm       = 695700.0
+)
+
+// ShadowState is how much of the Sun a spacecraft sees past the Earth.
+type ShadowState int
+
+const (
+	Sunlit   ShadowState = iota // the whole disc
+	Penumbra                    // part of the disc
+	Umbra                       // none of it
+)
+
+var shadowStateNames = [...]string{"sunlit", "penumbra", "umbra"}
+
+func (s ShadowState) String() string {
+	if s < 0 || int(s) >= len(shadowStateNames) {
+		return "unknown"
+	}
+	return shadowStateNames[s]
+}
+
+// SpacecraftShadow returns the shadow state of a spacecraft at position r, in
+// kilometres from the centre of the Earth, with the Sun at position s in the
+// same frame, such as from SunPositionECI. The Earth is taken as a sphere of
+// its equatorial radius and the shadow as a cone, ignoring the atmosphere.
+func SpacecraftShadow(r [3]float64, s [3]float64) ShadowState {
+	pen, umb := shadowMargins(r, s)
+	switch {
+	case umb < 0:
+		return Umbra
+	case pen < 0:
+		return Penumbra
+	}
+	return Sunlit
+}
+
+// shadowMargins returns the angle in degrees, as seen from r, between the
+// centres of the Sun and the Earth less the angle at which the discs start to
+// overlap, and less the angle at which the Earth covers the Sun entirely. The
+// first is negative in penumbra or umbra and the second in umbra.
+func shadowMargins(r [3]float64, s [3]float64) (penumbra float64, umbra float64) {
+	toSun := [3]float64{s[0] - r[0], s[1] - r[1], s[2] - r[2]}
+	dSun, dEarth := vecLen(toSun), vecLen(r)
+	a := angleAsin(math.Min(1, sunRadiusKm/dSun))
+	b := angleAsin(math.Min(1, earthEquatorialKm/dEarth))
+	cosC := -dot(r, toSun) / (dEarth * dSun)
+	c := angleAcos(math.Max(-1, math.Min(1, cosC)))
+	return c - (a + b), c - (b - a)
+}
+
+// SpacecraftEclipse is one passage of a spacecraft through the Earth's shadow.
+type SpacecraftEclipse struct {
+	Penumbra Period // from first to last contact with the shadow
+	// Umbra is the part in total shadow, zero for a partial eclipse or for an
+	// orbit high enough to see the Sun round the Earth as an annulus.
+	Umbra Period
+}
+
+// SpacecraftEclipses returns the eclipses of a spacecraft between start and
+// end, given its position at any time in kilometres in the frame of
+// SunPositionECI, as from the caller's orbit propagator. Entry and exit times
+// are to within a second. The position is sampled every ten minutes, so brief
+// grazing eclipses, shorter than that, may be missed; low orbits have eclipses
+// of half an hour or more. An eclipse under way at start or end is cut off
+// there.
+func SpacecraftEclipses(start time.Time, end time.Time, position func(time.Time) [3]float64) []SpacecraftEclipse {
+	margins := func(t time.Time) (float64, float64) {
+		return shadowMargins(position(t), SunPositionECI(t))
+	}
+	pen := periodsWhere(start, end, func(t time.Time) float64 {
+		p, _ := margins(t)
+		return -p
+	})
+	var es []SpacecraftEclipse
+	for _, p := range pen {
+		e := SpacecraftEclipse{Penumbra: p}
+		if u := periodsWhere(p.Start, p.End, func(t time.Time) float64 {
+			_, u := margins(t)
+			return -u
+		}); len(u) > 0 {
+			e.Umbra = Period{u[0].Start, u[len(u)-1].End}
+		}
+		es = append(es, e)
+	}
+	return es
+}
@@ -0,0 +1,100 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The Earth's umbra reaches R d / (R☉ - R) = 6378 × 149.6e6 / 689322 = 1.384
+// million km behind it; beyond that the Sun shows round it as a ring.
+func TestSpacecraftShadow(t *testing.T) {
+	sun := [3]float64{auKm, 0, 0}
+	for _, tt := range []struct {
+		name string
+		r    [3]float64
+		want ShadowState
+	}{
+		{"day side", [3]float64{7000, 0, 0}, Sunlit},
+		{"over the terminator", [3]float64{0, 7000, 0}, Sunlit},
+		{"night side", [3]float64{-7000, 0, 0}, Umbra},
+		{"geostationary at midnight", [3]float64{-42164, 0, 0}, Umbra},
+		{"geostationary beside the shadow", [3]float64{-42164, 6378.137 + 100, 0}, Penumbra},
+		{"geostationary clear of it", [3]float64{-42164, 8000, 0}, Sunlit},
+		{"inside the umbra's tip", [3]float64{-1.3e6, 0, 0}, Umbra},
+		{"past the umbra's tip", [3]float64{-1.5e6, 0, 0}, Penumbra},
+		{"the Moon at a total eclipse", [3]float64{-384400, 3000, 0}, Umbra},
+	} {
+		if got := SpacecraftShadow(tt.r, sun); got != tt.want {
+			t.Errorf("%s: SpacecraftShadow = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// A circular orbit 400 km up, r = 6778.137 km, in the plane of the Sun, has a
+// period of 2π √(r³/μ) = 5553.6 s and spends 2 asin(R/r) = 140.4 degrees of
+// each orbit, 36m06s, behind the Earth in a cylindrical shadow; the cone of
+// the umbra trims four seconds from each end and the penumbra adds eight.
+func TestSpacecraftEclipses(t *testing.T) {
+	start := time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC)
+	s := SunPositionECI(start)
+	u := scaleVector(s, 1/math.Sqrt(dot(s, s)))
+	v := [3]float64{-u[1], u[0], 0}
+	v = scaleVector(v, 1/math.Sqrt(dot(v, v)))
+	const r = 6778.137
+	period := 2 * math.Pi * math.Sqrt(r*r*r/398600.4418)
+	position := func(at time.Time) [3]float64 {
+		a := 2 * math.Pi * at.Sub(start).Seconds() / period
+		c, s := r*math.Cos(a), r*math.Sin(a)
+		return [3]float64{c*u[0] + s*v[0], c*u[1] + s*v[1], c*u[2] + s*v[2]}
+	}
+
+	es := SpacecraftEclipses(start, start.Add(4*time.Hour), position)
+	if len(es) != 3 {
+		t.Fatalf("%d eclipses, want 3", len(es))
+	}
+	for i, e := range es[:2] {
+		// the middle of the shadow is half an orbit on from the subsolar point
+		mid := start.Add(secondsToDuration((float64(i) + 0.5) * period))
+		umbra, penumbra := e.Umbra.End.Sub(e.Umbra.Start), e.Penumbra.End.Sub(e.Penumbra.Start)
+		if (umbra - (36*time.Minute + 6*time.Second)).Abs() > 15*time.Second {
+			t.Errorf("eclipse %d: umbra lasts %v, want 36m06s", i, umbra)
+		}
+		if d := penumbra - umbra; d < 10*time.Second || d > 30*time.Second {
+			t.Errorf("eclipse %d: penumbra lasts %v, umbra %v", i, penumbra, umbra)
+		}
+		if c := e.Umbra.Start.Add(umbra / 2); !within(c, mid, 2*time.Second) {
+			t.Errorf("eclipse %d: umbra centred on %v, want %v", i, c, mid)
+		}
+		if e.Penumbra.Start.After(e.Umbra.Start) || e.Penumbra.End.Before(e.Umbra.End) {
+			t.Errorf("eclipse %d: umbra %v outside penumbra %v", i, e.Umbra, e.Penumbra)
+		}
+	}
+	// the third is cut off at the end
+	if !es[2].Penumbra.End.Equal(start.Add(4 * time.Hour)) {
+		t.Errorf("last eclipse ends at %v, want the end", es[2].Penumbra.End)
+	}
+
+	// a geostationary satellite in the equator sees no eclipse at the solstice
+	sol := time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC)
+	geo := func(at time.Time) [3]float64 {
+		a := 2 * math.Pi * at.Sub(sol).Hours() / 23.9345
+		return [3]float64{42164 * math.Cos(a), 42164 * math.Sin(a), 0}
+	}
+	if es := SpacecraftEclipses(sol, sol.Add(48*time.Hour), geo); len(es) != 0 {
+		t.Errorf("geostationary eclipses at the solstice: %v", es)
+	}
+}
+
+func TestShadowState(t *testing.T) {
+	for _, tt := range []struct {
+		s    ShadowState
+		want string
+	}{
+		{Sunlit, "sunlit"}, {Penumbra, "penumbra"}, {Umbra, "umbra"}, {ShadowState(3), "unknown"},
+	} {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.s), got, tt.want)
+		}
+	}
+}
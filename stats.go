@@ -0,0 +1,100 @@
+package sun
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// MonthStats summarises the daylight of one calendar month.
+type MonthStats struct {
+	Month    time.Month
+	Days     int
+	Total    time.Duration // sum of the day lengths
+	Average  time.Duration
+	Shortest time.Time // date of the shortest day
+	Longest  time.Time // date of the longest day
+	// ShortestLength and LongestLength are the lengths of those days.
+	ShortestLength time.Duration
+	LongestLength  time.Duration
+	// Change is the percentage change of Average from the month before, which
+	// for January is December of the previous year. It is zero when the month
+	// before had no daylight at all.
+	Change float64
+}
+
+// DaylightStats is a month by month summary of day length through a year.
+type DaylightStats struct {
+	Year     int
+	Location *time.Location
+	Months   [12]MonthStats
+}
+
+// NewDaylightStats summarises the day lengths, as given by DayLength, of each
+// month of the given year, on dates in loc.
+func NewDaylightStats(year int, loc *time.Location, latitude float64, longitude float64) *DaylightStats {
+	s := &DaylightStats{Year: year, Location: loc}
+	prev := monthStats(year, 0, loc, latitude, longitude).Average
+	for i := range s.Months {
+		m := monthStats(year, time.Month(i+1), loc, latitude, longitude)
+		if prev > 0 {
+			m.Change = 100 * float64(m.Average-prev) / float64(prev)
+		}
+		s.Months[i], prev = m, m.Average
+	}
+	return s
+}
+
+// monthStats returns the statistics for month m of year, without Change. The
+// month is normalised as by time.Date, so month 0 is December of the year
+// before.
+func monthStats(year int, month time.Month, loc *time.Location, latitude float64, longitude float64) MonthStats {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	m := MonthStats{Month: first.Month()}
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		l := DayLength(d, latitude, longitude)
+		if m.Days == 0 || l < m.ShortestLength {
+			m.Shortest, m.ShortestLength = d, l
+		}
+		if m.Days == 0 || l > m.LongestLength {
+			m.Longest, m.LongestLength = d, l
+		}
+		m.Total += l
+		m.Days++
+	}
+	m.Average = m.Total / time.Duration(m.Days)
+	return m
+}
+
+// WriteCSV writes the statistics with one row per month. Durations are in
+// hours and Change in percent.
+func (s *DaylightStats) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	hours := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+	}
+	err := cw.Write([]string{"month", "days", "total_hours", "average_hours",
+		"shortest_date", "shortest_hours", "longest_date", "longest_hours", "change_percent"})
+	if err != nil {
+		return err
+	}
+	for _, m := range s.Months {
+		err := cw.Write([]string{
+			time.Date(s.Year, m.Month, 1, 0, 0, 0, 0, s.Location).Format("2006-01"),
+			strconv.Itoa(m.Days),
+			hours(m.Total),
+			hours(m.Average),
+			m.Shortest.Format("2006-01-02"),
+			hours(m.ShortestLength),
+			m.Longest.Format("2006-01-02"),
+			hours(m.LongestLength),
+			strconv.FormatFloat(m.Change, 'f', 1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
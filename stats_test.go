@@ -0,0 +1,113 @@
+package sun
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+)
+
+// The longest and shortest days in London in 2024 from the timeanddate.com
+// almanac: 16h38m24s on 20 June and 7h49m42s on 21 December. On the equator
+// refraction and the Sun's radius add an hour angle of asin(sin 0.833 / cos δ)
+// at each end, so the day is 12h06.7m at the equinoxes and 12h07.2m at the
+// solstices, give or take the ten seconds the equation of time moves noon in
+// half a day.
+func TestDaylightStats(t *testing.T) {
+	london := location(t, "Europe/London")
+	s := NewDaylightStats(2024, london, 51.5074, -0.1278)
+	if s.Year != 2024 || s.Location != london {
+		t.Errorf("stats for %d in %v", s.Year, s.Location)
+	}
+	days := [12]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	for i, m := range s.Months {
+		if m.Month != time.Month(i+1) || m.Days != days[i] {
+			t.Errorf("month %d is %v with %d days, want %v with %d", i, m.Month, m.Days, time.Month(i+1), days[i])
+		}
+		if m.Average != m.Total/time.Duration(m.Days) {
+			t.Errorf("%v: average %v, want %v", m.Month, m.Average, m.Total/time.Duration(m.Days))
+		}
+		if m.ShortestLength > m.Average || m.LongestLength < m.Average {
+			t.Errorf("%v: average %v outside %v to %v", m.Month, m.Average, m.ShortestLength, m.LongestLength)
+		}
+		// the days lengthen to June and shorten after it
+		if rising := m.Change > 0; rising != (i < 6) {
+			t.Errorf("%v: change %.1f%%", m.Month, m.Change)
+		}
+		if i > 0 {
+			prev := s.Months[i-1].Average
+			if want := 100 * float64(m.Average-prev) / float64(prev); m.Change != want {
+				t.Errorf("%v: change %v, want %v", m.Month, m.Change, want)
+			}
+		}
+	}
+	for _, tt := range []struct {
+		name   string
+		at     time.Time
+		length time.Duration
+		want   time.Time
+		wantL  time.Duration
+	}{
+		{"longest", s.Months[5].Longest, s.Months[5].LongestLength, date(london, 2024, time.June, 20), 16*time.Hour + 38*time.Minute + 24*time.Second},
+		{"shortest", s.Months[11].Shortest, s.Months[11].ShortestLength, date(london, 2024, time.December, 21), 7*time.Hour + 49*time.Minute + 42*time.Second},
+	} {
+		if !within(tt.at, tt.want, 24*time.Hour) || tt.length-tt.wantL > time.Minute || tt.wantL-tt.length > time.Minute {
+			t.Errorf("%s day %s, %v, want %s, %v", tt.name, tt.at.Format("Jan 2"), tt.length, tt.want.Format("Jan 2"), tt.wantL)
+		}
+	}
+
+	for _, m := range NewDaylightStats(2024, time.UTC, 0, 0).Months {
+		if m.Average < 12*time.Hour+6*time.Minute+25*time.Second || m.Average > 12*time.Hour+7*time.Minute+30*time.Second || math.Abs(m.Change) > 0.2 {
+			t.Errorf("equator in %v: average %v, change %.2f%%", m.Month, m.Average, m.Change)
+		}
+	}
+}
+
+func TestDaylightStatsPolar(t *testing.T) {
+	s := NewDaylightStats(2024, time.UTC, 69.6492, 18.9553)
+	// the December before was polar night, so January has no change, and
+	// the polar night returns at the end of November
+	if jan := s.Months[0]; jan.Change != 0 || jan.ShortestLength != 0 {
+		t.Errorf("January: change %v, shortest %v", jan.Change, jan.ShortestLength)
+	}
+	if dec := s.Months[11]; dec.Total != 0 || dec.Change != -100 {
+		t.Errorf("December: total %v, change %v", dec.Total, dec.Change)
+	}
+	if jun := s.Months[5]; jun.Total != 30*24*time.Hour {
+		t.Errorf("June: total %v, want the midnight sun all month", jun.Total)
+	}
+}
+
+func TestDaylightStatsCSV(t *testing.T) {
+	london := location(t, "Europe/London")
+	var b bytes.Buffer
+	if err := NewDaylightStats(2024, london, 51.5074, -0.1278).WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 13 {
+		t.Fatalf("%d rows, want 13", len(rows))
+	}
+	if got := rows[0][0] + " " + rows[0][8]; got != "month change_percent" {
+		t.Errorf("header = %v", rows[0])
+	}
+	for i, want := range [][]string{
+		{"2024-06", "30", "496.80", "16.56", "2024-06-01", "16.34", "2024-06-20", "16.64", "5.8"},
+		{"2024-12", "31", "245.50", "7.92", "2024-12-21", "7.83", "2024-12-01", "8.17", "-10.6"},
+	} {
+		row := rows[6+6*i]
+		for j := range want {
+			if row[j] != want[j] {
+				t.Errorf("row %s = %v, want %v", want[0], row, want)
+				break
+			}
+		}
+	}
+	if err := NewDaylightStats(2024, london, 51.5074, -0.1278).WriteCSV(failWriter{}); err == nil {
+		t.Error("WriteCSV to a failing writer: no error")
+	}
+}
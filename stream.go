@@ -0,0 +1,286 @@
+package sun
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Sample is the position of the Sun at one instant.
+type Sample struct {
+	Time     time.Time
+	Altitude float64 // degrees
+	Azimuth  float64 // degrees east of north
+}
+
+// SampleWriter writes a stream of samples in some encoding. Flush must be
+// called after the last sample.
+type SampleWriter interface {
+	WriteSample(s Sample) error
+	Flush() error
+}
+
+// WriteSeries writes the position of the Sun at the given location from start
+// up to and including end, every step, without holding the series in memory.
+// It flushes w before returning. A step that is not positive is an error.
+func WriteSeries(w SampleWriter, start time.Time, end time.Time, step time.Duration, latitude float64, longitude float64) error {
+	if step <= 0 {
+		return errNonPositiveStep
+	}
+	for t := start; !t.After(end); t = t.Add(step) {
+		s := Sample{t, Altitude(t, latitude, longitude), Azimuth(t, latitude, longitude)}
+		if err := w.WriteSample(s); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+type csvSampleWriter struct {
+	w      *csv.Writer
+	header bool
+	row    [3]string
+}
+
+// NewCSVWriter returns a SampleWriter writing CSV with a header line and the
+// columns time (RFC 3339), altitude and azimuth.
+func NewCSVWriter(w io.Writer) SampleWriter {
+	return &csvSampleWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvSampleWriter) WriteSample(s Sample) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write([]string{"time", "altitude", "azimuth"}); err != nil {
+			return err
+		}
+	}
+	c.row[0] = s.Time.Format(time.RFC3339)
+	c.row[1] = strconv.FormatFloat(s.Altitude, 'f', 4, 64)
+	c.row[2] = strconv.FormatFloat(s.Azimuth, 'f', 4, 64)
+	return c.w.Write(c.row[:])
+}
+
+func (c *csvSampleWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonSampleWriter struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewNDJSONWriter returns a SampleWriter writing one JSON object per line with
+// the fields time, altitude and azimuth.
+func NewNDJSONWriter(w io.Writer) SampleWriter {
+	return &ndjsonSampleWriter{w: bufio.NewWriter(w)}
+}
+
+func (n *ndjsonSampleWriter) WriteSample(s Sample) error {
+	b := append(n.buf[:0], `{"time":"`...)
+	b = s.Time.AppendFormat(b, time.RFC3339)
+	b = append(b, `","altitude":`...)
+	b = strconv.AppendFloat(b, s.Altitude, 'f', 4, 64)
+	b = append(b, `,"azimuth":`...)
+	b = strconv.AppendFloat(b, s.Azimuth, 'f', 4, 64)
+	b = append(b, "}\n"...)
+	n.buf = b
+	_, err := n.w.Write(b)
+	return err
+}
+
+func (n *ndjsonSampleWriter) Flush() error {
+	return n.w.Flush()
+}
+
+// parquetRowGroup is the number of samples in each row group of the Parquet
+// writer, and so the number it holds in memory: about 1.5 MB.
+const parquetRowGroup = 1 << 16
+
+var parquetColumns = [3]struct {
+	name string
+	typ  int32
+}{{"time", parquetInt64}, {"altitude", parquetDouble}, {"azimuth", parquetDouble}}
+
+type parquetChunk struct {
+	offset int64 // of the page header
+	size   int64 // of the header and page
+}
+
+type parquetGroup struct {
+	rows    int64
+	columns [3]parquetChunk
+}
+
+type parquetSampleWriter struct {
+	w       *bufio.Writer
+	offset  int64
+	times   []int64
+	alts    []float64
+	azs     []float64
+	groups  []parquetGroup
+	page    []byte
+	header  []byte
+	started bool
+	closed  bool
+}
+
+// NewParquetWriter returns a SampleWriter writing an Apache Parquet file with
+// the columns time (INT64, TIMESTAMP_MILLIS), altitude and azimuth (DOUBLE),
+// all required. Samples are written in row groups of 65536, each column of a
+// group as one PLAIN encoded, uncompressed page, so memory use is bounded
+// however long the series. Flush ends the file by writing the footer; writing
+// after it is an error.
+func NewParquetWriter(w io.Writer) SampleWriter {
+	return &parquetSampleWriter{w: bufio.NewWriter(w)}
+}
+
+func (p *parquetSampleWriter) WriteSample(s Sample) error {
+	if p.closed {
+		return errWriterClosed
+	}
+	p.times = append(p.times, s.Time.UnixMilli())
+	p.alts = append(p.alts, s.Altitude)
+	p.azs = append(p.azs, s.Azimuth)
+	if len(p.times) == parquetRowGroup {
+		return p.writeGroup()
+	}
+	return nil
+}
+
+func (p *parquetSampleWriter) write(b []byte) error {
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	return err
+}
+
+func (p *parquetSampleWriter) start() error {
+	if p.started {
+		return nil
+	}
+	p.started = true
+	return p.write([]byte("PAR1"))
+}
+
+// writeGroup writes the buffered samples as a row group, after the magic
+// number that begins the file if this is the first.
+func (p *parquetSampleWriter) writeGroup() error {
+	if err := p.start(); err != nil {
+		return err
+	}
+	g := parquetGroup{rows: int64(len(p.times))}
+	for i := range parquetColumns {
+		if i == 0 {
+			p.page = appendPlainInt64(p.page[:0], p.times)
+		} else if i == 1 {
+			p.page = appendPlainDouble(p.page[:0], p.alts)
+		} else {
+			p.page = appendPlainDouble(p.page[:0], p.azs)
+		}
+		t := newThriftWriter(p.header)
+		t.i32(1, parquetDataPage)
+		t.i32(2, int32(len(p.page)))
+		t.i32(3, int32(len(p.page)))
+		t.beginStruct(5)
+		t.i32(1, int32(g.rows))
+		t.i32(2, parquetPlain)
+		t.i32(3, parquetRLE)
+		t.i32(4, parquetRLE)
+		t.endStruct()
+		t.endStruct()
+		p.header = t.b
+
+		g.columns[i] = parquetChunk{p.offset, int64(len(p.header) + len(p.page))}
+		if err := p.write(p.header); err != nil {
+			return err
+		}
+		if err := p.write(p.page); err != nil {
+			return err
+		}
+	}
+	p.groups = append(p.groups, g)
+	p.times, p.alts, p.azs = p.times[:0], p.alts[:0], p.azs[:0]
+	return nil
+}
+
+// Flush writes any buffered samples and the footer, which ends the file.
+func (p *parquetSampleWriter) Flush() error {
+	if p.closed {
+		return p.w.Flush()
+	}
+	if len(p.times) > 0 {
+		if err := p.writeGroup(); err != nil {
+			return err
+		}
+	}
+	if err := p.start(); err != nil {
+		return err
+	}
+	p.closed = true
+
+	var rows int64
+	for _, g := range p.groups {
+		rows += g.rows
+	}
+	t := newThriftWriter(p.header)
+	t.i32(1, 1) // version
+	t.list(2, thriftStruct, 1+len(parquetColumns))
+	t.beginStruct(0)
+	t.binary(4, "schema")
+	t.i32(5, int32(len(parquetColumns)))
+	t.endStruct()
+	for i, c := range parquetColumns {
+		t.beginStruct(0)
+		t.i32(1, c.typ)
+		t.i32(3, parquetRequired)
+		t.binary(4, c.name)
+		if i == 0 {
+			t.i32(6, parquetTimestampMillis)
+		}
+		t.endStruct()
+	}
+	t.i64(3, rows)
+	t.list(4, thriftStruct, len(p.groups))
+	for _, g := range p.groups {
+		var size int64
+		t.beginStruct(0)
+		t.list(1, thriftStruct, len(parquetColumns))
+		for i, c := range parquetColumns {
+			chunk := g.columns[i]
+			size += chunk.size
+			t.beginStruct(0)
+			t.i64(2, chunk.offset)
+			t.beginStruct(3)
+			t.i32(1, c.typ)
+			t.list(2, thriftI32, 2)
+			t.zigzag(parquetPlain)
+			t.zigzag(parquetRLE)
+			t.list(3, thriftBinary, 1)
+			t.str(c.name)
+			t.i32(4, parquetUncompressed)
+			t.i64(5, g.rows)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, size)
+		t.i64(3, g.rows)
+		t.endStruct()
+	}
+	t.binary(6, "github.com/exploded/sun")
+	t.endStruct()
+
+	footer := t.b
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(t.b)))
+	footer = append(footer, "PAR1"...)
+	if err := p.write(footer); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
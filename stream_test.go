@@ -0,0 +1,215 @@
+package sun
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var streamStart = time.Date(2024, 6, 21, 11, 0, 0, 0, time.UTC)
+
+func TestCSVWriter(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteSeries(NewCSVWriter(&b), streamStart, streamStart.Add(2*time.Hour), time.Hour, 51.4779, -0.0015); err != nil {
+		t.Fatal(err)
+	}
+	want := "time,altitude,azimuth\n" +
+		"2024-06-21T11:00:00Z,59.5291,151.1230\n" +
+		"2024-06-21T12:00:00Z,61.9572,179.0570\n" +
+		"2024-06-21T13:00:00Z,59.8117,207.2128\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteSeries(NewNDJSONWriter(&b), streamStart.Add(time.Hour), streamStart.Add(time.Hour), time.Hour, 51.4779, -0.0015); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"time":"2024-06-21T12:00:00Z","altitude":61.9572,"azimuth":179.0570}` + "\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestWriteSeriesStep(t *testing.T) {
+	if err := WriteSeries(NewCSVWriter(&bytes.Buffer{}), streamStart, streamStart, 0, 0, 0); err != errNonPositiveStep {
+		t.Errorf("err = %v, want %v", err, errNonPositiveStep)
+	}
+}
+
+// thriftReader decodes the Thrift compact protocol into maps from field id to
+// value for structures, slices for lists, int64 for integers and string for
+// binaries, which is enough to check the Parquet footer.
+type thriftReader struct {
+	b   []byte
+	err bool
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.err = true
+		r.b = nil
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) int() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) byte() byte {
+	if len(r.b) == 0 {
+		r.err = true
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.int()
+	case thriftBinary:
+		n := int(r.uvarint())
+		if n > len(r.b) {
+			r.err = true
+			return ""
+		}
+		s := string(r.b[:n])
+		r.b = r.b[n:]
+		return s
+	case thriftList:
+		h := r.byte()
+		n := int(h >> 4)
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		l := make([]interface{}, n)
+		for i := range l {
+			l[i] = r.value(h & 0x0f)
+		}
+		return l
+	case thriftStruct:
+		m := map[int16]interface{}{}
+		var id int16
+		for !r.err {
+			h := r.byte()
+			if h == 0 {
+				break
+			}
+			if d := h >> 4; d != 0 {
+				id += int16(d)
+			} else {
+				id = int16(r.int())
+			}
+			m[id] = r.value(h & 0x0f)
+		}
+		return m
+	}
+	r.err = true
+	return nil
+}
+
+func TestParquetWriter(t *testing.T) {
+	n := parquetRowGroup + 1000
+	samples := make([]Sample, n)
+	for i := range samples {
+		samples[i] = Sample{streamStart.Add(time.Duration(i) * time.Minute), float64(i) / 1000, 360 - float64(i)/1000}
+	}
+	var b bytes.Buffer
+	w := NewParquetWriter(&b)
+	for _, s := range samples {
+		if err := w.WriteSample(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteSample(samples[0]); err != errWriterClosed {
+		t.Errorf("write after Flush: err = %v, want %v", err, errWriterClosed)
+	}
+
+	file := b.Bytes()
+	if string(file[:4]) != "PAR1" || string(file[len(file)-4:]) != "PAR1" {
+		t.Fatalf("missing magic numbers")
+	}
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	r := &thriftReader{b: file[len(file)-8-size : len(file)-8]}
+	meta := r.value(thriftStruct).(map[int16]interface{})
+	if r.err || len(r.b) != 0 {
+		t.Fatalf("footer does not decode")
+	}
+	if meta[3] != int64(n) {
+		t.Errorf("num_rows = %v, want %d", meta[3], n)
+	}
+	schema := meta[2].([]interface{})
+	for i, name := range []string{"schema", "time", "altitude", "azimuth"} {
+		if got := schema[i].(map[int16]interface{})[4]; got != name {
+			t.Errorf("schema element %d is %v, want %s", i, got, name)
+		}
+	}
+
+	groups := meta[4].([]interface{})
+	if len(groups) != 2 {
+		t.Fatalf("%d row groups, want 2", len(groups))
+	}
+	row := 0
+	for _, g := range groups {
+		group := g.(map[int16]interface{})
+		rows := int(group[3].(int64))
+		for c, col := range group[1].([]interface{}) {
+			cm := col.(map[int16]interface{})[3].(map[int16]interface{})
+			if cm[5] != int64(rows) {
+				t.Errorf("column %d has %v values, want %d", c, cm[5], rows)
+			}
+			r := &thriftReader{b: file[cm[9].(int64):]}
+			page := r.value(thriftStruct).(map[int16]interface{})
+			data := r.b[:page[3].(int64)]
+			if len(data) != 8*rows {
+				t.Fatalf("column %d page is %d bytes, want %d", c, len(data), 8*rows)
+			}
+			for i := 0; i < rows; i++ {
+				v := binary.LittleEndian.Uint64(data[8*i:])
+				s := samples[row+i]
+				var ok bool
+				switch c {
+				case 0:
+					ok = int64(v) == s.Time.UnixMilli()
+				case 1:
+					ok = math.Float64frombits(v) == s.Altitude
+				case 2:
+					ok = math.Float64frombits(v) == s.Azimuth
+				}
+				if !ok {
+					t.Fatalf("column %d row %d does not match", c, row+i)
+				}
+			}
+		}
+		row += rows
+	}
+	if row != n {
+		t.Errorf("row groups hold %d rows, want %d", row, n)
+	}
+}
+
+func TestParquetWriterEmpty(t *testing.T) {
+	var b bytes.Buffer
+	if err := NewParquetWriter(&b).Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); !strings.HasPrefix(s, "PAR1") || !strings.HasSuffix(s, "PAR1") {
+		t.Errorf("empty file %q lacks the magic numbers", s)
+	}
+}
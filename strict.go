@@ -0,0 +1,229 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// StrictAltitude returns the same altitude as Altitude, computed so that the
+// result is bit for bit identical on every architecture.
+//
+// Go allows the compiler to fuse x*y+z into a single instruction, which it does
+// on arm64, ppc64 and s390x but not by default on amd64, so the last bits of
+// Altitude can differ between machines. This matters to regression baselines
+// and consensus systems. Here every product is explicitly rounded, which stops
+// fusion, and the trigonometric functions are evaluated by our own polynomials
+// instead of the math package, which is itself subject to fusion. Only the
+// correctly rounded operations +, -, *, / and math.Sqrt remain.
+//
+// StrictAltitude is a little slower than Altitude and agrees with it to about
+// 1e-11 degree.
+func StrictAltitude(t time.Time, latitude float64, longitude float64) (altitude float64) {
+	jd := timeToJD(t)
+	rAsc, dec := strictSunCoords(jd)
+	ha := strictHourAngle(jd, longitude, rAsc)
+	x := float64(strictSin(latitude)*strictSin(dec)) + float64(float64(strictCos(latitude)*strictCos(dec))*strictCos(ha))
+	return strictAsin(x)
+}
+
+// StrictAzimuth returns the same azimuth as Azimuth, computed so that the result
+// is bit for bit identical on every architecture. See StrictAltitude.
+func StrictAzimuth(t time.Time, latitude float64, longitude float64) (azimuth float64) {
+	jd := timeToJD(t)
+	rAsc, dec := strictSunCoords(jd)
+	ha := strictHourAngle(jd, longitude, rAsc)
+	y := strictSin(ha)
+	x := float64(strictCos(ha)*strictSin(latitude)) - float64(float64(strictSin(dec)/strictCos(dec))*strictCos(latitude))
+	return between(0, 360, strictAtan2(y, x)+180)
+}
+
+// strictSunCoords follows getSunCoords with every product rounded
+func strictSunCoords(jd float64) (rAsc float64, dec float64) {
+	jdn := getJdn(jd)
+	l := between(0, 360, 280.460) + float64(0.9856474*jdn)
+	g := between(0, 360, 357.528) + float64(0.9856003*jdn)
+	ecLong := l + float64(1.915*strictSin(g)) + float64(0.02*strictSin(float64(2.0*g)))
+
+	rAsc = strictAtan(float64(strictCos(axialTilt) * float64(strictSin(ecLong)/strictCos(ecLong))))
+	for angleToQuadrant(ecLong) != angleToQuadrant(rAsc) {
+		if rAsc < ecLong {
+			rAsc += 90
+		} else {
+			rAsc += -90
+		}
+	}
+	dec = strictAsin(float64(strictSin(axialTilt) * strictSin(ecLong)))
+	return rAsc, dec
+}
+
+// strictHourAngle follows getHourAngle with every product rounded
+func strictHourAngle(jd float64, longitude float64, rightAscension float64) float64 {
+	jdm := getLastJdMidnight(jd)
+	ut := float64(24 * (jd - jdm))
+	gmst := 6.697374558 + float64(0.06570982441908*getJdn(jdm)) + float64(1.00273790935*ut)
+	gst := float64(15 * between(0, 24, gmst))
+	return between(0, 360, gst) + longitude - rightAscension
+}
+
+// Coefficients for sin and cos on [-π/4, π/4], as used by the math package
+// (from Cephes).
+var (
+	strictSinCoef = [...]float64{
+		1.58962301576546568060e-10,
+		-2.50507477628578072866e-8,
+		2.75573136213857245213e-6,
+		-1.98412698295895385996e-4,
+		8.33333333332211858878e-3,
+		-1.66666666666666307295e-1,
+	}
+	strictCosCoef = [...]float64{
+		-1.13585365213876817300e-11,
+		2.08757008419747316778e-9,
+		-2.75573141792967388112e-7,
+		2.48015872888517045348e-5,
+		-1.38888888888730564116e-3,
+		4.16666666666665929218e-2,
+	}
+)
+
+// strictSin returns the sine of x degrees
+func strictSin(x float64) float64 {
+	return strictSinCos(x, false)
+}
+
+// strictCos returns the cosine of x degrees
+func strictCos(x float64) float64 {
+	return strictSinCos(x, true)
+}
+
+// strictSinCos reduces x degrees to within 45 degrees of a multiple of 90,
+// which is exact in degrees, and evaluates the sine or cosine polynomial
+func strictSinCos(x float64, cos bool) float64 {
+	x = x - float64(360*math.Floor(x/360))
+	q := math.Floor(x/90 + 0.5)
+	r := toStrictRadians(x - float64(90*q))
+	n := int(q) % 4
+	if cos {
+		n = (n + 1) % 4
+	}
+	switch n {
+	case 0:
+		return strictSinPoly(r)
+	case 1:
+		return strictCosPoly(r)
+	case 2:
+		return -strictSinPoly(r)
+	default:
+		return -strictCosPoly(r)
+	}
+}
+
+func strictSinPoly(x float64) float64 {
+	z := float64(x * x)
+	p := strictSinCoef[0]
+	for _, c := range strictSinCoef[1:] {
+		p = float64(p*z) + c
+	}
+	return x + float64(float64(x*z)*p)
+}
+
+func strictCosPoly(x float64) float64 {
+	z := float64(x * x)
+	p := strictCosCoef[0]
+	for _, c := range strictCosCoef[1:] {
+		p = float64(p*z) + c
+	}
+	return 1.0 - float64(0.5*z) + float64(float64(z*z)*p)
+}
+
+// strictAtan returns the arctangent of x in degrees
+func strictAtan(x float64) float64 {
+	if x < 0 {
+		return -toAngle(strictSatan(-x))
+	}
+	return toAngle(strictSatan(x))
+}
+
+// strictAsin returns the arcsine of x in degrees
+func strictAsin(x float64) float64 {
+	sign := 1.0
+	if x < 0 {
+		x, sign = -x, -1
+	}
+	if x > 1 {
+		return math.NaN()
+	}
+	t := math.Sqrt(1 - float64(x*x))
+	if x > 0.7 {
+		return sign * toAngle(math.Pi/2-strictSatan(t/x))
+	}
+	return sign * toAngle(strictSatan(x/t))
+}
+
+// strictAtan2 returns the angle of (x, y) in degrees, in (-180, 180]
+func strictAtan2(y float64, x float64) float64 {
+	switch {
+	case x == 0 && y == 0:
+		return 0
+	case x == 0 && y > 0:
+		return 90
+	case x == 0:
+		return -90
+	}
+	a := strictAtan(y / x)
+	if x < 0 {
+		if y >= 0 {
+			return a + 180
+		}
+		return a - 180
+	}
+	return a
+}
+
+// strictSatan reduces x >= 0 and returns its arctangent in radians, after the
+// math package (from Cephes)
+func strictSatan(x float64) float64 {
+	const (
+		morebits = 6.123233995736765886130e-17
+		tan3pio8 = 2.41421356237309504880
+	)
+	if x <= 0.66 {
+		return strictXatan(x)
+	}
+	if x > tan3pio8 {
+		return math.Pi/2 - strictXatan(1/x) + morebits
+	}
+	return math.Pi/4 + strictXatan((x-1)/(x+1)) + 0.5*morebits
+}
+
+// strictXatan evaluates the arctangent series of x in [0, 0.66] in radians
+func strictXatan(x float64) float64 {
+	const (
+		p0 = -8.750608600031904122785e-01
+		p1 = -1.615753718733365076637e+01
+		p2 = -7.500855792314704667340e+01
+		p3 = -1.228866684490136173410e+02
+		p4 = -6.485021904942025371773e+01
+		q0 = +2.485846490142306297962e+01
+		q1 = +1.650270098316988542046e+02
+		q2 = +4.328810604912902668951e+02
+		q3 = +4.853903996359136964868e+02
+		q4 = +1.945506571482613964425e+02
+	)
+	z := float64(x * x)
+	num := float64(p0*z) + p1
+	num = float64(num*z) + p2
+	num = float64(num*z) + p3
+	num = float64(num*z) + p4
+	num = float64(num * z)
+	den := z + q0
+	den = float64(den*z) + q1
+	den = float64(den*z) + q2
+	den = float64(den*z) + q3
+	den = float64(den*z) + q4
+	return float64(x*(num/den)) + x
+}
+
+func toStrictRadians(angle float64) float64 {
+	return float64(angle * (math.Pi / 180))
+}
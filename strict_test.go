@@ -0,0 +1,74 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The expected bits were recorded on amd64, where Go does not fuse
+// multiply-adds; the strict functions must give the same bits everywhere.
+var strictCases = []struct {
+	t             time.Time
+	lat, lon      float64
+	altitude      uint64
+	azimuth       uint64
+	altitudeValue float64
+}{
+	{time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 51.4779, -0.0015,
+		0x404efa86f0a33135, 0x406661d3529dbb7c, 61.95724304169054},
+	{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 0, 0,
+		0x4050bcf75dca744d, 0x406641ea5c03e04a, 66.95259804507187},
+	{time.Date(2031, 11, 3, 4, 17, 23, 500000000, time.UTC), -33.8688, 151.2093,
+		0x4048cbfd6941e859, 0x4071ff174b55fdda, 49.59367099493165},
+	{time.Date(1987, 3, 14, 22, 45, 0, 0, time.UTC), 64.1466, -21.9426,
+		0xc034ecf442093c84, 0x40739273adc54574, -20.92560208058832},
+	{time.Date(2024, 9, 22, 18, 30, 0, 0, time.UTC), 35.6762, 139.6503,
+		0xc038c301bd646c86, 0x4051b303d2240dcd, -24.76174529744869},
+}
+
+func TestStrictBits(t *testing.T) {
+	for _, tt := range strictCases {
+		if got := math.Float64bits(StrictAltitude(tt.t, tt.lat, tt.lon)); got != tt.altitude {
+			t.Errorf("StrictAltitude(%v, %v, %v) bits = %#016x, want %#016x", tt.t, tt.lat, tt.lon, got, tt.altitude)
+		}
+		if got := math.Float64bits(StrictAzimuth(tt.t, tt.lat, tt.lon)); got != tt.azimuth {
+			t.Errorf("StrictAzimuth(%v, %v, %v) bits = %#016x, want %#016x", tt.t, tt.lat, tt.lon, got, tt.azimuth)
+		}
+		if math.Float64frombits(tt.altitude) != tt.altitudeValue {
+			t.Errorf("altitude bits %#016x do not encode %v", tt.altitude, tt.altitudeValue)
+		}
+	}
+}
+
+func TestStrictHelperBits(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		f    func(float64) float64
+		x    float64
+		want uint64
+	}{
+		{"strictXatan", strictXatan, 0.1, 0x3fb983e282e2cc4d},
+		{"strictXatan", strictXatan, 0.3, 0x3fd2a73a661eaf06},
+		{"strictXatan", strictXatan, 0.66, 0x3fe2aafdde4d0c9f},
+		{"strictSin", strictSin, 0.5, 0x3f81df37c4954c21},
+		{"strictSin", strictSin, 23.44, 0x3fd9755ded7fed21},
+		{"strictSin", strictSin, 137.25, 0x3fe5b8bc57520f96},
+		{"strictSin", strictSin, -300, 0x3febb67ae8584cab},
+	} {
+		if got := math.Float64bits(tt.f(tt.x)); got != tt.want {
+			t.Errorf("%s(%v) bits = %#016x, want %#016x", tt.name, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestStrictAgreesWithAltitude(t *testing.T) {
+	for _, tt := range strictCases {
+		if d := math.Abs(StrictAltitude(tt.t, tt.lat, tt.lon) - Altitude(tt.t, tt.lat, tt.lon)); d > 1e-9 {
+			t.Errorf("altitude at %v differs by %g", tt.t, d)
+		}
+		if d := math.Abs(StrictAzimuth(tt.t, tt.lat, tt.lon) - Azimuth(tt.t, tt.lat, tt.lon)); d > 1e-9 {
+			t.Errorf("azimuth at %v differs by %g", tt.t, d)
+		}
+	}
+}
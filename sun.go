@@ -46,13 +46,51 @@ const axialTilt float64 = 23.439
 //
 func Altitude(t time.Time, latitude float64, longitude float64) (altitude float64) {
 	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	ha := getHourAngle(jd, longitude, rAsc)
+	altitude = angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	traceHorizontal("Altitude", jd, latitude, longitude, rAsc, dec, ha, altitude)
+	return altitude
+}
+
+// Azimuth returns the azimuth of the Sun in degrees, measured clockwise from
+// true north, so east is 90 and west is 270. As for Altitude the time zone of
+// t is ignored and location is in decimal degrees.
+func Azimuth(t time.Time, latitude float64, longitude float64) (azimuth float64) {
+	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	ha := getHourAngle(jd, longitude, rAsc)
+	azimuth = getAzimuth(latitude, dec, ha)
+	traceHorizontal("Azimuth", jd, latitude, longitude, rAsc, dec, ha, azimuth)
+	return azimuth
+}
+
+// getENU returns the components of the unit vector towards the Sun in the
+// local east, north, up frame for the given declination and hour angle
+func getENU(latitude float64, dec float64, ha float64) (e float64, n float64, u float64) {
+	e = -angleCos(dec) * angleSin(ha)
+	n = angleCos(latitude)*angleSin(dec) - angleSin(latitude)*angleCos(dec)*angleCos(ha)
+	u = angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha)
+	return e, n, u
+}
+
+// getAzimuth returns the azimuth east of north for the given declination and
+// hour angle
+func getAzimuth(latitude float64, dec float64, ha float64) float64 {
+	az := angleAtan2(angleSin(ha), angleCos(ha)*angleSin(latitude)-angleTan(dec)*angleCos(latitude))
+	return between(0, 360, az+180)
+}
+
+// getSunCoords returns the ecliptic longitude, right ascension and declination
+// of the Sun in degrees for the julian day jd
+func getSunCoords(jd float64) (ecLong float64, rAsc float64, dec float64) {
 	jdn := getJdn(jd)
 
-	l := between(0, 360, 280.460) + 0.9856474*jdn
+	l := getMeanLong(jdn)
 	g := between(0, 360, 357.528) + 0.9856003*jdn
 
-	ecLong := getEclipticLong(l, g)
-	rAsc := getRightAscension(ecLong)
+	ecLong = getEclipticLong(l, g)
+	rAsc = getRightAscension(ecLong)
 
 	// make sure rightAscension is in same quadrant as eclipticLong
 	for angleToQuadrant(ecLong) != angleToQuadrant(rAsc) {
@@ -62,9 +100,12 @@ func Altitude(t time.Time, latitude float64, longitude float64) (altitude float6
 			rAsc += -90
 		}
 	}
-	dec := getDeclination(ecLong)
-	ha := getHourAngle(jd, longitude, rAsc)
-	return angleAsin(angleSin(latitude)*angleSin(dec) + angleCos(latitude)*angleCos(dec)*angleCos(ha))
+	dec = getDeclination(ecLong)
+	return ecLong, rAsc, dec
+}
+
+func getMeanLong(jdn float64) float64 {
+	return between(0, 360, 280.460) + 0.9856474*jdn
 }
 
 func getEclipticLong(l float64, g float64) float64 {
@@ -86,9 +127,9 @@ func getDeclination(eclipticLong float64) float64 {
 
 func getLastJdMidnight(jd float64) float64 {
 	if jd >= math.Floor(jd)+0.5 {
-		return math.Floor(jd-1) + 0.5
+		return math.Floor(jd) + 0.5
 	}
-	return math.Floor(jd) + 0.5
+	return math.Floor(jd) - 0.5
 }
 
 func getUtHours(jd float64, lastJdMidnight float64) float64 {
@@ -167,6 +208,14 @@ func angleAsin(x float64) float64 {
 	return toAngle(math.Asin(x))
 }
 
+func angleAcos(x float64) float64 {
+	return toAngle(math.Acos(x))
+}
+
+func angleAtan2(y float64, x float64) float64 {
+	return toAngle(math.Atan2(y, x))
+}
+
 func getJdn(jd float64) float64 {
 	return jd - 2451545.0
 }
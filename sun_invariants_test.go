@@ -0,0 +1,25 @@
+package sun_test
+
+import (
+	"testing"
+
+	"github.com/exploded/sun/internal/invariants"
+)
+
+// invariantSeed fixes the cases, so a failure here is a change in behaviour
+// and can be replayed with the suninvariants command.
+const invariantSeed = 1
+
+func TestInvariants(t *testing.T) {
+	n := 1000
+	if testing.Short() {
+		n = 100
+	}
+	failures, err := invariants.Run(n, invariantSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range failures {
+		t.Error(f)
+	}
+}
@@ -0,0 +1,111 @@
+package sun
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLastJdMidnight(t *testing.T) {
+	for _, tt := range []struct {
+		jd, want float64
+	}{
+		{2451545.0, 2451544.5}, // 2000-01-01 12:00
+		{2451544.5, 2451544.5}, // 2000-01-01 00:00
+		{2451544.4999, 2451543.5},
+		{2451545.9, 2451545.5},
+		{2460482.25, 2460481.5},
+	} {
+		if got := getLastJdMidnight(tt.jd); got != tt.want {
+			t.Errorf("getLastJdMidnight(%v) = %v, want %v", tt.jd, got, tt.want)
+		}
+	}
+}
+
+// The positions were recorded before getLastJdMidnight was changed to return
+// the last midnight instead of the next one before noon and the one a day
+// early after it. Sidereal time comes out the same either way, so the
+// positions must not move.
+func TestAltitudeAcrossMidnightChange(t *testing.T) {
+	for _, tt := range []struct {
+		local, zone       string
+		lat, lon          float64
+		altitude, azimuth float64
+	}{
+		{"1950-01-01 00:00:00", "UTC", 51.48, 0, -61.574794590276, 358.434377171223},
+		{"1999-12-31 23:59:59.5", "UTC", 0, 0, -66.915781156195, 181.802053328061},
+		{"2000-01-01 11:59:59.9", "UTC", 0, 0, 66.952583845190, 178.058879678476},
+		{"2000-01-01 12:00:00", "UTC", 0, 0, 66.952598045082, 178.059858330036},
+		{"2024-02-29 05:59:59", "America/Los_Angeles", 34.05, -118.24, -5.375696430847, 95.597958851615},
+		{"2024-02-29 16:00:00", "America/Los_Angeles", 34.05, -118.24, 20.740296026807, 244.918420323712},
+		{"2024-06-21 17:30:00", "Asia/Kolkata", 28.61, 77.21, 22.047219107432, 285.540441072675},
+		{"2024-06-21 05:29:59", "Asia/Kolkata", 28.61, 77.21, 0.336790842845, 63.262972435067},
+		{"2024-12-31 12:45:00", "Pacific/Chatham", -43.95, -176.56, 66.808489368528, 29.841604894511},
+		{"2025-01-01 00:44:59", "Pacific/Chatham", -43.95, -176.56, -22.054955521377, 192.272921156585},
+		{"2038-01-19 03:14:07", "Pacific/Kiritimati", 1.87, -157.43, -48.103950944802, 119.154236112104},
+		{"2100-03-01 12:00:01", "Europe/Oslo", 69.65, 18.96, 12.878176725384, 180.924653561677},
+	} {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Skip(err)
+		}
+		at, err := time.ParseInLocation("2006-01-02 15:04:05", tt.local, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Altitude(at, tt.lat, tt.lon); math.Abs(got-tt.altitude) > 1e-9 {
+			t.Errorf("Altitude at %s %s = %.12f, want %.12f", tt.local, tt.zone, got, tt.altitude)
+		}
+		if got := Azimuth(at, tt.lat, tt.lon); math.Abs(got-tt.azimuth) > 1e-9 {
+			t.Errorf("Azimuth at %s %s = %.12f, want %.12f", tt.local, tt.zone, got, tt.azimuth)
+		}
+		// the zone of t only names the instant
+		if Altitude(at.UTC(), tt.lat, tt.lon) != Altitude(at, tt.lat, tt.lon) {
+			t.Errorf("Altitude at %s depends on the zone", tt.local)
+		}
+	}
+}
+
+func TestExplainUniversalTime(t *testing.T) {
+	for _, h := range []int{0, 6, 11, 12, 18, 23} {
+		at := time.Date(2024, 3, 20, h, 30, 0, 0, time.UTC)
+		for _, s := range Explain(at, 51.5, 0).Steps {
+			if s.Symbol == "UT" && math.Abs(s.Value-(float64(h)+0.5)) > 1e-6 {
+				t.Errorf("UT at %02d:30 = %v", h, s.Value)
+			}
+		}
+	}
+}
+
+// location loads a time zone, skipping the test if the zone database is
+// missing
+func location(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skip(err)
+	}
+	return loc
+}
+
+// date returns midnight on the given date in loc
+func date(loc *time.Location, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// clock returns the given time of day on the date of d, in its location
+func clock(d time.Time, hour int, min int) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, min, 0, 0, d.Location())
+}
+
+// within reports whether a and b are no more than d apart
+func within(a time.Time, b time.Time, d time.Duration) bool {
+	diff := a.Sub(b)
+	return diff >= -d && diff <= d
+}
+
+// failWriter is an io.Writer that always fails
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("closed") }
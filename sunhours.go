@@ -0,0 +1,135 @@
+package sun
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"time"
+)
+
+// SunHoursTile is the average daily sun hours over a grid of cells covering a
+// bounding box, for a map overlay.
+type SunHoursTile struct {
+	Box        BoundingBox
+	Rows, Cols int
+	// Hours is the mean hours a day the Sun is above the threshold in each
+	// cell, in row order with the first row along the northern edge.
+	Hours []float64
+}
+
+// NewSunHoursTile returns the average daily hours from start to end that the
+// Sun is above threshold degrees at the centre of each of rows by cols cells
+// covering box, sampled every step with AltitudeGrid. Terrain and weather are
+// ignored, so these are the astronomical sun hours. A 10 minute step gives the
+// hours to within a few minutes; over a year on a large tile a coarser one may
+// be wanted. A rows, cols or step that is not positive is an error.
+func NewSunHoursTile(start time.Time, end time.Time, box BoundingBox, rows int, cols int, threshold float64, step time.Duration) (*SunHoursTile, error) {
+	if rows <= 0 || cols <= 0 {
+		return nil, errNonPositiveSize
+	}
+	if step <= 0 {
+		return nil, errNonPositiveStep
+	}
+	lats, lons := boxCentres(box, rows, cols)
+	count := make([]int, rows*cols)
+	var alt []float64
+	for t := start; t.Before(end); t = t.Add(step) {
+		alt = AltitudeGrid(t, lats, lons, alt)
+		for i, a := range alt {
+			if a > threshold {
+				count[i]++
+			}
+		}
+	}
+	tile := &SunHoursTile{box, rows, cols, make([]float64, rows*cols)}
+	days := end.Sub(start).Hours() / 24
+	if days <= 0 {
+		return tile, nil
+	}
+	for i, n := range count {
+		tile.Hours[i] = float64(n) * step.Hours() / days
+	}
+	return tile, nil
+}
+
+// cellSize returns the width and height of a cell in degrees
+func (s *SunHoursTile) cellSize() (dx float64, dy float64) {
+	width := s.Box.East - s.Box.West
+	if width < 0 {
+		width += 360
+	}
+	return width / float64(s.Cols), (s.Box.North - s.Box.South) / float64(s.Rows)
+}
+
+// Image returns the tile with one pixel per cell, shaded from dark blue for no
+// sun through orange at 12 hours to pale yellow at 24. The scale is fixed so
+// neighbouring tiles match.
+func (s *SunHoursTile) Image() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, s.Cols, s.Rows))
+	for r := 0; r < s.Rows; r++ {
+		for c := 0; c < s.Cols; c++ {
+			img.Set(c, r, sunHoursColor(s.Hours[r*s.Cols+c]))
+		}
+	}
+	return img
+}
+
+// sunHoursColor returns the colour of the tile image for h sun hours
+func sunHoursColor(h float64) color.RGBA {
+	none := color.RGBA{12, 14, 40, 255}
+	half := color.RGBA{245, 150, 40, 255}
+	all := color.RGBA{255, 250, 200, 255}
+	if h < 12 {
+		return lerpColor(none, half, h/12)
+	}
+	return lerpColor(half, all, (h-12)/12)
+}
+
+// WritePNG writes the tile image as a PNG.
+func (s *SunHoursTile) WritePNG(w io.Writer) error {
+	return png.Encode(w, s.Image())
+}
+
+// WriteWorldFile writes the world file that places the PNG on a map in
+// longitude and latitude (EPSG:4326), to be saved beside it with the
+// extension .pgw.
+func (s *SunHoursTile) WriteWorldFile(w io.Writer) error {
+	dx, dy := s.cellSize()
+	_, err := fmt.Fprintf(w, "%.10f\n0\n0\n%.10f\n%.10f\n%.10f\n",
+		dx, -dy, s.Box.West+dx/2, s.Box.North-dy/2)
+	return err
+}
+
+// WriteASCIIGrid writes the hours as an Esri ASCII grid, a georeferenced
+// raster that GDAL and desktop GIS read directly. Cells that are not square
+// are given as dx and dy, as GDAL allows.
+func (s *SunHoursTile) WriteASCIIGrid(w io.Writer) error {
+	dx, dy := s.cellSize()
+	size := fmt.Sprintf("cellsize %.10f\n", dx)
+	if dx != dy {
+		size = fmt.Sprintf("dx %.10f\ndy %.10f\n", dx, dy)
+	}
+	_, err := fmt.Fprintf(w, "ncols %d\nnrows %d\nxllcorner %.10f\nyllcorner %.10f\n%s",
+		s.Cols, s.Rows, s.Box.West, s.Box.South, size)
+	if err != nil {
+		return err
+	}
+	line := make([]byte, 0, 8*s.Cols)
+	for r := 0; r < s.Rows; r++ {
+		line = line[:0]
+		for c := 0; c < s.Cols; c++ {
+			if c > 0 {
+				line = append(line, ' ')
+			}
+			line = strconv.AppendFloat(line, s.Hours[r*s.Cols+c], 'f', 3, 64)
+		}
+		line = append(line, '\n')
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package sun
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSunHoursTile(t *testing.T) {
+	start := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	box := BoundingBox{South: -80, West: -30, North: 80, East: 30}
+	tile, err := NewSunHoursTile(start, start.AddDate(0, 0, 1), box, 4, 2, 0, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the length of the day on the solstice, from cos H = -tan φ tan δ
+	for i, lat := range []float64{60, 20, -20, -60} {
+		want := 2 * angleAcos(-angleTan(lat)*angleTan(23.44)) / 15
+		for c := 0; c < 2; c++ {
+			if got := tile.Hours[i*2+c]; math.Abs(got-want) > 0.2 {
+				t.Errorf("row %d column %d (latitude %v): %.2f hours, want %.2f", i, c, lat, got, want)
+			}
+		}
+	}
+}
+
+func TestNewSunHoursTileErrors(t *testing.T) {
+	start := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	box := BoundingBox{South: 0, West: 0, North: 1, East: 1}
+	for _, tt := range []struct {
+		rows, cols int
+		step       time.Duration
+		want       error
+	}{
+		{0, 1, time.Hour, errNonPositiveSize},
+		{1, -1, time.Hour, errNonPositiveSize},
+		{1, 1, 0, errNonPositiveStep},
+	} {
+		if _, err := NewSunHoursTile(start, start.AddDate(0, 0, 1), box, tt.rows, tt.cols, 0, tt.step); err != tt.want {
+			t.Errorf("NewSunHoursTile(%d, %d, %v): err = %v, want %v", tt.rows, tt.cols, tt.step, err, tt.want)
+		}
+	}
+}
+
+func TestSunHoursTileOutput(t *testing.T) {
+	tile := &SunHoursTile{BoundingBox{South: 50, West: -2, North: 52, East: 2}, 2, 2, []float64{0, 6, 12, 24}}
+	var b bytes.Buffer
+	if err := tile.WriteWorldFile(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "2.0000000000\n0\n0\n-1.0000000000\n-1.0000000000\n51.5000000000\n"
+	if b.String() != want {
+		t.Errorf("world file %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := tile.WriteASCIIGrid(&b); err != nil {
+		t.Fatal(err)
+	}
+	want = "ncols 2\nnrows 2\nxllcorner -2.0000000000\nyllcorner 50.0000000000\n" +
+		"dx 2.0000000000\ndy 1.0000000000\n0.000 6.000\n12.000 24.000\n"
+	if b.String() != want {
+		t.Errorf("ASCII grid %q, want %q", b.String(), want)
+	}
+
+	img := tile.Image()
+	if c := img.At(0, 0); c != sunHoursColor(0) {
+		t.Errorf("no sun drawn as %v", c)
+	}
+	b.Reset()
+	if err := tile.WritePNG(&b); err != nil || !strings.HasPrefix(b.String(), "\x89PNG") {
+		t.Errorf("WritePNG: err %v, %d bytes", err, b.Len())
+	}
+}
@@ -0,0 +1,74 @@
+package sunhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// gazetteer is a Geocoder that knows Berlin and fails for "down"
+func gazetteer(t *testing.T) sun.Geocoder {
+	berlin := sun.GeocodeResult{Name: "Berlin", Point: sun.Point{Latitude: 52.52, Longitude: 13.405}, Location: location(t, "Europe/Berlin")}
+	return sun.GeocoderFunc(func(ctx context.Context, query string) (sun.GeocodeResult, error) {
+		switch query {
+		case "Berlin":
+			return berlin, nil
+		case "down":
+			return sun.GeocodeResult{}, errors.New("timeout")
+		}
+		return sun.GeocodeResult{}, sun.ErrPlaceNotFound
+	})
+}
+
+func TestLocate(t *testing.T) {
+	g := gazetteer(t)
+	for _, tt := range []struct {
+		target   string
+		g        sun.Geocoder
+		status   int
+		lat, lon float64
+	}{
+		{"/?q=Berlin", g, http.StatusOK, 52.52, 13.405},
+		{"/?q=52.52,13.405", g, http.StatusOK, 52.52, 13.405},
+		{"/?q=Berlin&lat=1&lon=2", g, http.StatusOK, 52.52, 13.405},
+		{"/?q=Atlantis", g, http.StatusNotFound, 0, 0},
+		{"/?q=down", g, http.StatusBadGateway, 0, 0},
+		// without a geocoder q is ignored
+		{"/?q=Berlin&lat=1&lon=2", nil, http.StatusOK, 1, 2},
+		{"/?q=Berlin", nil, http.StatusBadRequest, 0, 0},
+	} {
+		place, status, err := locate(httptest.NewRequest(http.MethodGet, tt.target, nil), tt.g)
+		if status != tt.status || (err == nil) != (status == http.StatusOK) || place.Latitude != tt.lat || place.Longitude != tt.lon {
+			t.Errorf("locate(%s) = %+v, %d, %v, want %v, %v and %d", tt.target, place, status, err, tt.lat, tt.lon, tt.status)
+		}
+	}
+}
+
+// A place name gives the same feed and images as its coordinates, with the
+// geocoder's name and time zone as the defaults.
+func TestGeocodedHandlers(t *testing.T) {
+	g := gazetteer(t)
+	now := fixedNow(time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC))
+	for _, tt := range []struct {
+		h           http.Handler
+		byName, byQ string
+	}{
+		{ICalHandler{Geocoder: g, Now: now}, "/sun.ics?q=Berlin", "/sun.ics?lat=52.52&lon=13.405&tz=Europe/Berlin&name=Berlin"},
+		{SunPathHandler{Geocoder: g, Now: now}, "/sunpath.png?q=Berlin", "/sunpath.png?lat=52.52&lon=13.405"},
+		{PositionHandler{Geocoder: g, Now: now}, "/position?q=Berlin", "/position?lat=52.52&lon=13.405"},
+	} {
+		a, b := get(tt.h, tt.byName), get(tt.h, tt.byQ)
+		if a.Code != http.StatusOK || !bytes.Equal(a.Body.Bytes(), b.Body.Bytes()) {
+			t.Errorf("%s: status %d, body differs from %s:\n%s\n%s", tt.byName, a.Code, tt.byQ, a.Body, b.Body)
+		}
+		if w := get(tt.h, tt.byName[:len(tt.byName)-6]+"Atlantis"); w.Code != http.StatusNotFound {
+			t.Errorf("unknown place: status %d, want 404", w.Code)
+		}
+	}
+}
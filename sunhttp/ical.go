@@ -0,0 +1,126 @@
+package sunhttp
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/exploded/sun"
+	"github.com/exploded/sun/internal/ical"
+)
+
+// icalEvent is one solar event in a calendar
+type icalEvent struct {
+	uid     string
+	summary string
+	at      time.Time
+}
+
+// writeICal writes a VCALENDAR of sunrise and sunset from the date of start
+// for days days at the given location, with times in the zone loc. A
+// VTIMEZONE holding every offset change in the window is included, so
+// clients show the right clock times even for zones they do not know.
+func writeICal(w io.Writer, name string, start time.Time, days int, latitude float64, longitude float64, loc *time.Location) error {
+	var events []icalEvent
+	y, m, d := start.In(loc).Date()
+	key := strconv.FormatFloat(latitude, 'f', 4, 64) + "_" + strconv.FormatFloat(longitude, 'f', 4, 64)
+	for i := 0; i < days; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		date := day.Format("20060102")
+		if rise, ok := sun.Sunrise(day, latitude, longitude); ok {
+			events = append(events, icalEvent{"sunrise-" + date + "-" + key + "@sun", "Sunrise", rise})
+		}
+		if set, ok := sun.Sunset(day, latitude, longitude); ok {
+			events = append(events, icalEvent{"sunset-" + date + "-" + key + "@sun", "Sunset", set})
+		}
+	}
+
+	lw := ical.NewWriter(w)
+	lw.Line("BEGIN:VCALENDAR")
+	lw.Line("VERSION:2.0")
+	lw.Line("PRODID:-//exploded//sun//EN")
+	lw.Line("CALSCALE:GREGORIAN")
+	lw.Line("METHOD:PUBLISH")
+	lw.Line("X-WR-CALNAME:" + ical.Text(name))
+	lw.Line("X-PUBLISHED-TTL:P1D")
+	utc := loc == time.UTC
+	if !utc {
+		lw.Line("X-WR-TIMEZONE:" + loc.String())
+		writeVTimezone(lw, loc, time.Date(y, m, d, 0, 0, 0, 0, loc), time.Date(y, m, d+days, 0, 0, 0, 0, loc))
+	}
+	// stamp with the start of the window so the feed only changes once a day
+	stamp := time.Date(y, m, d, 0, 0, 0, 0, loc).UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		at := e.at.Round(time.Minute)
+		lw.Line("BEGIN:VEVENT")
+		lw.Line("UID:" + e.uid)
+		lw.Line("DTSTAMP:" + stamp)
+		if utc {
+			lw.Line("DTSTART:" + at.UTC().Format("20060102T150405Z"))
+			lw.Line("DTEND:" + at.UTC().Format("20060102T150405Z"))
+		} else {
+			lw.Line("DTSTART;TZID=" + loc.String() + ":" + at.In(loc).Format("20060102T150405"))
+			lw.Line("DTEND;TZID=" + loc.String() + ":" + at.In(loc).Format("20060102T150405"))
+		}
+		lw.Line("SUMMARY:" + ical.Text(e.summary+" "+at.In(loc).Format("15:04")))
+		lw.Line("TRANSP:TRANSPARENT")
+		lw.Line("END:VEVENT")
+	}
+	lw.Line("END:VCALENDAR")
+	return lw.Err()
+}
+
+// writeVTimezone writes a VTIMEZONE for loc with an observance for the offset
+// at from and one for every change of offset before to
+func writeVTimezone(lw *ical.Writer, loc *time.Location, from time.Time, to time.Time) {
+	lw.Line("BEGIN:VTIMEZONE")
+	lw.Line("TZID:" + loc.String())
+	_, off := from.Zone()
+	writeObservance(lw, from, off, from)
+	for t := from; t.Before(to); {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o != off {
+			// narrow the change down to the second
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, o := mid.Zone(); o == off {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			writeObservance(lw, hi, off, hi)
+			_, off = hi.Zone()
+		}
+		t = next
+	}
+	lw.Line("END:VTIMEZONE")
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT observance starting at onset,
+// changing from offset fromOffset to the offset in force at t
+func writeObservance(lw *ical.Writer, onset time.Time, fromOffset int, t time.Time) {
+	name, off := t.Zone()
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	lw.Line("BEGIN:" + kind)
+	// DTSTART is the local time of the onset in the offset being left
+	lw.Line("DTSTART:" + onset.In(time.FixedZone("", fromOffset)).Format("20060102T150405"))
+	lw.Line("TZOFFSETFROM:" + icalOffset(fromOffset))
+	lw.Line("TZOFFSETTO:" + icalOffset(off))
+	lw.Line("TZNAME:" + ical.Text(name))
+	lw.Line("END:" + kind)
+}
+
+// icalOffset formats an offset in seconds east of UTC as +HHMM
+func icalOffset(s int) string {
+	sign := "+"
+	if s < 0 {
+		sign, s = "-", -s
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, s/3600, s/60%60)
+}
@@ -0,0 +1,228 @@
+package sunhttp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedNow returns a Now func for the handlers that always gives t
+func fixedNow(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// get serves a GET of target from h and returns the recorded response
+func get(h http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// unfold returns the content lines of a calendar with folding undone
+func unfold(s string) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+// property returns the values of the named property in lines, ignoring
+// parameters
+func property(lines []string, name string) []string {
+	var vs []string
+	for _, l := range lines {
+		i := strings.IndexByte(l, ':')
+		if i < 0 {
+			continue
+		}
+		if n := l[:i]; n == name || strings.HasPrefix(n, name+";") {
+			vs = append(vs, l[i+1:])
+		}
+	}
+	return vs
+}
+
+const londonFeed = "/sun.ics?lat=51.5074&lon=-0.1278&tz=Europe/London&name=London"
+
+func TestICalHandler(t *testing.T) {
+	london := location(t, "Europe/London")
+	h := ICalHandler{Now: fixedNow(time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC))}
+	w := get(h, londonFeed)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	for _, tt := range []struct{ header, want string }{
+		{"Content-Type", "text/calendar; charset=utf-8"},
+		// the window is until midnight BST, 14 hours after 11:00 BST
+		{"Cache-Control", "public, max-age=46800"},
+		{"Last-Modified", "Thu, 20 Jun 2024 23:00:00 GMT"},
+	} {
+		if got := w.Header().Get(tt.header); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+
+	body := w.Body.String()
+	if strings.Contains(strings.ReplaceAll(body, "\r\n", ""), "\n") {
+		t.Error("calendar has a line not ending in CRLF")
+	}
+	lines := unfold(body)
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("calendar runs from %q to %q", lines[0], lines[len(lines)-1])
+	}
+	if got := property(lines, "X-WR-CALNAME"); len(got) != 1 || got[0] != "London" {
+		t.Errorf("X-WR-CALNAME = %q, want London", got)
+	}
+
+	// British Summer Time ended at 02:00 BST on 27 October 2024 and began
+	// again at 01:00 GMT on 30 March 2025
+	if got, want := property(lines, "DTSTART")[:3], []string{"20240621T000000", "20241027T020000", "20250330T010000"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("VTIMEZONE onsets = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(property(lines, "TZOFFSETTO"), " "), "+0100 +0000 +0100"; got != want {
+		t.Errorf("TZOFFSETTO = %s, want %s", got, want)
+	}
+
+	// a year of days, each with a sunrise and a sunset
+	uids := property(lines, "UID")
+	if len(uids) != 2*feedDays {
+		t.Errorf("%d events, want %d", len(uids), 2*feedDays)
+	}
+	seen := map[string]bool{}
+	for _, u := range uids {
+		if seen[u] {
+			t.Errorf("UID %s repeated", u)
+		}
+		seen[u] = true
+	}
+
+	// times to the minute from the timeanddate.com almanac; the feed rounds
+	// to the nearest minute where the almanac truncates, so a minute either
+	// way is allowed
+	starts := map[string]string{}
+	for i, u := range uids {
+		starts[u] = property(lines, "DTSTART;TZID=Europe/London")[i]
+	}
+	for _, tt := range []struct{ uid, want string }{
+		{"sunrise-20240621-51.5074_-0.1278@sun", "20240621T0443"},
+		{"sunset-20240621-51.5074_-0.1278@sun", "20240621T2121"},
+		{"sunrise-20241221-51.5074_-0.1278@sun", "20241221T0803"},
+		{"sunset-20241221-51.5074_-0.1278@sun", "20241221T1553"},
+	} {
+		got, err := time.ParseInLocation("20060102T150405", starts[tt.uid], london)
+		if err != nil {
+			t.Errorf("%s: %v", tt.uid, err)
+			continue
+		}
+		want, _ := time.ParseInLocation("20060102T1504", tt.want, london)
+		if d := got.Sub(want); d < -time.Minute || d > time.Minute {
+			t.Errorf("%s starts at %s, want %s", tt.uid, starts[tt.uid], tt.want)
+		}
+	}
+}
+
+func TestICalHandlerCaching(t *testing.T) {
+	h := ICalHandler{Now: fixedNow(time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC))}
+	first := get(h, londonFeed)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+	if w := get(h, londonFeed, "If-None-Match", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("conditional request: status %d with %d bytes, want 304 and none", w.Code, w.Body.Len())
+	}
+
+	// later the same day the feed is the same
+	h.Now = fixedNow(time.Date(2024, time.June, 21, 22, 59, 0, 0, time.UTC))
+	later := get(h, londonFeed)
+	if later.Header().Get("ETag") != etag || !bytes.Equal(later.Body.Bytes(), first.Body.Bytes()) {
+		t.Error("the feed changed within the day")
+	}
+	if got := later.Header().Get("Cache-Control"); got != "public, max-age=60" {
+		t.Errorf("Cache-Control a minute before midnight = %q", got)
+	}
+
+	// the next day the window rolls on, keeping the UIDs of the days it shares
+	h.Now = fixedNow(time.Date(2024, time.June, 21, 23, 0, 0, 0, time.UTC))
+	next := get(h, londonFeed)
+	if next.Header().Get("ETag") == etag {
+		t.Error("the ETag did not change with the window")
+	}
+	a, b := property(unfold(first.Body.String()), "UID"), property(unfold(next.Body.String()), "UID")
+	if strings.Join(a[2:], " ") != strings.Join(b[:len(b)-2], " ") {
+		t.Error("the UIDs of the shared days changed")
+	}
+
+	r := httptest.NewRequest(http.MethodHead, londonFeed, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.Len() != 0 || w.Header().Get("ETag") == "" {
+		t.Errorf("HEAD: status %d with %d bytes", w.Code, w.Body.Len())
+	}
+}
+
+func TestICalHandlerPolar(t *testing.T) {
+	// in UTC, with no VTIMEZONE; Tromsø has no sunrise or sunset from 20 May
+	// to 22 July, nor from 27 November to 15 January
+	h := ICalHandler{Now: fixedNow(time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC))}
+	w := get(h, "/sun.ics?lat=69.6492&lon=18.9553")
+	lines := unfold(w.Body.String())
+	if len(property(lines, "TZID")) != 0 {
+		t.Error("a UTC feed has a VTIMEZONE")
+	}
+	if got := property(lines, "X-WR-CALNAME"); len(got) != 1 || got[0] != "Sunrise and sunset" {
+		t.Errorf("X-WR-CALNAME = %q", got)
+	}
+	starts := property(lines, "DTSTART")
+	if n := len(starts); n < 2*(feedDays-130) || n > 2*(feedDays-110) {
+		t.Errorf("%d events, want about %d", n, 2*(feedDays-120))
+	}
+	if !strings.HasPrefix(starts[0], "202407") || !strings.HasSuffix(starts[0], "Z") {
+		t.Errorf("first event at %s, want a UTC time in July", starts[0])
+	}
+}
+
+func TestICalHandlerErrors(t *testing.T) {
+	h := ICalHandler{}
+	for _, target := range []string{
+		"/sun.ics",
+		"/sun.ics?lat=91&lon=0",
+		"/sun.ics?lat=0&lon=-180.5",
+		"/sun.ics?lat=north&lon=0",
+		"/sun.ics?lat=0&lon=0&tz=Mars/Olympus_Mons",
+	} {
+		if w := get(h, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestICalOffset(t *testing.T) {
+	for _, tt := range []struct {
+		s    int
+		want string
+	}{
+		{3600, "+0100"},
+		{0, "+0000"},
+		{-(3*3600 + 30*60), "-0330"},
+		{12*3600 + 45*60, "+1245"},
+	} {
+		if got := icalOffset(tt.s); got != tt.want {
+			t.Errorf("icalOffset(%d) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+// location loads the named zone, skipping the test if it is not installed
+func location(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skip(err)
+	}
+	return loc
+}
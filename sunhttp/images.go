@@ -0,0 +1,119 @@
+package sunhttp
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// maxImageSide bounds the width and height of served images, so one request
+// cannot ask for an enormous render
+const maxImageSide = 2048
+
+// SunPathHandler serves a sun-path diagram, as drawn by sun.SunPathImage, as
+// a PNG for the location in the query:
+//
+//	/sunpath.png?lat=52.52&lon=13.40&year=2024&w=640&h=320
+//
+// year defaults to the current year and the size to 640 by 320. The diagram
+// only changes with the year, so it may be cached for a day.
+type SunPathHandler struct {
+	// Geocoder, if set, resolves a place name given as q in place of lat
+	// and lon.
+	Geocoder sun.Geocoder
+	// Now returns the current time; nil means time.Now. It is there for tests.
+	Now func() time.Time
+}
+
+func (h SunPathHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	place, status, err := locate(r, h.Geocoder)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	lat, lon := place.Latitude, place.Longitude
+	width, height, ok := parseSize(q.Get("w"), q.Get("h"), 640, 320)
+	if !ok {
+		http.Error(w, "w and h must be whole numbers of pixels up to 2048", http.StatusBadRequest)
+		return
+	}
+	now := time.Now()
+	if h.Now != nil {
+		now = h.Now()
+	}
+	year := now.Year()
+	if s := q.Get("year"); s != "" {
+		if year, err = strconv.Atoi(s); err != nil {
+			http.Error(w, "year must be a whole number", http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	writePNG(w, sun.SunPathImage(year, lat, lon, width, height))
+}
+
+// TerminatorHandler serves a world map, as drawn by sun.TerminatorImage, as a
+// PNG showing where it is day, twilight and night now:
+//
+//	/terminator.png?w=720&h=360
+//
+// The size defaults to 720 by 360. The map is on a plain background unless
+// Base is set, and it is then drawn at the size of Base whatever the query.
+type TerminatorHandler struct {
+	Base image.Image
+	// Now returns the current time; nil means time.Now. It is there for tests.
+	Now func() time.Time
+}
+
+func (h TerminatorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	width, height, ok := parseSize(q.Get("w"), q.Get("h"), 720, 360)
+	if !ok {
+		http.Error(w, "w and h must be whole numbers of pixels up to 2048", http.StatusBadRequest)
+		return
+	}
+	if h.Base != nil {
+		width, height = h.Base.Bounds().Dx(), h.Base.Bounds().Dy()
+	}
+	now := time.Now()
+	if h.Now != nil {
+		now = h.Now()
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	writePNG(w, sun.TerminatorImage(now, h.Base, width, height))
+}
+
+// parseSize parses an image width and height, either of which may be empty
+// for the default
+func parseSize(w string, h string, defWidth int, defHeight int) (int, int, bool) {
+	width, height := defWidth, defHeight
+	var err1, err2 error
+	if w != "" {
+		width, err1 = strconv.Atoi(w)
+	}
+	if h != "" {
+		height, err2 = strconv.Atoi(h)
+	}
+	if err1 != nil || err2 != nil || width < 1 || height < 1 || width > maxImageSide || height > maxImageSide {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// writePNG encodes img before writing anything, so an encoding failure can
+// still be reported as an error
+func writePNG(w http.ResponseWriter, img image.Image) {
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(b.Bytes())
+}
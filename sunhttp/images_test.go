@@ -0,0 +1,146 @@
+package sunhttp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// decodePNG checks the response is a PNG and decodes it
+func decodePNG(t *testing.T, w *httptest.ResponseRecorder) image.Image {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+		t.Fatalf("Content-Type %q", ct)
+	}
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+// sameImage reports whether a and b have the same size and pixels
+func sameImage(a image.Image, b image.Image) bool {
+	if a.Bounds().Size() != b.Bounds().Size() {
+		return false
+	}
+	ra, rb := a.Bounds(), b.Bounds()
+	for y := 0; y < ra.Dy(); y++ {
+		for x := 0; x < ra.Dx(); x++ {
+			if color.RGBAModel.Convert(a.At(ra.Min.X+x, ra.Min.Y+y)) != color.RGBAModel.Convert(b.At(rb.Min.X+x, rb.Min.Y+y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSunPathHandler(t *testing.T) {
+	h := SunPathHandler{Now: fixedNow(time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC))}
+	for _, tt := range []struct {
+		target        string
+		year          int
+		width, height int
+	}{
+		{"/sunpath.png?lat=52.52&lon=13.40", 2024, 640, 320},
+		{"/sunpath.png?lat=52.52&lon=13.40&year=2000&w=300&h=200", 2000, 300, 200},
+		{"/sunpath.png?lat=-33.87&lon=151.21&w=2048&h=1", 2024, 2048, 1},
+	} {
+		w := get(h, tt.target)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", tt.target, w.Code, w.Body)
+		}
+		if cc := w.Header().Get("Cache-Control"); cc != "public, max-age=86400" {
+			t.Errorf("%s: Cache-Control %q", tt.target, cc)
+		}
+		img := decodePNG(t, w)
+		place, _, _ := locate(httptest.NewRequest(http.MethodGet, tt.target, nil), nil)
+		want := sun.SunPathImage(tt.year, place.Latitude, place.Longitude, tt.width, tt.height)
+		if !sameImage(img, want) {
+			t.Errorf("%s: image is not the %d diagram at %dx%d", tt.target, tt.year, tt.width, tt.height)
+		}
+	}
+
+	for _, target := range []string{
+		"/sunpath.png?lat=52.52",
+		"/sunpath.png?lat=91&lon=0",
+		"/sunpath.png?lat=52.52&lon=13.40&w=0",
+		"/sunpath.png?lat=52.52&lon=13.40&h=2049",
+		"/sunpath.png?lat=52.52&lon=13.40&w=wide",
+		"/sunpath.png?lat=52.52&lon=13.40&year=next",
+	} {
+		if w := get(h, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+// At noon UT on 20 March 2024 the Sun is over the equator at 1.8 E, so
+// Greenwich on the equator is in daylight, the plain background, and the date
+// line in full night, shaded 70 per cent of the way to {5, 5, 25}.
+func TestTerminatorHandler(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+	h := TerminatorHandler{Now: fixedNow(now)}
+	w := get(h, "/terminator.png")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("Cache-Control %q", cc)
+	}
+	img := decodePNG(t, w)
+	if !sameImage(img, sun.TerminatorImage(now, nil, 720, 360)) {
+		t.Error("image is not the terminator map at 720x360")
+	}
+	for _, tt := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{360, 180, color.RGBA{70, 110, 170, 255}},
+		{719, 180, color.RGBA{25, 37, 69, 255}},
+	} {
+		if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("pixel %d, %d = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	// a base map sets the size whatever the query
+	base := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	w = get(TerminatorHandler{Base: base, Now: fixedNow(now)}, "/terminator.png?w=720&h=360")
+	if img := decodePNG(t, w); img.Bounds().Dx() != 100 || img.Bounds().Dy() != 50 {
+		t.Errorf("size with a base map %v, want 100x50", img.Bounds())
+	}
+
+	for _, target := range []string{"/terminator.png?w=-1", "/terminator.png?h=4096", "/terminator.png?w=1.5"} {
+		if w := get(h, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for _, tt := range []struct {
+		w, h          string
+		width, height int
+		ok            bool
+	}{
+		{"", "", 640, 320, true},
+		{"100", "", 100, 320, true},
+		{"", "2048", 640, 2048, true},
+		{"0", "", 0, 0, false},
+		{"2049", "1", 0, 0, false},
+		{"x", "1", 0, 0, false},
+	} {
+		width, height, ok := parseSize(tt.w, tt.h, 640, 320)
+		if width != tt.width || height != tt.height || ok != tt.ok {
+			t.Errorf("parseSize(%q, %q) = %d, %d, %v, want %d, %d, %v", tt.w, tt.h, width, height, ok, tt.width, tt.height, tt.ok)
+		}
+	}
+}
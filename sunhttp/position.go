@@ -0,0 +1,189 @@
+package sunhttp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// Response schemas for PositionHandler.
+const (
+	SchemaDefault       = ""
+	SchemaHomeAssistant = "homeassistant"
+)
+
+// PositionHandler serves the current position of the Sun and today's events
+// as JSON for the location in the query:
+//
+//	/sun?lat=52.52&lon=13.40
+//
+// With Schema set to SchemaHomeAssistant, or schema=homeassistant in the
+// query, the response matches the state and attributes of Home Assistant's
+// sun integration (next_dawn, next_dusk, next_midnight, next_noon,
+// next_rising, next_setting, elevation, azimuth, rising), so it can be used
+// directly as a RESTful sensor with json_attributes_path "$.attributes".
+type PositionHandler struct {
+	Schema string
+	// Geocoder, if set, resolves a place name given as q in place of lat
+	// and lon.
+	Geocoder sun.Geocoder
+	// Now returns the current time; nil means time.Now. It is there for tests.
+	Now func() time.Time
+}
+
+// position is the default response
+type position struct {
+	Time      time.Time  `json:"time"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Altitude  float64    `json:"altitude"`
+	Azimuth   float64    `json:"azimuth"`
+	Phase     string     `json:"phase"`
+	Sunrise   *time.Time `json:"sunrise"`
+	Sunset    *time.Time `json:"sunset"`
+	Noon      time.Time  `json:"noon"`
+	DayLength float64    `json:"dayLength"` // seconds
+}
+
+// haState is the Home Assistant sun entity
+type haState struct {
+	State      string       `json:"state"`
+	Attributes haAttributes `json:"attributes"`
+}
+
+type haAttributes struct {
+	NextDawn     string  `json:"next_dawn"`
+	NextDusk     string  `json:"next_dusk"`
+	NextMidnight string  `json:"next_midnight"`
+	NextNoon     string  `json:"next_noon"`
+	NextRising   string  `json:"next_rising"`
+	NextSetting  string  `json:"next_setting"`
+	Elevation    float64 `json:"elevation"`
+	Azimuth      float64 `json:"azimuth"`
+	Rising       bool    `json:"rising"`
+}
+
+func (h PositionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	place, status, err := locate(r, h.Geocoder)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	lat, lon := place.Latitude, place.Longitude
+	now := time.Now()
+	if h.Now != nil {
+		now = h.Now()
+	}
+	now = now.UTC()
+	schema := h.Schema
+	if s := q.Get("schema"); s != "" {
+		schema = s
+	}
+
+	var body interface{}
+	switch schema {
+	case SchemaDefault:
+		body = newPosition(now, lat, lon)
+	case SchemaHomeAssistant:
+		body = newHAState(now, lat, lon)
+	default:
+		http.Error(w, "unknown schema", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	json.NewEncoder(w).Encode(body)
+}
+
+func newPosition(now time.Time, lat float64, lon float64) position {
+	day := sun.DayEvents(now, lat, lon)
+	phase, _ := sun.Phase(now, lat, lon)
+	p := position{
+		Time:      now,
+		Latitude:  lat,
+		Longitude: lon,
+		Altitude:  sun.Altitude(now, lat, lon),
+		Azimuth:   sun.Azimuth(now, lat, lon),
+		Phase:     phase.String(),
+		Noon:      day.Noon.Time,
+		DayLength: day.Length.Seconds(),
+	}
+	if !day.Sunrise.IsZero() {
+		p.Sunrise = &day.Sunrise
+	}
+	if !day.Sunset.IsZero() {
+		p.Sunset = &day.Sunset
+	}
+	return p
+}
+
+func newHAState(now time.Time, lat float64, lon float64) haState {
+	elevation := sun.Altitude(now, lat, lon)
+	altRate, _ := sun.Rates(now, lat, lon)
+	s := haState{State: "below_horizon"}
+	if elevation > sun.SunriseAltitude {
+		s.State = "above_horizon"
+	}
+	s.Attributes = haAttributes{
+		NextDawn:     haTime(nextCrossing(now, lat, lon, sun.CivilTwilightAltitude, true)),
+		NextDusk:     haTime(nextCrossing(now, lat, lon, sun.CivilTwilightAltitude, false)),
+		NextMidnight: haTime(nextNoon(now, lat, lon, true)),
+		NextNoon:     haTime(nextNoon(now, lat, lon, false)),
+		NextRising:   haTime(nextCrossing(now, lat, lon, sun.SunriseAltitude, true)),
+		NextSetting:  haTime(nextCrossing(now, lat, lon, sun.SunriseAltitude, false)),
+		Elevation:    round2(elevation),
+		Azimuth:      round2(sun.Azimuth(now, lat, lon)),
+		Rising:       altRate > 0,
+	}
+	return s
+}
+
+// nextCrossing returns the first time after now that the Sun passes altitude
+// alt in the given direction, looking up to a year ahead
+func nextCrossing(now time.Time, lat float64, lon float64, alt float64, rising bool) time.Time {
+	for day := now.AddDate(0, 0, -1); day.Before(now.AddDate(1, 0, 1)); day = day.AddDate(0, 0, 1) {
+		noon := sun.Culminate(day, lat, lon).Time
+		var window time.Time
+		if rising {
+			window = noon.Add(-12 * time.Hour)
+		} else {
+			window = noon
+		}
+		for _, c := range sun.AltitudeCrossings(window, window.Add(12*time.Hour), lat, lon, alt) {
+			if c.Increasing == rising && c.Time.After(now) {
+				return c.Time
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// nextNoon returns the next solar noon, or solar midnight, after now
+func nextNoon(now time.Time, lat float64, lon float64, midnight bool) time.Time {
+	for day := now.AddDate(0, 0, -1); ; day = day.AddDate(0, 0, 1) {
+		t := sun.Culminate(day, lat, lon).Time
+		if midnight {
+			t = t.Add(12 * time.Hour)
+		}
+		if t.After(now) {
+			return t
+		}
+	}
+}
+
+// haTime formats t as Home Assistant does, in UTC with a +00:00 offset, or as
+// an empty string if it is zero
+func haTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Round(time.Second).Format("2006-01-02T15:04:05-07:00")
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,141 @@
+package sunhttp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// londonNow is 11:00 BST on the summer solstice in London. The almanac gives
+// sunrise 04:43, solar noon 13:02 and sunset 21:21 BST; for a declination of
+// 23.44 degrees the hour angle at the civil twilight altitude of -6 degrees is
+// 136.72 degrees, putting dawn at 03:55 and dusk at 22:09 BST.
+var londonNow = time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+
+const londonQuery = "lat=51.5074&lon=-0.1278"
+
+func TestPositionHandler(t *testing.T) {
+	w := get(PositionHandler{Now: fixedNow(londonNow)}, "/sun?"+londonQuery)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var p position
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Time.Equal(londonNow) || p.Latitude != 51.5074 || p.Longitude != -0.1278 || p.Phase != "Day" {
+		t.Errorf("position = %+v", p)
+	}
+	// noon is at 12:02:20 UT, so the hour angle is -30.58 degrees, which puts
+	// the Sun at 53.42 degrees altitude and 128.45 degrees azimuth
+	if math.Abs(p.Altitude-53.42) > 0.05 || math.Abs(p.Azimuth-128.45) > 0.1 {
+		t.Errorf("altitude, azimuth = %.2f, %.2f, want 53.42, 128.45", p.Altitude, p.Azimuth)
+	}
+	if p.Sunrise == nil || p.Sunset == nil {
+		t.Fatalf("sunrise %v, sunset %v", p.Sunrise, p.Sunset)
+	}
+	for _, tt := range []struct {
+		name      string
+		got, want time.Time
+	}{
+		{"sunrise", *p.Sunrise, time.Date(2024, time.June, 21, 3, 43, 0, 0, time.UTC)},
+		{"noon", p.Noon, time.Date(2024, time.June, 21, 12, 2, 0, 0, time.UTC)},
+		{"sunset", *p.Sunset, time.Date(2024, time.June, 21, 20, 21, 0, 0, time.UTC)},
+	} {
+		if d := tt.got.Sub(tt.want); d < -time.Minute || d > 2*time.Minute {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if got := p.Sunset.Sub(*p.Sunrise).Seconds(); p.DayLength != got {
+		t.Errorf("dayLength = %v, want %v", p.DayLength, got)
+	}
+}
+
+func TestPositionHandlerMidnightSun(t *testing.T) {
+	w := get(PositionHandler{Now: fixedNow(londonNow)}, "/sun?lat=69.6492&lon=18.9553")
+	var p map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p["sunrise"] != nil || p["sunset"] != nil || p["dayLength"] != 86400.0 {
+		t.Errorf("midnight sun: sunrise %v, sunset %v, dayLength %v", p["sunrise"], p["sunset"], p["dayLength"])
+	}
+}
+
+func TestPositionHandlerHomeAssistant(t *testing.T) {
+	for _, h := range []struct {
+		h      PositionHandler
+		target string
+	}{
+		{PositionHandler{Schema: SchemaHomeAssistant, Now: fixedNow(londonNow)}, "/sun?" + londonQuery},
+		{PositionHandler{Now: fixedNow(londonNow)}, "/sun?schema=homeassistant&" + londonQuery},
+	} {
+		w := get(h.h, h.target)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", w.Code, w.Body)
+		}
+		var s haState
+		if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		a := s.Attributes
+		if s.State != "above_horizon" || !a.Rising {
+			t.Errorf("state %q, rising %v, want above_horizon and rising", s.State, a.Rising)
+		}
+		if a.Elevation != math.Round(a.Elevation*100)/100 || math.Abs(a.Elevation-53.42) > 0.05 {
+			t.Errorf("elevation = %v, want 53.42 to two places", a.Elevation)
+		}
+		for _, tt := range []struct {
+			name, got string
+			want      time.Time
+		}{
+			{"next_dawn", a.NextDawn, time.Date(2024, time.June, 22, 2, 55, 0, 0, time.UTC)},
+			{"next_rising", a.NextRising, time.Date(2024, time.June, 22, 3, 43, 0, 0, time.UTC)},
+			{"next_noon", a.NextNoon, time.Date(2024, time.June, 21, 12, 2, 0, 0, time.UTC)},
+			{"next_setting", a.NextSetting, time.Date(2024, time.June, 21, 20, 21, 0, 0, time.UTC)},
+			{"next_dusk", a.NextDusk, time.Date(2024, time.June, 21, 21, 9, 0, 0, time.UTC)},
+			{"next_midnight", a.NextMidnight, time.Date(2024, time.June, 22, 0, 2, 0, 0, time.UTC)},
+		} {
+			got, err := time.Parse("2006-01-02T15:04:05-07:00", tt.got)
+			if err != nil || got.Format("-07:00") != "+00:00" {
+				t.Errorf("%s = %q, want a UTC time with a +00:00 offset", tt.name, tt.got)
+				continue
+			}
+			if d := got.Sub(tt.want); d < -2*time.Minute || d > 2*time.Minute {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPositionHandlerErrors(t *testing.T) {
+	for _, target := range []string{
+		"/sun?schema=xml&" + londonQuery,
+		"/sun?lat=51.5&lon=200",
+		"/sun",
+	} {
+		if w := get(PositionHandler{}, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestHATime(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{time.Date(2024, time.June, 21, 20, 21, 29, 600e6, time.UTC), "2024-06-21T20:21:30+00:00"},
+		{time.Date(2024, time.June, 21, 21, 21, 0, 0, time.FixedZone("BST", 3600)), "2024-06-21T20:21:00+00:00"},
+	} {
+		if got := haTime(tt.t); got != tt.want {
+			t.Errorf("haTime(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Package sunhttp serves solar events and positions over HTTP.
+package sunhttp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/exploded/sun"
+)
+
+// feedDays is the length of the rolling window served by ICalHandler
+const feedDays = 366
+
+// ICalHandler serves a rolling one year calendar of sunrise and sunset for the
+// location in the query, suitable for subscribing to as a webcal:// feed:
+//
+//	/sun.ics?lat=52.52&lon=13.40&tz=Europe/Berlin&name=Berlin
+//
+// With a Geocoder the location may instead be a place name, as in
+// q=Berlin,+Germany, which also supplies the default tz and name when the
+// geocoder knows them.
+//
+// tz is an IANA time zone name and defaults to UTC; name is the calendar
+// title. The window starts today in that zone. Event UIDs depend only on the
+// event, date and location, so clients update events rather than duplicating
+// them as the window rolls on. The response may be cached until the next
+// midnight, and carries an ETag for conditional requests.
+type ICalHandler struct {
+	// Geocoder, if set, resolves a place name given as q in place of lat
+	// and lon.
+	Geocoder sun.Geocoder
+	// Now returns the current time; nil means time.Now. It is there for tests.
+	Now func() time.Time
+}
+
+func (h ICalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	place, status, err := locate(r, h.Geocoder)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	lat, lon := place.Latitude, place.Longitude
+	loc := time.UTC
+	if place.Location != nil {
+		loc = place.Location
+	}
+	if tz := q.Get("tz"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			http.Error(w, "unknown time zone", http.StatusBadRequest)
+			return
+		}
+	}
+	name := q.Get("name")
+	if name == "" {
+		name = place.Name
+	}
+	if name == "" {
+		name = "Sunrise and sunset"
+	}
+
+	now := time.Now()
+	if h.Now != nil {
+		now = h.Now()
+	}
+	y, m, d := now.In(loc).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	tomorrow := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+
+	var b bytes.Buffer
+	if err := writeICal(&b, name, today, feedDays, lat, lon, loc); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(b.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="sun.ics"`)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", today.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(tomorrow.Sub(now).Seconds())))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(b.Bytes())
+}
+
+// locate returns the location asked for by the request, from the place name
+// in q when there is a geocoder and from lat and lon otherwise, with the HTTP
+// status to report if it fails
+func locate(r *http.Request, g sun.Geocoder) (sun.GeocodeResult, int, error) {
+	q := r.URL.Query()
+	if name := q.Get("q"); name != "" && g != nil {
+		place, err := sun.Resolve(r.Context(), name, g)
+		switch {
+		case err == sun.ErrPlaceNotFound:
+			return place, http.StatusNotFound, errors.New("no place by that name")
+		case err != nil:
+			return place, http.StatusBadGateway, errors.New("geocoder failed")
+		}
+		return place, http.StatusOK, nil
+	}
+	lat, lon, err := parseLocation(q.Get("lat"), q.Get("lon"))
+	if err != nil {
+		return sun.GeocodeResult{}, http.StatusBadRequest, err
+	}
+	return sun.GeocodeResult{Point: sun.Point{Latitude: lat, Longitude: lon}}, http.StatusOK, nil
+}
+
+// parseLocation parses and checks decimal latitude and longitude
+func parseLocation(lat string, lon string) (float64, float64, error) {
+	la, err1 := strconv.ParseFloat(lat, 64)
+	lo, err2 := strconv.ParseFloat(lon, 64)
+	if err1 != nil || err2 != nil || la < -90 || la > 90 || lo < -180 || lo > 180 {
+		return 0, 0, errors.New("lat and lon must be decimal degrees")
+	}
+	return la, lo, nil
+}
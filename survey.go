@@ -0,0 +1,79 @@
+package sun
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// SurveyNight is one night's dark windows for a field survey.
+type SurveyNight struct {
+	Date             time.Time     // midnight at the start of the evening's date
+	Windows          []Period      // times the illuminance is below the threshold
+	Dark             time.Duration // total length of Windows
+	MoonIllumination float64       // illuminated fraction at midnight
+}
+
+// SurveyNights returns, for each night from the date of start to the date of
+// end inclusive, the periods between noon and the next noon when the total
+// illuminance under a clear sky, from twilight, the Moon and the stars, is
+// below maxLux. Bat and moth surveys, for example, often call for less than
+// about 0.1 lux. Times are to within a second and in the time zone of start.
+func SurveyNights(start time.Time, end time.Time, latitude float64, longitude float64, maxLux float64) []SurveyNight {
+	var nights []SurveyNight
+	y, m, d := start.Date()
+	for i := 0; ; i++ {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, start.Location())
+		if afterDate(date, end) {
+			break
+		}
+		n := SurveyNight{
+			Date:             date,
+			MoonIllumination: MoonIllumination(date.AddDate(0, 0, 1)),
+		}
+		noon := date.Add(12 * time.Hour)
+		n.Windows = periodsWhere(noon, noon.Add(24*time.Hour), func(t time.Time) float64 {
+			return maxLux - Illuminance(t, latitude, longitude)
+		})
+		for _, w := range n.Windows {
+			n.Dark += w.Duration()
+		}
+		nights = append(nights, n)
+	}
+	return nights
+}
+
+// WriteSurveyCSV writes the nights as a field schedule with one row per
+// window, and a row with empty times for a night without one. Times are to
+// the minute and Moon illumination is in percent.
+func WriteSurveyCSV(w io.Writer, nights []SurveyNight) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"night", "start", "end", "minutes", "moon_percent"})
+	if err != nil {
+		return err
+	}
+	for _, n := range nights {
+		date := n.Date.Format("2006-01-02")
+		moon := strconv.FormatFloat(n.MoonIllumination*100, 'f', 0, 64)
+		if len(n.Windows) == 0 {
+			if err := cw.Write([]string{date, "", "", "0", moon}); err != nil {
+				return err
+			}
+		}
+		for _, p := range n.Windows {
+			err := cw.Write([]string{
+				date,
+				p.Start.Format("2006-01-02 15:04"),
+				p.End.Format("2006-01-02 15:04"),
+				strconv.Itoa(int(p.Duration().Minutes())),
+				moon,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
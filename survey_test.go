@@ -0,0 +1,64 @@
+package sun
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// Under 0.01 lux with 0.002 from the stars the Sun must give less than 0.008,
+// 10^-2.10, which the table puts at an altitude of -12.45 degrees. At London
+// on 8 April 2024, with δ = 7.4 and noon at 12:02 UT, sin h = 0.1008 + 0.6172
+// cos H puts the Sun there 8h03m from noon: the night after the new moon is
+// dark from 20:06 UT to 03:57 UT. The full moon of 24 March is up all night
+// and lights it throughout.
+func TestSurveyNights(t *testing.T) {
+	start := date(time.UTC, 2024, time.April, 8)
+	nights := SurveyNights(start, start.AddDate(0, 0, 1), 51.5074, -0.1278, 0.01)
+	if len(nights) != 2 {
+		t.Fatalf("%d nights, want 2", len(nights))
+	}
+	n := nights[0]
+	if !n.Date.Equal(start) || len(n.Windows) != 1 || n.MoonIllumination > 0.01 {
+		t.Fatalf("night of April 8 = %+v", n)
+	}
+	w := n.Windows[0]
+	if !within(w.Start, clock(start, 20, 6), 2*time.Minute) || !within(w.End, clock(start, 27, 57), 2*time.Minute) {
+		t.Errorf("dark from %v to %v, want 20:06 to 03:57", w.Start, w.End)
+	}
+	if n.Dark != w.Duration() {
+		t.Errorf("Dark = %v, want %v", n.Dark, w.Duration())
+	}
+	if !nights[1].Date.Equal(start.AddDate(0, 0, 1)) || nights[1].MoonIllumination < n.MoonIllumination {
+		t.Errorf("night of April 9 = %+v", nights[1])
+	}
+
+	full := SurveyNights(date(time.UTC, 2024, time.March, 24), date(time.UTC, 2024, time.March, 24), 51.5074, -0.1278, 0.01)
+	if len(full) != 1 || len(full[0].Windows) != 0 || full[0].Dark != 0 || full[0].MoonIllumination < 0.99 {
+		t.Errorf("full moon night = %+v, want no dark", full)
+	}
+	if got := SurveyNights(start, start.AddDate(0, 0, -1), 51.5074, -0.1278, 0.01); len(got) != 0 {
+		t.Errorf("SurveyNights ending before it starts = %v", got)
+	}
+}
+
+func TestWriteSurveyCSV(t *testing.T) {
+	d := date(time.UTC, 2024, time.April, 8)
+	nights := []SurveyNight{
+		{Date: d.AddDate(0, 0, -15), MoonIllumination: 0.9991},
+		{Date: d, Windows: []Period{{clock(d, 20, 6).Add(50 * time.Second), clock(d, 27, 56).Add(35 * time.Second)}}, MoonIllumination: 0.0009},
+	}
+	var b bytes.Buffer
+	if err := WriteSurveyCSV(&b, nights); err != nil {
+		t.Fatal(err)
+	}
+	want := "night,start,end,minutes,moon_percent\n" +
+		"2024-03-24,,,0,100\n" +
+		"2024-04-08,2024-04-08 20:06,2024-04-09 03:56,469,0\n"
+	if b.String() != want {
+		t.Errorf("WriteSurveyCSV =\n%s\nwant\n%s", b.String(), want)
+	}
+	if err := WriteSurveyCSV(failWriter{}, nights); err == nil {
+		t.Error("WriteSurveyCSV to a failing writer succeeded")
+	}
+}
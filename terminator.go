@@ -0,0 +1,65 @@
+package sun
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+)
+
+// SubsolarPoint returns the latitude and longitude, in decimal degrees, of the
+// place where the Sun is overhead at time t.
+func SubsolarPoint(t time.Time) (latitude float64, longitude float64) {
+	jd := timeToJD(t)
+	_, rAsc, dec := getSunCoords(jd)
+	return dec, between(-180, 180, rAsc-getGst(jd))
+}
+
+// nightShade is how strongly each phase of the day darkens the map
+var nightShade = [...]float64{
+	Night:                0.7,
+	AstronomicalTwilight: 0.6,
+	NauticalTwilight:     0.45,
+	CivilTwilight:        0.25,
+	Day:                  0,
+}
+
+// TerminatorImage shades the night side of the Earth at time t over an
+// equirectangular world map, running from 180 degrees west at the left edge to
+// 180 east at the right and from 90 north at the top to 90 south at the
+// bottom. Each band of twilight is shaded a step darker, so the terminator is
+// drawn as graded bands rather than a hard line.
+//
+// base is drawn first without scaling, so it should already be the given
+// width and height. If base is nil the map is a plain blue.
+func TerminatorImage(t time.Time, base image.Image, width int, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if base == nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{70, 110, 170, 255}), image.Point{}, draw.Src)
+	} else {
+		draw.Draw(img, img.Bounds(), base, base.Bounds().Min, draw.Src)
+	}
+
+	lats := make([]float64, height)
+	for y := range lats {
+		lats[y] = 90 - (float64(y)+0.5)*180/float64(height)
+	}
+	lons := make([]float64, width)
+	for x := range lons {
+		lons[x] = -180 + (float64(x)+0.5)*360/float64(width)
+	}
+	alts := AltitudeGrid(t, lats, lons, nil)
+
+	night := color.RGBA{5, 5, 25, 255}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a := nightShade[phaseOf(alts[y*width+x])]
+			if a == 0 {
+				continue
+			}
+			c := img.RGBAAt(x, y)
+			img.SetRGBA(x, y, lerpColor(c, night, a))
+		}
+	}
+	return img
+}
@@ -0,0 +1,81 @@
+package sun
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+	"time"
+)
+
+// At the June solstice, 20:51 UT on 20 June 2024, the Sun is over the Tropic
+// of Cancer. The subsolar point is west of where mean time puts it by the
+// equation of time, a quarter of a degree per minute: 16.4 minutes on 3
+// November, -14.2 on 11 February, -1.8 at the solstice and -7.5 at the
+// equinox.
+func TestSubsolarPoint(t *testing.T) {
+	for _, tt := range []struct {
+		t        time.Time
+		lat, lon float64
+		tol      float64
+	}{
+		{time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC), obliquity, -132.75 + 1.8/4, 0.05},
+		{time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC), math.NaN(), -16.4 / 4, 0.05},
+		{time.Date(2024, 2, 11, 12, 0, 0, 0, time.UTC), math.NaN(), 14.2 / 4, 0.05},
+		{time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC), 0, 133.5 + 7.5/4, 0.05},
+	} {
+		lat, lon := SubsolarPoint(tt.t)
+		if !math.IsNaN(tt.lat) && math.Abs(lat-tt.lat) > 0.01 {
+			t.Errorf("SubsolarPoint(%v) latitude = %.4f, want %.4f", tt.t, lat, tt.lat)
+		}
+		if math.Abs(lon-tt.lon) > tt.tol {
+			t.Errorf("SubsolarPoint(%v) longitude = %.4f, want %.4f", tt.t, lon, tt.lon)
+		}
+		if a := Altitude(tt.t, lat, lon); a < 89.99 {
+			t.Errorf("altitude at the subsolar point at %v = %v, want 90", tt.t, a)
+		}
+	}
+}
+
+func TestTerminatorImage(t *testing.T) {
+	at := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	img := TerminatorImage(at, nil, 360, 180)
+	if b := img.Bounds(); b.Dx() != 360 || b.Dy() != 180 {
+		t.Fatalf("image is %v, want 360×180", b)
+	}
+	blue := color.RGBA{70, 110, 170, 255}
+	night := color.RGBA{5, 5, 25, 255}
+	// near the equinox the terminator runs nearly along the meridians 90
+	// degrees either side of the subsolar point, close to Greenwich at noon
+	for _, tt := range []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"under the Sun", 180, 90, blue},
+		{"afternoon in Africa", 240, 60, blue},
+		{"midnight in the Pacific", 0, 90, lerpColor(blue, night, nightShade[Night])},
+		{"night near Hawaii", 40, 70, lerpColor(blue, night, nightShade[Night])},
+	} {
+		if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("%s at (%d, %d) = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+	// walking west along the equator from noon the shading only deepens
+	prev := 0.0
+	for x := 180; x >= 0; x-- {
+		c := color.RGBAModel.Convert(img.At(x, 90)).(color.RGBA)
+		dark := float64(blue.B) - float64(c.B)
+		if dark < prev {
+			t.Errorf("shading lightens at x = %d", x)
+			break
+		}
+		prev = dark
+	}
+
+	base := image.NewUniform(color.RGBA{200, 100, 0, 255})
+	img = TerminatorImage(at, base, 36, 18)
+	if got := color.RGBAModel.Convert(img.At(18, 9)); got != (color.RGBA{200, 100, 0, 255}) {
+		t.Errorf("base map under the Sun = %v, want it unshaded", got)
+	}
+}
@@ -0,0 +1,48 @@
+package sun
+
+import "time"
+
+// MoonElongation returns how far the Moon is east of the Sun in ecliptic
+// longitude at t, in degrees from 0 to 360: 0 at new moon, 90 at first
+// quarter, 180 at full moon and 270 at last quarter. Tides are strongest,
+// springs, near 0 and 180, and weakest, neaps, near 90 and 270.
+func MoonElongation(t time.Time) float64 {
+	return between(0, 360, MoonPosition(t).EclipticLongitude-LowPrecision{}.Sun(t).EclipticLongitude)
+}
+
+// SpringAge returns the time since the last new or full moon before t, when
+// the Sun and Moon pull in line and tides are at springs. Spring tides come a
+// day or two after it at most ports, as the oceans take time to respond, so
+// the age of the tide there is the best guide to strength.
+func SpringAge(t time.Time) time.Duration {
+	return t.Sub(lastSyzygy(t, 0))
+}
+
+// NeapAge returns the time since the last first or last quarter before t,
+// when the Sun and Moon pull at right angles and tides are at neaps, as for
+// SpringAge.
+func NeapAge(t time.Time) time.Duration {
+	return t.Sub(lastSyzygy(t, 90))
+}
+
+// lastSyzygy returns the last time before t that the elongation of the Moon
+// was offset or offset plus 180 degrees. If there is none in the two lunar
+// months before t, which cannot happen unless the Moon's position is not a
+// number, it returns t.
+func lastSyzygy(t time.Time, offset float64) time.Time {
+	// the phases come a little under 15 days apart, so one is nearly always
+	// found in the first window; the windows overlap by an hour so that a
+	// phase on the edge of one is inside the next
+	end := t
+	for i := 0; i < 4; i++ {
+		start := end.AddDate(0, 0, -16)
+		f := func(s float64) float64 {
+			return between(-90, 90, MoonElongation(start.Add(secondsToDuration(s)))-offset)
+		}
+		if cs := crossings(start, end, f, 90, defaultTolerance); len(cs) > 0 {
+			return cs[len(cs)-1].Time
+		}
+		end = start.Add(time.Hour)
+	}
+	return t
+}
@@ -0,0 +1,45 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTideAges(t *testing.T) {
+	// USNO phases of the Moon, January 2024: new moon 11th 11:57, first
+	// quarter 18th 03:53, full moon 25th 17:54 UTC
+	newMoon := time.Date(2024, 1, 11, 11, 57, 0, 0, time.UTC)
+	firstQuarter := time.Date(2024, 1, 18, 3, 53, 0, 0, time.UTC)
+	fullMoon := time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"spring age a day after new moon", SpringAge(newMoon.Add(24 * time.Hour)), 24 * time.Hour},
+		{"spring age before full moon", SpringAge(fullMoon.Add(-time.Hour)), fullMoon.Sub(newMoon) - time.Hour},
+		{"spring age after full moon", SpringAge(fullMoon.Add(36 * time.Hour)), 36 * time.Hour},
+		{"neap age after first quarter", NeapAge(firstQuarter.Add(44 * time.Hour)), 44 * time.Hour},
+	} {
+		// the Moon's position is good to a few minutes of time
+		if d := tt.got - tt.want; d < -20*time.Minute || d > 20*time.Minute {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestMoonElongation(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(2024, 1, 18, 3, 53, 0, 0, time.UTC), 90},
+		{time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC), 180},
+		{time.Date(2024, 2, 2, 23, 18, 0, 0, time.UTC), 270},
+	} {
+		if got := MoonElongation(tt.t); math.Abs(got-tt.want) > 0.2 {
+			t.Errorf("MoonElongation(%v) = %.2f, want %v", tt.t, got, tt.want)
+		}
+	}
+}
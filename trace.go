@@ -0,0 +1,86 @@
+package sun
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Quantity is a named intermediate value of a calculation.
+type Quantity struct {
+	Name  string
+	Value float64
+	Unit  string
+}
+
+// Tracer receives the intermediate quantities of each position calculation,
+// for debugging and teaching. calc names the function that was called, such as
+// "Altitude". The quantities slice is only valid during the call.
+//
+// Trace may be called from many goroutines at once.
+type Tracer interface {
+	Trace(calc string, quantities []Quantity)
+}
+
+type tracerHolder struct{ t Tracer }
+
+var tracer atomic.Value // of tracerHolder
+
+// SetTracer installs t to receive the intermediate quantities of every
+// Altitude and Azimuth calculation in the process, replacing any tracer set
+// before. SetTracer(nil) turns tracing off, which is the default; while it is
+// off tracing costs one atomic load per calculation.
+func SetTracer(t Tracer) {
+	tracer.Store(tracerHolder{t})
+}
+
+func currentTracer() Tracer {
+	h, _ := tracer.Load().(tracerHolder)
+	return h.t
+}
+
+// traceHorizontal reports the quantities behind a horizontal coordinate if a
+// tracer is installed
+func traceHorizontal(calc string, jd float64, latitude float64, longitude float64, rAsc float64, dec float64, ha float64, result float64) {
+	t := currentTracer()
+	if t == nil {
+		return
+	}
+	jdn := getJdn(jd)
+	l := getMeanLong(jdn)
+	g := between(0, 360, 357.528) + 0.9856003*jdn
+	t.Trace(calc, []Quantity{
+		{"julianDay", jd, "d"},
+		{"latitude", latitude, "deg"},
+		{"longitude", longitude, "deg"},
+		{"meanLongitude", between(0, 360, l), "deg"},
+		{"meanAnomaly", between(0, 360, g), "deg"},
+		{"eclipticLongitude", between(0, 360, getEclipticLong(l, g)), "deg"},
+		{"rightAscension", between(0, 360, rAsc), "deg"},
+		{"declination", dec, "deg"},
+		{"equationOfTime", 4 * between(-180, 180, l-rAsc), "min"},
+		{"siderealTime", getGst(jd), "deg"},
+		{"hourAngle", between(-180, 180, ha), "deg"},
+		{calc, result, "deg"},
+	})
+}
+
+// SlogTracer is a Tracer that logs each calculation as one record, with a
+// float attribute per quantity, at the given level.
+type SlogTracer struct {
+	Logger *slog.Logger
+	Level  slog.Level
+}
+
+// Trace logs the quantities.
+func (s SlogTracer) Trace(calc string, quantities []Quantity) {
+	ctx := context.Background()
+	if !s.Logger.Enabled(ctx, s.Level) {
+		return
+	}
+	attrs := make([]slog.Attr, len(quantities))
+	for i, q := range quantities {
+		attrs[i] = slog.Float64(q.Name, q.Value)
+	}
+	s.Logger.LogAttrs(ctx, s.Level, "sun."+calc, attrs...)
+}
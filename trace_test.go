@@ -0,0 +1,102 @@
+package sun
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a Tracer that keeps what it is given
+type recorder struct {
+	mu    sync.Mutex
+	calcs []string
+	last  map[string]Quantity
+}
+
+func (r *recorder) Trace(calc string, quantities []Quantity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calcs = append(r.calcs, calc)
+	r.last = map[string]Quantity{}
+	for _, q := range quantities {
+		r.last[q.Name] = q
+	}
+}
+
+// At J2000.0, noon UT on 1 January 2000, the Astronomical Almanac gives the
+// Sun's mean longitude as 280.46, mean anomaly 357.53, right ascension 281.29
+// (18h45m), declination -23.03 and sidereal time 280.46, so at Greenwich the
+// hour angle is -0.83 and the equation of time -3.3 minutes.
+func TestTracer(t *testing.T) {
+	r := &recorder{}
+	SetTracer(r)
+	defer SetTracer(nil)
+
+	at := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	alt := Altitude(at, 0, 0)
+	if len(r.calcs) != 1 || r.calcs[0] != "Altitude" {
+		t.Fatalf("traced %v, want Altitude", r.calcs)
+	}
+	for _, tt := range []struct {
+		name  string
+		value float64
+		unit  string
+		tol   float64
+	}{
+		{"julianDay", 2451545, "d", 0},
+		{"latitude", 0, "deg", 0},
+		{"meanLongitude", 280.46, "deg", 0.01},
+		{"meanAnomaly", 357.53, "deg", 0.01},
+		{"rightAscension", 281.29, "deg", 0.01},
+		{"declination", -23.03, "deg", 0.01},
+		{"siderealTime", 280.46, "deg", 0.01},
+		{"hourAngle", -0.83, "deg", 0.01},
+		{"equationOfTime", -3.3, "min", 0.05},
+		{"Altitude", alt, "deg", 0},
+	} {
+		q, ok := r.last[tt.name]
+		if !ok {
+			t.Errorf("no %s traced", tt.name)
+			continue
+		}
+		if math.Abs(q.Value-tt.value) > tt.tol || q.Unit != tt.unit {
+			t.Errorf("%s = %v %s, want %v %s", tt.name, q.Value, q.Unit, tt.value, tt.unit)
+		}
+	}
+
+	Azimuth(at, 0, 0)
+	if len(r.calcs) != 2 || r.calcs[1] != "Azimuth" {
+		t.Errorf("traced %v, want Altitude then Azimuth", r.calcs)
+	}
+
+	SetTracer(nil)
+	Altitude(at, 0, 0)
+	if len(r.calcs) != 2 {
+		t.Errorf("traced after SetTracer(nil)")
+	}
+}
+
+func TestSlogTracer(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	SetTracer(SlogTracer{Logger: logger, Level: slog.LevelInfo})
+	defer SetTracer(nil)
+	Altitude(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 0, 0)
+	out := b.String()
+	for _, want := range []string{"level=INFO", "msg=sun.Altitude", "julianDay=2.451545e+06", "declination=-23.03"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q does not contain %q", out, want)
+		}
+	}
+
+	b.Reset()
+	SetTracer(SlogTracer{Logger: logger, Level: slog.LevelDebug})
+	Altitude(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 0, 0)
+	if b.Len() != 0 {
+		t.Errorf("logged %q below the handler's level", b.String())
+	}
+}
@@ -0,0 +1,74 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// Crossing is a moment the Sun passes a given altitude or azimuth.
+type Crossing struct {
+	Time       time.Time
+	Increasing bool // altitude climbing, or azimuth moving clockwise
+}
+
+// AltitudeCrossings returns every time between start and end that the Sun
+// passes through the given altitude, that is crosses the almucantar at that
+// altitude, in time order. Sunrise and sunset are the crossings of
+// SunriseAltitude.
+//
+// Times are to within a second and in the time zone of start. Two crossings
+// less than ten minutes apart, as when the Sun just grazes the altitude, may
+// be missed.
+func AltitudeCrossings(start time.Time, end time.Time, latitude float64, longitude float64, altitude float64) []Crossing {
+	return altitudeCrossings(start, end, latitude, longitude, altitude, defaultTolerance)
+}
+
+// AltitudeCrossings returns the crossings of an altitude as the package
+// function AltitudeCrossings does, to the tolerance of o.
+func (o EventOptions) AltitudeCrossings(start time.Time, end time.Time, latitude float64, longitude float64, altitude float64) []Crossing {
+	return altitudeCrossings(start, end, latitude, longitude, altitude, o.tolerance())
+}
+
+func altitudeCrossings(start time.Time, end time.Time, latitude float64, longitude float64, altitude float64, tol float64) []Crossing {
+	f := func(s float64) float64 {
+		return Altitude(start.Add(secondsToDuration(s)), latitude, longitude) - altitude
+	}
+	return crossings(start, end, f, math.Inf(1), tol)
+}
+
+// AzimuthCrossings returns every time between start and end that the Sun
+// passes through the given azimuth, that is crosses the vertical circle at that
+// azimuth, in time order. This answers questions such as when the Sun lines up
+// with a street or shines straight along a valley.
+//
+// Times are to within a second and in the time zone of start.
+func AzimuthCrossings(start time.Time, end time.Time, latitude float64, longitude float64, azimuth float64) []Crossing {
+	return azimuthCrossings(start, end, latitude, longitude, azimuth, defaultTolerance)
+}
+
+// AzimuthCrossings returns the crossings of an azimuth as the package function
+// AzimuthCrossings does, to the tolerance of o.
+func (o EventOptions) AzimuthCrossings(start time.Time, end time.Time, latitude float64, longitude float64, azimuth float64) []Crossing {
+	return azimuthCrossings(start, end, latitude, longitude, azimuth, o.tolerance())
+}
+
+func azimuthCrossings(start time.Time, end time.Time, latitude float64, longitude float64, azimuth float64, tol float64) []Crossing {
+	f := func(s float64) float64 {
+		return between(-180, 180, Azimuth(start.Add(secondsToDuration(s)), latitude, longitude)-azimuth)
+	}
+	// the difference jumps by 360 when the Sun passes the opposite azimuth
+	return crossings(start, end, f, 180, tol)
+}
+
+// crossings finds the roots of f, a function of seconds after start, up to
+// end, to within tol seconds
+func crossings(start time.Time, end time.Time, f func(float64) float64, jump float64, tol float64) []Crossing {
+	var cs []Crossing
+	for _, s := range findRoots(f, 0, end.Sub(start).Seconds(), tol, jump) {
+		cs = append(cs, Crossing{
+			Time:       start.Add(secondsToDuration(s)),
+			Increasing: f(s+1) > f(s-1),
+		})
+	}
+	return cs
+}
@@ -0,0 +1,105 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAltitudeCrossingsAreSunriseAndSunset(t *testing.T) {
+	loc := location(t, "Europe/London")
+	d := date(loc, 2024, time.June, 21)
+	cs := AltitudeCrossings(d, d.AddDate(0, 0, 1), 51.5074, -0.1278, SunriseAltitude)
+	if len(cs) != 2 {
+		t.Fatalf("%d crossings, want 2", len(cs))
+	}
+	rise, _ := Sunrise(d, 51.5074, -0.1278)
+	set, _ := Sunset(d, 51.5074, -0.1278)
+	if !within(cs[0].Time, rise, time.Second) || !cs[0].Increasing {
+		t.Errorf("first crossing %v, increasing %v, want sunrise at %v", cs[0].Time, cs[0].Increasing, rise)
+	}
+	if !within(cs[1].Time, set, time.Second) || cs[1].Increasing {
+		t.Errorf("second crossing %v, increasing %v, want sunset at %v", cs[1].Time, cs[1].Increasing, set)
+	}
+	for _, c := range cs {
+		if c.Time.Location() != loc {
+			t.Errorf("crossing in %v, want %v", c.Time.Location(), loc)
+		}
+	}
+}
+
+func TestAltitudeCrossings(t *testing.T) {
+	start := date(time.UTC, 2024, time.March, 20)
+	for _, tt := range []struct {
+		name     string
+		lat      float64
+		altitude float64
+		days     int
+		want     int
+	}{
+		{"sunrise and sunset for a week", 30, SunriseAltitude, 7, 14},
+		{"civil twilight", 51.5, CivilTwilightAltitude, 1, 2},
+		{"30 degrees in the tropics", 10, 30, 3, 6},
+		// on the equinox at 60 N the Sun climbs to 30 degrees, so never
+		// reaches 40
+		{"above the noon altitude", 60, 40, 2, 0},
+	} {
+		cs := AltitudeCrossings(start, start.AddDate(0, 0, tt.days), tt.lat, 0, tt.altitude)
+		if len(cs) != tt.want {
+			t.Errorf("%s: %d crossings, want %d", tt.name, len(cs), tt.want)
+		}
+		for i, c := range cs {
+			if a := Altitude(c.Time, tt.lat, 0); math.Abs(a-tt.altitude) > 0.005 {
+				t.Errorf("%s: altitude at crossing %d = %v, want %v", tt.name, i, a, tt.altitude)
+			}
+			if c.Increasing != (i%2 == 0) {
+				t.Errorf("%s: crossing %d increasing %v", tt.name, i, c.Increasing)
+			}
+			if i > 0 && !c.Time.After(cs[i-1].Time) {
+				t.Errorf("%s: crossings out of order", tt.name)
+			}
+		}
+	}
+}
+
+func TestAzimuthCrossings(t *testing.T) {
+	equinox := date(time.UTC, 2024, time.March, 20)
+	// the Sun rises due east on the equinox, so it passes azimuth 90 within
+	// a few minutes of sunrise
+	cs := AzimuthCrossings(equinox, equinox.AddDate(0, 0, 1), 45, 0, 90)
+	rise, _ := Sunrise(equinox, 45, 0)
+	if len(cs) != 1 || !within(cs[0].Time, rise, 10*time.Minute) || !cs[0].Increasing {
+		t.Errorf("crossings of azimuth 90 = %v, want one near sunrise at %v", cs, rise)
+	}
+
+	// the meridian crossing comes within half a minute of the culmination
+	cs = AzimuthCrossings(equinox, equinox.AddDate(0, 0, 3), 51.48, 0, 180)
+	if len(cs) != 3 {
+		t.Fatalf("%d crossings of the meridian in three days, want 3", len(cs))
+	}
+	for _, c := range cs {
+		noon := Culminate(c.Time, 51.48, 0).Time
+		if !within(c.Time, noon, 30*time.Second) || !c.Increasing {
+			t.Errorf("meridian crossing at %v, want increasing near %v", c.Time, noon)
+		}
+		if a := Azimuth(c.Time, 51.48, 0); math.Abs(a-180) > 0.01 {
+			t.Errorf("azimuth at the meridian crossing = %v", a)
+		}
+	}
+
+	// in the midnight sun the Sun passes north, where azimuth wraps round;
+	// the wrap at the opposite azimuth, south, must not count
+	solstice := date(time.UTC, 2024, time.June, 21)
+	cs = AzimuthCrossings(solstice, solstice.AddDate(0, 0, 2), 75, 0, 0)
+	if len(cs) != 2 {
+		t.Fatalf("%d crossings of north in two days of midnight sun, want 2", len(cs))
+	}
+	for _, c := range cs {
+		if h := c.Time.Hour(); h != 23 && h != 0 {
+			t.Errorf("north crossing at %v, want near midnight", c.Time)
+		}
+		if !c.Increasing {
+			t.Errorf("north crossing at %v not clockwise", c.Time)
+		}
+	}
+}
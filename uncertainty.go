@@ -0,0 +1,105 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// horizonRefractionUncertainty is the typical spread of refraction at the
+// horizon about its standard value, in degrees, from the weather near the
+// ground
+const horizonRefractionUncertainty = 0.2
+
+// Estimate is a value with its uncertainty, one standard deviation either way.
+type Estimate struct {
+	Value       float64
+	Uncertainty float64
+}
+
+// ModelUncertainty returns the estimated error in degrees of the position of
+// the Sun given by eph at t. It grows with distance from 2000, as the models'
+// secular terms run out and as LowPrecision and Meeus, which take universal
+// time for dynamical time, fall behind by DeltaT. VSOP87 and SPA are good to
+// an arcsecond or so, and a *JPL ephemeris counts as exact, to within the
+// error of the DeltaT estimate; any other Ephemeris is taken to be as good as
+// LowPrecision.
+func ModelUncertainty(eph Ephemeris, t time.Time) float64 {
+	centuries := math.Abs(getJdn(timeToJD(t))) / 36525
+	// the Sun moves 0.9856 degree a day
+	lag := math.Abs(deltaT(t)) / 86400 * 0.9856
+	switch eph.(type) {
+	case *JPL:
+		// DeltaT is known to about a tenth of itself before the 20th century
+		return 0.0001 + lag/10
+	case SPA, VSOP87:
+		return 0.0003 + lag/10
+	case Meeus:
+		return 0.003 + 0.001*centuries + lag
+	}
+	return 0.01 + 0.013*centuries + lag
+}
+
+// PositionEstimate is the position of the Sun with uncertainties, in degrees.
+type PositionEstimate struct {
+	Altitude Estimate
+	Azimuth  Estimate
+}
+
+// PositionWithUncertainty returns the altitude and azimuth of the Sun at t from
+// eph, with their uncertainties from ModelUncertainty. If refracted is true the
+// altitude is the apparent one, raised by Refraction, which is taken to vary
+// by a tenth of itself with the weather. The azimuth is less certain as the
+// Sun nears the zenith, to 180 degrees there.
+func PositionWithUncertainty(eph Ephemeris, t time.Time, latitude float64, longitude float64, refracted bool) PositionEstimate {
+	alt := AltitudeWith(eph, t, latitude, longitude)
+	sigma := ModelUncertainty(eph, t)
+	p := PositionEstimate{
+		Altitude: Estimate{alt, sigma},
+		Azimuth:  Estimate{AzimuthWith(eph, t, latitude, longitude), math.Min(180, sigma/angleCos(alt))},
+	}
+	if refracted {
+		r := Refraction(alt)
+		p.Altitude = Estimate{alt + r, math.Hypot(sigma, r/10)}
+	}
+	return p
+}
+
+// SunriseWithUncertainty returns the first sunrise on the date of t from eph,
+// with its uncertainty from ModelUncertainty and a spread of 0.2 degree in the
+// refraction at the horizon, which usually dominates. The uncertainty is
+// the error in altitude over the rate the Sun climbs, so it grows large where
+// the Sun rises at a shallow angle, as at high latitudes. Times are to within
+// EventTolerance and in the time zone of t; ok is false if the Sun does not
+// rise.
+func SunriseWithUncertainty(eph Ephemeris, t time.Time, latitude float64, longitude float64) (e EventEstimate, ok bool) {
+	return eventWithUncertainty(eph, t, latitude, longitude, true)
+}
+
+// SunsetWithUncertainty returns the first sunset on the date of t, as for
+// SunriseWithUncertainty.
+func SunsetWithUncertainty(eph Ephemeris, t time.Time, latitude float64, longitude float64) (e EventEstimate, ok bool) {
+	return eventWithUncertainty(eph, t, latitude, longitude, false)
+}
+
+func eventWithUncertainty(eph Ephemeris, t time.Time, latitude float64, longitude float64, rising bool) (EventEstimate, bool) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	alt := func(s float64) float64 {
+		return AltitudeWith(eph, start.Add(secondsToDuration(s)), latitude, longitude)
+	}
+	f := func(s float64) float64 {
+		return alt(s) - SunriseAltitude
+	}
+	for _, c := range crossings(start, end, f, math.Inf(1), defaultTolerance) {
+		if c.Increasing != rising {
+			continue
+		}
+		s := c.Time.Sub(start).Seconds()
+		// degrees a second
+		rate := math.Abs(alt(s+30)-alt(s-30)) / 60
+		sigma := math.Hypot(ModelUncertainty(eph, c.Time), horizonRefractionUncertainty)
+		return EventEstimate{c.Time, secondsToDuration(sigma / rate)}, true
+	}
+	return EventEstimate{}, false
+}
@@ -0,0 +1,79 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// At J2000 DeltaT is 63.86 seconds, which the Sun covers in 0.000729 degree.
+func TestModelUncertainty(t *testing.T) {
+	j2000 := time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		eph  Ephemeris
+		want float64
+	}{
+		{LowPrecision{}, 0.010729},
+		{Meeus{}, 0.003729},
+		{SPA{}, 0.000373},
+		{VSOP87{}, 0.000373},
+	} {
+		if got := ModelUncertainty(tt.eph, j2000); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("ModelUncertainty(%T) = %.6f, want %v", tt.eph, got, tt.want)
+		}
+	}
+	// in the year 1000 DeltaT is over half an hour
+	if got := ModelUncertainty(Meeus{}, time.Date(1000, time.January, 1, 12, 0, 0, 0, time.UTC)); got < 0.03 {
+		t.Errorf("ModelUncertainty in 1000 = %v", got)
+	}
+}
+
+func TestPositionWithUncertainty(t *testing.T) {
+	// at a London noon on the equinox the azimuth is 0.004086 / cos 38.64 =
+	// 0.005232 degree out
+	at := time.Date(2024, time.March, 20, 12, 7, 0, 0, time.UTC)
+	p := PositionWithUncertainty(Meeus{}, at, 51.5074, -0.1278, false)
+	if sigma := ModelUncertainty(Meeus{}, at); p.Altitude.Uncertainty != sigma || math.Abs(p.Azimuth.Uncertainty-0.005232) > 1e-6 {
+		t.Errorf("uncertainties %+v, want %v and 0.005232", p, sigma)
+	}
+	if p.Altitude.Value != AltitudeWith(Meeus{}, at, 51.5074, -0.1278) {
+		t.Errorf("altitude %v", p.Altitude.Value)
+	}
+
+	// at sunrise refraction of 0.574 degree is uncertain by a tenth
+	rise, _ := Sunrise(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278)
+	p = PositionWithUncertainty(Meeus{}, rise, 51.5074, -0.1278, true)
+	alt := AltitudeWith(Meeus{}, rise, 51.5074, -0.1278)
+	if r := Refraction(alt); p.Altitude.Value != alt+r || math.Abs(p.Altitude.Uncertainty-math.Hypot(ModelUncertainty(Meeus{}, rise), r/10)) > 1e-12 {
+		t.Errorf("refracted altitude %+v", p.Altitude)
+	}
+
+	// near the zenith the azimuth is barely known
+	lat, lon := SubsolarPoint(at)
+	if p := PositionWithUncertainty(Meeus{}, at, lat, lon, false); p.Azimuth.Uncertainty < 45 {
+		t.Errorf("azimuth uncertainty overhead = %v", p.Azimuth.Uncertainty)
+	}
+}
+
+// As for TestHistoricalSunrise, 0.2 degree of refraction is 102 seconds at a
+// London sunrise in June.
+func TestSunriseWithUncertainty(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	rise, _ := Sunrise(d, 51.5074, -0.1278)
+	set, _ := Sunset(d, 51.5074, -0.1278)
+	for _, tt := range []struct {
+		f    func(Ephemeris, time.Time, float64, float64) (EventEstimate, bool)
+		want time.Time
+	}{
+		{SunriseWithUncertainty, rise},
+		{SunsetWithUncertainty, set},
+	} {
+		e, ok := tt.f(Meeus{}, d, 51.5074, -0.1278)
+		if !ok || !within(e.Time, tt.want, time.Second) || (e.Uncertainty-102*time.Second).Abs() > time.Second {
+			t.Errorf("%v ± %v, want %v ± 1m42s", e.Time, e.Uncertainty, tt.want)
+		}
+	}
+	if e, ok := SunriseWithUncertainty(Meeus{}, date(time.UTC, 2024, time.December, 21), 69.6492, 18.9553); ok {
+		t.Errorf("sunrise at Tromsø in the polar night = %v", e)
+	}
+}
@@ -0,0 +1,66 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// AngleUnit is a unit in which to express angles, which the package works in
+// degrees.
+type AngleUnit int
+
+const (
+	Degrees AngleUnit = iota
+	Radians
+)
+
+// From converts an angle in degrees to the unit u.
+func (u AngleUnit) From(degrees float64) float64 {
+	if u == Radians {
+		return toRadians(degrees)
+	}
+	return degrees
+}
+
+// To converts an angle in the unit u to degrees, for passing to the package.
+func (u AngleUnit) To(angle float64) float64 {
+	if u == Radians {
+		return toAngle(angle)
+	}
+	return angle
+}
+
+// TimeFormat is a numeric form in which to express times, for code that keeps
+// them as plain numbers.
+type TimeFormat int
+
+const (
+	UnixSeconds TimeFormat = iota // seconds since 1970-01-01 UTC
+	JulianDate                    // days since noon UTC on 1 January 4713 BC
+)
+
+// From converts t to the format f.
+func (f TimeFormat) From(t time.Time) float64 {
+	if f == JulianDate {
+		return timeToJD(t)
+	}
+	return float64(t.UnixNano()) / 1e9
+}
+
+// To converts a time in the format f back to a time.Time in UTC.
+func (f TimeFormat) To(v float64) time.Time {
+	if f == JulianDate {
+		// JD 2440587.5 is the Unix epoch
+		v = (v - 2440587.5) * 86400
+	}
+	s := math.Floor(v)
+	return time.Unix(int64(s), int64((v-s)*1e9)).UTC()
+}
+
+// Position returns the altitude and azimuth of the Sun at t, as from Altitude
+// and Azimuth, in the unit u, with latitude and longitude also in u. With
+// Radians the results plug straight into math and physics code.
+func Position(t time.Time, latitude float64, longitude float64, u AngleUnit) (altitude float64, azimuth float64) {
+	lat, lon := u.To(latitude), u.To(longitude)
+	return u.From(Altitude(t, lat, lon)), u.From(Azimuth(t, lat, lon))
+}
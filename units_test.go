@@ -0,0 +1,78 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAngleUnit(t *testing.T) {
+	for _, tt := range []struct {
+		u       AngleUnit
+		degrees float64
+		want    float64
+	}{
+		{Degrees, 180, 180},
+		{Radians, 180, math.Pi},
+		{Radians, 90, math.Pi / 2},
+		{Radians, -45, -math.Pi / 4},
+		{Radians, 57.29577951308232, 1},
+	} {
+		if got := tt.u.From(tt.degrees); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%d.From(%v) = %v, want %v", tt.u, tt.degrees, got, tt.want)
+		}
+		if got := tt.u.To(tt.want); math.Abs(got-tt.degrees) > 1e-12 {
+			t.Errorf("%d.To(%v) = %v, want %v", tt.u, tt.want, got, tt.degrees)
+		}
+	}
+}
+
+// J2000.0, noon UT on 1 January 2000, is JD 2451545.0 and Unix time 946728000;
+// Meeus, example 7.a, gives JD 2436116.31 for the launch of Sputnik 1 on 1957
+// October 4.81; and the Unix epoch is JD 2440587.5.
+func TestTimeFormat(t *testing.T) {
+	sputnik := time.Date(1957, time.October, 4, 19, 26, 24, 0, time.UTC)
+	for _, tt := range []struct {
+		t         time.Time
+		jd, unix  float64
+		tolerance float64
+	}{
+		{time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC), 2451545.0, 946728000, 1e-6},
+		{sputnik, 2436116.31, float64(sputnik.Unix()), 1e-6},
+		{time.Unix(0, 0).UTC(), 2440587.5, 0, 1e-6},
+		{time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC), 2460389.629167, 1710903960, 1e-6},
+	} {
+		if got := JulianDate.From(tt.t); math.Abs(got-tt.jd) > tt.tolerance {
+			t.Errorf("JulianDate.From(%v) = %.6f, want %v", tt.t, got, tt.jd)
+		}
+		if got := UnixSeconds.From(tt.t); got != tt.unix {
+			t.Errorf("UnixSeconds.From(%v) = %v, want %v", tt.t, got, tt.unix)
+		}
+		if got := JulianDate.To(tt.jd); !within(got, tt.t, 50*time.Millisecond) || got.Location() != time.UTC {
+			t.Errorf("JulianDate.To(%v) = %v, want %v", tt.jd, got, tt.t)
+		}
+		if got := UnixSeconds.To(tt.unix); !got.Equal(tt.t) {
+			t.Errorf("UnixSeconds.To(%v) = %v, want %v", tt.unix, got, tt.t)
+		}
+	}
+	// fractions of a second survive, before the epoch too
+	if got := UnixSeconds.To(-1.25); !got.Equal(time.Unix(-2, 750e6)) {
+		t.Errorf("UnixSeconds.To(-1.25) = %v", got)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	at := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	alt, az := Position(at, 51.5074, -0.1278, Degrees)
+	if alt != Altitude(at, 51.5074, -0.1278) || az != Azimuth(at, 51.5074, -0.1278) {
+		t.Errorf("Position in degrees = %v, %v", alt, az)
+	}
+	// 53.42 and 128.45 degrees, as in the sunhttp tests
+	ralt, raz := Position(at, toRadians(51.5074), toRadians(-0.1278), Radians)
+	if math.Abs(ralt-toRadians(53.42)) > toRadians(0.05) || math.Abs(raz-toRadians(128.45)) > toRadians(0.1) {
+		t.Errorf("Position in radians = %v, %v, want %v, %v", ralt, raz, toRadians(53.42), toRadians(128.45))
+	}
+	if math.Abs(ralt-toRadians(alt)) > 1e-12 || math.Abs(raz-toRadians(az)) > 1e-12 {
+		t.Errorf("Position in radians = %v, %v, want %v, %v", ralt, raz, toRadians(alt), toRadians(az))
+	}
+}
@@ -0,0 +1,44 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// UVIndex returns an estimate of the clear sky UV index at t, from the
+// altitude of the Sun alone by the rule of Madronich, 12.5 times the cosine of
+// the zenith angle to the power 2.42. It assumes a typical ozone column of 300
+// Dobson units and sea level, and is good to a unit or two; thin ozone,
+// altitude and snow all raise it.
+func UVIndex(t time.Time, latitude float64, longitude float64) float64 {
+	mu := angleSin(Altitude(t, latitude, longitude))
+	if mu <= 0 {
+		return 0
+	}
+	return 12.5 * math.Pow(mu, 2.42)
+}
+
+// SunAdvisory is the part of a day when the Sun is high enough to warrant
+// protection from sunburn and heat.
+type SunAdvisory struct {
+	Period
+	PeakAltitude float64 // at culmination, degrees
+	PeakUV       float64 // clear sky UV index at culmination
+}
+
+// SunAdvisoryBand returns the period on the date of t when the Sun is above
+// minAltitude degrees, with the peak clear sky UV index, in the time zone of
+// t. Around 40 degrees is a common threshold, above which the UV index on a
+// clear day is usually 3 or more and sun protection is advised. The period is
+// the whole date if the Sun stays above minAltitude all day, as in the
+// midnight sun with a low threshold, and ok is false if it gets no higher.
+func SunAdvisoryBand(t time.Time, latitude float64, longitude float64, minAltitude float64) (a SunAdvisory, ok bool) {
+	a.Period, ok = aboveAltitude(t, latitude, longitude, minAltitude)
+	if !ok {
+		return SunAdvisory{}, false
+	}
+	c := Culminate(t, latitude, longitude)
+	a.PeakAltitude = c.Altitude
+	a.PeakUV = UVIndex(c.Time, latitude, longitude)
+	return a, true
+}
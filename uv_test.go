@@ -0,0 +1,55 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// 12.5 μ^2.42 is 12.5 with the Sun overhead, 12.5 × 0.8824^2.42 = 9.24 at a
+// London noon in June and 12.5 × 0.5^2.42 = 2.34 with the Sun 30 degrees up.
+func TestUVIndex(t *testing.T) {
+	at := time.Date(2024, time.March, 20, 12, 7, 0, 0, time.UTC)
+	lat, lon := SubsolarPoint(at)
+	if got := UVIndex(at, lat, lon); math.Abs(got-12.5) > 0.01 {
+		t.Errorf("UVIndex overhead = %.3f, want 12.5", got)
+	}
+	noon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278).Time
+	if got := UVIndex(noon, 51.5074, -0.1278); math.Abs(got-9.24) > 0.01 {
+		t.Errorf("UVIndex at a London noon = %.3f, want 9.24", got)
+	}
+	// 60 degrees from the subsolar point the Sun is 30 degrees up
+	p := Destination(Point{lat, lon}, 90, toRadians(60)*earthRadius)
+	if got := UVIndex(at, p.Latitude, p.Longitude); math.Abs(got-2.34) > 0.01 {
+		t.Errorf("UVIndex at 30 degrees = %.3f, want 2.34", got)
+	}
+	if got := UVIndex(at, -lat, lon+180); got != 0 {
+		t.Errorf("UVIndex at night = %v, want 0", got)
+	}
+}
+
+// Above 40 degrees, sin 40 = 0.3113 + 0.5711 cos H at London on the June
+// solstice gives 54.51 degrees, 3h38.0m either side of the 12:02.3 UT noon.
+func TestSunAdvisoryBand(t *testing.T) {
+	d := date(time.UTC, 2024, time.June, 21)
+	a, ok := SunAdvisoryBand(d, 51.5074, -0.1278, 40)
+	if !ok || !within(a.Start, clock(d, 8, 24), time.Minute) || !within(a.End, clock(d, 15, 40), time.Minute) {
+		t.Errorf("SunAdvisoryBand = %v, %v, want 08:24 to 15:40", a.Period, ok)
+	}
+	if math.Abs(a.PeakAltitude-61.93) > 0.01 || math.Abs(a.PeakUV-9.24) > 0.01 {
+		t.Errorf("peak %.2f degrees, UV %.2f, want 61.93, 9.24", a.PeakAltitude, a.PeakUV)
+	}
+	if a, ok := SunAdvisoryBand(date(time.UTC, 2024, time.December, 21), 51.5074, -0.1278, 40); ok {
+		t.Errorf("SunAdvisoryBand in December = %+v", a)
+	}
+
+	// at 75 N on the June solstice the Sun swings between 23.44 - 15 = 8.44
+	// and 23.44 + 15 = 38.44 degrees, so it is above 5 all day and crosses 10
+	// twice
+	if a, ok := SunAdvisoryBand(d, 75, 0, 5); !ok || !a.Start.Equal(d) || !a.End.Equal(d.AddDate(0, 0, 1)) || math.Abs(a.PeakAltitude-38.44) > 0.02 {
+		t.Errorf("SunAdvisoryBand at 75 N above 5 degrees = %+v, %v, want all day", a, ok)
+	}
+	if a, ok := SunAdvisoryBand(d, 75, 0, 10); !ok || a.Start.Before(d) || a.End.After(d.AddDate(0, 0, 1)) || a.End.Sub(a.Start) < 18*time.Hour {
+		t.Errorf("SunAdvisoryBand at 75 N above 10 degrees = %+v, %v", a, ok)
+	}
+}
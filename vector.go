@@ -0,0 +1,73 @@
+package sun
+
+import "time"
+
+// SunVector returns the unit vector towards the Sun at t in the local east,
+// north, up frame of the given location, as renderers and game engines want a
+// light direction. The up component is the sine of the altitude.
+func SunVector(t time.Time, latitude float64, longitude float64) [3]float64 {
+	return enuAt(t, latitude, longitude)
+}
+
+// SunVectorECEF returns the unit vector from the centre of the Earth towards
+// the Sun at t in the Earth-fixed frame, with x towards latitude and longitude
+// 0, y towards longitude 90 east and z towards the north pole. It points at the
+// SubsolarPoint.
+func SunVectorECEF(t time.Time) [3]float64 {
+	lat, lon := SubsolarPoint(t)
+	return unitVector(lat, lon)
+}
+
+// SunVectorECI returns the unit vector from the centre of the Earth towards the
+// Sun at t in the inertial frame of the equator and equinox of date, with x
+// towards the equinox and z towards the north celestial pole. It is the
+// Earth-fixed vector turned back through the sidereal time.
+func SunVectorECI(t time.Time) [3]float64 {
+	_, rAsc, dec := getSunCoords(timeToJD(t))
+	return unitVector(dec, rAsc)
+}
+
+// unitVector returns the unit vector at latitude lat and longitude lon, or
+// declination and right ascension
+func unitVector(lat float64, lon float64) [3]float64 {
+	return [3]float64{angleCos(lat) * angleCos(lon), angleCos(lat) * angleSin(lon), angleSin(lat)}
+}
+
+// SunPositionECI returns the position of the Sun relative to the centre of the
+// Earth at t in kilometres, in the inertial frame of the mean equator and
+// equinox of J2000, which the GCRS matches to well within the accuracy of the
+// model: about 0.01 degree in direction, or some 30000 km. That is ample for
+// eclipse prediction, where the Earth's shadow is what matters.
+func SunPositionECI(t time.Time) [3]float64 {
+	c := LowPrecision{}.Sun(t)
+	v := unprecessJ2000(unitVector(c.Declination, c.RightAscension), getJdn(timeToJD(t))/36525)
+	return scaleVector(v, c.Distance*auKm)
+}
+
+// SunPositionECEF returns the position of the Sun relative to the centre of the
+// Earth at t in kilometres, in the Earth-fixed frame of SunVectorECEF: the
+// vector of date turned through the Greenwich sidereal time.
+func SunPositionECEF(t time.Time) [3]float64 {
+	return scaleVector(SunVectorECEF(t), EarthSunDistance(t)*auKm)
+}
+
+// unprecessJ2000 carries vector v from the equator and equinox of T Julian
+// centuries after J2000 back to those of J2000, by the transpose of the
+// rotation of precessJ2000
+func unprecessJ2000(v [3]float64, T float64) [3]float64 {
+	var r [3]float64
+	for i := range r {
+		var e [3]float64
+		e[i] = 1
+		r[i] = dot(precessJ2000(e, T), v)
+	}
+	return r
+}
+
+func dot(a [3]float64, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func scaleVector(v [3]float64, s float64) [3]float64 {
+	return [3]float64{v[0] * s, v[1] * s, v[2] * s}
+}
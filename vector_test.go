@@ -0,0 +1,103 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func vectorNear(a [3]float64, b [3]float64, tol float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// From London at 10:00 UT on the June solstice the Sun is 53.40 degrees up at
+// azimuth 128.45, so east is cos h sin A = 0.4671, north cos h cos A = -0.3705
+// and up sin h = 0.8028; at noon it is due south, 61.93 degrees up.
+func TestSunVector(t *testing.T) {
+	at := time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	if got := SunVector(at, 51.5074, -0.1278); !vectorNear(got, [3]float64{0.4671, -0.3705, 0.8028}, 0.002) {
+		t.Errorf("SunVector at 10:00 = %v", got)
+	}
+	noon := Culminate(date(time.UTC, 2024, time.June, 21), 51.5074, -0.1278)
+	want := [3]float64{0, -angleCos(noon.Altitude), angleSin(noon.Altitude)}
+	if got := SunVector(noon.Time, 51.5074, -0.1278); !vectorNear(got, want, 1e-4) {
+		t.Errorf("SunVector at noon = %v, want %v", got, want)
+	}
+}
+
+// At the March equinox the Sun is at the equinox, on the x axis of the frame
+// of date, and at the June solstice it is at right ascension 6h and
+// declination ε, (0, cos ε, sin ε) = (0, 0.9175, 0.3978). The Earth-fixed
+// vector differs from it in longitude by the Greenwich sidereal time, which
+// Meeus, example 12.a, gives as 13h10m46.37s = 197.6932 degrees at 0h UT on
+// 1987 April 10.
+func TestSunVectorFrames(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want [3]float64
+	}{
+		{time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC), [3]float64{1, 0, 0}},
+		{time.Date(2024, time.June, 20, 20, 51, 0, 0, time.UTC), [3]float64{0, 0.9175, 0.3978}},
+	} {
+		if got := SunVectorECI(tt.t); !vectorNear(got, tt.want, 2e-4) {
+			t.Errorf("SunVectorECI(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+
+	at := time.Date(1987, time.April, 10, 0, 0, 0, 0, time.UTC)
+	eci, ecef := SunVectorECI(at), SunVectorECEF(at)
+	if gst := between(0, 360, toAngle(math.Atan2(eci[1], eci[0])-math.Atan2(ecef[1], ecef[0]))); math.Abs(gst-197.6932) > 0.002 {
+		t.Errorf("sidereal time between the frames = %.4f, want 197.6932", gst)
+	}
+	if math.Abs(eci[2]-ecef[2]) > 1e-9 {
+		t.Errorf("z differs between frames: %v, %v", eci[2], ecef[2])
+	}
+
+	// the Earth-fixed vector points at the subsolar point
+	at = time.Date(2024, time.June, 21, 10, 0, 0, 0, time.UTC)
+	lat, lon := SubsolarPoint(at)
+	if got := SunVector(at, lat, lon); !vectorNear(got, [3]float64{0, 0, 1}, 1e-6) {
+		t.Errorf("SunVector at the subsolar point = %v, want straight up", got)
+	}
+	if got := SunVectorECEF(at); !vectorNear(got, unitVector(lat, lon), 1e-12) {
+		t.Errorf("SunVectorECEF = %v, want %v", got, unitVector(lat, lon))
+	}
+}
+
+// The Sun is 0.983307 AU, 147100633 km, away at perihelion in 2024 and
+// 1.016725 AU, 152099895 km, at aphelion. Precession has carried the equinox
+// 50.3 arcseconds a year, 0.338 degrees, west since J2000, so at the 2024 March
+// equinox the J2000 vector is at ecliptic longitude -0.338: (0.99998, -0.0054,
+// -0.0024).
+func TestSunPosition(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(2024, time.January, 3, 0, 38, 0, 0, time.UTC), 147100633},
+		{time.Date(2024, time.July, 5, 5, 6, 0, 0, time.UTC), 152099895},
+	} {
+		eci, ecef := SunPositionECI(tt.t), SunPositionECEF(tt.t)
+		if r := math.Sqrt(dot(eci, eci)); math.Abs(r-tt.want) > 15000 {
+			t.Errorf("SunPositionECI(%v) is %.0f km away, want %v", tt.t.Format("Jan 2"), r, tt.want)
+		}
+		if r := math.Sqrt(dot(ecef, ecef)); math.Abs(r-tt.want) > 15000 {
+			t.Errorf("SunPositionECEF(%v) is %.0f km away, want %v", tt.t.Format("Jan 2"), r, tt.want)
+		}
+		r := math.Sqrt(dot(ecef, ecef))
+		if got := scaleVector(ecef, 1/r); !vectorNear(got, SunVectorECEF(tt.t), 1e-9) {
+			t.Errorf("SunPositionECEF direction = %v, want %v", got, SunVectorECEF(tt.t))
+		}
+	}
+
+	at := time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC)
+	p := SunPositionECI(at)
+	if got := scaleVector(p, 1/math.Sqrt(dot(p, p))); !vectorNear(got, [3]float64{0.99998, -0.0054, -0.0024}, 2e-4) {
+		t.Errorf("SunPositionECI direction at the equinox = %v", got)
+	}
+}
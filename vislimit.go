@@ -0,0 +1,112 @@
+package sun
+
+import (
+	"math"
+	"time"
+)
+
+// SkyConditions are the state of the air and of the observer's eye that decide
+// how faint a star can be seen, for Schaefer's model of visibility
+// (Sky & Telescope, May 1998) in the V band.
+type SkyConditions struct {
+	Elevation   float64 // metres above sea level
+	Temperature float64 // degrees Celsius
+	Humidity    float64 // relative humidity in per cent, between 0 and 100
+	// SnellenRatio is the sharpness of the observer's sight, 1 for normal
+	// vision and 0 taken as 1. The threshold falls as its square.
+	SnellenRatio float64
+}
+
+// DefaultSkyConditions are a sea level site at 20°C and 50% humidity, seen
+// with normal sight.
+var DefaultSkyConditions = SkyConditions{Temperature: 20, Humidity: 50, SnellenRatio: 1}
+
+// Schaefer's constants for the V band: the magnitudes of the Sun and the full
+// Moon, ozone and water vapour absorption, and the brightness of the dark
+// night sky
+const (
+	sunMagnitudeV  = -26.74
+	moonMagnitudeV = -11.05
+	ozoneV         = 0.031
+	waterV         = 0.031
+	nightSkyV      = 1.0e-13
+)
+
+// Extinction returns the extinction coefficient in the V band, in magnitudes
+// per airmass, at t and the given latitude: the sum of Rayleigh scattering,
+// aerosols, ozone and water vapour. Aerosols thicken in the local summer and
+// with humidity, and everything but ozone thins with height.
+func (c SkyConditions) Extinction(t time.Time, latitude float64) float64 {
+	kr, ka, ko, kw := c.extinction(t, latitude)
+	return kr + ka + ko + kw
+}
+
+// extinction returns the Rayleigh, aerosol, ozone and water vapour parts of
+// the extinction coefficient
+func (c SkyConditions) extinction(t time.Time, latitude float64) (kr float64, ka float64, ko float64, kw float64) {
+	season := float64(t.Month()-3) * 30
+	hemisphere := 1.0
+	if latitude < 0 {
+		hemisphere = -1
+	}
+	humidity := math.Min(math.Max(c.Humidity, 1), 99) / 100
+	kr = 0.1066 * math.Exp(-c.Elevation/8200)
+	ka = 0.1 * math.Exp(-c.Elevation/1500) * math.Pow(1-0.32/math.Log(humidity), 1.33) * (1 + 0.33*hemisphere*angleSin(season))
+	ko = ozoneV * (3 + 0.4*(toRadians(latitude)*angleCos(season)-math.Cos(3*toRadians(latitude)))) / 3
+	kw = waterV * 0.94 * humidity * math.Exp(c.Temperature/15) * math.Exp(-c.Elevation/8200)
+	return kr, ka, ko, kw
+}
+
+// airmasses returns the airmass at zenith distance z degrees for the gases,
+// the aerosols, which lie lower, and the ozone, which lies higher
+func airmasses(z float64) (gas float64, aerosol float64, ozone float64) {
+	cz := angleCos(z)
+	gas = 1 / (cz + 0.0286*math.Exp(-10.5*cz))
+	aerosol = 1 / (cz + 0.0123*math.Exp(-24.5*cz))
+	s := angleSin(z) / (1 + 20/6378.0)
+	ozone = 1 / math.Sqrt(1-s*s)
+	return gas, aerosol, ozone
+}
+
+// LimitingMagnitude returns the faintest magnitude, as measured outside the
+// atmosphere, of a star that can be seen at the given altitude and azimuth at
+// t from the given location. It allows for the extinction along the line of
+// sight and for the brightness of the sky there from the night sky glow,
+// which follows the solar cycle, and from twilight. The Moon is taken to be
+// down. The result is -Inf for a star below the horizon or when the Sun is up.
+func (c SkyConditions) LimitingMagnitude(t time.Time, latitude float64, longitude float64, altitude float64, azimuth float64) float64 {
+	sunAlt := Altitude(t, latitude, longitude)
+	if altitude <= 0 || sunAlt >= 0 {
+		return math.Inf(-1)
+	}
+	z := 90 - altitude
+	kr, ka, ko, kw := c.extinction(t, latitude)
+	k := kr + ka + ko + kw
+	gas, aerosol, ozone := airmasses(z)
+	dimming := kr*gas + ka*aerosol + ko*ozone + kw*gas
+
+	// the night sky, brighter at solar maximum and towards the horizon
+	years := float64(t.Year()-1992) + float64(t.YearDay())/365.25
+	night := nightSkyV * (1 + 0.3*math.Cos(2*math.Pi*years/11))
+	night *= 0.4 + 0.6/math.Sqrt(1-0.96*angleSin(z)*angleSin(z))
+	night *= math.Pow(10, -0.4*k*gas)
+
+	// twilight, fading a magnitude for each degree the Sun sinks and brighter
+	// towards it and towards the horizon
+	elongation := math.Max(angularDistance(altitude, azimuth, sunAlt, Azimuth(t, latitude, longitude)), 1)
+	twilight := math.Pow(10, -0.4*(sunMagnitudeV-moonMagnitudeV+32.5-sunAlt-z/(360*k)))
+	twilight *= 100 / elongation * (1 - math.Pow(10, -0.4*k*gas))
+
+	// the threshold of the eye for a point source against a sky of that
+	// brightness in nanolamberts, rod vision below 1500 and cone vision above
+	b := (night + twilight) / 1.11e-15
+	c1, c2 := math.Pow(10, -9.8), math.Pow(10, -1.9)
+	if b > 1500 {
+		c1, c2 = math.Pow(10, -8.35), math.Pow(10, -5.9)
+	}
+	threshold := c1 * math.Pow(1+math.Sqrt(c2*b), 2)
+	if c.SnellenRatio > 0 {
+		threshold /= c.SnellenRatio * c.SnellenRatio
+	}
+	return -16.57 - 2.5*math.Log10(threshold) - dimming
+}
@@ -0,0 +1,80 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// In January at 51.5 N, at sea level, 20°C and 50% humidity, Schaefer's terms
+// are 0.1066 for Rayleigh scattering, 0.1 × 1.6571 × (1 - 0.33 sin 60°) =
+// 0.1184 for aerosols, 0.031 × 3.5408 / 3 = 0.0366 for ozone and 0.031 × 0.94
+// × 0.5 × e^(4/3) = 0.0553 for water: 0.3168 in all. In July the aerosols are
+// 1.29/0.71 times as thick and the ozone a little thinner, while July south of
+// the equator is January north of it. On a mountain 2400 m up at 5°C and 20%
+// humidity the terms are 0.0796, 0.0184, 0.0318 and 0.0061.
+func TestExtinction(t *testing.T) {
+	for _, tt := range []struct {
+		c     SkyConditions
+		month time.Month
+		lat   float64
+		want  float64
+	}{
+		{DefaultSkyConditions, time.January, 51.5, 0.3168},
+		{DefaultSkyConditions, time.July, 51.5, 0.4078},
+		{DefaultSkyConditions, time.July, -51.5, 0.3168},
+		{SkyConditions{Elevation: 2400, Temperature: 5, Humidity: 20}, time.January, 28.76, 0.1358},
+	} {
+		at := time.Date(2024, tt.month, 10, 0, 0, 0, 0, time.UTC)
+		if got := tt.c.Extinction(at, tt.lat); math.Abs(got-tt.want) > 5e-4 {
+			t.Errorf("%+v.Extinction(%v, %v) = %.4f, want %.4f", tt.c, tt.month, tt.lat, got, tt.want)
+		}
+	}
+}
+
+// On a dark January night in 2024, near the peak of the solar cycle, the sky
+// at the zenith is 1e-13 × 1.2549 × 10^(-0.4 × 0.3168) / 1.11e-15 = 84.4
+// nanolamberts. The rod threshold against that is 10^-9.8 × (1 + √(10^-1.9 ×
+// 84.4))² = 6.54e-10, magnitude 6.39 at the eye and 6.07 above the air. Sight
+// twice as sharp lowers the threshold fourfold, 1.51 magnitudes.
+func TestLimitingMagnitude(t *testing.T) {
+	night := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	sharp := DefaultSkyConditions
+	sharp.SnellenRatio = 2
+	for _, tt := range []struct {
+		c        SkyConditions
+		at       time.Time
+		altitude float64
+		want     float64
+	}{
+		{DefaultSkyConditions, night, 90, 6.07},
+		{sharp, night, 90, 6.07 + 1.51},
+		{SkyConditions{Temperature: 20, Humidity: 50}, night, 90, 6.07},
+		{DefaultSkyConditions, night, 0, math.Inf(-1)},
+		{DefaultSkyConditions, night.Add(12 * time.Hour), 90, math.Inf(-1)},
+	} {
+		got := tt.c.LimitingMagnitude(tt.at, 51.5, 0, tt.altitude, 0)
+		if math.IsInf(tt.want, -1) && !math.IsInf(got, -1) || math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("%+v.LimitingMagnitude(%v, %v) = %.2f, want %.2f", tt.c, tt.at, tt.altitude, got, tt.want)
+		}
+	}
+
+	// the limit falls towards the horizon and as the twilight brightens
+	prev := math.Inf(1)
+	for _, alt := range []float64{90, 45, 20, 10, 5, 2} {
+		m := DefaultSkyConditions.LimitingMagnitude(night, 51.5, 0, alt, 0)
+		if m >= prev {
+			t.Errorf("limit at %v degrees is %.2f, no lower than %.2f above", alt, m, prev)
+		}
+		prev = m
+	}
+	dawn, _ := Sunrise(night, 51.5, 0)
+	prev = math.Inf(1)
+	for _, before := range []time.Duration{90, 60, 45, 30} {
+		m := DefaultSkyConditions.LimitingMagnitude(dawn.Add(-before*time.Minute), 51.5, 0, 45, 270)
+		if m >= prev {
+			t.Errorf("limit %v minutes before sunrise is %.2f, no lower than %.2f before", before, m, prev)
+		}
+		prev = m
+	}
+}
@@ -0,0 +1,39 @@
+package sun
+
+import "time"
+
+// VitaminDAltitude is the solar altitude in degrees above which sunlight is
+// commonly taken to carry enough UVB for the skin to make vitamin D: the rule
+// of thumb that your shadow should be shorter than you are.
+const VitaminDAltitude float64 = 45
+
+// VitaminDWindow returns the period on the date of t when the Sun is above
+// minAltitude degrees, usually VitaminDAltitude, in the time zone of t, all of
+// it if the Sun stays above all day. ok is false when it gets no higher all
+// day, as through winter above about 35 degrees of latitude, when little or no
+// vitamin D can be made from sunlight.
+func VitaminDWindow(t time.Time, latitude float64, longitude float64, minAltitude float64) (window Period, ok bool) {
+	return aboveAltitude(t, latitude, longitude, minAltitude)
+}
+
+// aboveAltitude returns the period around noon on the date of t that the Sun is
+// above alt, running to midnight at either end if it stays above alt through
+// the night
+func aboveAltitude(t time.Time, latitude float64, longitude float64, alt float64) (Period, bool) {
+	dayStart, dayEnd := dateSpan(t)
+	start, ok := crossing(t, latitude, longitude, alt, true, defaultTolerance)
+	if !ok {
+		if lowestAltitude(t.AddDate(0, 0, -1), latitude, longitude) <= alt {
+			return Period{}, false
+		}
+		start = dayStart
+	}
+	end, ok := crossing(t, latitude, longitude, alt, false, defaultTolerance)
+	if !ok {
+		if lowestAltitude(t, latitude, longitude) <= alt {
+			return Period{}, false
+		}
+		end = dayEnd
+	}
+	return Period{start, end}, true
+}
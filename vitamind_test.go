@@ -0,0 +1,50 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// At London on the June solstice sin 45 = 0.3113 + 0.5711 cos H gives an hour
+// angle of 46.13 degrees, 3h04.5m either side of the 12:02.3 UT noon. At
+// Sydney on the December one, sin 45 = 0.2217 + 0.7618 cos H gives 50.42
+// degrees, 3h21.7m either side of 12:53 AEDT. London's noon Sun is below 45
+// degrees while δ < 45 - 38.49 = 6.5, until early April.
+func TestVitaminDWindow(t *testing.T) {
+	sydney := location(t, "Australia/Sydney")
+	june, december := date(time.UTC, 2024, time.June, 21), date(sydney, 2024, time.December, 21)
+	for _, tt := range []struct {
+		name       string
+		d          time.Time
+		lat, lon   float64
+		start, end time.Time
+	}{
+		{"London", june, 51.5074, -0.1278, clock(june, 8, 58), clock(june, 15, 7)},
+		{"Sydney", december, -33.8688, 151.2093, clock(december, 9, 31), clock(december, 16, 15)},
+	} {
+		w, ok := VitaminDWindow(tt.d, tt.lat, tt.lon, VitaminDAltitude)
+		if !ok || !within(w.Start, tt.start, time.Minute) || !within(w.End, tt.end, time.Minute) || w.Start.Location() != tt.d.Location() {
+			t.Errorf("%s: VitaminDWindow = %v, %v, want %v to %v", tt.name, w, ok, tt.start, tt.end)
+		}
+	}
+
+	for _, tt := range []struct {
+		d  time.Time
+		ok bool
+	}{
+		{date(time.UTC, 2024, time.April, 1), false},
+		{date(time.UTC, 2024, time.May, 1), true},
+		{date(time.UTC, 2024, time.September, 1), true},
+		{date(time.UTC, 2024, time.October, 1), false},
+		{date(time.UTC, 2024, time.December, 21), false},
+	} {
+		if _, ok := VitaminDWindow(tt.d, 51.5074, -0.1278, VitaminDAltitude); ok != tt.ok {
+			t.Errorf("London on %v: ok = %v, want %v", tt.d.Format("Jan 2"), ok, tt.ok)
+		}
+	}
+	// a lower threshold widens the window
+	w30, _ := VitaminDWindow(june, 51.5074, -0.1278, 30)
+	if !within(w30.Start, clock(june, 7, 19), time.Minute) || !within(w30.End, clock(june, 16, 45), time.Minute) {
+		t.Errorf("window above 30 degrees = %v, want 07:19 to 16:45", w30)
+	}
+}
@@ -0,0 +1,42 @@
+package sun
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVSOP87(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 25.b: 1992 October 13.0 TD
+	td := time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)
+	l, b, r := vsop87Earth(getJdn(timeToJD(td)) / 365250)
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+		tolerance float64
+	}{
+		{"L", l, 19.907372, 1e-6},
+		{"B", b, -0.000179, 1e-6},
+		{"R", r, 0.99760775, 1e-8},
+	} {
+		if math.Abs(tt.got-tt.want) > tt.tolerance {
+			t.Errorf("%s = %.8f, want %.8f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// the apparent position, to within the arcsecond of the short nutation
+	c := VSOP87{}.Sun(td.Add(-time.Duration(deltaT(td) * float64(time.Second))))
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"ecliptic longitude", c.EclipticLongitude, 199.906060},
+		{"right ascension", c.RightAscension, 198.378121},
+		{"declination", c.Declination, -7.783817},
+		{"distance", c.Distance, 0.99760775},
+	} {
+		if math.Abs(tt.got-tt.want) > 0.0003 {
+			t.Errorf("%s = %.6f, want %.6f", tt.name, tt.got, tt.want)
+		}
+	}
+}
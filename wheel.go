@@ -0,0 +1,43 @@
+package sun
+
+import "time"
+
+// SeasonalPoint is a solstice, an equinox or one of the cross-quarter days
+// between them, when the apparent longitude of the Sun is a multiple of 45
+// degrees.
+type SeasonalPoint struct {
+	Longitude float64
+	Name      string
+	Festival  string // the traditional Celtic festival near it
+	Time      time.Time
+}
+
+// seasonalPointNames are the names of the points in order of longitude from 0,
+// with their festivals as kept in the northern hemisphere
+var seasonalPointNames = [8][2]string{
+	{"March equinox", "Ostara"},
+	{"Cross-quarter", "Beltane"},
+	{"June solstice", "Litha"},
+	{"Cross-quarter", "Lughnasadh"},
+	{"September equinox", "Mabon"},
+	{"Cross-quarter", "Samhain"},
+	{"December solstice", "Yule"},
+	{"Cross-quarter", "Imbolc"},
+}
+
+// SeasonalPoints returns the eight points of the solar year falling in the
+// given year in loc, in time order with times in loc: the equinoxes and
+// solstices at longitudes 0, 90, 180 and 270 degrees, and the astronomical
+// cross-quarter days halfway between at 45, 135, 225 and 315. The festivals
+// named are those of the northern hemisphere, where Imbolc falls at the start
+// of February; in the southern hemisphere they are kept six months apart from
+// these. The traditional dates of the festivals, such as 1 February, are a few
+// days from the astronomical ones. Times are as for SolarTerms.
+func SeasonalPoints(year int, loc *time.Location) []SeasonalPoint {
+	var ps []SeasonalPoint
+	for _, c := range longitudeCrossings(year, loc, 45) {
+		names := seasonalPointNames[int(c.longitude)/45]
+		ps = append(ps, SeasonalPoint{c.longitude, names[0], names[1], c.time})
+	}
+	return ps
+}
@@ -0,0 +1,44 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// The equinoxes and solstices of 2024 from the USNO, 20 March 03:06, 20 June
+// 20:51, 22 September 12:44 and 21 December 09:21 UT, and the cross-quarter
+// days at the Lichun, Lixia, Liqiu and Lidong solar terms.
+func TestSeasonalPoints(t *testing.T) {
+	want := []struct {
+		longitude float64
+		festival  string
+		at        time.Time
+	}{
+		{315, "Imbolc", time.Date(2024, time.February, 4, 8, 27, 0, 0, time.UTC)},
+		{0, "Ostara", time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC)},
+		{45, "Beltane", time.Date(2024, time.May, 5, 0, 10, 0, 0, time.UTC)},
+		{90, "Litha", time.Date(2024, time.June, 20, 20, 51, 0, 0, time.UTC)},
+		{135, "Lughnasadh", time.Date(2024, time.August, 7, 0, 9, 0, 0, time.UTC)},
+		{180, "Mabon", time.Date(2024, time.September, 22, 12, 44, 0, 0, time.UTC)},
+		{225, "Samhain", time.Date(2024, time.November, 6, 22, 20, 0, 0, time.UTC)},
+		{270, "Yule", time.Date(2024, time.December, 21, 9, 21, 0, 0, time.UTC)},
+	}
+	got := SeasonalPoints(2024, time.UTC)
+	if len(got) != len(want) {
+		t.Fatalf("%d points, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Longitude != w.longitude || g.Festival != w.festival || !within(g.Time, w.at, 10*time.Minute) {
+			t.Errorf("point %d = %v %s at %v, want %v %s at %v", i, g.Longitude, g.Festival, g.Time, w.longitude, w.festival, w.at)
+		}
+	}
+	if got[3].Name != "June solstice" || got[2].Name != "Cross-quarter" {
+		t.Errorf("names %q and %q", got[3].Name, got[2].Name)
+	}
+
+	// the June solstice is on the 20th in UTC but the 21st in Sydney
+	if d := SeasonalPoints(2024, location(t, "Australia/Sydney"))[3].Time; d.Day() != 21 {
+		t.Errorf("June solstice in Sydney on %v", d.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,70 @@
+package sun
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CardinalFacades are walls facing north, east, south and west.
+var CardinalFacades = []Facade{
+	{"North", 0},
+	{"East", 90},
+	{"South", 180},
+	{"West", 270},
+}
+
+// WindowSun is the direct sun falling on a vertical window over a year.
+type WindowSun struct {
+	Facade
+	Months [12]time.Duration // totals for January to December
+	Annual time.Duration
+}
+
+// AnnualWindowSun returns the hours of direct sun on a vertical window in
+// each of facades over every date of the year in the time zone loc, by month
+// and in total, ignoring anything in the way: the sun hours that daylighting
+// rules of thumb ask of the main windows of a home. Use CardinalFacades for
+// the four points of the compass.
+func AnnualWindowSun(year int, loc *time.Location, p Point, facades []Facade) []WindowSun {
+	ws := make([]WindowSun, len(facades))
+	for i, f := range facades {
+		ws[i].Facade = f
+		for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+			am, pm := facadeSun(d, p.Latitude, p.Longitude, f.Azimuth)
+			ws[i].Months[d.Month()-1] += am + pm
+			ws[i].Annual += am + pm
+		}
+	}
+	return ws
+}
+
+// WriteWindowSunCSV writes a table with a row per window: its name and
+// azimuth, then the hours of sun in each month and the year, to a tenth of an
+// hour.
+func WriteWindowSunCSV(w io.Writer, ws []WindowSun) error {
+	cw := csv.NewWriter(w)
+	header := []string{"facade", "azimuth"}
+	for m := time.January; m <= time.December; m++ {
+		header = append(header, strings.ToLower(m.String()[:3])+"_hours")
+	}
+	if err := cw.Write(append(header, "annual_hours")); err != nil {
+		return err
+	}
+	hours := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Hours(), 'f', 1, 64)
+	}
+	for _, s := range ws {
+		row := []string{s.Name, strconv.FormatFloat(s.Azimuth, 'f', -1, 64)}
+		for _, m := range s.Months {
+			row = append(row, hours(m))
+		}
+		if err := cw.Write(append(row, hours(s.Annual))); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
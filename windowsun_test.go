@@ -0,0 +1,90 @@
+package sun
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// On the equator the Sun crosses the meridian at noon every day, so an east
+// wall is lit for the six hours before it and a west wall for the six after:
+// 366 * 6 = 2196 hours in 2024. It stands north of the east-west line from
+// the March equinox (20 Mar 03:06) to the September one (22 Sep 12:44), 186.4
+// days of twelve hours, or about 2237 hours on a north wall and 2155 on a
+// south one. Whenever the Sun is up it lights one of north and south and one
+// of east and west, so the four walls together see twice the daylight.
+func TestAnnualWindowSun(t *testing.T) {
+	for _, tt := range []struct {
+		p                  Point
+		north, east, south time.Duration
+		tolerance          time.Duration
+		total              time.Duration
+	}{
+		{Point{0, 0}, 2237 * time.Hour, 2196 * time.Hour, 2155 * time.Hour, 3 * time.Hour, 2 * 4392 * time.Hour},
+		{Point{51.5074, -0.1278}, 808*time.Hour + 31*time.Minute, 2206*time.Hour + 58*time.Minute, 3604*time.Hour + 49*time.Minute, time.Minute, 8827*time.Hour + 16*time.Minute},
+	} {
+		ws := AnnualWindowSun(2024, time.UTC, tt.p, CardinalFacades)
+		if len(ws) != 4 {
+			t.Fatalf("%v: %d facades, want 4", tt.p, len(ws))
+		}
+		var total time.Duration
+		for _, w := range ws {
+			var months time.Duration
+			for _, m := range w.Months {
+				months += m
+			}
+			if months != w.Annual {
+				t.Errorf("%v %s: months sum to %v, annual %v", tt.p, w.Name, months, w.Annual)
+			}
+			total += w.Annual
+		}
+		for i, want := range []time.Duration{tt.north, tt.east, tt.south, tt.east} {
+			if (ws[i].Annual - want).Abs() > tt.tolerance {
+				t.Errorf("%v %s: %v, want %v", tt.p, ws[i].Name, ws[i].Annual, want)
+			}
+		}
+		if (total - tt.total).Abs() > 10*time.Minute {
+			t.Errorf("%v: four walls %v, want %v", tt.p, total, tt.total)
+		}
+	}
+}
+
+// A north wall in London sees no direct Sun between the autumn and spring
+// equinoxes, and a south wall on the equator none between March and September.
+func TestAnnualWindowSunMonths(t *testing.T) {
+	london := AnnualWindowSun(2024, time.UTC, Point{51.5074, -0.1278}, CardinalFacades)
+	equator := AnnualWindowSun(2024, time.UTC, Point{0, 0}, CardinalFacades)
+	for _, tt := range []struct {
+		w     WindowSun
+		month time.Month
+		hours float64
+	}{
+		{london[0], time.June, 209.1},
+		{london[0], time.December, 0},
+		{london[2], time.December, 238.4},
+		{equator[0], time.June, 360.1},
+		{equator[2], time.June, 0},
+		{equator[2], time.December, 372.1},
+	} {
+		if got := tt.w.Months[tt.month-1].Hours(); math.Abs(got-tt.hours) > 0.1 {
+			t.Errorf("%s %v: %.1f hours, want %.1f", tt.w.Name, tt.month, got, tt.hours)
+		}
+	}
+}
+
+func TestWriteWindowSunCSV(t *testing.T) {
+	ws := AnnualWindowSun(2024, time.UTC, Point{0, 0}, CardinalFacades[:1])
+	var b strings.Builder
+	if err := WriteWindowSunCSV(&b, ws); err != nil {
+		t.Fatal(err)
+	}
+	want := "facade,azimuth,jan_hours,feb_hours,mar_hours,apr_hours,may_hours,jun_hours,jul_hours,aug_hours,sep_hours,oct_hours,nov_hours,dec_hours,annual_hours\n" +
+		"North,0,0.0,0.0,144.0,359.9,372.0,360.1,372.0,371.9,258.7,0.0,0.0,0.0,2238.7\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+	if err := WriteWindowSunCSV(failWriter{}, ws); err == nil {
+		t.Error("no error from a failing writer")
+	}
+}
@@ -0,0 +1,32 @@
+package sun
+
+import "time"
+
+// zodiacSigns are the signs of the tropical zodiac from Aries at longitude 0
+var zodiacSigns = [12]string{"Aries", "Taurus", "Gemini", "Cancer", "Leo", "Virgo",
+	"Libra", "Scorpio", "Sagittarius", "Capricorn", "Aquarius", "Pisces"}
+
+// Ingress is the moment the Sun enters a sign of the zodiac.
+type Ingress struct {
+	Sign      string
+	Longitude float64 // the start of the sign, degrees
+	Time      time.Time
+}
+
+// ZodiacSign returns the sign of the tropical zodiac the Sun is in at t, from
+// its apparent ecliptic longitude.
+func ZodiacSign(t time.Time) string {
+	return zodiacSigns[int(SolarLongitude(t)/30)%12]
+}
+
+// Ingresses returns the moments the Sun enters each sign of the tropical
+// zodiac in the given year in loc, when its apparent longitude crosses a
+// multiple of 30 degrees, in time order with times in loc. Times are as for
+// SolarTerms.
+func Ingresses(year int, loc *time.Location) []Ingress {
+	var is []Ingress
+	for _, c := range longitudeCrossings(year, loc, 30) {
+		is = append(is, Ingress{zodiacSigns[int(c.longitude)/30], c.longitude, c.time})
+	}
+	return is
+}
@@ -0,0 +1,58 @@
+package sun
+
+import (
+	"testing"
+	"time"
+)
+
+// The Sun's ingresses in 2024 in UT, at the major solar terms, to the ten
+// minutes SolarTerms promises.
+func TestIngresses(t *testing.T) {
+	want := []struct {
+		sign     string
+		month    time.Month
+		day      int
+		hour, mn int
+	}{
+		{"Aquarius", time.January, 20, 14, 7},
+		{"Pisces", time.February, 19, 4, 13},
+		{"Aries", time.March, 20, 3, 6},
+		{"Taurus", time.April, 19, 13, 59},
+		{"Gemini", time.May, 20, 12, 59},
+		{"Cancer", time.June, 20, 20, 51},
+		{"Leo", time.July, 22, 7, 44},
+		{"Virgo", time.August, 22, 14, 55},
+		{"Libra", time.September, 22, 12, 44},
+		{"Scorpio", time.October, 22, 22, 15},
+		{"Sagittarius", time.November, 21, 19, 56},
+		{"Capricorn", time.December, 21, 9, 21},
+	}
+	got := Ingresses(2024, time.UTC)
+	if len(got) != len(want) {
+		t.Fatalf("%d ingresses, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		at := time.Date(2024, w.month, w.day, w.hour, w.mn, 0, 0, time.UTC)
+		if g := got[i]; g.Sign != w.sign || g.Longitude != float64((10+i)%12)*30 || !within(g.Time, at, 10*time.Minute) {
+			t.Errorf("ingress %d = %+v, want %s at %v", i, g, w.sign, at)
+		}
+	}
+}
+
+func TestZodiacSign(t *testing.T) {
+	for _, tt := range []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), "Capricorn"},
+		{time.Date(2024, time.March, 20, 2, 30, 0, 0, time.UTC), "Pisces"},
+		{time.Date(2024, time.March, 20, 3, 30, 0, 0, time.UTC), "Aries"},
+		{time.Date(2024, time.July, 4, 12, 0, 0, 0, time.UTC), "Cancer"},
+		{time.Date(2024, time.October, 31, 12, 0, 0, 0, time.UTC), "Scorpio"},
+		{time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC), "Capricorn"},
+	} {
+		if got := ZodiacSign(tt.t); got != tt.want {
+			t.Errorf("ZodiacSign(%v) = %s, want %s", tt.t, got, tt.want)
+		}
+	}
+}